@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSagemakerCodeRepository() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerCodeRepositoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"code_repository_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"git_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"repository_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"secret_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerCodeRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("code_repository_name").(string)
+
+	codeRepository, err := conn.DescribeCodeRepository(&sagemaker.DescribeCodeRepositoryInput{
+		CodeRepositoryName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing Sagemaker Code Repository (%s): %s", name, err)
+	}
+
+	d.SetId(aws.StringValue(codeRepository.CodeRepositoryName))
+
+	if err := d.Set("code_repository_name", codeRepository.CodeRepositoryName); err != nil {
+		return fmt.Errorf("error setting code_repository_name for code repository %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("arn", codeRepository.CodeRepositoryArn); err != nil {
+		return fmt.Errorf("error setting arn for code repository %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("git_config", flattenSagemakerCodeRepositoryGitConfig(codeRepository.GitConfig)); err != nil {
+		return fmt.Errorf("error setting git_config for code repository %q: %s", d.Id(), err)
+	}
+
+	if codeRepository.CreationTime != nil {
+		if err := d.Set("creation_time", codeRepository.CreationTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting creation_time for code repository %q: %s", d.Id(), err)
+		}
+	}
+
+	if codeRepository.LastModifiedTime != nil {
+		if err := d.Set("last_modified_time", codeRepository.LastModifiedTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting last_modified_time for code repository %q: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
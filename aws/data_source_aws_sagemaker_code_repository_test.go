@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSSagemakerCodeRepositoryDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_sagemaker_code_repository.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerCodeRepositoryDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "arn"),
+					resource.TestCheckResourceAttr(dataSourceName, "git_config.0.repository_url", "https://github.com/hashicorp/terraform.git"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerCodeRepositoryDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_code_repository" "test" {
+  code_repository_name = %[1]q
+
+  git_config {
+    repository_url = "https://github.com/hashicorp/terraform.git"
+  }
+}
+
+data "aws_sagemaker_code_repository" "test" {
+  code_repository_name = aws_sagemaker_code_repository.test.code_repository_name
+}
+`, rName)
+}
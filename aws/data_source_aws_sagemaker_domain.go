@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSagemakerDomain() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerDomainRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"auth_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"home_efs_file_system_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"single_sign_on_managed_application_instance_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerDomainRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID := d.Get("domain_id").(string)
+
+	domain, err := conn.DescribeDomain(&sagemaker.DescribeDomainInput{
+		DomainId: aws.String(domainID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return fmt.Errorf("Sagemaker Domain %q not found", domainID)
+		}
+		return fmt.Errorf("error describing Sagemaker Domain (%s): %s", domainID, err)
+	}
+
+	d.SetId(aws.StringValue(domain.DomainId))
+
+	if err := d.Set("domain_name", domain.DomainName); err != nil {
+		return fmt.Errorf("error setting domain_name for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("auth_mode", domain.AuthMode); err != nil {
+		return fmt.Errorf("error setting auth_mode for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("vpc_id", domain.VpcId); err != nil {
+		return fmt.Errorf("error setting vpc_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("subnet_ids", aws.StringValueSlice(domain.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("url", domain.Url); err != nil {
+		return fmt.Errorf("error setting url for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("home_efs_file_system_id", domain.HomeEfsFileSystemId); err != nil {
+		return fmt.Errorf("error setting home_efs_file_system_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("kms_key_id", domain.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("single_sign_on_managed_application_instance_id", domain.SingleSignOnManagedApplicationInstanceId); err != nil {
+		return fmt.Errorf("error setting single_sign_on_managed_application_instance_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", domain.DomainArn); err != nil {
+		return fmt.Errorf("error setting arn for domain %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
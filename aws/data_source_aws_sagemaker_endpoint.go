@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSagemakerEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerEndpointRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"endpoint_config_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	endpoint, err := conn.DescribeEndpoint(&sagemaker.DescribeEndpointInput{
+		EndpointName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing Sagemaker Endpoint (%s): %s", name, err)
+	}
+
+	d.SetId(aws.StringValue(endpoint.EndpointName))
+
+	if err := d.Set("name", endpoint.EndpointName); err != nil {
+		return fmt.Errorf("error setting name for endpoint %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("arn", endpoint.EndpointArn); err != nil {
+		return fmt.Errorf("error setting arn for endpoint %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("endpoint_config_name", endpoint.EndpointConfigName); err != nil {
+		return fmt.Errorf("error setting endpoint_config_name for endpoint %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("status", endpoint.EndpointStatus); err != nil {
+		return fmt.Errorf("error setting status for endpoint %q: %s", d.Id(), err)
+	}
+
+	if endpoint.CreationTime != nil {
+		if err := d.Set("creation_time", endpoint.CreationTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting creation_time for endpoint %q: %s", d.Id(), err)
+		}
+	}
+
+	if endpoint.LastModifiedTime != nil {
+		if err := d.Set("last_modified_time", endpoint.LastModifiedTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting last_modified_time for endpoint %q: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
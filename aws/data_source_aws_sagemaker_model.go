@@ -0,0 +1,170 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSagemakerModel() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerModelRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"execution_role_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"enable_network_isolation": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"primary_container": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model_data_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"container_hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"container": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model_data_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"container_hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"vpc_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_group_ids": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"subnets": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerModelRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	model, err := conn.DescribeModel(&sagemaker.DescribeModelInput{
+		ModelName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return fmt.Errorf("no Sagemaker Model found with name: %s", name)
+		}
+		return err
+	}
+
+	d.SetId(aws.StringValue(model.ModelName))
+
+	if err := d.Set("name", model.ModelName); err != nil {
+		return fmt.Errorf("error setting name for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("execution_role_arn", model.ExecutionRoleArn); err != nil {
+		return fmt.Errorf("error setting execution_role_arn for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("enable_network_isolation", model.EnableNetworkIsolation); err != nil {
+		return fmt.Errorf("error setting enable_network_isolation for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", model.ModelArn); err != nil {
+		return fmt.Errorf("error setting arn for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("primary_container", flattenSagemakerModelContainer(model.PrimaryContainer)); err != nil {
+		return fmt.Errorf("error setting primary_container for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("container", flattenSagemakerModelContainers(model.Containers)); err != nil {
+		return fmt.Errorf("error setting container for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("vpc_config", flattenSagemakerTrainingJobVpcConfig(model.VpcConfig)); err != nil {
+		return fmt.Errorf("error setting vpc_config for model %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, model.ModelArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for model %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSSagemakerModelDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_sagemaker_model.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerModelDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", rName),
+					resource.TestCheckResourceAttrPair(dataSourceName, "execution_role_arn", "aws_iam_role.test", "arn"),
+					resource.TestCheckResourceAttr(dataSourceName, "primary_container.0.image", "382416733822.dkr.ecr.us-east-1.amazonaws.com/linear-learner:1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerModelDataSource_providerAlias exercises the data source
+// through an aliased "aws" provider (a second provider configuration, as
+// used for e.g. a cross-region reference) rather than the default provider,
+// confirming it resolves meta off whichever provider instance it's attached
+// to instead of always reading from the default one.
+func TestAccAWSSagemakerModelDataSource_providerAlias(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_sagemaker_model.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerModelDataSourceConfigProviderAlias(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", rName),
+					resource.TestCheckResourceAttrPair(dataSourceName, "execution_role_arn", "aws_iam_role.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerModelDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_model" "test" {
+  name               = %[1]q
+  execution_role_arn = aws_iam_role.test.arn
+
+  primary_container {
+    image = "382416733822.dkr.ecr.us-east-1.amazonaws.com/linear-learner:1"
+  }
+}
+
+data "aws_sagemaker_model" "test" {
+  name = aws_sagemaker_model.test.name
+}
+`, rName)
+}
+
+func testAccAWSSagemakerModelDataSourceConfigProviderAlias(rName string) string {
+	return fmt.Sprintf(`
+provider "aws" {
+  alias  = "other_region"
+  region = "us-west-2"
+}
+
+resource "aws_iam_role" "test" {
+  provider = aws.other_region
+
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_model" "test" {
+  provider = aws.other_region
+
+  name               = %[1]q
+  execution_role_arn = aws_iam_role.test.arn
+
+  primary_container {
+    image = "382416733822.dkr.ecr.us-west-2.amazonaws.com/linear-learner:1"
+  }
+}
+
+data "aws_sagemaker_model" "test" {
+  provider = aws.other_region
+
+  name = aws_sagemaker_model.test.name
+}
+`, rName)
+}
@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceAwsSagemakerNotebookInstance looks up a SageMaker notebook
+// instance by name via DescribeNotebookInstance and ListTags, reusing
+// SagemakerNotebookInstanceStateRefreshFunc -- the same lookup the
+// aws_sagemaker_notebook_instance resource polls during Create/Update/Delete
+// -- rather than calling DescribeNotebookInstance directly, so this data
+// source and that resource treat "not found" identically. It exists for
+// referencing a notebook instance managed out-of-band, e.g. to wire its ARN
+// into an IAM policy.
+func dataSourceAwsSagemakerNotebookInstance() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstanceRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// allow_missing lets idempotent automation check for a notebook
+			// instance's presence without the data source erroring when it
+			// doesn't exist; see the "exists" attribute.
+			"allow_missing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// exists is only meaningful when allow_missing is set: it is
+			// always true otherwise, since a missing instance is an error.
+			"exists": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instance_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"security_groups": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"lifecycle_config_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, name)()
+	if err != nil {
+		return err
+	}
+
+	if notebookInstanceRaw == nil {
+		if !d.Get("allow_missing").(bool) {
+			return fmt.Errorf("no Sagemaker Notebook Instance found with name: %s", name)
+		}
+
+		d.SetId(name)
+		return d.Set("exists", false)
+	}
+
+	notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+
+	d.SetId(aws.StringValue(notebookInstance.NotebookInstanceName))
+
+	if err := d.Set("exists", true); err != nil {
+		return fmt.Errorf("error setting exists for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("name", notebookInstance.NotebookInstanceName); err != nil {
+		return fmt.Errorf("error setting name for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", notebookInstance.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("instance_type", notebookInstance.InstanceType); err != nil {
+		return fmt.Errorf("error setting instance_type for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("subnet_id", notebookInstance.SubnetId); err != nil {
+		return fmt.Errorf("error setting subnet_id for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("security_groups", flattenStringList(notebookInstance.SecurityGroups)); err != nil {
+		return fmt.Errorf("error setting security_groups for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("kms_key_id", notebookInstance.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("lifecycle_config_name", notebookInstance.NotebookInstanceLifecycleConfigName); err != nil {
+		return fmt.Errorf("error setting lifecycle_config_name for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("url", notebookInstance.NotebookInstanceUrl); err != nil {
+		return fmt.Errorf("error setting url for notebook instance %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, notebookInstance.NotebookInstanceArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("arn", notebookInstance.NotebookInstanceArn); err != nil {
+		return fmt.Errorf("error setting arn for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// sagemakerNotebookInstanceAutostopScriptTemplate is AWS's well-known
+// auto-stop-on-idle notebook lifecycle sample (the cron-scheduled
+// autostop.py it installs and the check that drives it), reproduced here so
+// teams stop maintaining their own copy of it. %[1]d is idle_time_minutes.
+const sagemakerNotebookInstanceAutostopScriptTemplate = `#!/bin/bash
+set -e
+
+IDLE_TIME=%[1]d
+
+wget -O /home/ec2-user/autostop.py https://raw.githubusercontent.com/aws-samples/amazon-sagemaker-notebook-instance-lifecycle-config-samples/master/scripts/auto-stop-idle/autostop.py
+
+(crontab -l 2>/dev/null; echo "*/5 * * * * /usr/bin/python3 /home/ec2-user/autostop.py --time $IDLE_TIME --ignore-connections >> /home/ec2-user/autostop.log") | crontab -
+`
+
+func dataSourceAwsSagemakerNotebookInstanceAutostopScript() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstanceAutostopScriptRead,
+
+		Schema: map[string]*schema.Schema{
+			"idle_time_minutes": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"on_start": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstanceAutostopScriptRead(d *schema.ResourceData, meta interface{}) error {
+	idleTimeMinutes := d.Get("idle_time_minutes").(int)
+
+	script := fmt.Sprintf(sagemakerNotebookInstanceAutostopScriptTemplate, idleTimeMinutes)
+
+	d.SetId(resource.UniqueId())
+
+	if err := d.Set("on_start", base64.StdEncoding.EncodeToString([]byte(script))); err != nil {
+		return fmt.Errorf("error setting on_start: %s", err)
+	}
+
+	return nil
+}
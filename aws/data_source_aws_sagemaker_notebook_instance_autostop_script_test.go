@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceAwsSagemakerNotebookInstanceAutostopScriptRead(t *testing.T) {
+	d := dataSourceAwsSagemakerNotebookInstanceAutostopScript().TestResourceData()
+	if err := d.Set("idle_time_minutes", 45); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := dataSourceAwsSagemakerNotebookInstanceAutostopScriptRead(d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	onStart := d.Get("on_start").(string)
+	decoded, err := base64.StdEncoding.DecodeString(onStart)
+	if err != nil {
+		t.Fatalf("on_start is not valid base64: %s", err)
+	}
+
+	script := string(decoded)
+	if !strings.HasPrefix(script, "#!/bin/bash") {
+		t.Errorf("expected the script to start with a shebang, got: %s", script)
+	}
+	if !strings.Contains(script, "autostop.py") {
+		t.Errorf("expected the script to reference autostop.py, got: %s", script)
+	}
+	if !strings.Contains(script, "IDLE_TIME="+strconv.Itoa(45)+"\n") {
+		t.Errorf("expected idle_time_minutes to be parameterized into IDLE_TIME, got: %s", script)
+	}
+	if !strings.Contains(script, "--time $IDLE_TIME") {
+		t.Errorf("expected IDLE_TIME to be passed to the --time flag, got: %s", script)
+	}
+
+	if d.Id() == "" {
+		t.Error("expected an id to be set")
+	}
+}
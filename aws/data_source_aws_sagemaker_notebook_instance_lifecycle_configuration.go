@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSagemakerNotebookInstanceLifecycleConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"on_create": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"on_start": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	lifecycleConfig, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing Sagemaker Notebook Instance Lifecycle Configuration (%s): %s", name, err)
+	}
+
+	d.SetId(aws.StringValue(lifecycleConfig.NotebookInstanceLifecycleConfigName))
+
+	if err := d.Set("name", lifecycleConfig.NotebookInstanceLifecycleConfigName); err != nil {
+		return fmt.Errorf("error setting name for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	if len(lifecycleConfig.OnCreate) > 0 {
+		if err := d.Set("on_create", lifecycleConfig.OnCreate[0].Content); err != nil {
+			return fmt.Errorf("error setting on_create for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+		}
+	}
+
+	if len(lifecycleConfig.OnStart) > 0 {
+		if err := d.Set("on_start", lifecycleConfig.OnStart[0].Content); err != nil {
+			return fmt.Errorf("error setting on_start for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("arn", lifecycleConfig.NotebookInstanceLifecycleConfigArn); err != nil {
+		return fmt.Errorf("error setting arn for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("creation_time", lifecycleConfig.CreationTime.String()); err != nil {
+		return fmt.Errorf("error setting creation_time for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("last_modified_time", lifecycleConfig.LastModifiedTime.String()); err != nil {
+		return fmt.Errorf("error setting last_modified_time for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// sagemakerNotebookInstanceLifecycleMountScriptTemplate mounts an EFS file
+// system (optionally through a specific access point) over NFSv4.1 with TLS
+// enabled, the AWS-recommended mount options for EFS. %[1]s is the access
+// point mountpoint option (empty when no access point is given), %[2]s is
+// the EFS file system ID, and %[3]s is the local mount point.
+const sagemakerNotebookInstanceLifecycleMountScriptTemplate = `#!/bin/bash
+set -e
+
+mkdir -p %[3]s
+mount -t efs -o tls%[1]s %[2]s:/ %[3]s
+`
+
+func dataSourceAwsSagemakerNotebookInstanceLifecycleMountScript() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstanceLifecycleMountScriptRead,
+
+		Schema: map[string]*schema.Schema{
+			"efs_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"mount_point": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"access_point_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"on_start": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstanceLifecycleMountScriptRead(d *schema.ResourceData, meta interface{}) error {
+	efsID := d.Get("efs_id").(string)
+	mountPoint := d.Get("mount_point").(string)
+
+	accessPointOpt := ""
+	if v, ok := d.GetOk("access_point_id"); ok {
+		accessPointOpt = fmt.Sprintf(",accesspoint=%s", v.(string))
+	}
+
+	script := fmt.Sprintf(sagemakerNotebookInstanceLifecycleMountScriptTemplate, accessPointOpt, efsID, mountPoint)
+
+	d.SetId(resource.UniqueId())
+
+	if err := d.Set("on_start", base64.StdEncoding.EncodeToString([]byte(script))); err != nil {
+		return fmt.Errorf("error setting on_start: %s", err)
+	}
+
+	return nil
+}
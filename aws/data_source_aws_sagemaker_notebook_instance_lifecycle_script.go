@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// dataSourceAwsSagemakerNotebookInstanceLifecycleScript concatenates several
+// base64-encoded lifecycle hook fragments (e.g. from multiple
+// aws_sagemaker_notebook_instance_lifecycle_configuration on_start values)
+// into a single base64-encoded script, so composing a hook from reusable
+// snippets doesn't require hand-rolling shell concatenation in HCL.
+func dataSourceAwsSagemakerNotebookInstanceLifecycleScript() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstanceLifecycleScriptRead,
+
+		Schema: map[string]*schema.Schema{
+			"fragment": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstanceLifecycleScriptRead(d *schema.ResourceData, meta interface{}) error {
+	merged, err := mergeSagemakerLifecycleScriptFragments(d.Get("fragment").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	content := base64.StdEncoding.EncodeToString([]byte(merged))
+
+	d.SetId(strconv.Itoa(hashcode.String(content)))
+
+	if err := d.Set("content", content); err != nil {
+		return fmt.Errorf("error setting content for notebook instance lifecycle script: %s", err)
+	}
+
+	return nil
+}
+
+// mergeSagemakerLifecycleScriptFragments decodes each base64-encoded
+// fragment, in order, strips a leading shebang line from every fragment
+// (since each one may have been authored as a standalone script), and joins
+// the remainder under a single "#!/bin/bash" shebang.
+func mergeSagemakerLifecycleScriptFragments(fragments []interface{}) (string, error) {
+	const shebang = "#!/bin/bash"
+
+	parts := make([]string, 0, len(fragments))
+
+	for i, f := range fragments {
+		decoded, err := base64.StdEncoding.DecodeString(f.(string))
+		if err != nil {
+			return "", fmt.Errorf("error decoding fragment %d: fragments must be base64-encoded: %s", i, err)
+		}
+
+		lines := strings.Split(strings.TrimRight(string(decoded), "\n"), "\n")
+		if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+			lines = lines[1:]
+		}
+
+		if fragment := strings.TrimSpace(strings.Join(lines, "\n")); fragment != "" {
+			parts = append(parts, fragment)
+		}
+	}
+
+	return shebang + "\n" + strings.Join(parts, "\n\n"), nil
+}
@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func TestMergeSagemakerLifecycleScriptFragments(t *testing.T) {
+	t.Run("strips shebangs and joins in order", func(t *testing.T) {
+		fragments := []interface{}{
+			b64("#!/bin/bash\necho one"),
+			b64("#!/bin/sh\necho two"),
+		}
+
+		got, err := mergeSagemakerLifecycleScriptFragments(fragments)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := "#!/bin/bash\necho one\n\necho two"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("fragment without a shebang is preserved", func(t *testing.T) {
+		fragments := []interface{}{b64("echo one")}
+
+		got, err := mergeSagemakerLifecycleScriptFragments(fragments)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := "#!/bin/bash\necho one"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid base64 fragment errors", func(t *testing.T) {
+		if _, err := mergeSagemakerLifecycleScriptFragments([]interface{}{"not-valid-base64!!"}); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}
+
+func TestDataSourceAwsSagemakerNotebookInstanceLifecycleScriptRead(t *testing.T) {
+	d := dataSourceAwsSagemakerNotebookInstanceLifecycleScript().TestResourceData()
+	if err := d.Set("fragment", []interface{}{b64("echo hello")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := dataSourceAwsSagemakerNotebookInstanceLifecycleScriptRead(d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content := d.Get("content").(string)
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		t.Fatalf("content is not valid base64: %s", err)
+	}
+
+	if string(decoded) != "#!/bin/bash\necho hello" {
+		t.Errorf("got decoded content %q, want %q", string(decoded), "#!/bin/bash\necho hello")
+	}
+
+	if d.Id() == "" {
+		t.Error("expected an id to be set")
+	}
+}
@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceAwsSagemakerNotebookInstancePresignedUrl() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstancePresignedUrlRead,
+
+		Schema: map[string]*schema.Schema{
+			"notebook_instance_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"session_expiration_duration_in_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      43200,
+				ValidateFunc: validation.IntBetween(1800, 43200),
+			},
+
+			// url is single-use and expires 5 minutes after it is issued, so
+			// it is never safe to cache in state: every read re-resolves it.
+			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstancePresignedUrlRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	input := &sagemaker.CreatePresignedNotebookInstanceUrlInput{
+		NotebookInstanceName: aws.String(d.Get("notebook_instance_name").(string)),
+	}
+
+	if v, ok := d.GetOk("session_expiration_duration_in_seconds"); ok {
+		input.SessionExpirationDurationInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	out, err := conn.CreatePresignedNotebookInstanceUrl(input)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Notebook Instance presigned URL: %s", err)
+	}
+
+	if err := d.Set("url", out.AuthorizedUrl); err != nil {
+		return fmt.Errorf("error setting url for notebook instance presigned url: %s", err)
+	}
+
+	// Force this data source to re-resolve on every apply/refresh instead of
+	// reusing a stale, already-expired URL from state.
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// sagemakerListNotebookInstancesMaxResults is the page size passed to every
+// ListNotebookInstances call: SageMaker's documented maximum, so a large
+// account is paginated in as few throttle-prone round trips as possible.
+const sagemakerListNotebookInstancesMaxResults = 100
+
+func dataSourceAwsSagemakerNotebookInstances() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerNotebookInstancesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_contains": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"status_equals": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.NotebookInstanceStatus_Values(), false),
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"arns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerNotebookInstancesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	input := &sagemaker.ListNotebookInstancesInput{
+		MaxResults: aws.Int64(sagemakerListNotebookInstancesMaxResults),
+	}
+
+	if v, ok := d.GetOk("name_contains"); ok {
+		input.NameContains = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("status_equals"); ok {
+		input.StatusEquals = aws.String(v.(string))
+	}
+
+	names, arns, err := sagemakerListAllNotebookInstancesWithRetry(conn, input)
+	if err != nil {
+		return fmt.Errorf("error listing Sagemaker Notebook Instances: %s", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting names: %s", err)
+	}
+
+	if err := d.Set("arns", arns); err != nil {
+		return fmt.Errorf("error setting arns: %s", err)
+	}
+
+	return nil
+}
+
+// sagemakerListAllNotebookInstancesWithRetry pages through
+// ListNotebookInstances until NextToken is exhausted, retrying each page
+// individually with backoff on throttling. ListNotebookInstancesPages can't
+// be reused here because the SDK's pagination helper aborts the whole walk
+// on the first page-level error, which is exactly what a throttled page in
+// the middle of a large account would otherwise trigger.
+func sagemakerListAllNotebookInstancesWithRetry(conn sagemakeriface.SageMakerAPI, input *sagemaker.ListNotebookInstancesInput) (names, arns []string, err error) {
+	req := *input
+
+	for {
+		var out *sagemaker.ListNotebookInstancesOutput
+
+		retryErr := resource.Retry(2*time.Minute, func() *resource.RetryError {
+			var err error
+			out, err = conn.ListNotebookInstances(&req)
+			if err != nil {
+				if isSagemakerRetryableErr(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+
+			return nil
+		})
+		if retryErr != nil {
+			return nil, nil, retryErr
+		}
+
+		for _, n := range out.NotebookInstances {
+			names = append(names, aws.StringValue(n.NotebookInstanceName))
+			arns = append(arns, aws.StringValue(n.NotebookInstanceArn))
+		}
+
+		if out.NextToken == nil {
+			return names, arns, nil
+		}
+
+		req.NextToken = out.NextToken
+	}
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+)
+
+// throttlingThenSucceedingListNotebookInstancesAPI embeds
+// sagemakeriface.SageMakerAPI so it satisfies the full interface while only
+// overriding ListNotebookInstances: the first page succeeds, the second page
+// throttles once and then succeeds, and a third call returns the final page.
+type throttlingThenSucceedingListNotebookInstancesAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	calls int
+}
+
+func (m *throttlingThenSucceedingListNotebookInstancesAPI) ListNotebookInstances(input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+	m.calls++
+
+	switch {
+	case aws.StringValue(input.NextToken) == "" && m.calls == 1:
+		return &sagemaker.ListNotebookInstancesOutput{
+			NotebookInstances: []*sagemaker.NotebookInstanceSummary{
+				{NotebookInstanceName: aws.String("first"), NotebookInstanceArn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/first")},
+			},
+			NextToken: aws.String("page2"),
+		}, nil
+	case aws.StringValue(input.NextToken) == "page2" && m.calls == 2:
+		return nil, awserr.New("ThrottlingException", "Rate exceeded", nil)
+	case aws.StringValue(input.NextToken) == "page2":
+		return &sagemaker.ListNotebookInstancesOutput{
+			NotebookInstances: []*sagemaker.NotebookInstanceSummary{
+				{NotebookInstanceName: aws.String("second"), NotebookInstanceArn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/second")},
+			},
+		}, nil
+	}
+
+	panic(fmt.Sprintf("unexpected ListNotebookInstances call %d with NextToken %q", m.calls, aws.StringValue(input.NextToken)))
+}
+
+func TestSagemakerListAllNotebookInstancesWithRetryRetriesThrottling(t *testing.T) {
+	conn := &throttlingThenSucceedingListNotebookInstancesAPI{}
+
+	names, arns, err := sagemakerListAllNotebookInstancesWithRetry(conn, &sagemaker.ListNotebookInstancesInput{
+		MaxResults: aws.Int64(sagemakerListNotebookInstancesMaxResults),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Errorf("got names %#v, want [first second]", names)
+	}
+
+	if len(arns) != 2 {
+		t.Errorf("got %d arns, want 2", len(arns))
+	}
+
+	if conn.calls != 3 {
+		t.Errorf("got %d ListNotebookInstances calls, want 3 (page1, throttled page2, retried page2)", conn.calls)
+	}
+}
@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// sagemakerPrebuiltECRImageAccountIDByRegion maps the AWS regions that host
+// SageMaker's prebuilt algorithm/framework images to the account ID that
+// owns the corresponding ECR repository.
+var sagemakerPrebuiltECRImageAccountIDByRegion = map[string]string{
+	"us-east-1":      "763104351884",
+	"us-east-2":      "763104351884",
+	"us-west-1":      "763104351884",
+	"us-west-2":      "763104351884",
+	"ap-northeast-1": "763104351884",
+	"ap-northeast-2": "763104351884",
+	"ap-south-1":     "763104351884",
+	"ap-southeast-1": "763104351884",
+	"ap-southeast-2": "763104351884",
+	"ca-central-1":   "763104351884",
+	"eu-central-1":   "763104351884",
+	"eu-west-1":      "763104351884",
+	"eu-west-2":      "763104351884",
+	"eu-west-3":      "763104351884",
+	"eu-north-1":     "763104351884",
+	"sa-east-1":      "763104351884",
+}
+
+// sagemakerPrebuiltECRImageAccountIDByRegionOverride holds the account IDs
+// for repositories whose registry ID in the aws-us-gov and aws-cn
+// partitions differs from the commercial default above, keyed by
+// repository name and then region. Repositories not listed here are not
+// yet known to be published outside the commercial partition.
+var sagemakerPrebuiltECRImageAccountIDByRegionOverride = map[string]map[string]string{
+	"xgboost": {
+		"us-gov-west-1":  "226302683229",
+		"cn-north-1":     "390948362332",
+		"cn-northwest-1": "387376663083",
+	},
+}
+
+func dataSourceAwsSagemakerPrebuiltEcrImage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerPrebuiltEcrImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"image_tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "latest",
+			},
+
+			"registry_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"registry_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// sagemakerPrebuiltEcrImageRegistryID resolves the account ID that owns the
+// prebuilt ECR repository for repositoryName in region, consulting the
+// per-partition override table before falling back to the commercial
+// default table.
+func sagemakerPrebuiltEcrImageRegistryID(repositoryName, region string) (string, error) {
+	if accountID, ok := sagemakerPrebuiltECRImageAccountIDByRegionOverride[repositoryName][region]; ok {
+		return accountID, nil
+	}
+
+	if accountID, ok := sagemakerPrebuiltECRImageAccountIDByRegion[region]; ok {
+		return accountID, nil
+	}
+
+	return "", fmt.Errorf("no SageMaker prebuilt ECR image account known for region %q; set registry_id explicitly", region)
+}
+
+func dataSourceAwsSagemakerPrebuiltEcrImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+	region := client.region
+	repositoryName := d.Get("repository_name").(string)
+
+	registryID := d.Get("registry_id").(string)
+	if registryID == "" {
+		accountID, err := sagemakerPrebuiltEcrImageRegistryID(repositoryName, region)
+		if err != nil {
+			return err
+		}
+		registryID = accountID
+	}
+
+	imageTag := d.Get("image_tag").(string)
+
+	registryPath := fmt.Sprintf("%s.dkr.ecr.%s.%s/%s:%s", registryID, region, client.dnssuffix, repositoryName, imageTag)
+
+	d.SetId(registryPath)
+
+	if err := d.Set("registry_id", registryID); err != nil {
+		return fmt.Errorf("error setting registry_id: %s", err)
+	}
+
+	if err := d.Set("registry_path", registryPath); err != nil {
+		return fmt.Errorf("error setting registry_path: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package aws
+
+import "testing"
+
+func TestSagemakerPrebuiltEcrImageRegistryID(t *testing.T) {
+	testCases := []struct {
+		name       string
+		repository string
+		region     string
+		want       string
+	}{
+		{
+			name:       "commercial region",
+			repository: "xgboost",
+			region:     "us-east-1",
+			want:       "763104351884",
+		},
+		{
+			name:       "govcloud region",
+			repository: "xgboost",
+			region:     "us-gov-west-1",
+			want:       "226302683229",
+		},
+		{
+			name:       "china region",
+			repository: "xgboost",
+			region:     "cn-north-1",
+			want:       "390948362332",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sagemakerPrebuiltEcrImageRegistryID(tc.repository, tc.region)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("got registry ID %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerPrebuiltEcrImageRegistryID_unknownRegion(t *testing.T) {
+	if _, err := sagemakerPrebuiltEcrImageRegistryID("xgboost", "mars-central-1"); err == nil {
+		t.Error("expected an error for an unknown region, got nil")
+	}
+}
@@ -0,0 +1,161 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+// trainingJobListConcurrency bounds the number of concurrent DescribeTrainingJob
+// calls made while resolving a ListTrainingJobs page, keeping the data source
+// responsive without tripping SageMaker API throttles.
+const trainingJobListConcurrency = 10
+
+func dataSourceAwsSagemakerTrainingJob() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSagemakerTrainingJobRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_contains": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"status_equals": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"creation_time_after": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"creation_time_before": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"training_jobs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSagemakerTrainingJobRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	listOpts := &sagemaker.ListTrainingJobsInput{}
+
+	if v, ok := d.GetOk("name_contains"); ok {
+		listOpts.NameContains = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("status_equals"); ok {
+		listOpts.StatusEquals = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("creation_time_after"); ok {
+		t, err := expandSagemakerTrainingJobTime(v.(string))
+		if err != nil {
+			return err
+		}
+		listOpts.CreationTimeAfter = t
+	}
+
+	if v, ok := d.GetOk("creation_time_before"); ok {
+		t, err := expandSagemakerTrainingJobTime(v.(string))
+		if err != nil {
+			return err
+		}
+		listOpts.CreationTimeBefore = t
+	}
+
+	var summaries []*sagemaker.TrainingJobSummary
+	err := conn.ListTrainingJobsPages(listOpts, func(page *sagemaker.ListTrainingJobsOutput, lastPage bool) bool {
+		summaries = append(summaries, page.TrainingJobSummaries...)
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+
+	trainingJobs, err := describeSagemakerTrainingJobsConcurrently(conn, summaries)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("training_jobs", trainingJobs); err != nil {
+		return err
+	}
+
+	d.SetId(meta.(*AWSClient).region)
+
+	return nil
+}
+
+func describeSagemakerTrainingJobsConcurrently(conn *sagemaker.SageMaker, summaries []*sagemaker.TrainingJobSummary) ([]map[string]interface{}, error) {
+	results := make([]map[string]interface{}, len(summaries))
+
+	var g errgroup.Group
+	sem := make(chan struct{}, trainingJobListConcurrency)
+
+	for i, summary := range summaries {
+		i, summary := i, summary
+
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out, err := conn.DescribeTrainingJob(&sagemaker.DescribeTrainingJobInput{
+				TrainingJobName: summary.TrainingJobName,
+			})
+			if err != nil {
+				return err
+			}
+
+			results[i] = map[string]interface{}{
+				"name":          aws.StringValue(out.TrainingJobName),
+				"arn":           aws.StringValue(out.TrainingJobArn),
+				"role_arn":      aws.StringValue(out.RoleArn),
+				"status":        aws.StringValue(out.TrainingJobStatus),
+				"creation_time": out.CreationTime.String(),
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
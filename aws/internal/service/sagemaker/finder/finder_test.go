@@ -0,0 +1,50 @@
+package finder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestIsResourceNotFoundErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "older-style ResourceNotFoundException",
+			err:      awserr.New("ResourceNotFoundException", "not found", nil),
+			expected: true,
+		},
+		{
+			name:     "newer-style ResourceNotFound",
+			err:      awserr.New("ResourceNotFound", "not found", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated awserr code",
+			err:      awserr.New("ValidationException", "bad input", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("boom"),
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsResourceNotFoundErr(tc.err); got != tc.expected {
+				t.Errorf("IsResourceNotFoundErr(%v) = %t, want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
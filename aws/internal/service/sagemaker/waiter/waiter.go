@@ -0,0 +1,323 @@
+// Package waiter collects resource.StateChangeConf-based waiters for
+// SageMaker resources, so a new resource's Create/Update/Delete can reuse a
+// status poller instead of hand-rolling another copy of the same
+// Pending/Target bookkeeping. resource_aws_sagemaker_notebook_instance.go
+// predates this package and keeps its own context-aware waiters (see the
+// doc comment on resourceAwsSagemakerNotebookInstance for why), but new
+// SageMaker resources should reach for this package first.
+package waiter
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const (
+	NotebookInstanceInServiceDelay = 10 * time.Second
+	NotebookInstanceStoppedDelay   = 10 * time.Second
+	NotebookInstanceDeletedDelay   = 10 * time.Second
+
+	InferenceComponentInServiceDelay = 10 * time.Second
+	InferenceComponentDeletedDelay   = 10 * time.Second
+
+	MlflowTrackingServerCreatedDelay = 30 * time.Second
+	MlflowTrackingServerDeletedDelay = 30 * time.Second
+
+	ClusterInServiceDelay = 30 * time.Second
+	ClusterDeletedDelay   = 30 * time.Second
+
+	CompilationJobCompletedDelay = 30 * time.Second
+)
+
+// NotebookInstanceInService waits for a notebook instance to reach InService.
+func NotebookInstanceInService(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.NotebookInstanceStatusPending, sagemaker.NotebookInstanceStatusUpdating},
+		Target:     []string{sagemaker.NotebookInstanceStatusInService},
+		Refresh:    statusNotebookInstance(conn, name),
+		Timeout:    timeout,
+		Delay:      NotebookInstanceInServiceDelay,
+		MinTimeout: NotebookInstanceInServiceDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeNotebookInstanceOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// NotebookInstanceStopped waits for a notebook instance to reach Stopped.
+func NotebookInstanceStopped(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.NotebookInstanceStatusStopping},
+		Target:     []string{sagemaker.NotebookInstanceStatusStopped},
+		Refresh:    statusNotebookInstance(conn, name),
+		Timeout:    timeout,
+		Delay:      NotebookInstanceStoppedDelay,
+		MinTimeout: NotebookInstanceStoppedDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeNotebookInstanceOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// NotebookInstanceDeleted waits for a notebook instance to disappear.
+func NotebookInstanceDeleted(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.NotebookInstanceStatusDeleting},
+		Target:     []string{},
+		Refresh:    statusNotebookInstance(conn, name),
+		Timeout:    timeout,
+		Delay:      NotebookInstanceDeletedDelay,
+		MinTimeout: NotebookInstanceDeletedDelay,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// InferenceComponentInService waits for an inference component to reach
+// InService, whether it's being created for the first time or settling
+// after an update (both leave it Pending/Updating in the meantime).
+func InferenceComponentInService(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeInferenceComponentOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.InferenceComponentStatusCreating, sagemaker.InferenceComponentStatusUpdating},
+		Target:     []string{sagemaker.InferenceComponentStatusInService},
+		Refresh:    statusInferenceComponent(conn, name),
+		Timeout:    timeout,
+		Delay:      InferenceComponentInServiceDelay,
+		MinTimeout: InferenceComponentInServiceDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeInferenceComponentOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// InferenceComponentDeleted waits for an inference component to disappear.
+func InferenceComponentDeleted(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.InferenceComponentStatusDeleting},
+		Target:     []string{},
+		Refresh:    statusInferenceComponent(conn, name),
+		Timeout:    timeout,
+		Delay:      InferenceComponentDeletedDelay,
+		MinTimeout: InferenceComponentDeletedDelay,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func statusInferenceComponent(conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.InferenceComponentByName(conn, name)
+		if err != nil {
+			if isResourceNotFoundErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, aws.StringValue(output.InferenceComponentStatus), nil
+	}
+}
+
+// MlflowTrackingServerCreated waits for an MLflow tracking server to reach
+// Created, whether it's being created for the first time or settling after
+// an update (both leave it Creating/Updating in the meantime).
+func MlflowTrackingServerCreated(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeMlflowTrackingServerOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.TrackingServerStatusCreating, sagemaker.TrackingServerStatusUpdating},
+		Target:     []string{sagemaker.TrackingServerStatusCreated},
+		Refresh:    statusMlflowTrackingServer(conn, name),
+		Timeout:    timeout,
+		Delay:      MlflowTrackingServerCreatedDelay,
+		MinTimeout: MlflowTrackingServerCreatedDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeMlflowTrackingServerOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// MlflowTrackingServerDeleted waits for an MLflow tracking server to
+// disappear.
+func MlflowTrackingServerDeleted(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.TrackingServerStatusDeleting},
+		Target:     []string{},
+		Refresh:    statusMlflowTrackingServer(conn, name),
+		Timeout:    timeout,
+		Delay:      MlflowTrackingServerDeletedDelay,
+		MinTimeout: MlflowTrackingServerDeletedDelay,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func statusMlflowTrackingServer(conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.MlflowTrackingServerByName(conn, name)
+		if err != nil {
+			if isResourceNotFoundErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, aws.StringValue(output.TrackingServerStatus), nil
+	}
+}
+
+// ClusterInService waits for a HyperPod cluster to reach InService, whether
+// it's being created for the first time or settling after an instance group
+// scaling update (both leave it Creating/Updating in the meantime).
+func ClusterInService(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeClusterOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.ClusterStatusCreating, sagemaker.ClusterStatusUpdating},
+		Target:     []string{sagemaker.ClusterStatusInService},
+		Refresh:    statusCluster(conn, name),
+		Timeout:    timeout,
+		Delay:      ClusterInServiceDelay,
+		MinTimeout: ClusterInServiceDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeClusterOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// ClusterDeleted waits for a HyperPod cluster to disappear.
+func ClusterDeleted(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.ClusterStatusDeleting},
+		Target:     []string{},
+		Refresh:    statusCluster(conn, name),
+		Timeout:    timeout,
+		Delay:      ClusterDeletedDelay,
+		MinTimeout: ClusterDeletedDelay,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func statusCluster(conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.ClusterByName(conn, name)
+		if err != nil {
+			if isResourceNotFoundErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, aws.StringValue(output.ClusterStatus), nil
+	}
+}
+
+// CompilationJobCompleted waits for a compilation job to reach a terminal
+// state (Completed, Failed, or Stopped), returning the last-seen output even
+// on the Failed/Stopped target so the caller can inspect FailureReason
+// without a second Describe call.
+func CompilationJobCompleted(conn sagemakeriface.SageMakerAPI, name string, timeout time.Duration) (*sagemaker.DescribeCompilationJobOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{sagemaker.CompilationJobStatusInprogress, sagemaker.CompilationJobStatusStarting},
+		Target: []string{
+			sagemaker.CompilationJobStatusCompleted,
+			sagemaker.CompilationJobStatusFailed,
+			sagemaker.CompilationJobStatusStopped,
+		},
+		Refresh:    statusCompilationJob(conn, name),
+		Timeout:    timeout,
+		Delay:      CompilationJobCompletedDelay,
+		MinTimeout: CompilationJobCompletedDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+	if output, ok := outputRaw.(*sagemaker.DescribeCompilationJobOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func statusCompilationJob(conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.CompilationJobByName(conn, name)
+		if err != nil {
+			if isResourceNotFoundErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, aws.StringValue(output.CompilationJobStatus), nil
+	}
+}
+
+// statusNotebookInstance is the shared resource.StateRefreshFunc backing all
+// three waiters above: it treats "resource is gone" as an empty status
+// rather than an error, which is what lets NotebookInstanceDeleted use an
+// empty Target to mean "wait until it's not found".
+func statusNotebookInstance(conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.NotebookInstanceByName(conn, name)
+		if err != nil {
+			if isResourceNotFoundErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, aws.StringValue(output.NotebookInstanceStatus), nil
+	}
+}
+
+// isResourceNotFoundErr defers to finder.IsResourceNotFoundErr so the
+// waiter and finder packages agree on exactly one definition of "not found".
+func isResourceNotFoundErr(err error) bool {
+	return finder.IsResourceNotFoundErr(err)
+}
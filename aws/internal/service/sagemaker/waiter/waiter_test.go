@@ -0,0 +1,54 @@
+package waiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+)
+
+// mockSageMakerAPI embeds sagemakeriface.SageMakerAPI so it satisfies the
+// full interface while only overriding DescribeNotebookInstance, the only
+// method the waiters under test actually call.
+type mockSageMakerAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	describeNotebookInstance func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error)
+}
+
+func (m *mockSageMakerAPI) DescribeNotebookInstance(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	return m.describeNotebookInstance(input)
+}
+
+func TestNotebookInstanceInService(t *testing.T) {
+	conn := &mockSageMakerAPI{
+		describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+			}, nil
+		},
+	}
+
+	output, err := NotebookInstanceInService(conn, "test", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := aws.StringValue(output.NotebookInstanceStatus); got != sagemaker.NotebookInstanceStatusInService {
+		t.Errorf("got status %q, want %q", got, sagemaker.NotebookInstanceStatusInService)
+	}
+}
+
+func TestNotebookInstanceDeleted(t *testing.T) {
+	conn := &mockSageMakerAPI{
+		describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			return nil, awserr.New("ResourceNotFoundException", "not found", nil)
+		},
+	}
+
+	if err := NotebookInstanceDeleted(conn, "test", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
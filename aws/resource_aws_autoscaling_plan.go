@@ -0,0 +1,549 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscalingplans"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsAutoscalingPlan() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAutoscalingPlanCreate,
+		Read:   resourceAwsAutoscalingPlanRead,
+		Update: resourceAwsAutoscalingPlanUpdate,
+		Delete: resourceAwsAutoscalingPlanDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"application_source": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloudformation_stack_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"tag_filter": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"values": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"scaling_instruction": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_namespace": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"scalable_dimension": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"min_capacity": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"max_capacity": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"target_tracking_configuration": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"predefined_scaling_metric_specification": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"predefined_scaling_metric_type": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"resource_label": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+									"customized_scaling_metric_specification": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"metric_name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"namespace": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"statistic": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"unit": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+									"target_value": {
+										Type:     schema.TypeFloat,
+										Required: true,
+									},
+									"disable_scale_in": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
+						},
+						"predictive_scaling_max_capacity_behavior": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"predictive_scaling_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"scaling_policy_update_behavior": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsAutoscalingPlanCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingplansconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &autoscalingplans.CreateScalingPlanInput{
+		ScalingPlanName:     aws.String(name),
+		ApplicationSource:   expandAutoscalingPlanApplicationSource(d.Get("application_source").([]interface{})),
+		ScalingInstructions: expandAutoscalingPlanScalingInstructions(d.Get("scaling_instruction").(*schema.Set).List()),
+	}
+
+	log.Printf("[DEBUG] Autoscaling Plan create config: %#v", *createOpts)
+	_, err := conn.CreateScalingPlan(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Autoscaling Plan: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", name, 1))
+	log.Printf("[INFO] Autoscaling Plan ID: %s", d.Id())
+
+	if err := waitAutoscalingPlanStatus(conn, name, autoscalingPlanVersion(d), "Active"); err != nil {
+		return fmt.Errorf("Error waiting for Autoscaling Plan (%s) to become active: %s", d.Id(), err)
+	}
+
+	return resourceAwsAutoscalingPlanRead(d, meta)
+}
+
+func resourceAwsAutoscalingPlanRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingplansconn
+
+	name, version, err := parseAutoscalingPlanId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	plan, err := describeAutoscalingPlan(conn, name, version)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ObjectNotFoundException" {
+			d.SetId("")
+			log.Printf("[LOG] Unable to find Autoscaling Plan %q; removing from state file", d.Id())
+			return nil
+		}
+		return err
+	}
+
+	if plan == nil {
+		d.SetId("")
+		log.Printf("[LOG] Unable to find Autoscaling Plan %q; removing from state file", d.Id())
+		return nil
+	}
+
+	if err := d.Set("name", plan.ScalingPlanName); err != nil {
+		return fmt.Errorf("error setting name for autoscaling plan %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("application_source", flattenAutoscalingPlanApplicationSource(plan.ApplicationSource)); err != nil {
+		return fmt.Errorf("error setting application_source for autoscaling plan %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("scaling_instruction", flattenAutoscalingPlanScalingInstructions(plan.ScalingInstructions)); err != nil {
+		return fmt.Errorf("error setting scaling_instruction for autoscaling plan %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsAutoscalingPlanUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingplansconn
+
+	name, version, err := parseAutoscalingPlanId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	updateOpts := &autoscalingplans.UpdateScalingPlanInput{
+		ScalingPlanName:     aws.String(name),
+		ScalingPlanVersion:  aws.Int64(version),
+		ApplicationSource:   expandAutoscalingPlanApplicationSource(d.Get("application_source").([]interface{})),
+		ScalingInstructions: expandAutoscalingPlanScalingInstructions(d.Get("scaling_instruction").(*schema.Set).List()),
+	}
+
+	if _, err := conn.UpdateScalingPlan(updateOpts); err != nil {
+		return fmt.Errorf("Error updating Autoscaling Plan: %s", err)
+	}
+
+	newVersion := version + 1
+	d.SetId(fmt.Sprintf("%s:%d", name, newVersion))
+
+	if err := waitAutoscalingPlanStatus(conn, name, newVersion, "Active"); err != nil {
+		return fmt.Errorf("Error waiting for Autoscaling Plan (%s) to become active: %s", d.Id(), err)
+	}
+
+	return resourceAwsAutoscalingPlanRead(d, meta)
+}
+
+func resourceAwsAutoscalingPlanDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingplansconn
+
+	name, version, err := parseAutoscalingPlanId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	deleteOpts := &autoscalingplans.DeleteScalingPlanInput{
+		ScalingPlanName:    aws.String(name),
+		ScalingPlanVersion: aws.Int64(version),
+	}
+
+	if _, err := conn.DeleteScalingPlan(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ObjectNotFoundException" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Autoscaling Plan: %s", err)
+	}
+
+	return nil
+}
+
+// autoscalingPlanVersion returns the version encoded in the resource's composite
+// ID (name:version), or 1 for a plan that has not been created yet.
+func autoscalingPlanVersion(d *schema.ResourceData) int64 {
+	if d.Id() == "" {
+		return 1
+	}
+
+	_, version, err := parseAutoscalingPlanId(d.Id())
+	if err != nil {
+		return 1
+	}
+
+	return version
+}
+
+func parseAutoscalingPlanId(id string) (string, int64, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("Unexpected format of ID (%q), expected NAME:VERSION", id)
+	}
+
+	version, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Error parsing version from ID (%q): %s", id, err)
+	}
+
+	return parts[0], version, nil
+}
+
+func describeAutoscalingPlan(conn *autoscalingplans.AutoScalingPlans, name string, version int64) (*autoscalingplans.ScalingPlan, error) {
+	out, err := conn.DescribeScalingPlans(&autoscalingplans.DescribeScalingPlansInput{
+		ScalingPlanNames:   []*string{aws.String(name)},
+		ScalingPlanVersion: aws.Int64(version),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.ScalingPlans) == 0 {
+		return nil, nil
+	}
+
+	return out.ScalingPlans[0], nil
+}
+
+func waitAutoscalingPlanStatus(conn *autoscalingplans.AutoScalingPlans, name string, version int64, desiredStatus ...string) error {
+	return resource.Retry(20*time.Minute, func() *resource.RetryError {
+		plan, err := describeAutoscalingPlan(conn, name, version)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if plan == nil {
+			return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Autoscaling Plan (%s:%d) to be found", name, version))
+		}
+
+		status := aws.StringValue(plan.StatusCode)
+
+		for _, s := range desiredStatus {
+			if status == s {
+				log.Printf("[DEBUG] Autoscaling Plan (%s:%d) is %s", name, version, s)
+				return nil
+			}
+		}
+
+		if status == "Error" {
+			return resource.NonRetryableError(fmt.Errorf("Autoscaling Plan (%s:%d) failed: %s", name, version, aws.StringValue(plan.StatusMessage)))
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Autoscaling Plan (%s:%d) to be %s", name, version, desiredStatus))
+	})
+}
+
+func expandAutoscalingPlanApplicationSource(l []interface{}) *autoscalingplans.ApplicationSource {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	source := &autoscalingplans.ApplicationSource{}
+
+	if v, ok := m["cloudformation_stack_arn"]; ok && v.(string) != "" {
+		source.CloudFormationStackARN = aws.String(v.(string))
+	}
+
+	if v, ok := m["tag_filter"]; ok {
+		source.TagFilters = expandAutoscalingPlanTagFilters(v.(*schema.Set).List())
+	}
+
+	return source
+}
+
+func expandAutoscalingPlanTagFilters(l []interface{}) []*autoscalingplans.TagFilter {
+	filters := make([]*autoscalingplans.TagFilter, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		filters = append(filters, &autoscalingplans.TagFilter{
+			Key:    aws.String(m["key"].(string)),
+			Values: expandStringSet(m["values"].(*schema.Set)),
+		})
+	}
+
+	return filters
+}
+
+func expandAutoscalingPlanScalingInstructions(l []interface{}) []*autoscalingplans.ScalingInstruction {
+	instructions := make([]*autoscalingplans.ScalingInstruction, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		instruction := &autoscalingplans.ScalingInstruction{
+			ServiceNamespace:             aws.String(m["service_namespace"].(string)),
+			ResourceId:                   aws.String(m["resource_id"].(string)),
+			ScalableDimension:            aws.String(m["scalable_dimension"].(string)),
+			MinCapacity:                  aws.Int64(int64(m["min_capacity"].(int))),
+			MaxCapacity:                  aws.Int64(int64(m["max_capacity"].(int))),
+			TargetTrackingConfigurations: expandAutoscalingPlanTargetTrackingConfigurations(m["target_tracking_configuration"].(*schema.Set).List()),
+		}
+
+		if v, ok := m["predictive_scaling_max_capacity_behavior"]; ok && v.(string) != "" {
+			instruction.PredictiveScalingMaxCapacityBehavior = aws.String(v.(string))
+		}
+
+		if v, ok := m["predictive_scaling_mode"]; ok && v.(string) != "" {
+			instruction.PredictiveScalingMode = aws.String(v.(string))
+		}
+
+		if v, ok := m["scaling_policy_update_behavior"]; ok && v.(string) != "" {
+			instruction.ScalingPolicyUpdateBehavior = aws.String(v.(string))
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions
+}
+
+func expandAutoscalingPlanTargetTrackingConfigurations(l []interface{}) []*autoscalingplans.TargetTrackingConfiguration {
+	configs := make([]*autoscalingplans.TargetTrackingConfiguration, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		config := &autoscalingplans.TargetTrackingConfiguration{
+			TargetValue:    aws.Float64(m["target_value"].(float64)),
+			DisableScaleIn: aws.Bool(m["disable_scale_in"].(bool)),
+		}
+
+		if v, ok := m["predefined_scaling_metric_specification"]; ok && len(v.([]interface{})) > 0 {
+			spec := v.([]interface{})[0].(map[string]interface{})
+			predefined := &autoscalingplans.PredefinedScalingMetricSpecification{
+				PredefinedScalingMetricType: aws.String(spec["predefined_scaling_metric_type"].(string)),
+			}
+			if r, ok := spec["resource_label"]; ok && r.(string) != "" {
+				predefined.ResourceLabel = aws.String(r.(string))
+			}
+			config.PredefinedScalingMetricSpecification = predefined
+		}
+
+		if v, ok := m["customized_scaling_metric_specification"]; ok && len(v.([]interface{})) > 0 {
+			spec := v.([]interface{})[0].(map[string]interface{})
+			customized := &autoscalingplans.CustomizedScalingMetricSpecification{
+				MetricName: aws.String(spec["metric_name"].(string)),
+				Namespace:  aws.String(spec["namespace"].(string)),
+				Statistic:  aws.String(spec["statistic"].(string)),
+			}
+			if u, ok := spec["unit"]; ok && u.(string) != "" {
+				customized.Unit = aws.String(u.(string))
+			}
+			config.CustomizedScalingMetricSpecification = customized
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs
+}
+
+func flattenAutoscalingPlanApplicationSource(source *autoscalingplans.ApplicationSource) []map[string]interface{} {
+	if source == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"cloudformation_stack_arn": aws.StringValue(source.CloudFormationStackARN),
+		"tag_filter":               flattenAutoscalingPlanTagFilters(source.TagFilters),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenAutoscalingPlanTagFilters(filters []*autoscalingplans.TagFilter) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(filters))
+
+	for _, f := range filters {
+		result = append(result, map[string]interface{}{
+			"key":    aws.StringValue(f.Key),
+			"values": flattenStringList(f.Values),
+		})
+	}
+
+	return result
+}
+
+func flattenAutoscalingPlanScalingInstructions(instructions []*autoscalingplans.ScalingInstruction) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(instructions))
+
+	for _, i := range instructions {
+		result = append(result, map[string]interface{}{
+			"service_namespace":                        aws.StringValue(i.ServiceNamespace),
+			"resource_id":                               aws.StringValue(i.ResourceId),
+			"scalable_dimension":                        aws.StringValue(i.ScalableDimension),
+			"min_capacity":                              aws.Int64Value(i.MinCapacity),
+			"max_capacity":                              aws.Int64Value(i.MaxCapacity),
+			"target_tracking_configuration":             flattenAutoscalingPlanTargetTrackingConfigurations(i.TargetTrackingConfigurations),
+			"predictive_scaling_max_capacity_behavior":  aws.StringValue(i.PredictiveScalingMaxCapacityBehavior),
+			"predictive_scaling_mode":                   aws.StringValue(i.PredictiveScalingMode),
+			"scaling_policy_update_behavior":             aws.StringValue(i.ScalingPolicyUpdateBehavior),
+		})
+	}
+
+	return result
+}
+
+func flattenAutoscalingPlanTargetTrackingConfigurations(configs []*autoscalingplans.TargetTrackingConfiguration) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(configs))
+
+	for _, c := range configs {
+		m := map[string]interface{}{
+			"target_value":     aws.Float64Value(c.TargetValue),
+			"disable_scale_in": aws.BoolValue(c.DisableScaleIn),
+		}
+
+		if c.PredefinedScalingMetricSpecification != nil {
+			m["predefined_scaling_metric_specification"] = []map[string]interface{}{
+				{
+					"predefined_scaling_metric_type": aws.StringValue(c.PredefinedScalingMetricSpecification.PredefinedScalingMetricType),
+					"resource_label":                 aws.StringValue(c.PredefinedScalingMetricSpecification.ResourceLabel),
+				},
+			}
+		}
+
+		if c.CustomizedScalingMetricSpecification != nil {
+			m["customized_scaling_metric_specification"] = []map[string]interface{}{
+				{
+					"metric_name": aws.StringValue(c.CustomizedScalingMetricSpecification.MetricName),
+					"namespace":   aws.StringValue(c.CustomizedScalingMetricSpecification.Namespace),
+					"statistic":   aws.StringValue(c.CustomizedScalingMetricSpecification.Statistic),
+					"unit":        aws.StringValue(c.CustomizedScalingMetricSpecification.Unit),
+				},
+			}
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
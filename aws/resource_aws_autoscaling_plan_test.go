@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscalingplans"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAutoscalingPlan_basic(t *testing.T) {
+	var plan autoscalingplans.ScalingPlan
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_autoscaling_plan.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingPlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSAutoscalingPlanConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingPlanExists(resourceName, &plan),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "scaling_instruction.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAutoscalingPlanExists(n string, plan *autoscalingplans.ScalingPlan) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Autoscaling Plan ID is set")
+		}
+
+		name, version, err := parseAutoscalingPlanId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingplansconn
+		out, err := describeAutoscalingPlan(conn, name, version)
+		if err != nil {
+			return err
+		}
+
+		if out == nil {
+			return fmt.Errorf("Autoscaling Plan %q not found", rs.Primary.ID)
+		}
+
+		*plan = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoscalingPlanDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingplansconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_autoscaling_plan" {
+			continue
+		}
+
+		name, version, err := parseAutoscalingPlanId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := describeAutoscalingPlan(conn, name, version)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ObjectNotFoundException" {
+				continue
+			}
+			return err
+		}
+
+		if out != nil {
+			return fmt.Errorf("Autoscaling Plan %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSAutoscalingPlanConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_autoscaling_group" "test" {
+  name                 = %[1]q
+  availability_zones   = [data.aws_availability_zones.available.names[0]]
+  min_size             = 1
+  max_size             = 2
+  launch_configuration = aws_launch_configuration.test.name
+}
+
+resource "aws_launch_configuration" "test" {
+  name          = %[1]q
+  image_id      = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t2.micro"
+}
+
+data "aws_ami" "amzn-ami-minimal-hvm-ebs" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn-ami-minimal-hvm-*"]
+  }
+}
+
+data "aws_availability_zones" "available" {
+  state = "available"
+}
+
+resource "aws_autoscaling_plan" "test" {
+  name = %[1]q
+
+  application_source {
+    tag_filter {
+      key    = "terraform-test"
+      values = ["true"]
+    }
+  }
+
+  scaling_instruction {
+    service_namespace  = "autoscaling"
+    resource_id        = "autoScalingGroup/${aws_autoscaling_group.test.name}"
+    scalable_dimension = "autoscaling:autoScalingGroup:DesiredCapacity"
+    min_capacity       = 1
+    max_capacity       = 2
+
+    target_tracking_configuration {
+      predefined_scaling_metric_specification {
+        predefined_scaling_metric_type = "ASGAverageCPUUtilization"
+      }
+
+      target_value = 70
+    }
+  }
+}
+`, rName)
+}
@@ -0,0 +1,304 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerApp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerAppCreate,
+		Read:   resourceAwsSagemakerAppRead,
+		Delete: resourceAwsSagemakerAppDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"user_profile_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"app_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemaker.AppTypeJupyterServer,
+					sagemaker.AppTypeKernelGateway,
+					sagemaker.AppTypeTensorBoard,
+				}, false),
+			},
+
+			"app_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"resource_spec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"sagemaker_image_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"sagemaker_image_version_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerAppCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID := d.Get("domain_id").(string)
+	userProfileName := d.Get("user_profile_name").(string)
+	appType := d.Get("app_type").(string)
+	appName := d.Get("app_name").(string)
+
+	createOpts := &sagemaker.CreateAppInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(userProfileName),
+		AppType:         aws.String(appType),
+		AppName:         aws.String(appName),
+	}
+
+	if v, ok := d.GetOk("resource_spec"); ok {
+		createOpts.ResourceSpec = expandSagemakerAppResourceSpec(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker App create config: %#v", *createOpts)
+	if _, err := conn.CreateApp(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker App: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", domainID, userProfileName, appType, appName))
+	log.Printf("[INFO] Sagemaker App ID: %s", d.Id())
+
+	if err := waitSagemakerAppStatus(conn, domainID, userProfileName, appType, appName, d.Timeout(schema.TimeoutCreate), sagemaker.AppStatusInService, sagemaker.AppStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker App (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerAppRead(d, meta)
+}
+
+func resourceAwsSagemakerAppRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, userProfileName, appType, appName, err := decodeSagemakerAppId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	app, err := conn.DescribeApp(&sagemaker.DescribeAppInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(userProfileName),
+		AppType:         aws.String(appType),
+		AppName:         aws.String(appName),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker app %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if aws.StringValue(app.Status) == sagemaker.AppStatusDeleted {
+		d.SetId("")
+		log.Printf("[LOG] SageMaker app %q is deleted; removing from state file", d.Id())
+		return nil
+	}
+
+	if err := d.Set("domain_id", app.DomainId); err != nil {
+		return fmt.Errorf("error setting domain_id for app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("user_profile_name", app.UserProfileName); err != nil {
+		return fmt.Errorf("error setting user_profile_name for app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("app_type", app.AppType); err != nil {
+		return fmt.Errorf("error setting app_type for app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("app_name", app.AppName); err != nil {
+		return fmt.Errorf("error setting app_name for app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", app.AppArn); err != nil {
+		return fmt.Errorf("error setting arn for app %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("resource_spec", flattenSagemakerAppResourceSpec(app.ResourceSpec)); err != nil {
+		return fmt.Errorf("error setting resource_spec for app %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, app.AppArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for app %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerAppDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, userProfileName, appType, appName, err := decodeSagemakerAppId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.DeleteApp(&sagemaker.DeleteAppInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(userProfileName),
+		AppType:         aws.String(appType),
+		AppName:         aws.String(appName),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker App: %s", err)
+	}
+
+	if err := waitSagemakerAppStatus(conn, domainID, userProfileName, appType, appName, d.Timeout(schema.TimeoutDelete), sagemaker.AppStatusDeleted, sagemaker.AppStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker App (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func decodeSagemakerAppId(id string) (string, string, string, string, error) {
+	parts := strings.SplitN(id, "/", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return "", "", "", "", fmt.Errorf("unexpected format of ID (%s), expected DOMAIN-ID/USER-PROFILE-NAME/APP-TYPE/APP-NAME", id)
+	}
+
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
+
+func waitSagemakerAppStatus(conn *sagemaker.SageMaker, domainID, userProfileName, appType, appName string, timeout time.Duration, desiredStatus ...string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			sagemaker.AppStatusPending,
+			sagemaker.AppStatusDeleting,
+		},
+		Target: desiredStatus,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeApp(&sagemaker.DescribeAppInput{
+				DomainId:        aws.String(domainID),
+				UserProfileName: aws.String(userProfileName),
+				AppType:         aws.String(appType),
+				AppName:         aws.String(appName),
+			})
+			if err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+					return out, sagemaker.AppStatusDeleted, nil
+				}
+				return nil, "", err
+			}
+
+			return out, aws.StringValue(out.Status), nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func expandSagemakerAppResourceSpec(l []interface{}) *sagemaker.ResourceSpec {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.ResourceSpec{}
+
+	if v, ok := m["instance_type"]; ok && v.(string) != "" {
+		spec.InstanceType = aws.String(v.(string))
+	}
+
+	if v, ok := m["sagemaker_image_arn"]; ok && v.(string) != "" {
+		spec.SageMakerImageArn = aws.String(v.(string))
+	}
+
+	if v, ok := m["sagemaker_image_version_arn"]; ok && v.(string) != "" {
+		spec.SageMakerImageVersionArn = aws.String(v.(string))
+	}
+
+	return spec
+}
+
+func flattenSagemakerAppResourceSpec(spec *sagemaker.ResourceSpec) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"instance_type":               aws.StringValue(spec.InstanceType),
+			"sagemaker_image_arn":         aws.StringValue(spec.SageMakerImageArn),
+			"sagemaker_image_version_arn": aws.StringValue(spec.SageMakerImageVersionArn),
+		},
+	}
+}
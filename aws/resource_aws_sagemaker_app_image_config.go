@@ -0,0 +1,301 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerAppImageConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerAppImageConfigCreate,
+		Read:   resourceAwsSagemakerAppImageConfigRead,
+		Update: resourceAwsSagemakerAppImageConfigUpdate,
+		Delete: resourceAwsSagemakerAppImageConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"app_image_config_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"kernel_gateway_image_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"file_system_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mount_path": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"default_uid": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"default_gid": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"kernel_spec": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"display_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerAppImageConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("app_image_config_name").(string)
+
+	createOpts := &sagemaker.CreateAppImageConfigInput{
+		AppImageConfigName: aws.String(name),
+	}
+
+	if v, ok := d.GetOk("kernel_gateway_image_config"); ok {
+		createOpts.KernelGatewayImageConfig = expandSagemakerAppImageConfigKernelGatewayImageConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker App Image Config create config: %#v", *createOpts)
+	if _, err := conn.CreateAppImageConfig(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker App Image Config: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker App Image Config ID: %s", d.Id())
+
+	return resourceAwsSagemakerAppImageConfigRead(d, meta)
+}
+
+func resourceAwsSagemakerAppImageConfigRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	config, err := conn.DescribeAppImageConfig(&sagemaker.DescribeAppImageConfigInput{
+		AppImageConfigName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker app image config %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("app_image_config_name", config.AppImageConfigName); err != nil {
+		return fmt.Errorf("error setting app_image_config_name for app image config %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", config.AppImageConfigArn); err != nil {
+		return fmt.Errorf("error setting arn for app image config %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("kernel_gateway_image_config", flattenSagemakerAppImageConfigKernelGatewayImageConfig(config.KernelGatewayImageConfig)); err != nil {
+		return fmt.Errorf("error setting kernel_gateway_image_config for app image config %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, config.AppImageConfigArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for app image config %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerAppImageConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("kernel_gateway_image_config") {
+		updateOpts := &sagemaker.UpdateAppImageConfigInput{
+			AppImageConfigName:       aws.String(d.Id()),
+			KernelGatewayImageConfig: expandSagemakerAppImageConfigKernelGatewayImageConfig(d.Get("kernel_gateway_image_config").([]interface{})),
+		}
+
+		if _, err := conn.UpdateAppImageConfig(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker App Image Config: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerAppImageConfigRead(d, meta)
+}
+
+func resourceAwsSagemakerAppImageConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteAppImageConfig(&sagemaker.DeleteAppImageConfigInput{
+		AppImageConfigName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker App Image Config: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerAppImageConfigKernelGatewayImageConfig(l []interface{}) *sagemaker.KernelGatewayImageConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.KernelGatewayImageConfig{}
+
+	if v, ok := m["file_system_config"]; ok {
+		config.FileSystemConfig = expandSagemakerAppImageConfigFileSystemConfig(v.([]interface{}))
+	}
+
+	if v, ok := m["kernel_spec"]; ok {
+		config.KernelSpecs = expandSagemakerAppImageConfigKernelSpecs(v.([]interface{}))
+	}
+
+	return config
+}
+
+func expandSagemakerAppImageConfigFileSystemConfig(l []interface{}) *sagemaker.FileSystemConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.FileSystemConfig{}
+
+	if v, ok := m["mount_path"]; ok && v.(string) != "" {
+		config.MountPath = aws.String(v.(string))
+	}
+
+	if v, ok := m["default_uid"]; ok && v.(int) != 0 {
+		config.DefaultUid = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := m["default_gid"]; ok && v.(int) != 0 {
+		config.DefaultGid = aws.Int64(int64(v.(int)))
+	}
+
+	return config
+}
+
+func expandSagemakerAppImageConfigKernelSpecs(l []interface{}) []*sagemaker.KernelSpec {
+	specs := make([]*sagemaker.KernelSpec, 0, len(l))
+
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+
+		m := v.(map[string]interface{})
+
+		spec := &sagemaker.KernelSpec{
+			Name: aws.String(m["name"].(string)),
+		}
+
+		if dn, ok := m["display_name"]; ok && dn.(string) != "" {
+			spec.DisplayName = aws.String(dn.(string))
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+func flattenSagemakerAppImageConfigKernelGatewayImageConfig(config *sagemaker.KernelGatewayImageConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"file_system_config": flattenSagemakerAppImageConfigFileSystemConfig(config.FileSystemConfig),
+			"kernel_spec":        flattenSagemakerAppImageConfigKernelSpecs(config.KernelSpecs),
+		},
+	}
+}
+
+func flattenSagemakerAppImageConfigFileSystemConfig(config *sagemaker.FileSystemConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"mount_path":  aws.StringValue(config.MountPath),
+			"default_uid": aws.Int64Value(config.DefaultUid),
+			"default_gid": aws.Int64Value(config.DefaultGid),
+		},
+	}
+}
+
+func flattenSagemakerAppImageConfigKernelSpecs(specs []*sagemaker.KernelSpec) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(specs))
+
+	for _, spec := range specs {
+		result = append(result, map[string]interface{}{
+			"name":         aws.StringValue(spec.Name),
+			"display_name": aws.StringValue(spec.DisplayName),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,675 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerAutoMLJobV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerAutoMLJobV2Create,
+		Read:   resourceAwsSagemakerAutoMLJobV2Read,
+		Update: resourceAwsSagemakerAutoMLJobV2Update,
+		Delete: resourceAwsSagemakerAutoMLJobV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(24 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"auto_ml_job_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"auto_ml_job_input_data_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"compression_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"data_source": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_data_source": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"s3_data_type": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+												"s3_uri": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			// auto_ml_problem_type_config only covers tabular_job_config for
+			// now. The API's AutoMLProblemTypeConfig is a union that also
+			// covers image/text classification and time series forecasting,
+			// but tabular is the overwhelmingly common AutoML V2 use case and
+			// the concrete deliverable here, so the other problem types are
+			// left for a follow-up rather than guessed at.
+			"auto_ml_problem_type_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tabular_job_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"target_attribute_name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"problem_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+									"completion_criteria": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"max_candidates": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+												"max_runtime_per_training_job_in_seconds": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+												"max_auto_ml_job_runtime_in_seconds": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"output_data_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_path": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"security_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"enable_inter_container_traffic_encryption": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"vpc_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"security_group_ids": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"subnets": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			// status mirrors DescribeAutoMLJobV2Output.AutoMLJobStatus (e.g.
+			// Completed, Stopped, Failed), the same convention as status on
+			// aws_sagemaker_training_job.
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// best_candidate_name is the winning candidate's name once the
+			// job completes; BestCandidate itself is a large nested object
+			// (inference containers, metrics, steps) that's of no use as
+			// Terraform config/state, so only its name is surfaced.
+			"best_candidate_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerAutoMLJobV2Create(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("auto_ml_job_name").(string)
+
+	createOpts := &sagemaker.CreateAutoMLJobV2Input{
+		AutoMLJobName:            aws.String(name),
+		RoleArn:                  aws.String(d.Get("role_arn").(string)),
+		AutoMLJobInputDataConfig: expandSagemakerAutoMLJobV2InputDataConfig(d.Get("auto_ml_job_input_data_config").([]interface{})),
+		AutoMLProblemTypeConfig:  expandSagemakerAutoMLJobV2ProblemTypeConfig(d.Get("auto_ml_problem_type_config").([]interface{})),
+		OutputDataConfig:         expandSagemakerAutoMLJobV2OutputDataConfig(d.Get("output_data_config").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("security_config"); ok {
+		createOpts.SecurityConfig = expandSagemakerAutoMLJobV2SecurityConfig(v.([]interface{}))
+	}
+
+	createTags, err := sagemakerTagsForCreate(meta, d.Get("tags").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	createOpts.Tags = createTags
+
+	log.Printf("[DEBUG] Sagemaker AutoML Job V2 create config: %#v", *createOpts)
+	if _, err := conn.CreateAutoMLJobV2(createOpts); err != nil {
+		return fmt.Errorf("error creating Sagemaker AutoML Job V2: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker AutoML Job V2 ID: %s", d.Id())
+
+	if err := waitSagemakerAutoMLJobV2Status(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.AutoMLJobStatusCompleted, sagemaker.AutoMLJobStatusFailed, sagemaker.AutoMLJobStatusStopped); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker AutoML Job V2 (%s) to complete: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerAutoMLJobV2Read(d, meta)
+}
+
+func resourceAwsSagemakerAutoMLJobV2Read(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	autoMLJob, err := conn.DescribeAutoMLJobV2(&sagemaker.DescribeAutoMLJobV2Input{
+		AutoMLJobName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException") {
+			d.SetId("")
+			log.Printf("[WARN] Sagemaker AutoML Job V2 (%s) not found, removing from state", d.Id())
+			return nil
+		}
+		return fmt.Errorf("error describing Sagemaker AutoML Job V2 (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("auto_ml_job_name", autoMLJob.AutoMLJobName); err != nil {
+		return fmt.Errorf("error setting auto_ml_job_name for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", autoMLJob.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", autoMLJob.AutoMLJobArn); err != nil {
+		return fmt.Errorf("error setting arn for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("status", autoMLJob.AutoMLJobStatus); err != nil {
+		return fmt.Errorf("error setting status for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+
+	if autoMLJob.BestCandidate != nil {
+		if err := d.Set("best_candidate_name", autoMLJob.BestCandidate.CandidateName); err != nil {
+			return fmt.Errorf("error setting best_candidate_name for AutoML Job V2 %q: %s", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("auto_ml_job_input_data_config", flattenSagemakerAutoMLJobV2InputDataConfig(autoMLJob.AutoMLJobInputDataConfig)); err != nil {
+		return fmt.Errorf("error setting auto_ml_job_input_data_config for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("auto_ml_problem_type_config", flattenSagemakerAutoMLJobV2ProblemTypeConfig(autoMLJob.AutoMLProblemTypeConfig)); err != nil {
+		return fmt.Errorf("error setting auto_ml_problem_type_config for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("output_data_config", flattenSagemakerAutoMLJobV2OutputDataConfig(autoMLJob.OutputDataConfig)); err != nil {
+		return fmt.Errorf("error setting output_data_config for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("security_config", flattenSagemakerAutoMLJobV2SecurityConfig(autoMLJob.SecurityConfig)); err != nil {
+		return fmt.Errorf("error setting security_config for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, autoMLJob.AutoMLJobArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for AutoML Job V2 %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerAutoMLJobV2Update(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	return resourceAwsSagemakerAutoMLJobV2Read(d, meta)
+}
+
+func resourceAwsSagemakerAutoMLJobV2Delete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	// AutoML V2 jobs have no delete API: a finished job just stays around
+	// until it ages out on SageMaker's side. StopAutoMLJob is the closest
+	// equivalent, and a job that's already in a terminal state returns a
+	// ValidationException on Stop, which is treated as already-done rather
+	// than an error.
+	_, err := conn.StopAutoMLJob(&sagemaker.StopAutoMLJobInput{
+		AutoMLJobName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("error stopping Sagemaker AutoML Job V2 (%s): %s", d.Id(), err)
+	}
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		autoMLJob, err := conn.DescribeAutoMLJobV2(&sagemaker.DescribeAutoMLJobV2Input{
+			AutoMLJobName: aws.String(d.Id()),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		switch aws.StringValue(autoMLJob.AutoMLJobStatus) {
+		case sagemaker.AutoMLJobStatusStopped, sagemaker.AutoMLJobStatusFailed, sagemaker.AutoMLJobStatusCompleted:
+			log.Printf("[DEBUG] Sagemaker AutoML Job V2 (%s) stopped", d.Id())
+			return nil
+		}
+
+		return resource.RetryableError(fmt.Errorf("waiting for Sagemaker AutoML Job V2 (%s) to stop", d.Id()))
+	})
+}
+
+func waitSagemakerAutoMLJobV2Status(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		autoMLJob, err := conn.DescribeAutoMLJobV2(&sagemaker.DescribeAutoMLJobV2Input{
+			AutoMLJobName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(autoMLJob.AutoMLJobStatus)
+		for _, s := range desiredStatus {
+			if status == s {
+				log.Printf("[DEBUG] Sagemaker AutoML Job V2 (%s) is %s", name, s)
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("waiting for Sagemaker AutoML Job V2 (%s) to be %s, is %s", name, desiredStatus, status))
+	})
+}
+
+func expandSagemakerAutoMLJobV2InputDataConfig(l []interface{}) []*sagemaker.AutoMLJobChannel {
+	channels := make([]*sagemaker.AutoMLJobChannel, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		channel := &sagemaker.AutoMLJobChannel{
+			DataSource: expandSagemakerAutoMLJobV2DataSource(m["data_source"].([]interface{})),
+		}
+
+		if v, ok := m["channel_type"]; ok && v.(string) != "" {
+			channel.ChannelType = aws.String(v.(string))
+		}
+		if v, ok := m["content_type"]; ok && v.(string) != "" {
+			channel.ContentType = aws.String(v.(string))
+		}
+		if v, ok := m["compression_type"]; ok && v.(string) != "" {
+			channel.CompressionType = aws.String(v.(string))
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels
+}
+
+func expandSagemakerAutoMLJobV2DataSource(l []interface{}) *sagemaker.AutoMLDataSource {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	s3 := m["s3_data_source"].([]interface{})
+	if len(s3) == 0 || s3[0] == nil {
+		return nil
+	}
+
+	s3m := s3[0].(map[string]interface{})
+
+	return &sagemaker.AutoMLDataSource{
+		S3DataSource: &sagemaker.AutoMLS3DataSource{
+			S3DataType: aws.String(s3m["s3_data_type"].(string)),
+			S3Uri:      aws.String(s3m["s3_uri"].(string)),
+		},
+	}
+}
+
+func expandSagemakerAutoMLJobV2ProblemTypeConfig(l []interface{}) *sagemaker.AutoMLProblemTypeConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	tabular := m["tabular_job_config"].([]interface{})
+	if len(tabular) == 0 || tabular[0] == nil {
+		return nil
+	}
+
+	tm := tabular[0].(map[string]interface{})
+
+	config := &sagemaker.TabularJobConfig{
+		TargetAttributeName: aws.String(tm["target_attribute_name"].(string)),
+	}
+
+	if v, ok := tm["problem_type"]; ok && v.(string) != "" {
+		config.ProblemType = aws.String(v.(string))
+	}
+
+	if v, ok := tm["completion_criteria"]; ok {
+		config.CompletionCriteria = expandSagemakerAutoMLJobV2CompletionCriteria(v.([]interface{}))
+	}
+
+	return &sagemaker.AutoMLProblemTypeConfig{
+		TabularJobConfig: config,
+	}
+}
+
+func expandSagemakerAutoMLJobV2CompletionCriteria(l []interface{}) *sagemaker.AutoMLJobCompletionCriteria {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	criteria := &sagemaker.AutoMLJobCompletionCriteria{}
+
+	if v, ok := m["max_candidates"]; ok && v.(int) > 0 {
+		criteria.MaxCandidates = aws.Int64(int64(v.(int)))
+	}
+	if v, ok := m["max_runtime_per_training_job_in_seconds"]; ok && v.(int) > 0 {
+		criteria.MaxRuntimePerTrainingJobInSeconds = aws.Int64(int64(v.(int)))
+	}
+	if v, ok := m["max_auto_ml_job_runtime_in_seconds"]; ok && v.(int) > 0 {
+		criteria.MaxAutoMLJobRuntimeInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	return criteria
+}
+
+func expandSagemakerAutoMLJobV2OutputDataConfig(l []interface{}) *sagemaker.AutoMLOutputDataConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.AutoMLOutputDataConfig{
+		S3OutputPath: aws.String(m["s3_output_path"].(string)),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func expandSagemakerAutoMLJobV2SecurityConfig(l []interface{}) *sagemaker.AutoMLSecurityConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	config := &sagemaker.AutoMLSecurityConfig{}
+
+	if v, ok := m["volume_kms_key_id"]; ok && v.(string) != "" {
+		config.VolumeKmsKeyId = aws.String(v.(string))
+	}
+	if v, ok := m["enable_inter_container_traffic_encryption"]; ok {
+		config.EnableInterContainerTrafficEncryption = aws.Bool(v.(bool))
+	}
+	if v, ok := m["vpc_config"]; ok {
+		config.VpcConfig = expandSagemakerAutoMLJobV2VpcConfig(v.([]interface{}))
+	}
+
+	return config
+}
+
+func expandSagemakerAutoMLJobV2VpcConfig(l []interface{}) *sagemaker.VpcConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.VpcConfig{
+		SecurityGroupIds: expandStringSet(m["security_group_ids"].(*schema.Set)),
+		Subnets:          expandStringSet(m["subnets"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerAutoMLJobV2InputDataConfig(channels []*sagemaker.AutoMLJobChannel) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(channels))
+
+	for _, c := range channels {
+		result = append(result, map[string]interface{}{
+			"channel_type":     aws.StringValue(c.ChannelType),
+			"content_type":     aws.StringValue(c.ContentType),
+			"compression_type": aws.StringValue(c.CompressionType),
+			"data_source":      flattenSagemakerAutoMLJobV2DataSource(c.DataSource),
+		})
+	}
+
+	return result
+}
+
+func flattenSagemakerAutoMLJobV2DataSource(source *sagemaker.AutoMLDataSource) []map[string]interface{} {
+	if source == nil || source.S3DataSource == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"s3_data_source": []map[string]interface{}{
+				{
+					"s3_data_type": aws.StringValue(source.S3DataSource.S3DataType),
+					"s3_uri":       aws.StringValue(source.S3DataSource.S3Uri),
+				},
+			},
+		},
+	}
+}
+
+func flattenSagemakerAutoMLJobV2ProblemTypeConfig(config *sagemaker.AutoMLProblemTypeConfig) []map[string]interface{} {
+	if config == nil || config.TabularJobConfig == nil {
+		return []map[string]interface{}{}
+	}
+
+	tabular := config.TabularJobConfig
+
+	m := map[string]interface{}{
+		"target_attribute_name": aws.StringValue(tabular.TargetAttributeName),
+		"problem_type":          aws.StringValue(tabular.ProblemType),
+		"completion_criteria":   flattenSagemakerAutoMLJobV2CompletionCriteria(tabular.CompletionCriteria),
+	}
+
+	return []map[string]interface{}{
+		{
+			"tabular_job_config": []map[string]interface{}{m},
+		},
+	}
+}
+
+func flattenSagemakerAutoMLJobV2CompletionCriteria(criteria *sagemaker.AutoMLJobCompletionCriteria) []map[string]interface{} {
+	if criteria == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"max_candidates":                          aws.Int64Value(criteria.MaxCandidates),
+			"max_runtime_per_training_job_in_seconds": aws.Int64Value(criteria.MaxRuntimePerTrainingJobInSeconds),
+			"max_auto_ml_job_runtime_in_seconds":       aws.Int64Value(criteria.MaxAutoMLJobRuntimeInSeconds),
+		},
+	}
+}
+
+func flattenSagemakerAutoMLJobV2OutputDataConfig(config *sagemaker.AutoMLOutputDataConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"s3_output_path": aws.StringValue(config.S3OutputPath),
+			"kms_key_id":     aws.StringValue(config.KmsKeyId),
+		},
+	}
+}
+
+func flattenSagemakerAutoMLJobV2SecurityConfig(config *sagemaker.AutoMLSecurityConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"volume_kms_key_id": aws.StringValue(config.VolumeKmsKeyId),
+			"enable_inter_container_traffic_encryption": aws.BoolValue(config.EnableInterContainerTrafficEncryption),
+			"vpc_config":                                flattenSagemakerAutoMLJobV2VpcConfig(config.VpcConfig),
+		},
+	}
+}
+
+func flattenSagemakerAutoMLJobV2VpcConfig(config *sagemaker.VpcConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"security_group_ids": flattenStringList(config.SecurityGroupIds),
+			"subnets":            flattenStringList(config.Subnets),
+		},
+	}
+}
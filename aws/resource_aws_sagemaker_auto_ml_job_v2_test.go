@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerAutoMLJobV2_basic(t *testing.T) {
+	var autoMLJob sagemaker.DescribeAutoMLJobV2Output
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_auto_ml_job_v2.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerAutoMLJobV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerAutoMLJobV2Config(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerAutoMLJobV2Exists(resourceName, &autoMLJob),
+					resource.TestCheckResourceAttr(resourceName, "auto_ml_job_name", rName),
+					resource.TestCheckResourceAttr(resourceName, "auto_ml_problem_type_config.0.tabular_job_config.0.target_attribute_name", "target"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerAutoMLJobV2Exists(n string, autoMLJob *sagemaker.DescribeAutoMLJobV2Output) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker AutoML Job V2 ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeAutoMLJobV2(&sagemaker.DescribeAutoMLJobV2Input{
+			AutoMLJobName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*autoMLJob = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerAutoMLJobV2Destroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_auto_ml_job_v2" {
+			continue
+		}
+
+		out, err := conn.DescribeAutoMLJobV2(&sagemaker.DescribeAutoMLJobV2Input{
+			AutoMLJobName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				continue
+			}
+			return err
+		}
+
+		status := aws.StringValue(out.AutoMLJobStatus)
+		if status != sagemaker.AutoMLJobStatusStopped && status != sagemaker.AutoMLJobStatusFailed && status != sagemaker.AutoMLJobStatusCompleted {
+			return fmt.Errorf("Sagemaker AutoML Job V2 %q still exists in state %q", rs.Primary.ID, status)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerAutoMLJobV2Config(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_auto_ml_job_v2" "test" {
+  auto_ml_job_name = %[1]q
+  role_arn         = aws_iam_role.test.arn
+
+  auto_ml_job_input_data_config {
+    data_source {
+      s3_data_source {
+        s3_data_type = "S3Prefix"
+        s3_uri       = "s3://${aws_s3_bucket.test.bucket}/train"
+      }
+    }
+  }
+
+  auto_ml_problem_type_config {
+    tabular_job_config {
+      target_attribute_name = "target"
+      problem_type          = "BinaryClassification"
+
+      completion_criteria {
+        max_candidates = 1
+      }
+    }
+  }
+
+  output_data_config {
+    s3_output_path = "s3://${aws_s3_bucket.test.bucket}/output"
+  }
+}
+
+resource "aws_s3_bucket" "test" {
+  bucket        = %[1]q
+  force_destroy = true
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+`, rName)
+}
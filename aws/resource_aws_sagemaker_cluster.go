@@ -0,0 +1,342 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/waiter"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerClusterCreate,
+		Read:   resourceAwsSagemakerClusterRead,
+		Update: resourceAwsSagemakerClusterUpdate,
+		Delete: resourceAwsSagemakerClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cluster_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"instance_groups": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_group_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"instance_type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"instance_count": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"life_cycle_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source_s3_uri": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"on_create": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+
+						"execution_role": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"threads_per_core": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"vpc_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_group_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"subnets": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("cluster_name").(string)
+
+	createOpts := &sagemaker.CreateClusterInput{
+		ClusterName:    aws.String(name),
+		InstanceGroups: expandSagemakerClusterInstanceGroups(d.Get("instance_groups").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("vpc_config"); ok {
+		createOpts.VpcConfig = expandSagemakerClusterVpcConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Cluster create config: %#v", *createOpts)
+	if _, err := conn.CreateCluster(createOpts); err != nil {
+		return fmt.Errorf("error creating Sagemaker Cluster: %s", err)
+	}
+
+	d.SetId(name)
+
+	if _, err := waiter.ClusterInService(conn, d.Id(), 60*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Cluster (%s) to be in service: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerClusterRead(d, meta)
+}
+
+func resourceAwsSagemakerClusterRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	cluster, err := finder.ClusterByName(conn, d.Id())
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			log.Printf("[WARN] Unable to find Sagemaker Cluster (%s); removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading Sagemaker Cluster (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("cluster_name", cluster.ClusterName); err != nil {
+		return fmt.Errorf("error setting cluster_name for cluster %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", cluster.ClusterArn); err != nil {
+		return fmt.Errorf("error setting arn for cluster %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("instance_groups", flattenSagemakerClusterInstanceGroups(cluster.InstanceGroups)); err != nil {
+		return fmt.Errorf("error setting instance_groups for cluster %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("vpc_config", flattenSagemakerClusterVpcConfig(cluster.VpcConfig)); err != nil {
+		return fmt.Errorf("error setting vpc_config for cluster %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, cluster.ClusterArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for cluster %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for cluster %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	d.Partial(true)
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	if d.HasChange("instance_groups") {
+		updateOpts := &sagemaker.UpdateClusterInput{
+			ClusterName:    aws.String(d.Id()),
+			InstanceGroups: expandSagemakerClusterInstanceGroups(d.Get("instance_groups").([]interface{})),
+		}
+
+		if _, err := conn.UpdateCluster(updateOpts); err != nil {
+			return fmt.Errorf("error updating Sagemaker Cluster (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.ClusterInService(conn, d.Id(), 60*time.Minute); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Cluster (%s) to update: %s", d.Id(), err)
+		}
+	}
+	d.SetPartial("instance_groups")
+
+	d.Partial(false)
+
+	return resourceAwsSagemakerClusterRead(d, meta)
+}
+
+func resourceAwsSagemakerClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	_, err := conn.DeleteCluster(&sagemaker.DeleteClusterInput{
+		ClusterName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("error deleting Sagemaker Cluster (%s): %s", d.Id(), err)
+	}
+
+	if err := waiter.ClusterDeleted(conn, d.Id(), 60*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Cluster (%s) to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandSagemakerClusterInstanceGroups(l []interface{}) []*sagemaker.ClusterInstanceGroupSpecification {
+	if len(l) == 0 {
+		return nil
+	}
+
+	groups := make([]*sagemaker.ClusterInstanceGroupSpecification, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		group := &sagemaker.ClusterInstanceGroupSpecification{
+			InstanceGroupName: aws.String(m["instance_group_name"].(string)),
+			InstanceType:      aws.String(m["instance_type"].(string)),
+			InstanceCount:     aws.Int64(int64(m["instance_count"].(int))),
+			ExecutionRole:     aws.String(m["execution_role"].(string)),
+			LifeCycleConfig:   expandSagemakerClusterLifeCycleConfig(m["life_cycle_config"].([]interface{})),
+		}
+
+		if v, ok := m["threads_per_core"]; ok && v.(int) != 0 {
+			group.ThreadsPerCore = aws.Int64(int64(v.(int)))
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+func flattenSagemakerClusterInstanceGroups(groups []*sagemaker.ClusterInstanceGroupDetails) []map[string]interface{} {
+	if len(groups) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	result := make([]map[string]interface{}, 0, len(groups))
+
+	for _, group := range groups {
+		m := map[string]interface{}{
+			"instance_group_name": aws.StringValue(group.InstanceGroupName),
+			"instance_type":       aws.StringValue(group.InstanceType),
+			"instance_count":      aws.Int64Value(group.CurrentCount),
+			"execution_role":      aws.StringValue(group.ExecutionRole),
+			"threads_per_core":    aws.Int64Value(group.ThreadsPerCore),
+			"life_cycle_config":   flattenSagemakerClusterLifeCycleConfig(group.LifeCycleConfig),
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func expandSagemakerClusterLifeCycleConfig(l []interface{}) *sagemaker.ClusterLifeCycleConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.ClusterLifeCycleConfig{
+		SourceS3Uri: aws.String(m["source_s3_uri"].(string)),
+		OnCreate:    aws.String(m["on_create"].(string)),
+	}
+}
+
+func flattenSagemakerClusterLifeCycleConfig(config *sagemaker.ClusterLifeCycleConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"source_s3_uri": aws.StringValue(config.SourceS3Uri),
+		"on_create":     aws.StringValue(config.OnCreate),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerClusterVpcConfig(l []interface{}) *sagemaker.VpcConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.VpcConfig{
+		SecurityGroupIds: expandStringSet(m["security_group_ids"].(*schema.Set)),
+		Subnets:          expandStringSet(m["subnets"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerClusterVpcConfig(config *sagemaker.VpcConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"security_group_ids": flattenStringList(config.SecurityGroupIds),
+		"subnets":            flattenStringList(config.Subnets),
+	}
+
+	return []map[string]interface{}{m}
+}
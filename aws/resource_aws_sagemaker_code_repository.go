@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerCodeRepository() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerCodeRepositoryCreate,
+		Read:   resourceAwsSagemakerCodeRepositoryRead,
+		Update: resourceAwsSagemakerCodeRepositoryUpdate,
+		Delete: resourceAwsSagemakerCodeRepositoryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"code_repository_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"git_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"repository_url": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"branch": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"secret_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerCodeRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("code_repository_name").(string)
+
+	createOpts := &sagemaker.CreateCodeRepositoryInput{
+		CodeRepositoryName: aws.String(name),
+		GitConfig:          expandSagemakerCodeRepositoryGitConfig(d.Get("git_config").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		tagsIn := v.(map[string]interface{})
+		createOpts.Tags = tagsFromMapSagemaker(tagsIn)
+	}
+
+	log.Printf("[DEBUG] Sagemaker Code Repository create config: %#v", *createOpts)
+	_, err := conn.CreateCodeRepository(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Code Repository: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Code Repository ID: %s", d.Id())
+
+	return resourceAwsSagemakerCodeRepositoryRead(d, meta)
+}
+
+func resourceAwsSagemakerCodeRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	request := &sagemaker.DescribeCodeRepositoryInput{
+		CodeRepositoryName: aws.String(d.Id()),
+	}
+
+	codeRepository, err := conn.DescribeCodeRepository(request)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker code repository %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("code_repository_name", codeRepository.CodeRepositoryName); err != nil {
+		return fmt.Errorf("error setting code_repository_name for code repository %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("git_config", flattenSagemakerCodeRepositoryGitConfig(codeRepository.GitConfig)); err != nil {
+		return fmt.Errorf("error setting git_config for code repository %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("arn", codeRepository.CodeRepositoryArn); err != nil {
+		return fmt.Errorf("error setting arn for code repository %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, codeRepository.CodeRepositoryArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for code repository %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerCodeRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("git_config") {
+		gitConfig := d.Get("git_config").([]interface{})
+		m := gitConfig[0].(map[string]interface{})
+
+		if v, ok := m["secret_arn"]; ok && v.(string) != "" {
+			updateOpts := &sagemaker.UpdateCodeRepositoryInput{
+				CodeRepositoryName: aws.String(d.Id()),
+				GitConfig: &sagemaker.GitConfigForUpdate{
+					SecretArn: aws.String(v.(string)),
+				},
+			}
+
+			if _, err := conn.UpdateCodeRepository(updateOpts); err != nil {
+				return fmt.Errorf("Error updating Sagemaker Code Repository: %s", err)
+			}
+		}
+	}
+
+	return resourceAwsSagemakerCodeRepositoryRead(d, meta)
+}
+
+func resourceAwsSagemakerCodeRepositoryDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	deleteOpts := &sagemaker.DeleteCodeRepositoryInput{
+		CodeRepositoryName: aws.String(d.Id()),
+	}
+
+	if _, err := conn.DeleteCodeRepository(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Code Repository: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerCodeRepositoryGitConfig(l []interface{}) *sagemaker.GitConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	gitConfig := &sagemaker.GitConfig{
+		RepositoryUrl: aws.String(m["repository_url"].(string)),
+	}
+
+	if v, ok := m["branch"]; ok && v.(string) != "" {
+		gitConfig.Branch = aws.String(v.(string))
+	}
+
+	if v, ok := m["secret_arn"]; ok && v.(string) != "" {
+		gitConfig.SecretArn = aws.String(v.(string))
+	}
+
+	return gitConfig
+}
+
+func flattenSagemakerCodeRepositoryGitConfig(config *sagemaker.GitConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"repository_url": aws.StringValue(config.RepositoryUrl),
+		"branch":         aws.StringValue(config.Branch),
+		"secret_arn":     aws.StringValue(config.SecretArn),
+	}
+
+	return []map[string]interface{}{m}
+}
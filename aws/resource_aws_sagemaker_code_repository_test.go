@@ -0,0 +1,147 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerCodeRepository_basic(t *testing.T) {
+	var codeRepository sagemaker.DescribeCodeRepositoryOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_code_repository.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerCodeRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerCodeRepositoryConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerCodeRepositoryExists(resourceName, &codeRepository),
+					resource.TestCheckResourceAttr(resourceName, "code_repository_name", rName),
+					resource.TestCheckResourceAttr(resourceName, "git_config.0.repository_url", "https://github.com/hashicorp/terraform-provider-aws.git"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerCodeRepositoryExists(n string, codeRepository *sagemaker.DescribeCodeRepositoryOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Code Repository ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeCodeRepository(&sagemaker.DescribeCodeRepositoryInput{
+			CodeRepositoryName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*codeRepository = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerCodeRepositoryDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_code_repository" {
+			continue
+		}
+
+		_, err := conn.DescribeCodeRepository(&sagemaker.DescribeCodeRepositoryInput{
+			CodeRepositoryName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Sagemaker Code Repository %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func TestAccAWSSagemakerNotebookInstance_codeRepositories(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceCodeRepositoriesConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "default_code_repository", "aws_sagemaker_code_repository.test", "code_repository_name"),
+					resource.TestCheckResourceAttr(resourceName, "additional_code_repositories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceCodeRepositoriesConfig(rName string) string {
+	return testAccAWSSagemakerCodeRepositoryConfig(rName) + fmt.Sprintf(`
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  default_code_repository      = aws_sagemaker_code_repository.test.code_repository_name
+  additional_code_repositories = [aws_sagemaker_code_repository.test.code_repository_name]
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+`, rName)
+}
+
+func testAccAWSSagemakerCodeRepositoryConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_code_repository" "test" {
+  code_repository_name = %[1]q
+
+  git_config {
+    repository_url = "https://github.com/hashicorp/terraform-provider-aws.git"
+  }
+}
+`, rName)
+}
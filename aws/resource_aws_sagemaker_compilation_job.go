@@ -0,0 +1,399 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/waiter"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerCompilationJob() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerCompilationJobCreate,
+		Read:   resourceAwsSagemakerCompilationJobRead,
+		Delete: resourceAwsSagemakerCompilationJobDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"compilation_job_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"input_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"data_input_config": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"framework": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"output_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_location": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"target_device": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"target_platform": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"os": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+
+									"arch": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+
+									"accelerator": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+
+						"compiler_options": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"stopping_condition": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_runtime_in_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"max_wait_time_in_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerCompilationJobCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("compilation_job_name").(string)
+
+	createOpts := &sagemaker.CreateCompilationJobInput{
+		CompilationJobName: aws.String(name),
+		RoleArn:            aws.String(d.Get("role_arn").(string)),
+		InputConfig:        expandSagemakerCompilationJobInputConfig(d.Get("input_config").([]interface{})),
+		OutputConfig:       expandSagemakerCompilationJobOutputConfig(d.Get("output_config").([]interface{})),
+		StoppingCondition:  expandSagemakerCompilationJobStoppingCondition(d.Get("stopping_condition").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Compilation Job create config: %#v", *createOpts)
+	if _, err := conn.CreateCompilationJob(createOpts); err != nil {
+		return fmt.Errorf("error creating Sagemaker Compilation Job: %s", err)
+	}
+
+	d.SetId(name)
+
+	output, err := waiter.CompilationJobCompleted(conn, d.Id(), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Compilation Job (%s) to complete: %s", d.Id(), err)
+	}
+
+	if output != nil && aws.StringValue(output.CompilationJobStatus) == sagemaker.CompilationJobStatusFailed {
+		return fmt.Errorf("error creating Sagemaker Compilation Job (%s): %s", d.Id(), aws.StringValue(output.FailureReason))
+	}
+
+	return resourceAwsSagemakerCompilationJobRead(d, meta)
+}
+
+func resourceAwsSagemakerCompilationJobRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	job, err := finder.CompilationJobByName(conn, d.Id())
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			log.Printf("[WARN] Unable to find Sagemaker Compilation Job (%s); removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading Sagemaker Compilation Job (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("compilation_job_name", job.CompilationJobName); err != nil {
+		return fmt.Errorf("error setting compilation_job_name for compilation job %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", job.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for compilation job %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", job.CompilationJobArn); err != nil {
+		return fmt.Errorf("error setting arn for compilation job %q: %s", d.Id(), err)
+	}
+	if err := d.Set("status", job.CompilationJobStatus); err != nil {
+		return fmt.Errorf("error setting status for compilation job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("input_config", flattenSagemakerCompilationJobInputConfig(job.InputConfig)); err != nil {
+		return fmt.Errorf("error setting input_config for compilation job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("output_config", flattenSagemakerCompilationJobOutputConfig(job.OutputConfig)); err != nil {
+		return fmt.Errorf("error setting output_config for compilation job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("stopping_condition", flattenSagemakerCompilationJobStoppingCondition(job.StoppingCondition)); err != nil {
+		return fmt.Errorf("error setting stopping_condition for compilation job %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, job.CompilationJobArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for compilation job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for compilation job %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerCompilationJobDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	_, err := conn.DeleteCompilationJob(&sagemaker.DeleteCompilationJobInput{
+		CompilationJobName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("error deleting Sagemaker Compilation Job (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandSagemakerCompilationJobInputConfig(l []interface{}) *sagemaker.InputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.InputConfig{
+		S3Uri:           aws.String(m["s3_uri"].(string)),
+		DataInputConfig: aws.String(m["data_input_config"].(string)),
+		Framework:       aws.String(m["framework"].(string)),
+	}
+}
+
+func flattenSagemakerCompilationJobInputConfig(config *sagemaker.InputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"s3_uri":            aws.StringValue(config.S3Uri),
+		"data_input_config": aws.StringValue(config.DataInputConfig),
+		"framework":         aws.StringValue(config.Framework),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerCompilationJobOutputConfig(l []interface{}) *sagemaker.OutputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.OutputConfig{
+		S3OutputLocation: aws.String(m["s3_output_location"].(string)),
+	}
+
+	if v, ok := m["target_device"]; ok && v.(string) != "" {
+		config.TargetDevice = aws.String(v.(string))
+	}
+
+	if v, ok := m["target_platform"]; ok && len(v.([]interface{})) > 0 {
+		config.TargetPlatform = expandSagemakerCompilationJobTargetPlatform(v.([]interface{}))
+	}
+
+	if v, ok := m["compiler_options"]; ok && v.(string) != "" {
+		config.CompilerOptions = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerCompilationJobOutputConfig(config *sagemaker.OutputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"s3_output_location": aws.StringValue(config.S3OutputLocation),
+		"target_device":      aws.StringValue(config.TargetDevice),
+		"target_platform":    flattenSagemakerCompilationJobTargetPlatform(config.TargetPlatform),
+		"compiler_options":   aws.StringValue(config.CompilerOptions),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerCompilationJobTargetPlatform(l []interface{}) *sagemaker.TargetPlatform {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	platform := &sagemaker.TargetPlatform{
+		Os:   aws.String(m["os"].(string)),
+		Arch: aws.String(m["arch"].(string)),
+	}
+
+	if v, ok := m["accelerator"]; ok && v.(string) != "" {
+		platform.Accelerator = aws.String(v.(string))
+	}
+
+	return platform
+}
+
+func flattenSagemakerCompilationJobTargetPlatform(platform *sagemaker.TargetPlatform) []map[string]interface{} {
+	if platform == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"os":          aws.StringValue(platform.Os),
+		"arch":        aws.StringValue(platform.Arch),
+		"accelerator": aws.StringValue(platform.Accelerator),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerCompilationJobStoppingCondition(l []interface{}) *sagemaker.StoppingCondition {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	condition := &sagemaker.StoppingCondition{}
+
+	if v, ok := m["max_runtime_in_seconds"]; ok && v.(int) != 0 {
+		condition.MaxRuntimeInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := m["max_wait_time_in_seconds"]; ok && v.(int) != 0 {
+		condition.MaxWaitTimeInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	return condition
+}
+
+func flattenSagemakerCompilationJobStoppingCondition(condition *sagemaker.StoppingCondition) []map[string]interface{} {
+	if condition == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"max_runtime_in_seconds":   aws.Int64Value(condition.MaxRuntimeInSeconds),
+		"max_wait_time_in_seconds": aws.Int64Value(condition.MaxWaitTimeInSeconds),
+	}
+
+	return []map[string]interface{}{m}
+}
@@ -0,0 +1,696 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceAwsSagemakerDataQualityJobDefinition has no Update: every field other
+// than tags is ForceNew because CreateDataQualityJobDefinition/DescribeDataQualityJobDefinition
+// have no corresponding Update API -- changing any of them means replacing the
+// job definition.
+func resourceAwsSagemakerDataQualityJobDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerDataQualityJobDefinitionCreate,
+		Read:   resourceAwsSagemakerDataQualityJobDefinitionRead,
+		Delete: resourceAwsSagemakerDataQualityJobDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"data_quality_app_specification": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"container_arguments": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"container_entrypoint": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"record_preprocessor_source_uri": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"post_analytics_processor_source_uri": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"data_quality_job_input": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint_input": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"endpoint_name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"local_path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"s3_input_mode": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ForceNew:     true,
+										Default:      sagemaker.ProcessingS3InputModeFile,
+										ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3InputMode_Values(), false),
+									},
+									"s3_data_distribution_type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ForceNew:     true,
+										Default:      sagemaker.ProcessingS3DataDistributionTypeFullyReplicated,
+										ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3DataDistributionType_Values(), false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"data_quality_job_output_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"monitoring_outputs": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_output": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"local_path": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+												"s3_uri": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+												"s3_upload_mode": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ForceNew:     true,
+													Default:      sagemaker.ProcessingS3UploadModeEndOfJob,
+													ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3UploadMode_Values(), false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"job_resources": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_count": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"instance_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"volume_size_in_gb": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"volume_kms_key_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"network_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_inter_container_traffic_encryption": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"enable_network_isolation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"vpc_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"security_group_ids": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"subnets": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"stopping_condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_runtime_in_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerDataQualityJobDefinitionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	var name string
+	if v, ok := d.GetOk("name"); ok {
+		name = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		name = resource.PrefixedUniqueId(v.(string))
+	} else {
+		name = resource.UniqueId()
+	}
+
+	createOpts := &sagemaker.CreateDataQualityJobDefinitionInput{
+		JobDefinitionName:           aws.String(name),
+		RoleArn:                     aws.String(d.Get("role_arn").(string)),
+		DataQualityAppSpecification: expandSagemakerDataQualityJobDefinitionAppSpecification(d.Get("data_quality_app_specification").([]interface{})),
+		DataQualityJobInput:         expandSagemakerDataQualityJobDefinitionJobInput(d.Get("data_quality_job_input").([]interface{})),
+		DataQualityJobOutputConfig:  expandSagemakerDataQualityJobDefinitionJobOutputConfig(d.Get("data_quality_job_output_config").([]interface{})),
+		JobResources:                expandSagemakerDataQualityJobDefinitionJobResources(d.Get("job_resources").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("network_config"); ok {
+		createOpts.NetworkConfig = expandSagemakerDataQualityJobDefinitionNetworkConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("stopping_condition"); ok {
+		createOpts.StoppingCondition = expandSagemakerTrainingJobStoppingCondition(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Data Quality Job Definition create config: %#v", *createOpts)
+	_, err := conn.CreateDataQualityJobDefinition(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Data Quality Job Definition: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Data Quality Job Definition ID: %s", d.Id())
+
+	return resourceAwsSagemakerDataQualityJobDefinitionRead(d, meta)
+}
+
+func resourceAwsSagemakerDataQualityJobDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	jobDefinition, err := conn.DescribeDataQualityJobDefinition(&sagemaker.DescribeDataQualityJobDefinitionInput{
+		JobDefinitionName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			d.SetId("")
+			log.Printf("[LOG] Unable to find SageMaker Data Quality Job Definition %q; removing from state file", d.Id())
+			return nil
+		}
+		return err
+	}
+
+	if err := d.Set("name", jobDefinition.JobDefinitionName); err != nil {
+		return fmt.Errorf("error setting name for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", jobDefinition.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", jobDefinition.JobDefinitionArn); err != nil {
+		return fmt.Errorf("error setting arn for data quality job definition %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("data_quality_app_specification", flattenSagemakerDataQualityJobDefinitionAppSpecification(jobDefinition.DataQualityAppSpecification)); err != nil {
+		return fmt.Errorf("error setting data_quality_app_specification for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("data_quality_job_input", flattenSagemakerDataQualityJobDefinitionJobInput(jobDefinition.DataQualityJobInput)); err != nil {
+		return fmt.Errorf("error setting data_quality_job_input for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("data_quality_job_output_config", flattenSagemakerDataQualityJobDefinitionJobOutputConfig(jobDefinition.DataQualityJobOutputConfig)); err != nil {
+		return fmt.Errorf("error setting data_quality_job_output_config for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("job_resources", flattenSagemakerDataQualityJobDefinitionJobResources(jobDefinition.JobResources)); err != nil {
+		return fmt.Errorf("error setting job_resources for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("network_config", flattenSagemakerDataQualityJobDefinitionNetworkConfig(jobDefinition.NetworkConfig)); err != nil {
+		return fmt.Errorf("error setting network_config for data quality job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("stopping_condition", flattenSagemakerTrainingJobStoppingCondition(jobDefinition.StoppingCondition)); err != nil {
+		return fmt.Errorf("error setting stopping_condition for data quality job definition %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, jobDefinition.JobDefinitionArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for data quality job definition %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerDataQualityJobDefinitionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteDataQualityJobDefinition(&sagemaker.DeleteDataQualityJobDefinitionInput{
+		JobDefinitionName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Data Quality Job Definition: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerDataQualityJobDefinitionAppSpecification(l []interface{}) *sagemaker.DataQualityAppSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.DataQualityAppSpecification{
+		ImageUri: aws.String(m["image_uri"].(string)),
+	}
+
+	if v, ok := m["container_arguments"]; ok && len(v.([]interface{})) > 0 {
+		spec.ContainerArguments = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := m["container_entrypoint"]; ok && len(v.([]interface{})) > 0 {
+		spec.ContainerEntrypoint = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := m["record_preprocessor_source_uri"]; ok && v.(string) != "" {
+		spec.RecordPreprocessorSourceUri = aws.String(v.(string))
+	}
+
+	if v, ok := m["post_analytics_processor_source_uri"]; ok && v.(string) != "" {
+		spec.PostAnalyticsProcessorSourceUri = aws.String(v.(string))
+	}
+
+	if v, ok := m["environment"]; ok && len(v.(map[string]interface{})) > 0 {
+		spec.Environment = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	return spec
+}
+
+func flattenSagemakerDataQualityJobDefinitionAppSpecification(spec *sagemaker.DataQualityAppSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"image_uri":                           aws.StringValue(spec.ImageUri),
+		"container_arguments":                 flattenStringList(spec.ContainerArguments),
+		"container_entrypoint":                flattenStringList(spec.ContainerEntrypoint),
+		"record_preprocessor_source_uri":      aws.StringValue(spec.RecordPreprocessorSourceUri),
+		"post_analytics_processor_source_uri": aws.StringValue(spec.PostAnalyticsProcessorSourceUri),
+		"environment":                         pointersMapToStringList(spec.Environment),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionJobInput(l []interface{}) *sagemaker.DataQualityJobInput {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.DataQualityJobInput{
+		EndpointInput: expandSagemakerDataQualityJobDefinitionEndpointInput(m["endpoint_input"].([]interface{})),
+	}
+}
+
+func flattenSagemakerDataQualityJobDefinitionJobInput(input *sagemaker.DataQualityJobInput) []map[string]interface{} {
+	if input == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"endpoint_input": flattenSagemakerDataQualityJobDefinitionEndpointInput(input.EndpointInput),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionEndpointInput(l []interface{}) *sagemaker.EndpointInput {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.EndpointInput{
+		EndpointName:           aws.String(m["endpoint_name"].(string)),
+		LocalPath:              aws.String(m["local_path"].(string)),
+		S3InputMode:            aws.String(m["s3_input_mode"].(string)),
+		S3DataDistributionType: aws.String(m["s3_data_distribution_type"].(string)),
+	}
+}
+
+func flattenSagemakerDataQualityJobDefinitionEndpointInput(input *sagemaker.EndpointInput) []map[string]interface{} {
+	if input == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"endpoint_name":             aws.StringValue(input.EndpointName),
+		"local_path":                aws.StringValue(input.LocalPath),
+		"s3_input_mode":             aws.StringValue(input.S3InputMode),
+		"s3_data_distribution_type": aws.StringValue(input.S3DataDistributionType),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionJobOutputConfig(l []interface{}) *sagemaker.MonitoringOutputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.MonitoringOutputConfig{
+		MonitoringOutputs: expandSagemakerDataQualityJobDefinitionMonitoringOutputs(m["monitoring_outputs"].([]interface{})),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerDataQualityJobDefinitionJobOutputConfig(config *sagemaker.MonitoringOutputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"kms_key_id":         aws.StringValue(config.KmsKeyId),
+		"monitoring_outputs": flattenSagemakerDataQualityJobDefinitionMonitoringOutputs(config.MonitoringOutputs),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionMonitoringOutputs(l []interface{}) []*sagemaker.MonitoringOutput {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	s3l := m["s3_output"].([]interface{})
+	if len(s3l) == 0 || s3l[0] == nil {
+		return []*sagemaker.MonitoringOutput{{}}
+	}
+
+	s3m := s3l[0].(map[string]interface{})
+
+	return []*sagemaker.MonitoringOutput{
+		{
+			S3Output: &sagemaker.MonitoringS3Output{
+				LocalPath:    aws.String(s3m["local_path"].(string)),
+				S3Uri:        aws.String(s3m["s3_uri"].(string)),
+				S3UploadMode: aws.String(s3m["s3_upload_mode"].(string)),
+			},
+		},
+	}
+}
+
+func flattenSagemakerDataQualityJobDefinitionMonitoringOutputs(outputs []*sagemaker.MonitoringOutput) []map[string]interface{} {
+	if len(outputs) == 0 || outputs[0].S3Output == nil {
+		return []map[string]interface{}{}
+	}
+
+	s3Output := outputs[0].S3Output
+
+	m := map[string]interface{}{
+		"s3_output": []map[string]interface{}{
+			{
+				"local_path":     aws.StringValue(s3Output.LocalPath),
+				"s3_uri":         aws.StringValue(s3Output.S3Uri),
+				"s3_upload_mode": aws.StringValue(s3Output.S3UploadMode),
+			},
+		},
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionJobResources(l []interface{}) *sagemaker.MonitoringResources {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.MonitoringResources{
+		ClusterConfig: expandSagemakerDataQualityJobDefinitionClusterConfig(m["cluster_config"].([]interface{})),
+	}
+}
+
+func flattenSagemakerDataQualityJobDefinitionJobResources(resources *sagemaker.MonitoringResources) []map[string]interface{} {
+	if resources == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"cluster_config": flattenSagemakerDataQualityJobDefinitionClusterConfig(resources.ClusterConfig),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionClusterConfig(l []interface{}) *sagemaker.MonitoringClusterConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.MonitoringClusterConfig{
+		InstanceCount:  aws.Int64(int64(m["instance_count"].(int))),
+		InstanceType:   aws.String(m["instance_type"].(string)),
+		VolumeSizeInGB: aws.Int64(int64(m["volume_size_in_gb"].(int))),
+	}
+
+	if v, ok := m["volume_kms_key_id"]; ok && v.(string) != "" {
+		config.VolumeKmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerDataQualityJobDefinitionClusterConfig(config *sagemaker.MonitoringClusterConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"instance_count":    aws.Int64Value(config.InstanceCount),
+		"instance_type":     aws.StringValue(config.InstanceType),
+		"volume_size_in_gb": aws.Int64Value(config.VolumeSizeInGB),
+		"volume_kms_key_id": aws.StringValue(config.VolumeKmsKeyId),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerDataQualityJobDefinitionNetworkConfig(l []interface{}) *sagemaker.MonitoringNetworkConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.MonitoringNetworkConfig{
+		EnableInterContainerTrafficEncryption: aws.Bool(m["enable_inter_container_traffic_encryption"].(bool)),
+		EnableNetworkIsolation:                aws.Bool(m["enable_network_isolation"].(bool)),
+	}
+
+	if v, ok := m["vpc_config"]; ok && len(v.([]interface{})) > 0 {
+		config.VpcConfig = expandSagemakerTrainingJobVpcConfig(v.([]interface{}))
+	}
+
+	return config
+}
+
+func flattenSagemakerDataQualityJobDefinitionNetworkConfig(config *sagemaker.MonitoringNetworkConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"enable_inter_container_traffic_encryption": aws.BoolValue(config.EnableInterContainerTrafficEncryption),
+		"enable_network_isolation":                  aws.BoolValue(config.EnableNetworkIsolation),
+		"vpc_config":                                 flattenSagemakerTrainingJobVpcConfig(config.VpcConfig),
+	}
+
+	return []map[string]interface{}{m}
+}
@@ -0,0 +1,222 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerDevice() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerDeviceCreate,
+		Read:   resourceAwsSagemakerDeviceRead,
+		Update: resourceAwsSagemakerDeviceUpdate,
+		Delete: resourceAwsSagemakerDeviceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"device_fleet_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"device": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"device_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateSagemakerName,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"iot_thing_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"device_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"registration_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerDeviceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	fleetName := d.Get("device_fleet_name").(string)
+	device := expandSagemakerDevice(d.Get("device").([]interface{}))
+
+	createOpts := &sagemaker.RegisterDevicesInput{
+		DeviceFleetName: aws.String(fleetName),
+		Devices:         []*sagemaker.Device{device},
+	}
+
+	log.Printf("[DEBUG] Sagemaker Device create config: %#v", *createOpts)
+	if _, err := conn.RegisterDevices(createOpts); err != nil {
+		return fmt.Errorf("Error registering Sagemaker Device: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", fleetName, aws.StringValue(device.DeviceName)))
+	log.Printf("[INFO] Sagemaker Device ID: %s", d.Id())
+
+	return resourceAwsSagemakerDeviceRead(d, meta)
+}
+
+func resourceAwsSagemakerDeviceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	fleetName, deviceName, err := decodeSagemakerDeviceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	device, err := conn.DescribeDevice(&sagemaker.DescribeDeviceInput{
+		DeviceFleetName: aws.String(fleetName),
+		DeviceName:      aws.String(deviceName),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[WARN] Unable to find SageMaker device %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("device_fleet_name", device.DeviceFleetName); err != nil {
+		return fmt.Errorf("error setting device_fleet_name for device %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("device", flattenSagemakerDevice(device)); err != nil {
+		return fmt.Errorf("error setting device for device %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("device_arn", device.DeviceArn); err != nil {
+		return fmt.Errorf("error setting device_arn for device %q: %s", d.Id(), err)
+	}
+
+	if device.RegistrationTime != nil {
+		if err := d.Set("registration_time", device.RegistrationTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting registration_time for device %q: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerDeviceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	fleetName, _, err := decodeSagemakerDeviceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("device") {
+		updateOpts := &sagemaker.UpdateDevicesInput{
+			DeviceFleetName: aws.String(fleetName),
+			Devices:         []*sagemaker.Device{expandSagemakerDevice(d.Get("device").([]interface{}))},
+		}
+
+		if _, err := conn.UpdateDevices(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Device: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerDeviceRead(d, meta)
+}
+
+func resourceAwsSagemakerDeviceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	fleetName, deviceName, err := decodeSagemakerDeviceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.DeregisterDevices(&sagemaker.DeregisterDevicesInput{
+		DeviceFleetName: aws.String(fleetName),
+		DeviceNames:     []*string{aws.String(deviceName)},
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deregistering Sagemaker Device: %s", err)
+	}
+
+	return nil
+}
+
+func decodeSagemakerDeviceId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected DEVICE-FLEET-NAME/DEVICE-NAME", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func expandSagemakerDevice(l []interface{}) *sagemaker.Device {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	device := &sagemaker.Device{
+		DeviceName: aws.String(m["device_name"].(string)),
+	}
+
+	if v, ok := m["description"]; ok && v.(string) != "" {
+		device.Description = aws.String(v.(string))
+	}
+
+	if v, ok := m["iot_thing_name"]; ok && v.(string) != "" {
+		device.IotThingName = aws.String(v.(string))
+	}
+
+	return device
+}
+
+func flattenSagemakerDevice(device *sagemaker.DescribeDeviceOutput) []map[string]interface{} {
+	if device == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"device_name":    aws.StringValue(device.DeviceName),
+			"description":    aws.StringValue(device.Description),
+			"iot_thing_name": aws.StringValue(device.IotThingName),
+		},
+	}
+}
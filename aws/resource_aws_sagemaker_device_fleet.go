@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerDeviceFleet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerDeviceFleetCreate,
+		Read:   resourceAwsSagemakerDeviceFleetRead,
+		Update: resourceAwsSagemakerDeviceFleetUpdate,
+		Delete: resourceAwsSagemakerDeviceFleetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"device_fleet_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"iot_role_alias": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"enable_iot_role_alias": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"output_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_location": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerDeviceFleetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("device_fleet_name").(string)
+
+	createOpts := &sagemaker.CreateDeviceFleetInput{
+		DeviceFleetName: aws.String(name),
+		RoleArn:         aws.String(d.Get("role_arn").(string)),
+		OutputConfig:    expandSagemakerDeviceFleetOutputConfig(d.Get("output_config").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		createOpts.Description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOkExists("enable_iot_role_alias"); ok {
+		createOpts.EnableIotRoleAlias = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Device Fleet create config: %#v", *createOpts)
+	if _, err := conn.CreateDeviceFleet(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Device Fleet: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Device Fleet ID: %s", d.Id())
+
+	return resourceAwsSagemakerDeviceFleetRead(d, meta)
+}
+
+func resourceAwsSagemakerDeviceFleetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	fleet, err := conn.DescribeDeviceFleet(&sagemaker.DescribeDeviceFleetInput{
+		DeviceFleetName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker device fleet %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("device_fleet_name", fleet.DeviceFleetName); err != nil {
+		return fmt.Errorf("error setting device_fleet_name for device fleet %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", fleet.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for device fleet %q: %s", d.Id(), err)
+	}
+	if err := d.Set("description", fleet.Description); err != nil {
+		return fmt.Errorf("error setting description for device fleet %q: %s", d.Id(), err)
+	}
+	if err := d.Set("iot_role_alias", fleet.IotRoleAlias); err != nil {
+		return fmt.Errorf("error setting iot_role_alias for device fleet %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", fleet.DeviceFleetArn); err != nil {
+		return fmt.Errorf("error setting arn for device fleet %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("output_config", flattenSagemakerDeviceFleetOutputConfig(fleet.OutputConfig)); err != nil {
+		return fmt.Errorf("error setting output_config for device fleet %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, fleet.DeviceFleetArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for device fleet %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerDeviceFleetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("role_arn") || d.HasChange("description") || d.HasChange("output_config") || d.HasChange("enable_iot_role_alias") {
+		updateOpts := &sagemaker.UpdateDeviceFleetInput{
+			DeviceFleetName: aws.String(d.Id()),
+			RoleArn:         aws.String(d.Get("role_arn").(string)),
+			OutputConfig:    expandSagemakerDeviceFleetOutputConfig(d.Get("output_config").([]interface{})),
+		}
+
+		if v, ok := d.GetOk("description"); ok {
+			updateOpts.Description = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOkExists("enable_iot_role_alias"); ok {
+			updateOpts.EnableIotRoleAlias = aws.Bool(v.(bool))
+		}
+
+		if _, err := conn.UpdateDeviceFleet(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Device Fleet: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerDeviceFleetRead(d, meta)
+}
+
+func resourceAwsSagemakerDeviceFleetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteDeviceFleet(&sagemaker.DeleteDeviceFleetInput{
+		DeviceFleetName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Device Fleet: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerDeviceFleetOutputConfig(l []interface{}) *sagemaker.EdgeOutputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.EdgeOutputConfig{
+		S3OutputLocation: aws.String(m["s3_output_location"].(string)),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerDeviceFleetOutputConfig(config *sagemaker.EdgeOutputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"s3_output_location": aws.StringValue(config.S3OutputLocation),
+			"kms_key_id":         aws.StringValue(config.KmsKeyId),
+		},
+	}
+}
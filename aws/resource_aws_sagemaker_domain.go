@@ -0,0 +1,283 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerDomainCreate,
+		Read:   resourceAwsSagemakerDomainRead,
+		Update: resourceAwsSagemakerDomainUpdate,
+		Delete: resourceAwsSagemakerDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"auth_mode": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemaker.AuthModeSso,
+					sagemaker.AuthModeIam,
+				}, false),
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"home_efs_file_system_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"default_user_settings": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"execution_role": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"security_groups": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("domain_name").(string)
+
+	createOpts := &sagemaker.CreateDomainInput{
+		DomainName:          aws.String(name),
+		AuthMode:            aws.String(d.Get("auth_mode").(string)),
+		VpcId:               aws.String(d.Get("vpc_id").(string)),
+		SubnetIds:           expandStringSet(d.Get("subnet_ids").(*schema.Set)),
+		DefaultUserSettings: expandSagemakerUserProfileUserSettings(d.Get("default_user_settings").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		createOpts.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Domain create config: %#v", *createOpts)
+	out, err := conn.CreateDomain(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Domain: %s", err)
+	}
+
+	d.SetId(aws.StringValue(out.DomainArn))
+	log.Printf("[INFO] Sagemaker Domain ID: %s", d.Id())
+
+	domainID, err := sagemakerDomainIDFromArn(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := waitSagemakerDomainStatus(conn, domainID, sagemaker.DomainStatusInService, sagemaker.DomainStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Domain (%s) to be created: %s", name, err)
+	}
+
+	return resourceAwsSagemakerDomainRead(d, meta)
+}
+
+func resourceAwsSagemakerDomainRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, err := sagemakerDomainIDFromArn(d.Id())
+	if err != nil {
+		return err
+	}
+
+	domain, err := conn.DescribeDomain(&sagemaker.DescribeDomainInput{
+		DomainId: aws.String(domainID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker domain %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("domain_name", domain.DomainName); err != nil {
+		return fmt.Errorf("error setting domain_name for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("auth_mode", domain.AuthMode); err != nil {
+		return fmt.Errorf("error setting auth_mode for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("vpc_id", domain.VpcId); err != nil {
+		return fmt.Errorf("error setting vpc_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("subnet_ids", flattenStringList(domain.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("kms_key_id", domain.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("home_efs_file_system_id", domain.HomeEfsFileSystemId); err != nil {
+		return fmt.Errorf("error setting home_efs_file_system_id for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("url", domain.Url); err != nil {
+		return fmt.Errorf("error setting url for domain %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", domain.DomainArn); err != nil {
+		return fmt.Errorf("error setting arn for domain %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("default_user_settings", flattenSagemakerUserProfileUserSettings(domain.DefaultUserSettings)); err != nil {
+		return fmt.Errorf("error setting default_user_settings for domain %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, domain.DomainArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for domain %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerDomainUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("default_user_settings") {
+		domainID, err := sagemakerDomainIDFromArn(d.Id())
+		if err != nil {
+			return err
+		}
+
+		updateOpts := &sagemaker.UpdateDomainInput{
+			DomainId:            aws.String(domainID),
+			DefaultUserSettings: expandSagemakerUserProfileUserSettings(d.Get("default_user_settings").([]interface{})),
+		}
+
+		if _, err := conn.UpdateDomain(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Domain: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerDomainRead(d, meta)
+}
+
+func resourceAwsSagemakerDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, err := sagemakerDomainIDFromArn(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.DeleteDomain(&sagemaker.DeleteDomainInput{
+		DomainId: aws.String(domainID),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Domain: %s", err)
+	}
+
+	return nil
+}
+
+func sagemakerDomainIDFromArn(arnStr string) (string, error) {
+	parts := strings.Split(arnStr, "/")
+	if len(parts) < 2 || parts[len(parts)-1] == "" {
+		return "", fmt.Errorf("unexpected format of domain ARN (%s)", arnStr)
+	}
+
+	return parts[len(parts)-1], nil
+}
+
+func waitSagemakerDomainStatus(conn *sagemaker.SageMaker, domainID string, desiredStatus ...string) error {
+	return resource.Retry(10*time.Minute, func() *resource.RetryError {
+		out, err := conn.DescribeDomain(&sagemaker.DescribeDomainInput{
+			DomainId: aws.String(domainID),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		for _, s := range desiredStatus {
+			if aws.StringValue(out.Status) == s {
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Domain (%s) to be %s", domainID, desiredStatus))
+	})
+}
@@ -0,0 +1,541 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerEndpointCreate,
+		Read:   resourceAwsSagemakerEndpointRead,
+		Update: resourceAwsSagemakerEndpointUpdate,
+		Delete: resourceAwsSagemakerEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		// Endpoint creation and endpoint_config_name swaps both provision new
+		// hosting capacity behind the scenes, which routinely takes well over
+		// the provider's default 20 minutes, so Create/Update get generous
+		// non-default timeouts here rather than leaving callers to override
+		// them themselves.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"endpoint_config_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			// production_variants lets desired_weight/desired_instance_count
+			// be nudged without swapping endpoint_config_name: when it's the
+			// only thing that changed, Update calls
+			// UpdateEndpointWeightsAndCapacities directly instead of
+			// creating a new endpoint configuration for a simple traffic
+			// shift. Omit this block entirely to manage variants solely
+			// through endpoint_config_name.
+			"production_variants": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variant_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"desired_weight": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+							Default:  1,
+						},
+						"desired_instance_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+						// scalable_target_resource_id is the ResourceId an
+						// aws_appautoscaling_target for this variant must use
+						// (endpoint/NAME/variant/VARIANT), computed here so users can
+						// reference it directly instead of string-building it.
+						"scalable_target_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			// deployment_config controls how a future endpoint_config_name
+			// change is rolled out. It isn't a persisted endpoint attribute --
+			// DescribeEndpoint doesn't return it, UpdateEndpoint just takes it
+			// as a one-time parameter for that call -- so it's Optional with
+			// no Computed/Read wiring, and changing it alone never triggers an
+			// UpdateEndpoint call on its own; it only takes effect the next
+			// time endpoint_config_name changes.
+			"deployment_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"blue_green_update_policy": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"traffic_routing_configuration": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"type": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice([]string{"CANARY", "LINEAR"}, false),
+												},
+												"wait_interval_in_seconds": {
+													Type:     schema.TypeInt,
+													Required: true,
+												},
+												"canary_size":      sagemakerEndpointCapacitySizeSchema(),
+												"linear_step_size": sagemakerEndpointCapacitySizeSchema(),
+											},
+										},
+									},
+									"termination_wait_in_seconds": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"maximum_execution_timeout_in_seconds": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"auto_rollback_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"alarms": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"alarm_name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+// sagemakerEndpointCapacitySizeSchema is shared by canary_size and
+// linear_step_size, which are both SageMaker CapacitySize values (a step
+// expressed either as a raw instance count or as a percentage of total
+// capacity).
+func sagemakerEndpointCapacitySizeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"INSTANCE_COUNT", "CAPACITY_PERCENT"}, false),
+				},
+				"value": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateEndpointInput{
+		EndpointName:       aws.String(name),
+		EndpointConfigName: aws.String(d.Get("endpoint_config_name").(string)),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Endpoint create config: %#v", *createOpts)
+	_, err := conn.CreateEndpoint(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Endpoint: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Endpoint ID: %s", d.Id())
+
+	if err := waitSagemakerEndpointStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.EndpointStatusInService); err != nil {
+		return fmt.Errorf("Error waiting for Sagemaker Endpoint (%s) to be in service: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerEndpointRead(d, meta)
+}
+
+func resourceAwsSagemakerEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	endpointRaw, _, err := SagemakerEndpointStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		if awserr, ok := err.(awserr.Error); ok {
+			if awserr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker endpoint %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if endpointRaw == nil {
+		d.SetId("")
+		log.Printf("[LOG] Unable to find SageMaker endpoint %q; removing from state file", d.Id())
+		return nil
+	}
+
+	endpoint := endpointRaw.(*sagemaker.DescribeEndpointOutput)
+
+	if err := d.Set("name", endpoint.EndpointName); err != nil {
+		return fmt.Errorf("error setting name for endpoint %q: %s", d.Id(), err)
+	}
+	if err := d.Set("endpoint_config_name", endpoint.EndpointConfigName); err != nil {
+		return fmt.Errorf("error setting endpoint_config_name for endpoint %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", endpoint.EndpointArn); err != nil {
+		return fmt.Errorf("error setting arn for endpoint %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("production_variants", flattenSagemakerEndpointProductionVariants(aws.StringValue(endpoint.EndpointName), endpoint.ProductionVariants)); err != nil {
+		return fmt.Errorf("error setting production_variants for endpoint %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, endpoint.EndpointArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for endpoint %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	d.Partial(true)
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	if d.HasChange("endpoint_config_name") {
+		updateOpts := &sagemaker.UpdateEndpointInput{
+			EndpointName:       aws.String(d.Id()),
+			EndpointConfigName: aws.String(d.Get("endpoint_config_name").(string)),
+		}
+
+		if v, ok := d.GetOk("deployment_config"); ok {
+			updateOpts.DeploymentConfig = expandSagemakerEndpointDeploymentConfig(v.([]interface{}))
+		}
+
+		if _, err := conn.UpdateEndpoint(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Endpoint: %s", err)
+		}
+
+		if err := waitSagemakerEndpointStatus(conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemaker.EndpointStatusInService); err != nil {
+			return fmt.Errorf("Error waiting for Sagemaker Endpoint (%s) to update: %s", d.Id(), err)
+		}
+	} else if d.HasChange("production_variants") {
+		// No endpoint_config_name change, so this is a pure weight/capacity
+		// shift: apply it directly instead of forcing a new endpoint config.
+		updateOpts := &sagemaker.UpdateEndpointWeightsAndCapacitiesInput{
+			EndpointName:                aws.String(d.Id()),
+			DesiredWeightsAndCapacities: expandSagemakerEndpointProductionVariants(d.Get("production_variants").([]interface{})),
+		}
+
+		if _, err := conn.UpdateEndpointWeightsAndCapacities(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Endpoint weights and capacities: %s", err)
+		}
+
+		if err := waitSagemakerEndpointStatus(conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemaker.EndpointStatusInService); err != nil {
+			return fmt.Errorf("Error waiting for Sagemaker Endpoint (%s) to update: %s", d.Id(), err)
+		}
+	}
+	d.SetPartial("production_variants")
+
+	d.Partial(false)
+
+	return resourceAwsSagemakerEndpointRead(d, meta)
+}
+
+func resourceAwsSagemakerEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	deleteOpts := &sagemaker.DeleteEndpointInput{
+		EndpointName: aws.String(d.Id()),
+	}
+
+	if _, err := conn.DeleteEndpoint(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Endpoint: %s", err)
+	}
+
+	return nil
+}
+
+func SagemakerEndpointStateRefreshFunc(conn *sagemaker.SageMaker, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		describeEndpointInput := &sagemaker.DescribeEndpointInput{
+			EndpointName: aws.String(name),
+		}
+		endpoint, err := conn.DescribeEndpoint(describeEndpointInput)
+		if err != nil {
+			if sagemakerErr, ok := err.(awserr.Error); ok && sagemakerErr.Code() == "ValidationException" {
+				endpoint = nil
+			} else {
+				log.Printf("Error on SagemakerEndpointStateRefreshFunc: %s", err)
+				return nil, "", err
+			}
+		}
+
+		if endpoint == nil {
+			return nil, "", nil
+		}
+
+		return endpoint, *endpoint.EndpointStatus, nil
+	}
+}
+
+// waitSagemakerEndpointStatus waits up to timeout for name to reach
+// desiredStatus. A Failed status is always terminal -- regardless of what
+// desiredStatus was asked for -- and the returned error includes the
+// DescribeEndpoint FailureReason rather than just naming the status, since
+// "failed to create or update" alone gives callers nothing to act on.
+func waitSagemakerEndpointStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		endpointRaw, status, err := SagemakerEndpointStateRefreshFunc(conn, name)()
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if status == desiredStatus {
+			log.Printf("[DEBUG] Sagemaker Endpoint (%s) is %s", name, status)
+			return nil
+		}
+
+		if status == sagemaker.EndpointStatusFailed {
+			failureReason := ""
+			if endpointRaw != nil {
+				failureReason = aws.StringValue(endpointRaw.(*sagemaker.DescribeEndpointOutput).FailureReason)
+			}
+			return resource.NonRetryableError(errors.New(sagemakerEndpointFailureMessage(name, failureReason)))
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Endpoint (%s) to be %s", name, desiredStatus))
+	})
+}
+
+// sagemakerEndpointFailureMessage builds the terminal error
+// waitSagemakerEndpointStatus returns once name reaches Failed, extracted out
+// so the failureReason-included-or-not wording is testable without a
+// *sagemaker.SageMaker client.
+func sagemakerEndpointFailureMessage(name, failureReason string) string {
+	if failureReason == "" {
+		return fmt.Sprintf("Sagemaker Endpoint (%s) failed to create or update", name)
+	}
+	return fmt.Sprintf("Sagemaker Endpoint (%s) failed to create or update: %s", name, failureReason)
+}
+
+func expandSagemakerEndpointProductionVariants(l []interface{}) []*sagemaker.DesiredWeightAndCapacity {
+	variants := make([]*sagemaker.DesiredWeightAndCapacity, 0, len(l))
+
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+
+		m := v.(map[string]interface{})
+
+		variants = append(variants, &sagemaker.DesiredWeightAndCapacity{
+			VariantName:          aws.String(m["variant_name"].(string)),
+			DesiredWeight:        aws.Float64(m["desired_weight"].(float64)),
+			DesiredInstanceCount: aws.Int64(int64(m["desired_instance_count"].(int))),
+		})
+	}
+
+	return variants
+}
+
+func expandSagemakerEndpointDeploymentConfig(l []interface{}) *sagemaker.DeploymentConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.DeploymentConfig{
+		BlueGreenUpdatePolicy: expandSagemakerEndpointBlueGreenUpdatePolicy(m["blue_green_update_policy"].([]interface{})),
+	}
+
+	if v, ok := m["auto_rollback_configuration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ar := v[0].(map[string]interface{})
+
+		alarms := ar["alarms"].([]interface{})
+		config.AutoRollbackConfiguration = &sagemaker.AutoRollbackConfig{
+			Alarms: make([]*sagemaker.Alarm, 0, len(alarms)),
+		}
+
+		for _, a := range alarms {
+			am := a.(map[string]interface{})
+			config.AutoRollbackConfiguration.Alarms = append(config.AutoRollbackConfiguration.Alarms, &sagemaker.Alarm{
+				AlarmName: aws.String(am["alarm_name"].(string)),
+			})
+		}
+	}
+
+	return config
+}
+
+func expandSagemakerEndpointBlueGreenUpdatePolicy(l []interface{}) *sagemaker.BlueGreenUpdatePolicy {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	policy := &sagemaker.BlueGreenUpdatePolicy{
+		TrafficRoutingConfiguration: expandSagemakerEndpointTrafficRoutingConfiguration(m["traffic_routing_configuration"].([]interface{})),
+	}
+
+	if v, ok := m["termination_wait_in_seconds"].(int); ok && v > 0 {
+		policy.TerminationWaitInSeconds = aws.Int64(int64(v))
+	}
+
+	if v, ok := m["maximum_execution_timeout_in_seconds"].(int); ok && v > 0 {
+		policy.MaximumExecutionTimeoutInSeconds = aws.Int64(int64(v))
+	}
+
+	return policy
+}
+
+func expandSagemakerEndpointTrafficRoutingConfiguration(l []interface{}) *sagemaker.TrafficRoutingConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.TrafficRoutingConfig{
+		Type:                  aws.String(m["type"].(string)),
+		WaitIntervalInSeconds: aws.Int64(int64(m["wait_interval_in_seconds"].(int))),
+	}
+
+	if v, ok := m["canary_size"].([]interface{}); ok && len(v) > 0 {
+		config.CanarySize = expandSagemakerEndpointCapacitySize(v)
+	}
+
+	if v, ok := m["linear_step_size"].([]interface{}); ok && len(v) > 0 {
+		config.LinearStepSize = expandSagemakerEndpointCapacitySize(v)
+	}
+
+	return config
+}
+
+func expandSagemakerEndpointCapacitySize(l []interface{}) *sagemaker.CapacitySize {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.CapacitySize{
+		Type:  aws.String(m["type"].(string)),
+		Value: aws.Int64(int64(m["value"].(int))),
+	}
+}
+
+func flattenSagemakerEndpointProductionVariants(endpointName string, variants []*sagemaker.ProductionVariantSummary) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(variants))
+
+	for _, v := range variants {
+		result = append(result, map[string]interface{}{
+			"variant_name":                aws.StringValue(v.VariantName),
+			"desired_weight":              aws.Float64Value(v.DesiredWeight),
+			"desired_instance_count":      aws.Int64Value(v.DesiredInstanceCount),
+			"scalable_target_resource_id": sagemakerEndpointScalableTargetResourceId(endpointName, aws.StringValue(v.VariantName)),
+		})
+	}
+
+	return result
+}
+
+// sagemakerEndpointScalableTargetResourceId builds the ResourceId an
+// aws_appautoscaling_target must use to target this endpoint variant
+// (endpoint/NAME/variant/VARIANT), per the sagemaker ScalableDimension
+// naming Application Auto Scaling requires.
+func sagemakerEndpointScalableTargetResourceId(endpointName, variantName string) string {
+	return fmt.Sprintf("endpoint/%s/variant/%s", endpointName, variantName)
+}
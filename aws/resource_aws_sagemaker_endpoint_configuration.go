@@ -0,0 +1,721 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerEndpointConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerEndpointConfigurationCreate,
+		Read:   resourceAwsSagemakerEndpointConfigurationRead,
+		Update: resourceAwsSagemakerEndpointConfigurationUpdate,
+		Delete: resourceAwsSagemakerEndpointConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceAwsSagemakerEndpointConfigurationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"production_variants": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variant_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"model_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"initial_instance_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"instance_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"initial_variant_weight": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+							ForceNew: true,
+							Default:  1,
+						},
+						"accelerator_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						// serverless_config is mutually exclusive with
+						// instance_type/initial_instance_count -- a variant
+						// is either instance-based or serverless, never
+						// both. Enforced in CustomizeDiff since ConflictsWith
+						// doesn't reach into list/set sub-resources.
+						"serverless_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"memory_size_in_mb": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"max_concurrency": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"provisioned_concurrency": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"data_capture_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_capture": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"initial_sampling_percentage": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(0, 100),
+						},
+						"destination_s3_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"capture_options": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"capture_mode": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringInSlice(sagemaker.CaptureMode_Values(), false),
+									},
+								},
+							},
+						},
+						"capture_content_type_header": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"csv_content_types": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"json_content_types": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"async_inference_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"max_concurrent_invocations_per_instance": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+						"output_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_output_path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"kms_key_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+									"notification_config": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"success_topic": {
+													Type:     schema.TypeString,
+													Optional: true,
+													ForceNew: true,
+												},
+												"error_topic": {
+													Type:     schema.TypeString,
+													Optional: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerEndpointConfigurationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return validateSagemakerEndpointConfigurationProductionVariants(d.Get("production_variants").([]interface{}))
+}
+
+// validateSagemakerEndpointConfigurationProductionVariants enforces that each
+// production variant is either instance-based or serverless, never both and
+// never neither, since CreateEndpointConfig silently picks whichever fields
+// happen to be set rather than erroring on an ambiguous mix.
+func validateSagemakerEndpointConfigurationProductionVariants(variants []interface{}) error {
+	for _, raw := range variants {
+		m := raw.(map[string]interface{})
+
+		hasInstanceFields := m["instance_type"].(string) != "" || m["initial_instance_count"].(int) > 0
+		hasServerlessConfig := len(m["serverless_config"].([]interface{})) > 0
+
+		variantName := m["variant_name"].(string)
+
+		if hasInstanceFields && hasServerlessConfig {
+			return fmt.Errorf("production_variants %q: instance_type/initial_instance_count and serverless_config are mutually exclusive", variantName)
+		}
+
+		if !hasInstanceFields && !hasServerlessConfig {
+			return fmt.Errorf("production_variants %q: must set either instance_type/initial_instance_count or serverless_config", variantName)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerEndpointConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateEndpointConfigInput{
+		EndpointConfigName: aws.String(name),
+		ProductionVariants: expandSagemakerEndpointConfigurationProductionVariants(d.Get("production_variants").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		createOpts.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("data_capture_config"); ok {
+		createOpts.DataCaptureConfig = expandSagemakerEndpointConfigurationDataCaptureConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("async_inference_config"); ok {
+		createOpts.AsyncInferenceConfig = expandSagemakerEndpointConfigurationAsyncInferenceConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Endpoint Configuration create config: %#v", *createOpts)
+	_, err := conn.CreateEndpointConfig(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Endpoint Configuration: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Endpoint Configuration ID: %s", d.Id())
+
+	return resourceAwsSagemakerEndpointConfigurationRead(d, meta)
+}
+
+func resourceAwsSagemakerEndpointConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	request := &sagemaker.DescribeEndpointConfigInput{
+		EndpointConfigName: aws.String(d.Id()),
+	}
+
+	endpointConfig, err := conn.DescribeEndpointConfig(request)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker endpoint configuration %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("name", endpointConfig.EndpointConfigName); err != nil {
+		return fmt.Errorf("error setting name for endpoint configuration %q: %s", d.Id(), err)
+	}
+	if err := d.Set("kms_key_id", endpointConfig.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for endpoint configuration %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", endpointConfig.EndpointConfigArn); err != nil {
+		return fmt.Errorf("error setting arn for endpoint configuration %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("production_variants", flattenSagemakerEndpointConfigurationProductionVariants(endpointConfig.ProductionVariants)); err != nil {
+		return fmt.Errorf("error setting production_variants for endpoint configuration %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("data_capture_config", flattenSagemakerEndpointConfigurationDataCaptureConfig(endpointConfig.DataCaptureConfig)); err != nil {
+		return fmt.Errorf("error setting data_capture_config for endpoint configuration %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("async_inference_config", flattenSagemakerEndpointConfigurationAsyncInferenceConfig(endpointConfig.AsyncInferenceConfig)); err != nil {
+		return fmt.Errorf("error setting async_inference_config for endpoint configuration %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, endpointConfig.EndpointConfigArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for endpoint configuration %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerEndpointConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	return resourceAwsSagemakerEndpointConfigurationRead(d, meta)
+}
+
+func resourceAwsSagemakerEndpointConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	deleteOpts := &sagemaker.DeleteEndpointConfigInput{
+		EndpointConfigName: aws.String(d.Id()),
+	}
+
+	if _, err := conn.DeleteEndpointConfig(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Endpoint Configuration: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerEndpointConfigurationProductionVariants(l []interface{}) []*sagemaker.ProductionVariant {
+	variants := make([]*sagemaker.ProductionVariant, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		variant := &sagemaker.ProductionVariant{
+			VariantName:          aws.String(m["variant_name"].(string)),
+			ModelName:            aws.String(m["model_name"].(string)),
+			InitialVariantWeight: aws.Float64(m["initial_variant_weight"].(float64)),
+		}
+
+		if v, ok := m["serverless_config"]; ok && len(v.([]interface{})) > 0 {
+			variant.ServerlessConfig = expandSagemakerEndpointConfigurationProductionVariantServerlessConfig(v.([]interface{}))
+		} else {
+			variant.InitialInstanceCount = aws.Int64(int64(m["initial_instance_count"].(int)))
+			variant.InstanceType = aws.String(m["instance_type"].(string))
+		}
+
+		if v, ok := m["accelerator_type"]; ok && v.(string) != "" {
+			variant.AcceleratorType = aws.String(v.(string))
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants
+}
+
+func flattenSagemakerEndpointConfigurationProductionVariants(variants []*sagemaker.ProductionVariant) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(variants))
+
+	for _, v := range variants {
+		result = append(result, map[string]interface{}{
+			"variant_name":           aws.StringValue(v.VariantName),
+			"model_name":             aws.StringValue(v.ModelName),
+			"initial_instance_count": aws.Int64Value(v.InitialInstanceCount),
+			"instance_type":          aws.StringValue(v.InstanceType),
+			"initial_variant_weight": aws.Float64Value(v.InitialVariantWeight),
+			"accelerator_type":       aws.StringValue(v.AcceleratorType),
+			"serverless_config":      flattenSagemakerEndpointConfigurationProductionVariantServerlessConfig(v.ServerlessConfig),
+		})
+	}
+
+	return result
+}
+
+func expandSagemakerEndpointConfigurationProductionVariantServerlessConfig(l []interface{}) *sagemaker.ProductionVariantServerlessConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.ProductionVariantServerlessConfig{
+		MemorySizeInMB: aws.Int64(int64(m["memory_size_in_mb"].(int))),
+		MaxConcurrency: aws.Int64(int64(m["max_concurrency"].(int))),
+	}
+
+	if v, ok := m["provisioned_concurrency"]; ok && v.(int) > 0 {
+		config.ProvisionedConcurrency = aws.Int64(int64(v.(int)))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationProductionVariantServerlessConfig(config *sagemaker.ProductionVariantServerlessConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"memory_size_in_mb":       aws.Int64Value(config.MemorySizeInMB),
+			"max_concurrency":         aws.Int64Value(config.MaxConcurrency),
+			"provisioned_concurrency": aws.Int64Value(config.ProvisionedConcurrency),
+		},
+	}
+}
+
+func expandSagemakerEndpointConfigurationDataCaptureConfig(l []interface{}) *sagemaker.DataCaptureConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.DataCaptureConfig{
+		EnableCapture:             aws.Bool(m["enable_capture"].(bool)),
+		InitialSamplingPercentage: aws.Int64(int64(m["initial_sampling_percentage"].(int))),
+		DestinationS3Uri:          aws.String(m["destination_s3_uri"].(string)),
+		CaptureOptions:            expandSagemakerEndpointConfigurationCaptureOptions(m["capture_options"].([]interface{})),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := m["capture_content_type_header"]; ok && len(v.([]interface{})) > 0 {
+		config.CaptureContentTypeHeader = expandSagemakerEndpointConfigurationCaptureContentTypeHeader(v.([]interface{}))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationDataCaptureConfig(config *sagemaker.DataCaptureConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"enable_capture":              aws.BoolValue(config.EnableCapture),
+		"initial_sampling_percentage": aws.Int64Value(config.InitialSamplingPercentage),
+		"destination_s3_uri":          aws.StringValue(config.DestinationS3Uri),
+		"kms_key_id":                  aws.StringValue(config.KmsKeyId),
+		"capture_options":             flattenSagemakerEndpointConfigurationCaptureOptions(config.CaptureOptions),
+		"capture_content_type_header": flattenSagemakerEndpointConfigurationCaptureContentTypeHeader(config.CaptureContentTypeHeader),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerEndpointConfigurationCaptureOptions(l []interface{}) []*sagemaker.CaptureOption {
+	options := make([]*sagemaker.CaptureOption, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		options = append(options, &sagemaker.CaptureOption{
+			CaptureMode: aws.String(m["capture_mode"].(string)),
+		})
+	}
+
+	return options
+}
+
+func flattenSagemakerEndpointConfigurationCaptureOptions(options []*sagemaker.CaptureOption) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(options))
+
+	for _, o := range options {
+		result = append(result, map[string]interface{}{
+			"capture_mode": aws.StringValue(o.CaptureMode),
+		})
+	}
+
+	return result
+}
+
+func expandSagemakerEndpointConfigurationCaptureContentTypeHeader(l []interface{}) *sagemaker.CaptureContentTypeHeader {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	header := &sagemaker.CaptureContentTypeHeader{}
+
+	if v, ok := m["csv_content_types"]; ok && v.(*schema.Set).Len() > 0 {
+		header.CsvContentTypes = expandStringSet(v.(*schema.Set))
+	}
+
+	if v, ok := m["json_content_types"]; ok && v.(*schema.Set).Len() > 0 {
+		header.JsonContentTypes = expandStringSet(v.(*schema.Set))
+	}
+
+	return header
+}
+
+func flattenSagemakerEndpointConfigurationCaptureContentTypeHeader(header *sagemaker.CaptureContentTypeHeader) []map[string]interface{} {
+	if header == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"csv_content_types":  flattenStringList(header.CsvContentTypes),
+		"json_content_types": flattenStringList(header.JsonContentTypes),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerEndpointConfigurationAsyncInferenceConfig(l []interface{}) *sagemaker.AsyncInferenceConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.AsyncInferenceConfig{
+		OutputConfig: expandSagemakerEndpointConfigurationAsyncInferenceOutputConfig(m["output_config"].([]interface{})),
+	}
+
+	if v, ok := m["client_config"]; ok && len(v.([]interface{})) > 0 {
+		config.ClientConfig = expandSagemakerEndpointConfigurationAsyncInferenceClientConfig(v.([]interface{}))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationAsyncInferenceConfig(config *sagemaker.AsyncInferenceConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"output_config": flattenSagemakerEndpointConfigurationAsyncInferenceOutputConfig(config.OutputConfig),
+	}
+
+	if config.ClientConfig != nil {
+		m["client_config"] = flattenSagemakerEndpointConfigurationAsyncInferenceClientConfig(config.ClientConfig)
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerEndpointConfigurationAsyncInferenceClientConfig(l []interface{}) *sagemaker.AsyncInferenceClientConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.AsyncInferenceClientConfig{}
+
+	if v, ok := m["max_concurrent_invocations_per_instance"]; ok && v.(int) > 0 {
+		config.MaxConcurrentInvocationsPerInstance = aws.Int64(int64(v.(int)))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationAsyncInferenceClientConfig(config *sagemaker.AsyncInferenceClientConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"max_concurrent_invocations_per_instance": aws.Int64Value(config.MaxConcurrentInvocationsPerInstance),
+		},
+	}
+}
+
+func expandSagemakerEndpointConfigurationAsyncInferenceOutputConfig(l []interface{}) *sagemaker.AsyncInferenceOutputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.AsyncInferenceOutputConfig{
+		S3OutputPath: aws.String(m["s3_output_path"].(string)),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := m["notification_config"]; ok && len(v.([]interface{})) > 0 {
+		config.NotificationConfig = expandSagemakerEndpointConfigurationAsyncInferenceNotificationConfig(v.([]interface{}))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationAsyncInferenceOutputConfig(config *sagemaker.AsyncInferenceOutputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"s3_output_path": aws.StringValue(config.S3OutputPath),
+		"kms_key_id":     aws.StringValue(config.KmsKeyId),
+	}
+
+	if config.NotificationConfig != nil {
+		m["notification_config"] = flattenSagemakerEndpointConfigurationAsyncInferenceNotificationConfig(config.NotificationConfig)
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerEndpointConfigurationAsyncInferenceNotificationConfig(l []interface{}) *sagemaker.AsyncInferenceNotificationConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.AsyncInferenceNotificationConfig{}
+
+	if v, ok := m["success_topic"]; ok && v.(string) != "" {
+		config.SuccessTopic = aws.String(v.(string))
+	}
+
+	if v, ok := m["error_topic"]; ok && v.(string) != "" {
+		config.ErrorTopic = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerEndpointConfigurationAsyncInferenceNotificationConfig(config *sagemaker.AsyncInferenceNotificationConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"success_topic": aws.StringValue(config.SuccessTopic),
+			"error_topic":   aws.StringValue(config.ErrorTopic),
+		},
+	}
+}
@@ -0,0 +1,273 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestExpandFlattenSagemakerEndpointConfigurationDataCaptureConfig(t *testing.T) {
+	l := []interface{}{
+		map[string]interface{}{
+			"enable_capture":              true,
+			"initial_sampling_percentage": 50,
+			"destination_s3_uri":          "s3://bucket/prefix",
+			"kms_key_id":                  "some-key-id",
+			"capture_options": []interface{}{
+				map[string]interface{}{"capture_mode": sagemaker.CaptureModeInput},
+				map[string]interface{}{"capture_mode": sagemaker.CaptureModeOutput},
+			},
+			"capture_content_type_header": []interface{}{
+				map[string]interface{}{
+					"csv_content_types":  schema.NewSet(schema.HashString, []interface{}{"text/csv"}),
+					"json_content_types": schema.NewSet(schema.HashString, []interface{}{"application/json"}),
+				},
+			},
+		},
+	}
+
+	expanded := expandSagemakerEndpointConfigurationDataCaptureConfig(l)
+	if !aws.BoolValue(expanded.EnableCapture) {
+		t.Error("got enable_capture false, want true")
+	}
+	if aws.Int64Value(expanded.InitialSamplingPercentage) != 50 {
+		t.Errorf("got initial sampling percentage %d, want 50", aws.Int64Value(expanded.InitialSamplingPercentage))
+	}
+	if aws.StringValue(expanded.DestinationS3Uri) != "s3://bucket/prefix" {
+		t.Errorf("got destination s3 uri %q, want %q", aws.StringValue(expanded.DestinationS3Uri), "s3://bucket/prefix")
+	}
+	if len(expanded.CaptureOptions) != 2 {
+		t.Fatalf("got %d capture options, want 2", len(expanded.CaptureOptions))
+	}
+	if aws.StringValue(expanded.CaptureContentTypeHeader.CsvContentTypes[0]) != "text/csv" {
+		t.Errorf("got csv content type %q, want %q", aws.StringValue(expanded.CaptureContentTypeHeader.CsvContentTypes[0]), "text/csv")
+	}
+
+	flattened := flattenSagemakerEndpointConfigurationDataCaptureConfig(&sagemaker.DataCaptureConfig{
+		EnableCapture:             aws.Bool(true),
+		InitialSamplingPercentage: aws.Int64(50),
+		DestinationS3Uri:          aws.String("s3://bucket/prefix"),
+		CaptureOptions: []*sagemaker.CaptureOption{
+			{CaptureMode: aws.String(sagemaker.CaptureModeInput)},
+		},
+		CaptureContentTypeHeader: &sagemaker.CaptureContentTypeHeader{
+			CsvContentTypes: aws.StringSlice([]string{"text/csv"}),
+		},
+	})
+	if len(flattened) != 1 {
+		t.Fatalf("got %d flattened configs, want 1", len(flattened))
+	}
+	if flattened[0]["initial_sampling_percentage"] != int64(50) {
+		t.Errorf("got initial sampling percentage %v, want 50", flattened[0]["initial_sampling_percentage"])
+	}
+}
+
+func TestValidateSagemakerEndpointConfigurationProductionVariants(t *testing.T) {
+	instanceVariant := map[string]interface{}{
+		"variant_name":           "instance-variant",
+		"instance_type":          "ml.t2.medium",
+		"initial_instance_count": 1,
+		"serverless_config":      []interface{}{},
+	}
+	serverlessVariant := map[string]interface{}{
+		"variant_name":           "serverless-variant",
+		"instance_type":          "",
+		"initial_instance_count": 0,
+		"serverless_config": []interface{}{
+			map[string]interface{}{"memory_size_in_mb": 2048, "max_concurrency": 5},
+		},
+	}
+	bothVariant := map[string]interface{}{
+		"variant_name":           "both-variant",
+		"instance_type":          "ml.t2.medium",
+		"initial_instance_count": 1,
+		"serverless_config": []interface{}{
+			map[string]interface{}{"memory_size_in_mb": 2048, "max_concurrency": 5},
+		},
+	}
+	neitherVariant := map[string]interface{}{
+		"variant_name":           "neither-variant",
+		"instance_type":          "",
+		"initial_instance_count": 0,
+		"serverless_config":      []interface{}{},
+	}
+
+	testCases := []struct {
+		name      string
+		variants  []interface{}
+		expectErr bool
+	}{
+		{name: "instance-based variant", variants: []interface{}{instanceVariant}},
+		{name: "serverless variant", variants: []interface{}{serverlessVariant}},
+		{name: "mix of both kinds across variants", variants: []interface{}{instanceVariant, serverlessVariant}},
+		{name: "both instance and serverless on one variant", variants: []interface{}{bothVariant}, expectErr: true},
+		{name: "neither instance nor serverless on one variant", variants: []interface{}{neitherVariant}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerEndpointConfigurationProductionVariants(tc.variants)
+			if tc.expectErr && err == nil {
+				t.Error("expected an error, got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestExpandFlattenSagemakerEndpointConfigurationAsyncInferenceConfig(t *testing.T) {
+	l := []interface{}{
+		map[string]interface{}{
+			"client_config": []interface{}{
+				map[string]interface{}{
+					"max_concurrent_invocations_per_instance": 4,
+				},
+			},
+			"output_config": []interface{}{
+				map[string]interface{}{
+					"s3_output_path": "s3://bucket/prefix",
+					"kms_key_id":     "some-key-id",
+					"notification_config": []interface{}{
+						map[string]interface{}{
+							"success_topic": "arn:aws:sns:us-east-1:123456789012:success",
+							"error_topic":   "arn:aws:sns:us-east-1:123456789012:error",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expanded := expandSagemakerEndpointConfigurationAsyncInferenceConfig(l)
+	if aws.Int64Value(expanded.ClientConfig.MaxConcurrentInvocationsPerInstance) != 4 {
+		t.Errorf("got max concurrent invocations %d, want 4", aws.Int64Value(expanded.ClientConfig.MaxConcurrentInvocationsPerInstance))
+	}
+	if aws.StringValue(expanded.OutputConfig.S3OutputPath) != "s3://bucket/prefix" {
+		t.Errorf("got s3 output path %q, want %q", aws.StringValue(expanded.OutputConfig.S3OutputPath), "s3://bucket/prefix")
+	}
+	if aws.StringValue(expanded.OutputConfig.NotificationConfig.SuccessTopic) != "arn:aws:sns:us-east-1:123456789012:success" {
+		t.Errorf("got success topic %q, want %q", aws.StringValue(expanded.OutputConfig.NotificationConfig.SuccessTopic), "arn:aws:sns:us-east-1:123456789012:success")
+	}
+
+	flattened := flattenSagemakerEndpointConfigurationAsyncInferenceConfig(&sagemaker.AsyncInferenceConfig{
+		ClientConfig: &sagemaker.AsyncInferenceClientConfig{
+			MaxConcurrentInvocationsPerInstance: aws.Int64(4),
+		},
+		OutputConfig: &sagemaker.AsyncInferenceOutputConfig{
+			S3OutputPath: aws.String("s3://bucket/prefix"),
+			NotificationConfig: &sagemaker.AsyncInferenceNotificationConfig{
+				ErrorTopic: aws.String("arn:aws:sns:us-east-1:123456789012:error"),
+			},
+		},
+	})
+	if len(flattened) != 1 {
+		t.Fatalf("got %d flattened configs, want 1", len(flattened))
+	}
+	outputConfig := flattened[0]["output_config"].([]map[string]interface{})
+	if len(outputConfig) != 1 {
+		t.Fatalf("got %d flattened output configs, want 1", len(outputConfig))
+	}
+	if outputConfig[0]["s3_output_path"] != "s3://bucket/prefix" {
+		t.Errorf("got s3 output path %v, want %q", outputConfig[0]["s3_output_path"], "s3://bucket/prefix")
+	}
+}
+
+func TestAccAWSSagemakerEndpointConfiguration_basic(t *testing.T) {
+	var config sagemaker.DescribeEndpointConfigOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_endpoint_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerEndpointConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerEndpointConfigurationConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerEndpointConfigurationExists(resourceName, &config),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "production_variants.0.variant_name", "main"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerEndpointConfigurationExists(n string, config *sagemaker.DescribeEndpointConfigOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Endpoint Configuration ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeEndpointConfig(&sagemaker.DescribeEndpointConfigInput{
+			EndpointConfigName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*config = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerEndpointConfigurationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_endpoint_configuration" {
+			continue
+		}
+
+		_, err := conn.DescribeEndpointConfig(&sagemaker.DescribeEndpointConfigInput{
+			EndpointConfigName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Sagemaker Endpoint Configuration %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerEndpointConfigurationConfig(rName string) string {
+	return testAccAWSSagemakerModelConfig(rName) + fmt.Sprintf(`
+resource "aws_sagemaker_endpoint_configuration" "test" {
+  name = %[1]q
+
+  production_variants {
+    variant_name           = "main"
+    model_name             = aws_sagemaker_model.test.name
+    initial_instance_count = 1
+    instance_type          = "ml.t2.medium"
+  }
+}
+`, rName)
+}
@@ -0,0 +1,274 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerEndpoint_basic(t *testing.T) {
+	var endpoint sagemaker.DescribeEndpointOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_endpoint.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerEndpointConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerEndpointExists(resourceName, &endpoint),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrPair(resourceName, "endpoint_config_name", "aws_sagemaker_endpoint_configuration.test", "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerEndpoint_productionVariantsWeight(t *testing.T) {
+	var endpoint sagemaker.DescribeEndpointOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_endpoint.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerEndpointConfigProductionVariants(rName, 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerEndpointExists(resourceName, &endpoint),
+					resource.TestCheckResourceAttr(resourceName, "production_variants.0.desired_weight", "1"),
+					resource.TestCheckResourceAttr(resourceName, "production_variants.0.desired_instance_count", "1"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerEndpointConfigProductionVariants(rName, 2, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerEndpointExists(resourceName, &endpoint),
+					resource.TestCheckResourceAttr(resourceName, "production_variants.0.desired_weight", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandFlattenSagemakerEndpointProductionVariants(t *testing.T) {
+	l := []interface{}{
+		map[string]interface{}{
+			"variant_name":           "main",
+			"desired_weight":         2.5,
+			"desired_instance_count": 3,
+		},
+	}
+
+	expanded := expandSagemakerEndpointProductionVariants(l)
+	if len(expanded) != 1 {
+		t.Fatalf("got %d variants, want 1", len(expanded))
+	}
+	if aws.StringValue(expanded[0].VariantName) != "main" {
+		t.Errorf("got variant name %q, want %q", aws.StringValue(expanded[0].VariantName), "main")
+	}
+	if aws.Float64Value(expanded[0].DesiredWeight) != 2.5 {
+		t.Errorf("got desired weight %v, want 2.5", aws.Float64Value(expanded[0].DesiredWeight))
+	}
+	if aws.Int64Value(expanded[0].DesiredInstanceCount) != 3 {
+		t.Errorf("got desired instance count %d, want 3", aws.Int64Value(expanded[0].DesiredInstanceCount))
+	}
+
+	flattened := flattenSagemakerEndpointProductionVariants("my-endpoint", []*sagemaker.ProductionVariantSummary{
+		{
+			VariantName:          aws.String("main"),
+			DesiredWeight:        aws.Float64(2.5),
+			DesiredInstanceCount: aws.Int64(3),
+		},
+	})
+	if len(flattened) != 1 {
+		t.Fatalf("got %d flattened variants, want 1", len(flattened))
+	}
+	if flattened[0]["variant_name"] != "main" {
+		t.Errorf("got variant name %v, want %q", flattened[0]["variant_name"], "main")
+	}
+	if got, want := flattened[0]["scalable_target_resource_id"], "endpoint/my-endpoint/variant/main"; got != want {
+		t.Errorf("got scalable_target_resource_id %v, want %q", got, want)
+	}
+}
+
+func TestSagemakerEndpointScalableTargetResourceId(t *testing.T) {
+	got := sagemakerEndpointScalableTargetResourceId("my-endpoint", "main")
+	want := "endpoint/my-endpoint/variant/main"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExpandSagemakerEndpointDeploymentConfig(t *testing.T) {
+	l := []interface{}{
+		map[string]interface{}{
+			"blue_green_update_policy": []interface{}{
+				map[string]interface{}{
+					"traffic_routing_configuration": []interface{}{
+						map[string]interface{}{
+							"type":                     "CANARY",
+							"wait_interval_in_seconds": 30,
+							"canary_size": []interface{}{
+								map[string]interface{}{
+									"type":  "CAPACITY_PERCENT",
+									"value": 10,
+								},
+							},
+						},
+					},
+					"termination_wait_in_seconds":          60,
+					"maximum_execution_timeout_in_seconds": 600,
+				},
+			},
+			"auto_rollback_configuration": []interface{}{
+				map[string]interface{}{
+					"alarms": []interface{}{
+						map[string]interface{}{"alarm_name": "latency-p99"},
+					},
+				},
+			},
+		},
+	}
+
+	config := expandSagemakerEndpointDeploymentConfig(l)
+	if config == nil {
+		t.Fatal("expected a non-nil deployment config")
+	}
+
+	policy := config.BlueGreenUpdatePolicy
+	if policy == nil {
+		t.Fatal("expected a non-nil blue/green update policy")
+	}
+	if aws.Int64Value(policy.TerminationWaitInSeconds) != 60 {
+		t.Errorf("got termination wait %d, want 60", aws.Int64Value(policy.TerminationWaitInSeconds))
+	}
+	if aws.Int64Value(policy.MaximumExecutionTimeoutInSeconds) != 600 {
+		t.Errorf("got max execution timeout %d, want 600", aws.Int64Value(policy.MaximumExecutionTimeoutInSeconds))
+	}
+
+	routing := policy.TrafficRoutingConfiguration
+	if routing == nil {
+		t.Fatal("expected a non-nil traffic routing configuration")
+	}
+	if aws.StringValue(routing.Type) != "CANARY" {
+		t.Errorf("got traffic routing type %q, want %q", aws.StringValue(routing.Type), "CANARY")
+	}
+	if aws.Int64Value(routing.WaitIntervalInSeconds) != 30 {
+		t.Errorf("got wait interval %d, want 30", aws.Int64Value(routing.WaitIntervalInSeconds))
+	}
+	if routing.CanarySize == nil || aws.Int64Value(routing.CanarySize.Value) != 10 {
+		t.Errorf("got canary size %#v, want value 10", routing.CanarySize)
+	}
+
+	if config.AutoRollbackConfiguration == nil || len(config.AutoRollbackConfiguration.Alarms) != 1 {
+		t.Fatalf("expected a single auto-rollback alarm, got %#v", config.AutoRollbackConfiguration)
+	}
+	if aws.StringValue(config.AutoRollbackConfiguration.Alarms[0].AlarmName) != "latency-p99" {
+		t.Errorf("got alarm name %q, want %q", aws.StringValue(config.AutoRollbackConfiguration.Alarms[0].AlarmName), "latency-p99")
+	}
+}
+
+func TestSagemakerEndpointFailureMessage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failureReason string
+		want          string
+	}{
+		{name: "with a failure reason", failureReason: "ClientError: algorithm error", want: "Sagemaker Endpoint (my-endpoint) failed to create or update: ClientError: algorithm error"},
+		{name: "without a failure reason", failureReason: "", want: "Sagemaker Endpoint (my-endpoint) failed to create or update"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerEndpointFailureMessage("my-endpoint", tc.failureReason); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func testAccCheckAWSSagemakerEndpointExists(n string, endpoint *sagemaker.DescribeEndpointOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Endpoint ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeEndpoint(&sagemaker.DescribeEndpointInput{
+			EndpointName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*endpoint = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerEndpointDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_endpoint" {
+			continue
+		}
+
+		_, err := conn.DescribeEndpoint(&sagemaker.DescribeEndpointInput{
+			EndpointName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Sagemaker Endpoint %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerEndpointConfig(rName string) string {
+	return testAccAWSSagemakerEndpointConfigurationConfig(rName) + fmt.Sprintf(`
+resource "aws_sagemaker_endpoint" "test" {
+  name                 = %[1]q
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.test.name
+}
+`, rName)
+}
+
+func testAccAWSSagemakerEndpointConfigProductionVariants(rName string, weight, instanceCount int) string {
+	return testAccAWSSagemakerEndpointConfigurationConfig(rName) + fmt.Sprintf(`
+resource "aws_sagemaker_endpoint" "test" {
+  name                 = %[1]q
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.test.name
+
+  production_variants {
+    variant_name           = "main"
+    desired_weight         = %[2]d
+    desired_instance_count = %[3]d
+  }
+}
+`, rName, weight, instanceCount)
+}
@@ -0,0 +1,387 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerFeatureGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerFeatureGroupCreate,
+		Read:   resourceAwsSagemakerFeatureGroupRead,
+		Update: resourceAwsSagemakerFeatureGroupUpdate,
+		Delete: resourceAwsSagemakerFeatureGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"feature_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"record_identifier_feature_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"event_time_feature_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"feature_definition": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"feature_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"feature_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"online_store_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_online_store": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"offline_store_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_storage_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_uri": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+						"disable_glue_table_creation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerFeatureGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("feature_group_name").(string)
+
+	createOpts := &sagemaker.CreateFeatureGroupInput{
+		FeatureGroupName:            aws.String(name),
+		RecordIdentifierFeatureName: aws.String(d.Get("record_identifier_feature_name").(string)),
+		EventTimeFeatureName:        aws.String(d.Get("event_time_feature_name").(string)),
+		RoleArn:                     aws.String(d.Get("role_arn").(string)),
+		FeatureDefinitions:          expandSagemakerFeatureGroupFeatureDefinitions(d.Get("feature_definition").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		createOpts.Description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("online_store_config"); ok {
+		createOpts.OnlineStoreConfig = expandSagemakerFeatureGroupOnlineStoreConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("offline_store_config"); ok {
+		createOpts.OfflineStoreConfig = expandSagemakerFeatureGroupOfflineStoreConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Feature Group create config: %#v", *createOpts)
+	_, err := conn.CreateFeatureGroup(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Feature Group: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Feature Group ID: %s", d.Id())
+
+	if err := waitSagemakerFeatureGroupStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.FeatureGroupStatusCreated); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Feature Group (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerFeatureGroupRead(d, meta)
+}
+
+func resourceAwsSagemakerFeatureGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	request := &sagemaker.DescribeFeatureGroupInput{
+		FeatureGroupName: aws.String(d.Id()),
+	}
+
+	featureGroup, err := conn.DescribeFeatureGroup(request)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker feature group %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("feature_group_name", featureGroup.FeatureGroupName); err != nil {
+		return fmt.Errorf("error setting feature_group_name for feature group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("record_identifier_feature_name", featureGroup.RecordIdentifierFeatureName); err != nil {
+		return fmt.Errorf("error setting record_identifier_feature_name for feature group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("event_time_feature_name", featureGroup.EventTimeFeatureName); err != nil {
+		return fmt.Errorf("error setting event_time_feature_name for feature group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", featureGroup.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for feature group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("description", featureGroup.Description); err != nil {
+		return fmt.Errorf("error setting description for feature group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", featureGroup.FeatureGroupArn); err != nil {
+		return fmt.Errorf("error setting arn for feature group %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("feature_definition", flattenSagemakerFeatureGroupFeatureDefinitions(featureGroup.FeatureDefinitions)); err != nil {
+		return fmt.Errorf("error setting feature_definition for feature group %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, featureGroup.FeatureGroupArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for feature group %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerFeatureGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("role_arn") {
+		updateOpts := &sagemaker.UpdateFeatureGroupInput{
+			FeatureGroupName: aws.String(d.Id()),
+			RoleArn:          aws.String(d.Get("role_arn").(string)),
+		}
+
+		if _, err := conn.UpdateFeatureGroup(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Feature Group: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerFeatureGroupRead(d, meta)
+}
+
+func resourceAwsSagemakerFeatureGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	deleteOpts := &sagemaker.DeleteFeatureGroupInput{
+		FeatureGroupName: aws.String(d.Id()),
+	}
+
+	if _, err := conn.DeleteFeatureGroup(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Feature Group: %s", err)
+	}
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.DescribeFeatureGroup(&sagemaker.DescribeFeatureGroupInput{
+			FeatureGroupName: aws.String(d.Id()),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Feature Group (%s) to be deleted", d.Id()))
+	})
+}
+
+func waitSagemakerFeatureGroupStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.FeatureGroupStatusCreating},
+		Target:     desiredStatus,
+		Refresh:    sagemakerFeatureGroupStateRefreshFunc(conn, name),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Feature Group (%s) to be %s: %s", name, desiredStatus, err)
+	}
+
+	return nil
+}
+
+func sagemakerFeatureGroupStateRefreshFunc(conn *sagemaker.SageMaker, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		out, err := conn.DescribeFeatureGroup(&sagemaker.DescribeFeatureGroupInput{
+			FeatureGroupName: aws.String(name),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		if aws.StringValue(out.FeatureGroupStatus) == sagemaker.FeatureGroupStatusCreateFailed {
+			return out, *out.FeatureGroupStatus, fmt.Errorf("%s", aws.StringValue(out.FailureReason))
+		}
+
+		return out, aws.StringValue(out.FeatureGroupStatus), nil
+	}
+}
+
+func expandSagemakerFeatureGroupFeatureDefinitions(l []interface{}) []*sagemaker.FeatureDefinition {
+	defs := make([]*sagemaker.FeatureDefinition, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		defs = append(defs, &sagemaker.FeatureDefinition{
+			FeatureName: aws.String(m["feature_name"].(string)),
+			FeatureType: aws.String(m["feature_type"].(string)),
+		})
+	}
+
+	return defs
+}
+
+func flattenSagemakerFeatureGroupFeatureDefinitions(defs []*sagemaker.FeatureDefinition) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(defs))
+
+	for _, def := range defs {
+		result = append(result, map[string]interface{}{
+			"feature_name": aws.StringValue(def.FeatureName),
+			"feature_type": aws.StringValue(def.FeatureType),
+		})
+	}
+
+	return result
+}
+
+func expandSagemakerFeatureGroupOnlineStoreConfig(l []interface{}) *sagemaker.OnlineStoreConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.OnlineStoreConfig{
+		EnableOnlineStore: aws.Bool(m["enable_online_store"].(bool)),
+	}
+}
+
+func expandSagemakerFeatureGroupOfflineStoreConfig(l []interface{}) *sagemaker.OfflineStoreConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.OfflineStoreConfig{
+		DisableGlueTableCreation: aws.Bool(m["disable_glue_table_creation"].(bool)),
+	}
+
+	if v, ok := m["s3_storage_config"]; ok {
+		s3l := v.([]interface{})
+		if len(s3l) > 0 && s3l[0] != nil {
+			s3m := s3l[0].(map[string]interface{})
+			config.S3StorageConfig = &sagemaker.S3StorageConfig{
+				S3Uri: aws.String(s3m["s3_uri"].(string)),
+			}
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,434 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerFlowDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerFlowDefinitionCreate,
+		Read:   resourceAwsSagemakerFlowDefinitionRead,
+		Delete: resourceAwsSagemakerFlowDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"flow_definition_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"human_loop_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"workteam_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateArn,
+						},
+						"human_task_ui_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateArn,
+						},
+						"task_count": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"task_description": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"task_title": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"public_workforce_task_price": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"amount_in_usd": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"cents": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+												"dollars": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+												"tenth_fractions_of_a_cent": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"human_loop_activation_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"human_loop_activation_conditions": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"output_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_path": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerFlowDefinitionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("flow_definition_name").(string)
+
+	createOpts := &sagemaker.CreateFlowDefinitionInput{
+		FlowDefinitionName: aws.String(name),
+		RoleArn:            aws.String(d.Get("role_arn").(string)),
+		HumanLoopConfig:    expandSagemakerFlowDefinitionHumanLoopConfig(d.Get("human_loop_config").([]interface{})),
+		OutputConfig:       expandSagemakerFlowDefinitionOutputConfig(d.Get("output_config").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("human_loop_activation_config"); ok {
+		createOpts.HumanLoopActivationConfig = expandSagemakerFlowDefinitionHumanLoopActivationConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Flow Definition create config: %#v", *createOpts)
+	if _, err := conn.CreateFlowDefinition(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Flow Definition: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Flow Definition ID: %s", d.Id())
+
+	if err := waitSagemakerFlowDefinitionStatus(conn, name, d.Timeout(schema.TimeoutCreate), sagemaker.FlowDefinitionStatusActive, sagemaker.FlowDefinitionStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Flow Definition (%s) to be created: %s", name, err)
+	}
+
+	return resourceAwsSagemakerFlowDefinitionRead(d, meta)
+}
+
+func resourceAwsSagemakerFlowDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	flow, err := conn.DescribeFlowDefinition(&sagemaker.DescribeFlowDefinitionInput{
+		FlowDefinitionName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker flow definition %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("flow_definition_name", flow.FlowDefinitionName); err != nil {
+		return fmt.Errorf("error setting flow_definition_name for flow definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", flow.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for flow definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", flow.FlowDefinitionArn); err != nil {
+		return fmt.Errorf("error setting arn for flow definition %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("human_loop_config", flattenSagemakerFlowDefinitionHumanLoopConfig(flow.HumanLoopConfig)); err != nil {
+		return fmt.Errorf("error setting human_loop_config for flow definition %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("human_loop_activation_config", flattenSagemakerFlowDefinitionHumanLoopActivationConfig(flow.HumanLoopActivationConfig)); err != nil {
+		return fmt.Errorf("error setting human_loop_activation_config for flow definition %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("output_config", flattenSagemakerFlowDefinitionOutputConfig(flow.OutputConfig)); err != nil {
+		return fmt.Errorf("error setting output_config for flow definition %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, flow.FlowDefinitionArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for flow definition %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerFlowDefinitionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteFlowDefinition(&sagemaker.DeleteFlowDefinitionInput{
+		FlowDefinitionName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Flow Definition: %s", err)
+	}
+
+	if err := waitSagemakerFlowDefinitionDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Flow Definition (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitSagemakerFlowDefinitionStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeFlowDefinition(&sagemaker.DescribeFlowDefinitionInput{
+			FlowDefinitionName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(out.FlowDefinitionStatus)
+		if status == sagemaker.FlowDefinitionStatusFailed {
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Flow Definition (%s) failed: %s", name, aws.StringValue(out.FailureReason)))
+		}
+
+		for _, s := range desiredStatus {
+			if status == s {
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Flow Definition (%s) to be %s", name, desiredStatus))
+	})
+}
+
+func waitSagemakerFlowDefinitionDeleted(conn *sagemaker.SageMaker, name string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeFlowDefinition(&sagemaker.DescribeFlowDefinitionInput{
+			FlowDefinitionName: aws.String(name),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException") {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		if aws.StringValue(out.FlowDefinitionStatus) == sagemaker.FlowDefinitionStatusDeleting {
+			return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Flow Definition (%s) to be deleted", name))
+		}
+
+		return resource.NonRetryableError(fmt.Errorf("Sagemaker Flow Definition (%s) in unexpected status during delete: %s", name, aws.StringValue(out.FlowDefinitionStatus)))
+	})
+}
+
+func expandSagemakerFlowDefinitionHumanLoopConfig(l []interface{}) *sagemaker.HumanLoopConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.HumanLoopConfig{
+		WorkteamArn:     aws.String(m["workteam_arn"].(string)),
+		HumanTaskUiArn:  aws.String(m["human_task_ui_arn"].(string)),
+		TaskCount:       aws.Int64(int64(m["task_count"].(int))),
+		TaskDescription: aws.String(m["task_description"].(string)),
+		TaskTitle:       aws.String(m["task_title"].(string)),
+	}
+
+	if v, ok := m["public_workforce_task_price"]; ok {
+		if pl := v.([]interface{}); len(pl) > 0 && pl[0] != nil {
+			pm := pl[0].(map[string]interface{})
+			if al := pm["amount_in_usd"].([]interface{}); len(al) > 0 && al[0] != nil {
+				am := al[0].(map[string]interface{})
+				config.PublicWorkforceTaskPrice = &sagemaker.PublicWorkforceTaskPrice{
+					AmountInUsd: &sagemaker.USD{
+						Cents:                 aws.Int64(int64(am["cents"].(int))),
+						Dollars:               aws.Int64(int64(am["dollars"].(int))),
+						TenthFractionsOfACent: aws.Int64(int64(am["tenth_fractions_of_a_cent"].(int))),
+					},
+				}
+			}
+		}
+	}
+
+	return config
+}
+
+func flattenSagemakerFlowDefinitionHumanLoopConfig(config *sagemaker.HumanLoopConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"workteam_arn":      aws.StringValue(config.WorkteamArn),
+		"human_task_ui_arn": aws.StringValue(config.HumanTaskUiArn),
+		"task_count":        aws.Int64Value(config.TaskCount),
+		"task_description":  aws.StringValue(config.TaskDescription),
+		"task_title":        aws.StringValue(config.TaskTitle),
+	}
+
+	if config.PublicWorkforceTaskPrice != nil && config.PublicWorkforceTaskPrice.AmountInUsd != nil {
+		amount := config.PublicWorkforceTaskPrice.AmountInUsd
+		m["public_workforce_task_price"] = []map[string]interface{}{
+			{
+				"amount_in_usd": []map[string]interface{}{
+					{
+						"cents":                     aws.Int64Value(amount.Cents),
+						"dollars":                   aws.Int64Value(amount.Dollars),
+						"tenth_fractions_of_a_cent": aws.Int64Value(amount.TenthFractionsOfACent),
+					},
+				},
+			},
+		}
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerFlowDefinitionHumanLoopActivationConfig(l []interface{}) *sagemaker.HumanLoopActivationConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.HumanLoopActivationConfig{
+		HumanLoopActivationConditionsConfig: &sagemaker.HumanLoopActivationConditionsConfig{
+			HumanLoopActivationConditions: aws.String(m["human_loop_activation_conditions"].(string)),
+		},
+	}
+}
+
+func flattenSagemakerFlowDefinitionHumanLoopActivationConfig(config *sagemaker.HumanLoopActivationConfig) []map[string]interface{} {
+	if config == nil || config.HumanLoopActivationConditionsConfig == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"human_loop_activation_conditions": aws.StringValue(config.HumanLoopActivationConditionsConfig.HumanLoopActivationConditions),
+		},
+	}
+}
+
+func expandSagemakerFlowDefinitionOutputConfig(l []interface{}) *sagemaker.FlowDefinitionOutputConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.FlowDefinitionOutputConfig{
+		S3OutputPath: aws.String(m["s3_output_path"].(string)),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerFlowDefinitionOutputConfig(config *sagemaker.FlowDefinitionOutputConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"s3_output_path": aws.StringValue(config.S3OutputPath),
+			"kms_key_id":     aws.StringValue(config.KmsKeyId),
+		},
+	}
+}
@@ -0,0 +1,289 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerHub() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerHubCreate,
+		Read:   resourceAwsSagemakerHubRead,
+		Update: resourceAwsSagemakerHubUpdate,
+		Delete: resourceAwsSagemakerHubDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hub_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"hub_description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"hub_display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"hub_search_keywords": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"s3_storage_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerHubCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("hub_name").(string)
+
+	createOpts := &sagemaker.CreateHubInput{
+		HubName:        aws.String(name),
+		HubDescription: aws.String(d.Get("hub_description").(string)),
+	}
+
+	if v, ok := d.GetOk("hub_display_name"); ok {
+		createOpts.HubDisplayName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("hub_search_keywords"); ok {
+		createOpts.HubSearchKeywords = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("s3_storage_config"); ok {
+		createOpts.S3StorageConfig = expandSagemakerHubS3StorageConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Hub create config: %#v", *createOpts)
+	_, err := conn.CreateHub(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Hub: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Hub ID: %s", d.Id())
+
+	if err := waitSagemakerHubStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.HubStatusInService); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Hub (%s) to be InService: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerHubRead(d, meta)
+}
+
+func resourceAwsSagemakerHubRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	hub, err := conn.DescribeHub(&sagemaker.DescribeHubInput{
+		HubName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker hub %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("hub_name", hub.HubName); err != nil {
+		return fmt.Errorf("error setting hub_name for hub %q: %s", d.Id(), err)
+	}
+	if err := d.Set("hub_description", hub.HubDescription); err != nil {
+		return fmt.Errorf("error setting hub_description for hub %q: %s", d.Id(), err)
+	}
+	if err := d.Set("hub_display_name", hub.HubDisplayName); err != nil {
+		return fmt.Errorf("error setting hub_display_name for hub %q: %s", d.Id(), err)
+	}
+	if err := d.Set("hub_search_keywords", flattenStringList(hub.HubSearchKeywords)); err != nil {
+		return fmt.Errorf("error setting hub_search_keywords for hub %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", hub.HubArn); err != nil {
+		return fmt.Errorf("error setting arn for hub %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("s3_storage_config", flattenSagemakerHubS3StorageConfig(hub.S3StorageConfig)); err != nil {
+		return fmt.Errorf("error setting s3_storage_config for hub %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, hub.HubArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for hub %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerHubUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChanges("hub_description", "hub_display_name", "hub_search_keywords") {
+		updateOpts := &sagemaker.UpdateHubInput{
+			HubName:        aws.String(d.Id()),
+			HubDescription: aws.String(d.Get("hub_description").(string)),
+		}
+
+		if v, ok := d.GetOk("hub_display_name"); ok {
+			updateOpts.HubDisplayName = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("hub_search_keywords"); ok {
+			updateOpts.HubSearchKeywords = expandStringList(v.([]interface{}))
+		}
+
+		if _, err := conn.UpdateHub(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Hub: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerHubRead(d, meta)
+}
+
+func resourceAwsSagemakerHubDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteHub(&sagemaker.DeleteHubInput{
+		HubName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Hub: %s", err)
+	}
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.DescribeHub(&sagemaker.DescribeHubInput{
+			HubName: aws.String(d.Id()),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Hub (%s) to be deleted", d.Id()))
+	})
+}
+
+func waitSagemakerHubStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.HubStatusCreating, sagemaker.HubStatusUpdating},
+		Target:     desiredStatus,
+		Refresh:    sagemakerHubStateRefreshFunc(conn, name),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Hub (%s) to be %s: %s", name, desiredStatus, err)
+	}
+
+	return nil
+}
+
+func sagemakerHubStateRefreshFunc(conn *sagemaker.SageMaker, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		out, err := conn.DescribeHub(&sagemaker.DescribeHubInput{
+			HubName: aws.String(name),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		if aws.StringValue(out.HubStatus) == sagemaker.HubStatusFailed {
+			return out, *out.HubStatus, fmt.Errorf("%s", aws.StringValue(out.FailureReason))
+		}
+
+		return out, aws.StringValue(out.HubStatus), nil
+	}
+}
+
+func expandSagemakerHubS3StorageConfig(l []interface{}) *sagemaker.HubS3StorageConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.HubS3StorageConfig{}
+
+	if v, ok := m["s3_output_path"]; ok && v.(string) != "" {
+		config.S3OutputPath = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerHubS3StorageConfig(config *sagemaker.HubS3StorageConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"s3_output_path": aws.StringValue(config.S3OutputPath),
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerHumanTaskUi() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerHumanTaskUiCreate,
+		Read:   resourceAwsSagemakerHumanTaskUiRead,
+		Delete: resourceAwsSagemakerHumanTaskUiDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"human_task_ui_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"ui_template": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"content_sha256": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerHumanTaskUiCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("human_task_ui_name").(string)
+
+	createOpts := &sagemaker.CreateHumanTaskUiInput{
+		HumanTaskUiName: aws.String(name),
+		UiTemplate:      expandSagemakerHumanTaskUiTemplate(d.Get("ui_template").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Human Task UI create config: %#v", *createOpts)
+	if _, err := conn.CreateHumanTaskUi(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Human Task UI: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Human Task UI ID: %s", d.Id())
+
+	return resourceAwsSagemakerHumanTaskUiRead(d, meta)
+}
+
+func resourceAwsSagemakerHumanTaskUiRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	ui, err := conn.DescribeHumanTaskUi(&sagemaker.DescribeHumanTaskUiInput{
+		HumanTaskUiName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker human task UI %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("human_task_ui_name", ui.HumanTaskUiName); err != nil {
+		return fmt.Errorf("error setting human_task_ui_name for human task UI %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", ui.HumanTaskUiArn); err != nil {
+		return fmt.Errorf("error setting arn for human task UI %q: %s", d.Id(), err)
+	}
+
+	uiTemplate := d.Get("ui_template").([]interface{})
+	content := ""
+	if len(uiTemplate) > 0 && uiTemplate[0] != nil {
+		content = uiTemplate[0].(map[string]interface{})["content"].(string)
+	}
+
+	if err := d.Set("ui_template", []map[string]interface{}{
+		{
+			"content":        content,
+			"content_sha256": aws.StringValue(ui.UiTemplate.ContentSha256),
+			"url":            aws.StringValue(ui.UiTemplate.Url),
+		},
+	}); err != nil {
+		return fmt.Errorf("error setting ui_template for human task UI %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, ui.HumanTaskUiArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for human task UI %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerHumanTaskUiDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteHumanTaskUi(&sagemaker.DeleteHumanTaskUiInput{
+		HumanTaskUiName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Human Task UI: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerHumanTaskUiTemplate(l []interface{}) *sagemaker.UiTemplate {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.UiTemplate{
+		Content: aws.String(m["content"].(string)),
+	}
+}
@@ -0,0 +1,182 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerImageCreate,
+		Read:   resourceAwsSagemakerImageRead,
+		Update: resourceAwsSagemakerImageUpdate,
+		Delete: resourceAwsSagemakerImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"image_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerImageCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("image_name").(string)
+
+	createOpts := &sagemaker.CreateImageInput{
+		ImageName: aws.String(name),
+		RoleArn:   aws.String(d.Get("role_arn").(string)),
+	}
+
+	if v, ok := d.GetOk("display_name"); ok {
+		createOpts.DisplayName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		createOpts.Description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Image create config: %#v", *createOpts)
+	if _, err := conn.CreateImage(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Image: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Image ID: %s", d.Id())
+
+	return resourceAwsSagemakerImageRead(d, meta)
+}
+
+func resourceAwsSagemakerImageRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	image, err := conn.DescribeImage(&sagemaker.DescribeImageInput{
+		ImageName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker image %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("image_name", image.ImageName); err != nil {
+		return fmt.Errorf("error setting image_name for image %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", image.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for image %q: %s", d.Id(), err)
+	}
+	if err := d.Set("display_name", image.DisplayName); err != nil {
+		return fmt.Errorf("error setting display_name for image %q: %s", d.Id(), err)
+	}
+	if err := d.Set("description", image.Description); err != nil {
+		return fmt.Errorf("error setting description for image %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", image.ImageArn); err != nil {
+		return fmt.Errorf("error setting arn for image %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, image.ImageArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for image %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("role_arn") || d.HasChange("display_name") || d.HasChange("description") {
+		updateOpts := &sagemaker.UpdateImageInput{
+			ImageName: aws.String(d.Id()),
+		}
+
+		if v, ok := d.GetOk("role_arn"); ok {
+			updateOpts.RoleArn = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("display_name"); ok {
+			updateOpts.DisplayName = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("description"); ok {
+			updateOpts.Description = aws.String(v.(string))
+		}
+
+		if _, err := conn.UpdateImage(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Image: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerImageRead(d, meta)
+}
+
+func resourceAwsSagemakerImageDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteImage(&sagemaker.DeleteImageInput{
+		ImageName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Image: %s", err)
+	}
+
+	return nil
+}
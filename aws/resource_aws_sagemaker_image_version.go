@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerImageVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerImageVersionCreate,
+		Read:   resourceAwsSagemakerImageVersionRead,
+		Delete: resourceAwsSagemakerImageVersionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"image_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"base_image": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"container_image": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerImageVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	imageName := d.Get("image_name").(string)
+
+	createOpts := &sagemaker.CreateImageVersionInput{
+		ImageName: aws.String(imageName),
+		BaseImage: aws.String(d.Get("base_image").(string)),
+	}
+
+	log.Printf("[DEBUG] Sagemaker Image Version create config: %#v", *createOpts)
+	if _, err := conn.CreateImageVersion(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Image Version: %s", err)
+	}
+
+	d.SetId(imageName)
+	log.Printf("[INFO] Sagemaker Image Version ID: %s", d.Id())
+
+	if err := waitSagemakerImageVersionCreated(conn, imageName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Image Version (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerImageVersionRead(d, meta)
+}
+
+func resourceAwsSagemakerImageVersionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	imageVersion, err := conn.DescribeImageVersion(&sagemaker.DescribeImageVersionInput{
+		ImageName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker image version %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("image_name", d.Id()); err != nil {
+		return fmt.Errorf("error setting image_name for image version %q: %s", d.Id(), err)
+	}
+	if err := d.Set("base_image", imageVersion.BaseImage); err != nil {
+		return fmt.Errorf("error setting base_image for image version %q: %s", d.Id(), err)
+	}
+	if err := d.Set("version", imageVersion.Version); err != nil {
+		return fmt.Errorf("error setting version for image version %q: %s", d.Id(), err)
+	}
+	if err := d.Set("container_image", imageVersion.ContainerImage); err != nil {
+		return fmt.Errorf("error setting container_image for image version %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", imageVersion.ImageVersionArn); err != nil {
+		return fmt.Errorf("error setting arn for image version %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerImageVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteImageVersion(&sagemaker.DeleteImageVersionInput{
+		ImageName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Image Version: %s", err)
+	}
+
+	return nil
+}
+
+func waitSagemakerImageVersionCreated(conn *sagemaker.SageMaker, imageName string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeImageVersion(&sagemaker.DescribeImageVersionInput{
+			ImageName: aws.String(imageName),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		switch aws.StringValue(out.ImageVersionStatus) {
+		case sagemaker.ImageVersionStatusCreated:
+			return nil
+		case sagemaker.ImageVersionStatusCreateFailed:
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Image Version (%s) creation failed: %s", imageName, aws.StringValue(out.FailureReason)))
+		default:
+			return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Image Version (%s) to be created", imageName))
+		}
+	})
+}
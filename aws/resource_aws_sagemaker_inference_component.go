@@ -0,0 +1,470 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/waiter"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerInferenceComponent() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerInferenceComponentCreate,
+		Read:   resourceAwsSagemakerInferenceComponentRead,
+		Update: resourceAwsSagemakerInferenceComponentUpdate,
+		Delete: resourceAwsSagemakerInferenceComponentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"inference_component_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"variant_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"specification": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"model_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"container": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"image": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"artifact_url": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"environment": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+
+						"compute_resource_requirements": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"number_of_cpu_cores_required": {
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+
+									"number_of_accelerator_devices_required": {
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+
+									"min_memory_required_in_mb": {
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+
+									"max_memory_required_in_mb": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"startup_parameters": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"model_data_download_timeout_in_seconds": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+
+									"container_startup_health_check_timeout_in_seconds": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"runtime_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"copy_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerInferenceComponentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("inference_component_name").(string)
+
+	createOpts := &sagemaker.CreateInferenceComponentInput{
+		InferenceComponentName: aws.String(name),
+		EndpointName:           aws.String(d.Get("endpoint_name").(string)),
+		VariantName:            aws.String(d.Get("variant_name").(string)),
+		Specification:          expandSagemakerInferenceComponentSpecification(d.Get("specification").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("runtime_config"); ok {
+		createOpts.RuntimeConfig = expandSagemakerInferenceComponentRuntimeConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Inference Component create config: %#v", *createOpts)
+	if _, err := conn.CreateInferenceComponent(createOpts); err != nil {
+		return fmt.Errorf("error creating Sagemaker Inference Component: %s", err)
+	}
+
+	d.SetId(name)
+
+	if _, err := waiter.InferenceComponentInService(conn, d.Id(), 20*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Inference Component (%s) to be in service: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerInferenceComponentRead(d, meta)
+}
+
+func resourceAwsSagemakerInferenceComponentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	component, err := finder.InferenceComponentByName(conn, d.Id())
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			log.Printf("[WARN] Unable to find Sagemaker Inference Component (%s); removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading Sagemaker Inference Component (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("inference_component_name", component.InferenceComponentName); err != nil {
+		return fmt.Errorf("error setting inference_component_name for inference component %q: %s", d.Id(), err)
+	}
+	if err := d.Set("endpoint_name", component.EndpointName); err != nil {
+		return fmt.Errorf("error setting endpoint_name for inference component %q: %s", d.Id(), err)
+	}
+	if err := d.Set("variant_name", component.VariantName); err != nil {
+		return fmt.Errorf("error setting variant_name for inference component %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", component.InferenceComponentArn); err != nil {
+		return fmt.Errorf("error setting arn for inference component %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("specification", flattenSagemakerInferenceComponentSpecification(component.Specification)); err != nil {
+		return fmt.Errorf("error setting specification for inference component %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("runtime_config", flattenSagemakerInferenceComponentRuntimeConfig(component.RuntimeConfig)); err != nil {
+		return fmt.Errorf("error setting runtime_config for inference component %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, component.InferenceComponentArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for inference component %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for inference component %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerInferenceComponentUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	d.Partial(true)
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	if d.HasChange("specification") || d.HasChange("runtime_config") {
+		updateOpts := &sagemaker.UpdateInferenceComponentInput{
+			InferenceComponentName: aws.String(d.Id()),
+			Specification:          expandSagemakerInferenceComponentSpecification(d.Get("specification").([]interface{})),
+		}
+
+		if v, ok := d.GetOk("runtime_config"); ok {
+			updateOpts.RuntimeConfig = expandSagemakerInferenceComponentRuntimeConfig(v.([]interface{}))
+		}
+
+		if _, err := conn.UpdateInferenceComponent(updateOpts); err != nil {
+			return fmt.Errorf("error updating Sagemaker Inference Component (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.InferenceComponentInService(conn, d.Id(), 20*time.Minute); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Inference Component (%s) to update: %s", d.Id(), err)
+		}
+	}
+	d.SetPartial("specification")
+	d.SetPartial("runtime_config")
+
+	d.Partial(false)
+
+	return resourceAwsSagemakerInferenceComponentRead(d, meta)
+}
+
+func resourceAwsSagemakerInferenceComponentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	_, err := conn.DeleteInferenceComponent(&sagemaker.DeleteInferenceComponentInput{
+		InferenceComponentName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("error deleting Sagemaker Inference Component (%s): %s", d.Id(), err)
+	}
+
+	if err := waiter.InferenceComponentDeleted(conn, d.Id(), 20*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Inference Component (%s) to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandSagemakerInferenceComponentSpecification(l []interface{}) *sagemaker.InferenceComponentSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.InferenceComponentSpecification{
+		ComputeResourceRequirements: expandSagemakerInferenceComponentComputeResourceRequirements(m["compute_resource_requirements"].([]interface{})),
+	}
+
+	if v, ok := m["model_name"]; ok && v.(string) != "" {
+		spec.ModelName = aws.String(v.(string))
+	}
+
+	if v, ok := m["container"]; ok && len(v.([]interface{})) > 0 {
+		spec.Container = expandSagemakerInferenceComponentContainer(v.([]interface{}))
+	}
+
+	if v, ok := m["startup_parameters"]; ok && len(v.([]interface{})) > 0 {
+		spec.StartupParameters = expandSagemakerInferenceComponentStartupParameters(v.([]interface{}))
+	}
+
+	return spec
+}
+
+func flattenSagemakerInferenceComponentSpecification(spec *sagemaker.InferenceComponentSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"model_name":                    aws.StringValue(spec.ModelName),
+		"container":                     flattenSagemakerInferenceComponentContainer(spec.Container),
+		"compute_resource_requirements": flattenSagemakerInferenceComponentComputeResourceRequirements(spec.ComputeResourceRequirements),
+		"startup_parameters":            flattenSagemakerInferenceComponentStartupParameters(spec.StartupParameters),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerInferenceComponentContainer(l []interface{}) *sagemaker.InferenceComponentContainerSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	container := &sagemaker.InferenceComponentContainerSpecification{}
+
+	if v, ok := m["image"]; ok && v.(string) != "" {
+		container.Image = aws.String(v.(string))
+	}
+
+	if v, ok := m["artifact_url"]; ok && v.(string) != "" {
+		container.ArtifactUrl = aws.String(v.(string))
+	}
+
+	if v, ok := m["environment"]; ok {
+		container.Environment = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	return container
+}
+
+func flattenSagemakerInferenceComponentContainer(container *sagemaker.InferenceComponentContainerSpecification) []map[string]interface{} {
+	if container == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"image":        aws.StringValue(container.Image),
+		"artifact_url": aws.StringValue(container.ArtifactUrl),
+		"environment":  aws.StringValueMap(container.Environment),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerInferenceComponentComputeResourceRequirements(l []interface{}) *sagemaker.InferenceComponentComputeResourceRequirements {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	requirements := &sagemaker.InferenceComponentComputeResourceRequirements{
+		MinMemoryRequiredInMb: aws.Int64(int64(m["min_memory_required_in_mb"].(int))),
+	}
+
+	if v, ok := m["number_of_cpu_cores_required"]; ok && v.(float64) != 0 {
+		requirements.NumberOfCpuCoresRequired = aws.Float64(v.(float64))
+	}
+
+	if v, ok := m["number_of_accelerator_devices_required"]; ok && v.(float64) != 0 {
+		requirements.NumberOfAcceleratorDevicesRequired = aws.Float64(v.(float64))
+	}
+
+	if v, ok := m["max_memory_required_in_mb"]; ok && v.(int) != 0 {
+		requirements.MaxMemoryRequiredInMb = aws.Int64(int64(v.(int)))
+	}
+
+	return requirements
+}
+
+func flattenSagemakerInferenceComponentComputeResourceRequirements(requirements *sagemaker.InferenceComponentComputeResourceRequirements) []map[string]interface{} {
+	if requirements == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"number_of_cpu_cores_required":          aws.Float64Value(requirements.NumberOfCpuCoresRequired),
+		"number_of_accelerator_devices_required": aws.Float64Value(requirements.NumberOfAcceleratorDevicesRequired),
+		"min_memory_required_in_mb":              aws.Int64Value(requirements.MinMemoryRequiredInMb),
+		"max_memory_required_in_mb":              aws.Int64Value(requirements.MaxMemoryRequiredInMb),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerInferenceComponentStartupParameters(l []interface{}) *sagemaker.InferenceComponentStartupParameters {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	params := &sagemaker.InferenceComponentStartupParameters{}
+
+	if v, ok := m["model_data_download_timeout_in_seconds"]; ok && v.(int) != 0 {
+		params.ModelDataDownloadTimeoutInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := m["container_startup_health_check_timeout_in_seconds"]; ok && v.(int) != 0 {
+		params.ContainerStartupHealthCheckTimeoutInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	return params
+}
+
+func flattenSagemakerInferenceComponentStartupParameters(params *sagemaker.InferenceComponentStartupParameters) []map[string]interface{} {
+	if params == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"model_data_download_timeout_in_seconds":            aws.Int64Value(params.ModelDataDownloadTimeoutInSeconds),
+		"container_startup_health_check_timeout_in_seconds": aws.Int64Value(params.ContainerStartupHealthCheckTimeoutInSeconds),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerInferenceComponentRuntimeConfig(l []interface{}) *sagemaker.InferenceComponentRuntimeConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.InferenceComponentRuntimeConfig{
+		CopyCount: aws.Int64(int64(m["copy_count"].(int))),
+	}
+}
+
+func flattenSagemakerInferenceComponentRuntimeConfig(config *sagemaker.InferenceComponentRuntimeConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"copy_count": aws.Int64Value(config.CopyCount),
+	}
+
+	return []map[string]interface{}{m}
+}
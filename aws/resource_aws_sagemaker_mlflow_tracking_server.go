@@ -0,0 +1,236 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/waiter"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerMlflowTrackingServer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerMlflowTrackingServerCreate,
+		Read:   resourceAwsSagemakerMlflowTrackingServerRead,
+		Update: resourceAwsSagemakerMlflowTrackingServerUpdate,
+		Delete: resourceAwsSagemakerMlflowTrackingServerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tracking_server_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"artifact_store_uri": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tracking_server_size": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      sagemaker.TrackingServerSizeSmall,
+				ValidateFunc: validation.StringInSlice(sagemaker.TrackingServerSize_Values(), false),
+			},
+
+			"mlflow_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"automatic_model_registration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"weekly_maintenance_window_start": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tracking_server_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerMlflowTrackingServerCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("tracking_server_name").(string)
+
+	createOpts := &sagemaker.CreateMlflowTrackingServerInput{
+		TrackingServerName:         aws.String(name),
+		ArtifactStoreUri:           aws.String(d.Get("artifact_store_uri").(string)),
+		RoleArn:                    aws.String(d.Get("role_arn").(string)),
+		TrackingServerSize:         aws.String(d.Get("tracking_server_size").(string)),
+		AutomaticModelRegistration: aws.Bool(d.Get("automatic_model_registration").(bool)),
+	}
+
+	if v, ok := d.GetOk("mlflow_version"); ok {
+		createOpts.MlflowVersion = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("weekly_maintenance_window_start"); ok {
+		createOpts.WeeklyMaintenanceWindowStart = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker MLflow Tracking Server create config: %#v", *createOpts)
+	if _, err := conn.CreateMlflowTrackingServer(createOpts); err != nil {
+		return fmt.Errorf("error creating Sagemaker MLflow Tracking Server: %s", err)
+	}
+
+	d.SetId(name)
+
+	if _, err := waiter.MlflowTrackingServerCreated(conn, d.Id(), 30*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker MLflow Tracking Server (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerMlflowTrackingServerRead(d, meta)
+}
+
+func resourceAwsSagemakerMlflowTrackingServerRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	server, err := finder.MlflowTrackingServerByName(conn, d.Id())
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			log.Printf("[WARN] Unable to find Sagemaker MLflow Tracking Server (%s); removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading Sagemaker MLflow Tracking Server (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("tracking_server_name", server.TrackingServerName); err != nil {
+		return fmt.Errorf("error setting tracking_server_name for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("artifact_store_uri", server.ArtifactStoreUri); err != nil {
+		return fmt.Errorf("error setting artifact_store_uri for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", server.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("tracking_server_size", server.TrackingServerSize); err != nil {
+		return fmt.Errorf("error setting tracking_server_size for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("mlflow_version", server.MlflowVersion); err != nil {
+		return fmt.Errorf("error setting mlflow_version for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("automatic_model_registration", server.AutomaticModelRegistration); err != nil {
+		return fmt.Errorf("error setting automatic_model_registration for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("weekly_maintenance_window_start", server.WeeklyMaintenanceWindowStart); err != nil {
+		return fmt.Errorf("error setting weekly_maintenance_window_start for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("tracking_server_url", server.TrackingServerUrl); err != nil {
+		return fmt.Errorf("error setting tracking_server_url for MLflow tracking server %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", server.TrackingServerArn); err != nil {
+		return fmt.Errorf("error setting arn for MLflow tracking server %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, server.TrackingServerArn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for MLflow tracking server %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for MLflow tracking server %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerMlflowTrackingServerUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	d.Partial(true)
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	if d.HasChange("tracking_server_size") || d.HasChange("automatic_model_registration") || d.HasChange("weekly_maintenance_window_start") {
+		updateOpts := &sagemaker.UpdateMlflowTrackingServerInput{
+			TrackingServerName:         aws.String(d.Id()),
+			TrackingServerSize:         aws.String(d.Get("tracking_server_size").(string)),
+			AutomaticModelRegistration: aws.Bool(d.Get("automatic_model_registration").(bool)),
+		}
+
+		if v, ok := d.GetOk("weekly_maintenance_window_start"); ok {
+			updateOpts.WeeklyMaintenanceWindowStart = aws.String(v.(string))
+		}
+
+		if _, err := conn.UpdateMlflowTrackingServer(updateOpts); err != nil {
+			return fmt.Errorf("error updating Sagemaker MLflow Tracking Server (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.MlflowTrackingServerCreated(conn, d.Id(), 30*time.Minute); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker MLflow Tracking Server (%s) to update: %s", d.Id(), err)
+		}
+	}
+	d.SetPartial("tracking_server_size")
+	d.SetPartial("automatic_model_registration")
+	d.SetPartial("weekly_maintenance_window_start")
+
+	d.Partial(false)
+
+	return resourceAwsSagemakerMlflowTrackingServerRead(d, meta)
+}
+
+func resourceAwsSagemakerMlflowTrackingServerDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	_, err := conn.DeleteMlflowTrackingServer(&sagemaker.DeleteMlflowTrackingServerInput{
+		TrackingServerName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("error deleting Sagemaker MLflow Tracking Server (%s): %s", d.Id(), err)
+	}
+
+	if err := waiter.MlflowTrackingServerDeleted(conn, d.Id(), 30*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker MLflow Tracking Server (%s) to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
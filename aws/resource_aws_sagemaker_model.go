@@ -0,0 +1,453 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// sagemakerModelDeleteTimeout bounds how long force_delete will retry a
+// delete rejected because the model is still referenced by an endpoint,
+// giving a concurrently-tearing-down endpoint time to finish releasing it.
+const sagemakerModelDeleteTimeout = 10 * time.Minute
+
+func resourceAwsSagemakerModel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerModelCreate,
+		Read:   resourceAwsSagemakerModelRead,
+		Update: resourceAwsSagemakerModelUpdate,
+		Delete: resourceAwsSagemakerModelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceAwsSagemakerModelCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"execution_role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"primary_container": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"model_data_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"container_hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"container": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"model_data_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"container_hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"vpc_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_group_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"subnets": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"enable_network_isolation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerModelCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateModelInput{
+		ModelName:              aws.String(name),
+		ExecutionRoleArn:       aws.String(d.Get("execution_role_arn").(string)),
+		EnableNetworkIsolation: aws.Bool(d.Get("enable_network_isolation").(bool)),
+	}
+
+	if v, ok := d.GetOk("primary_container"); ok {
+		createOpts.PrimaryContainer = expandSagemakerModelContainer(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("container"); ok {
+		createOpts.Containers = expandSagemakerModelContainers(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("vpc_config"); ok {
+		createOpts.VpcConfig = expandSagemakerTrainingJobVpcConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Model create config: %#v", *createOpts)
+	_, err := conn.CreateModel(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Model: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Model ID: %s", d.Id())
+
+	return resourceAwsSagemakerModelRead(d, meta)
+}
+
+func resourceAwsSagemakerModelRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	request := &sagemaker.DescribeModelInput{
+		ModelName: aws.String(d.Id()),
+	}
+
+	model, err := conn.DescribeModel(request)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker model %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("name", model.ModelName); err != nil {
+		return fmt.Errorf("error setting name for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("execution_role_arn", model.ExecutionRoleArn); err != nil {
+		return fmt.Errorf("error setting execution_role_arn for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("enable_network_isolation", model.EnableNetworkIsolation); err != nil {
+		return fmt.Errorf("error setting enable_network_isolation for model %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", model.ModelArn); err != nil {
+		return fmt.Errorf("error setting arn for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("primary_container", flattenSagemakerModelContainer(model.PrimaryContainer)); err != nil {
+		return fmt.Errorf("error setting primary_container for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("container", flattenSagemakerModelContainers(model.Containers)); err != nil {
+		return fmt.Errorf("error setting container for model %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("vpc_config", flattenSagemakerTrainingJobVpcConfig(model.VpcConfig)); err != nil {
+		return fmt.Errorf("error setting vpc_config for model %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, model.ModelArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for model %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerModelUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	return resourceAwsSagemakerModelRead(d, meta)
+}
+
+func resourceAwsSagemakerModelDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	return resourceAwsSagemakerModelDeleteWithRetry(conn, d.Id(), d.Get("force_delete").(bool))
+}
+
+// resourceAwsSagemakerModelDeleteWithRetry is the Delete logic extracted
+// from resourceAwsSagemakerModelDelete so it can be exercised against a fake
+// SageMakerAPI without a *schema.ResourceData/*AWSClient. When force_delete
+// is false, a dependency error from a referencing endpoint is returned
+// immediately with the likely endpoint named; when true, the same error is
+// retried for up to sagemakerModelDeleteTimeout in case the endpoint is
+// being torn down concurrently.
+func resourceAwsSagemakerModelDeleteWithRetry(conn sagemakeriface.SageMakerAPI, id string, forceDelete bool) error {
+	deleteOpts := &sagemaker.DeleteModelInput{
+		ModelName: aws.String(id),
+	}
+
+	_, err := conn.DeleteModel(deleteOpts)
+	if err == nil {
+		return nil
+	}
+
+	if !isSagemakerModelInUseErr(err) {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Model: %s", err)
+	}
+
+	if !forceDelete {
+		return sagemakerModelInUseError(id, err)
+	}
+
+	log.Printf("[DEBUG] Sagemaker Model (%s) is still in use, force_delete is set, waiting for it to be released: %s", id, err)
+
+	return resource.Retry(sagemakerModelDeleteTimeout, func() *resource.RetryError {
+		_, err := conn.DeleteModel(deleteOpts)
+		if err == nil {
+			return nil
+		}
+
+		if isSagemakerModelInUseErr(err) {
+			return resource.RetryableError(sagemakerModelInUseError(id, err))
+		}
+
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+
+		return resource.NonRetryableError(fmt.Errorf("Error deleting Sagemaker Model: %s", err))
+	})
+}
+
+// sagemakerModelInUseEndpointRegexp extracts the endpoint name SageMaker
+// names in a DeleteModel dependency error, e.g. "Cannot delete model
+// my-model because it is being used by endpoint my-endpoint", so the error
+// returned to the user can point at the likely culprit instead of leaving
+// them to hunt for it.
+var sagemakerModelInUseEndpointRegexp = regexp.MustCompile(`used by endpoint[^\w]*([\w.-]+)`)
+
+// isSagemakerModelInUseErr returns true when DeleteModel was rejected
+// because the model is still referenced by an endpoint, so the caller can
+// surface a clearer message or, with force_delete, retry until a
+// concurrently-tearing-down endpoint releases it.
+func isSagemakerModelInUseErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	if awsErr.Code() == "ResourceInUse" {
+		return true
+	}
+
+	return awsErr.Code() == "ValidationException" && strings.Contains(awsErr.Message(), "used by endpoint")
+}
+
+// sagemakerModelInUseError wraps a DeleteModel dependency error with the
+// likely endpoint name parsed out of it, when present, so the user knows
+// what to delete first instead of just seeing the API's generic message.
+func sagemakerModelInUseError(id string, err error) error {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return fmt.Errorf("Error deleting Sagemaker Model (%s): still in use: %s", id, err)
+	}
+
+	if m := sagemakerModelInUseEndpointRegexp.FindStringSubmatch(awsErr.Message()); len(m) == 2 {
+		return fmt.Errorf("Error deleting Sagemaker Model (%s): still in use by endpoint %q; delete the endpoint first, or set force_delete to retry until it is released", id, m[1])
+	}
+
+	return fmt.Errorf("Error deleting Sagemaker Model (%s): still in use by an endpoint: %s", id, awsErr.Message())
+}
+
+func expandSagemakerModelContainer(l []interface{}) *sagemaker.ContainerDefinition {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	container := &sagemaker.ContainerDefinition{
+		Image: aws.String(m["image"].(string)),
+	}
+
+	if v, ok := m["model_data_url"]; ok && v.(string) != "" {
+		container.ModelDataUrl = aws.String(v.(string))
+	}
+
+	if v, ok := m["container_hostname"]; ok && v.(string) != "" {
+		container.ContainerHostname = aws.String(v.(string))
+	}
+
+	if v, ok := m["environment"]; ok {
+		container.Environment = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	return container
+}
+
+func expandSagemakerModelContainers(l []interface{}) []*sagemaker.ContainerDefinition {
+	containers := make([]*sagemaker.ContainerDefinition, 0, len(l))
+
+	for _, raw := range l {
+		containers = append(containers, expandSagemakerModelContainer([]interface{}{raw}))
+	}
+
+	return containers
+}
+
+func flattenSagemakerModelContainer(container *sagemaker.ContainerDefinition) []map[string]interface{} {
+	if container == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{flattenSagemakerModelContainerMap(container)}
+}
+
+func flattenSagemakerModelContainers(containers []*sagemaker.ContainerDefinition) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(containers))
+
+	for _, c := range containers {
+		result = append(result, flattenSagemakerModelContainerMap(c))
+	}
+
+	return result
+}
+
+func flattenSagemakerModelContainerMap(container *sagemaker.ContainerDefinition) map[string]interface{} {
+	return map[string]interface{}{
+		"image":              aws.StringValue(container.Image),
+		"model_data_url":     aws.StringValue(container.ModelDataUrl),
+		"container_hostname": aws.StringValue(container.ContainerHostname),
+		"environment":        aws.StringValueMap(container.Environment),
+	}
+}
+
+// resourceAwsSagemakerModelCustomizeDiff warns (rather than errors, since the
+// combination is sometimes intentional, e.g. an existing VPC endpoint) when
+// enable_network_isolation is set alongside a model_data_url, since isolating
+// the container from the network also blocks the S3 access it needs to
+// download model artifacts unless a VPC endpoint for S3 is already in place.
+func resourceAwsSagemakerModelCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("enable_network_isolation").(bool) {
+		return nil
+	}
+
+	if sagemakerModelHasModelDataUrl(d.Get("primary_container").([]interface{})) || sagemakerModelHasModelDataUrl(d.Get("container").([]interface{})) {
+		log.Printf("[WARN] enable_network_isolation is true and a model_data_url is set; ensure a VPC endpoint for S3 is configured, or model artifact download will fail")
+	}
+
+	return nil
+}
+
+func sagemakerModelHasModelDataUrl(containers []interface{}) bool {
+	for _, c := range containers {
+		if c == nil {
+			continue
+		}
+
+		if url, ok := c.(map[string]interface{})["model_data_url"]; ok && url.(string) != "" {
+			return true
+		}
+	}
+
+	return false
+}
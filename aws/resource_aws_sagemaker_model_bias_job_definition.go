@@ -0,0 +1,581 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceAwsSagemakerModelBiasJobDefinition has no Update, the same as
+// resource_aws_sagemaker_data_quality_job_definition.go: there is no
+// UpdateModelBiasJobDefinition API, so every field other than tags is ForceNew.
+func resourceAwsSagemakerModelBiasJobDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerModelBiasJobDefinitionCreate,
+		Read:   resourceAwsSagemakerModelBiasJobDefinitionRead,
+		Delete: resourceAwsSagemakerModelBiasJobDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"model_bias_app_specification": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"config_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"model_bias_baseline_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"baselining_job_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"constraints_resource": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_uri": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"model_bias_job_input": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint_input": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"endpoint_name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"local_path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"s3_input_mode": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ForceNew:     true,
+										Default:      sagemaker.ProcessingS3InputModeFile,
+										ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3InputMode_Values(), false),
+									},
+									"s3_data_distribution_type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ForceNew:     true,
+										Default:      sagemaker.ProcessingS3DataDistributionTypeFullyReplicated,
+										ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3DataDistributionType_Values(), false),
+									},
+								},
+							},
+						},
+						"ground_truth_s3_input": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_uri": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"model_bias_job_output_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"monitoring_outputs": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_output": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"local_path": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+												"s3_uri": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+												},
+												"s3_upload_mode": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ForceNew:     true,
+													Default:      sagemaker.ProcessingS3UploadModeEndOfJob,
+													ValidateFunc: validation.StringInSlice(sagemaker.ProcessingS3UploadMode_Values(), false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"job_resources": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_count": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"instance_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"volume_size_in_gb": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+									},
+									"volume_kms_key_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"network_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_inter_container_traffic_encryption": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"enable_network_isolation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"vpc_config": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"security_group_ids": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"subnets": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"stopping_condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_runtime_in_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerModelBiasJobDefinitionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	var name string
+	if v, ok := d.GetOk("name"); ok {
+		name = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		name = resource.PrefixedUniqueId(v.(string))
+	} else {
+		name = resource.UniqueId()
+	}
+
+	createOpts := &sagemaker.CreateModelBiasJobDefinitionInput{
+		JobDefinitionName:         aws.String(name),
+		RoleArn:                   aws.String(d.Get("role_arn").(string)),
+		ModelBiasAppSpecification: expandSagemakerModelBiasJobDefinitionAppSpecification(d.Get("model_bias_app_specification").([]interface{})),
+		ModelBiasJobInput:         expandSagemakerModelBiasJobDefinitionJobInput(d.Get("model_bias_job_input").([]interface{})),
+		ModelBiasJobOutputConfig:  expandSagemakerDataQualityJobDefinitionJobOutputConfig(d.Get("model_bias_job_output_config").([]interface{})),
+		JobResources:              expandSagemakerDataQualityJobDefinitionJobResources(d.Get("job_resources").([]interface{})),
+	}
+
+
+	if v, ok := d.GetOk("model_bias_baseline_config"); ok {
+		createOpts.ModelBiasBaselineConfig = expandSagemakerModelBiasJobDefinitionBaselineConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("network_config"); ok {
+		createOpts.NetworkConfig = expandSagemakerDataQualityJobDefinitionNetworkConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("stopping_condition"); ok {
+		createOpts.StoppingCondition = expandSagemakerTrainingJobStoppingCondition(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Model Bias Job Definition create config: %#v", *createOpts)
+	_, err := conn.CreateModelBiasJobDefinition(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Model Bias Job Definition: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Model Bias Job Definition ID: %s", d.Id())
+
+	return resourceAwsSagemakerModelBiasJobDefinitionRead(d, meta)
+}
+
+func resourceAwsSagemakerModelBiasJobDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	jobDefinition, err := conn.DescribeModelBiasJobDefinition(&sagemaker.DescribeModelBiasJobDefinitionInput{
+		JobDefinitionName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			d.SetId("")
+			log.Printf("[LOG] Unable to find SageMaker Model Bias Job Definition %q; removing from state file", d.Id())
+			return nil
+		}
+		return err
+	}
+
+	if err := d.Set("name", jobDefinition.JobDefinitionName); err != nil {
+		return fmt.Errorf("error setting name for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", jobDefinition.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", jobDefinition.JobDefinitionArn); err != nil {
+		return fmt.Errorf("error setting arn for model bias job definition %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("model_bias_app_specification", flattenSagemakerModelBiasJobDefinitionAppSpecification(jobDefinition.ModelBiasAppSpecification)); err != nil {
+		return fmt.Errorf("error setting model_bias_app_specification for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_bias_baseline_config", flattenSagemakerModelBiasJobDefinitionBaselineConfig(jobDefinition.ModelBiasBaselineConfig)); err != nil {
+		return fmt.Errorf("error setting model_bias_baseline_config for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_bias_job_input", flattenSagemakerModelBiasJobDefinitionJobInput(jobDefinition.ModelBiasJobInput)); err != nil {
+		return fmt.Errorf("error setting model_bias_job_input for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_bias_job_output_config", flattenSagemakerDataQualityJobDefinitionJobOutputConfig(jobDefinition.ModelBiasJobOutputConfig)); err != nil {
+		return fmt.Errorf("error setting model_bias_job_output_config for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("job_resources", flattenSagemakerDataQualityJobDefinitionJobResources(jobDefinition.JobResources)); err != nil {
+		return fmt.Errorf("error setting job_resources for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("network_config", flattenSagemakerDataQualityJobDefinitionNetworkConfig(jobDefinition.NetworkConfig)); err != nil {
+		return fmt.Errorf("error setting network_config for model bias job definition %q: %s", d.Id(), err)
+	}
+	if err := d.Set("stopping_condition", flattenSagemakerTrainingJobStoppingCondition(jobDefinition.StoppingCondition)); err != nil {
+		return fmt.Errorf("error setting stopping_condition for model bias job definition %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, jobDefinition.JobDefinitionArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for model bias job definition %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerModelBiasJobDefinitionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteModelBiasJobDefinition(&sagemaker.DeleteModelBiasJobDefinitionInput{
+		JobDefinitionName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Model Bias Job Definition: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerModelBiasJobDefinitionAppSpecification(l []interface{}) *sagemaker.ModelBiasAppSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.ModelBiasAppSpecification{
+		ImageUri:  aws.String(m["image_uri"].(string)),
+		ConfigUri: aws.String(m["config_uri"].(string)),
+	}
+
+	if v, ok := m["environment"]; ok && len(v.(map[string]interface{})) > 0 {
+		spec.Environment = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	return spec
+}
+
+func flattenSagemakerModelBiasJobDefinitionAppSpecification(spec *sagemaker.ModelBiasAppSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"image_uri":   aws.StringValue(spec.ImageUri),
+		"config_uri":  aws.StringValue(spec.ConfigUri),
+		"environment": pointersMapToStringList(spec.Environment),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerModelBiasJobDefinitionBaselineConfig(l []interface{}) *sagemaker.ModelBiasBaselineConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.ModelBiasBaselineConfig{}
+
+	if v, ok := m["baselining_job_name"]; ok && v.(string) != "" {
+		config.BaseliningJobName = aws.String(v.(string))
+	}
+
+	if v, ok := m["constraints_resource"]; ok {
+		if l := v.([]interface{}); len(l) > 0 && l[0] != nil {
+			cm := l[0].(map[string]interface{})
+			constraints := &sagemaker.MonitoringConstraintsResource{}
+			if s, ok := cm["s3_uri"]; ok && s.(string) != "" {
+				constraints.S3Uri = aws.String(s.(string))
+			}
+			config.ConstraintsResource = constraints
+		}
+	}
+
+	return config
+}
+
+func flattenSagemakerModelBiasJobDefinitionBaselineConfig(config *sagemaker.ModelBiasBaselineConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"baselining_job_name": aws.StringValue(config.BaseliningJobName),
+	}
+
+	if config.ConstraintsResource != nil {
+		m["constraints_resource"] = []map[string]interface{}{
+			{
+				"s3_uri": aws.StringValue(config.ConstraintsResource.S3Uri),
+			},
+		}
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandSagemakerModelBiasJobDefinitionJobInput(l []interface{}) *sagemaker.ModelBiasJobInput {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	input := &sagemaker.ModelBiasJobInput{
+		EndpointInput: expandSagemakerDataQualityJobDefinitionEndpointInput(m["endpoint_input"].([]interface{})),
+	}
+
+	if gl, ok := m["ground_truth_s3_input"].([]interface{}); ok && len(gl) > 0 && gl[0] != nil {
+		gm := gl[0].(map[string]interface{})
+		input.GroundTruthS3Input = &sagemaker.MonitoringGroundTruthS3Input{
+			S3Uri: aws.String(gm["s3_uri"].(string)),
+		}
+	}
+
+	return input
+}
+
+func flattenSagemakerModelBiasJobDefinitionJobInput(input *sagemaker.ModelBiasJobInput) []map[string]interface{} {
+	if input == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"endpoint_input": flattenSagemakerDataQualityJobDefinitionEndpointInput(input.EndpointInput),
+	}
+
+	if input.GroundTruthS3Input != nil {
+		m["ground_truth_s3_input"] = []map[string]interface{}{
+			{
+				"s3_uri": aws.StringValue(input.GroundTruthS3Input.S3Uri),
+			},
+		}
+	}
+
+	return []map[string]interface{}{m}
+}
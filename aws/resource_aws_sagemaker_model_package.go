@@ -0,0 +1,584 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerModelPackage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerModelPackageCreate,
+		Read:   resourceAwsSagemakerModelPackageRead,
+		Update: resourceAwsSagemakerModelPackageUpdate,
+		Delete: resourceAwsSagemakerModelPackageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// model_package_name creates an unversioned model package;
+			// model_package_group_name creates the next version within that group.
+			"model_package_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"model_package_group_name"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"model_package_group_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"model_package_name"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"model_package_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"model_approval_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.ModelApprovalStatus_Values(), false),
+			},
+
+			"inference_specification": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"container": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"image": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"model_data_url": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+									"product_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+						"supported_content_types": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"supported_response_mime_types": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"supported_realtime_inference_instance_types": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"supported_transform_instance_types": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"source_algorithm_specification": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_algorithm": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"algorithm_name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"model_data_url": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"validation_specification": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"validation_role": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateArn,
+						},
+						"validation_profile_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"transform_job_instance_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"transform_job_instance_count": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"transform_input_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"transform_output_s3_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerModelPackageCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	createOpts := &sagemaker.CreateModelPackageInput{}
+
+	if v, ok := d.GetOk("model_package_name"); ok {
+		createOpts.ModelPackageName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("model_package_group_name"); ok {
+		createOpts.ModelPackageGroupName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("model_package_description"); ok {
+		createOpts.ModelPackageDescription = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("model_approval_status"); ok {
+		createOpts.ModelApprovalStatus = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("inference_specification"); ok {
+		createOpts.InferenceSpecification = expandSagemakerModelPackageInferenceSpecification(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("source_algorithm_specification"); ok {
+		createOpts.SourceAlgorithmSpecification = expandSagemakerModelPackageSourceAlgorithmSpecification(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("validation_specification"); ok {
+		createOpts.ValidationSpecification = expandSagemakerModelPackageValidationSpecification(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Model Package create config: %#v", *createOpts)
+	out, err := conn.CreateModelPackage(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Model Package: %s", err)
+	}
+
+	name, err := sagemakerModelPackageNameFromArn(aws.StringValue(out.ModelPackageArn))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Model Package ID: %s", d.Id())
+
+	if err := waitSagemakerModelPackageStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.ModelPackageStatusCompleted, sagemaker.ModelPackageStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Model Package (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerModelPackageRead(d, meta)
+}
+
+func resourceAwsSagemakerModelPackageRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	pkg, err := conn.DescribeModelPackage(&sagemaker.DescribeModelPackageInput{
+		ModelPackageName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker model package %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("model_package_name", pkg.ModelPackageName); err != nil {
+		return fmt.Errorf("error setting model_package_name for model package %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_package_group_name", pkg.ModelPackageGroupName); err != nil {
+		return fmt.Errorf("error setting model_package_group_name for model package %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_package_description", pkg.ModelPackageDescription); err != nil {
+		return fmt.Errorf("error setting model_package_description for model package %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_approval_status", pkg.ModelApprovalStatus); err != nil {
+		return fmt.Errorf("error setting model_approval_status for model package %q: %s", d.Id(), err)
+	}
+	if err := d.Set("status", pkg.ModelPackageStatus); err != nil {
+		return fmt.Errorf("error setting status for model package %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", pkg.ModelPackageArn); err != nil {
+		return fmt.Errorf("error setting arn for model package %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("inference_specification", flattenSagemakerModelPackageInferenceSpecification(pkg.InferenceSpecification)); err != nil {
+		return fmt.Errorf("error setting inference_specification for model package %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("source_algorithm_specification", flattenSagemakerModelPackageSourceAlgorithmSpecification(pkg.SourceAlgorithmSpecification)); err != nil {
+		return fmt.Errorf("error setting source_algorithm_specification for model package %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("validation_specification", flattenSagemakerModelPackageValidationSpecification(pkg.ValidationSpecification)); err != nil {
+		return fmt.Errorf("error setting validation_specification for model package %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, pkg.ModelPackageArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for model package %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerModelPackageUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("model_approval_status") {
+		updateOpts := &sagemaker.UpdateModelPackageInput{
+			ModelPackageArn:     aws.String(d.Get("arn").(string)),
+			ModelApprovalStatus: aws.String(d.Get("model_approval_status").(string)),
+		}
+
+		if _, err := conn.UpdateModelPackage(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Model Package: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerModelPackageRead(d, meta)
+}
+
+func resourceAwsSagemakerModelPackageDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteModelPackage(&sagemaker.DeleteModelPackageInput{
+		ModelPackageName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Model Package: %s", err)
+	}
+
+	return nil
+}
+
+func waitSagemakerModelPackageStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeModelPackage(&sagemaker.DescribeModelPackageInput{
+			ModelPackageName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(out.ModelPackageStatus)
+		if status == sagemaker.ModelPackageStatusFailed {
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Model Package (%s) failed: %s", name, aws.StringValue(out.ModelPackageStatusDetails.ValidationStatuses[0].FailureReason)))
+		}
+
+		for _, s := range desiredStatus {
+			if status == s {
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Model Package (%s) to be %s", name, desiredStatus))
+	})
+}
+
+// sagemakerModelPackageNameFromArn extracts the name/version segment SageMaker
+// assigns as the resource identifier, e.g. "my-model-package/1" for a
+// versioned package or "my-model-package" for an unversioned one.
+func sagemakerModelPackageNameFromArn(arn string) (string, error) {
+	idx := -1
+	for i := len(arn) - 1; i >= 0; i-- {
+		if arn[i] == '/' {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return "", fmt.Errorf("unable to parse model package name from ARN: %s", arn)
+	}
+
+	return arn[idx+1:], nil
+}
+
+func expandSagemakerModelPackageInferenceSpecification(l []interface{}) *sagemaker.InferenceSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.InferenceSpecification{
+		Containers: expandSagemakerModelPackageContainers(m["container"].([]interface{})),
+	}
+
+	if v, ok := m["supported_content_types"]; ok {
+		spec.SupportedContentTypes = expandStringList(v.([]interface{}))
+	}
+	if v, ok := m["supported_response_mime_types"]; ok {
+		spec.SupportedResponseMIMETypes = expandStringList(v.([]interface{}))
+	}
+	if v, ok := m["supported_realtime_inference_instance_types"]; ok {
+		spec.SupportedRealtimeInferenceInstanceTypes = expandStringList(v.([]interface{}))
+	}
+	if v, ok := m["supported_transform_instance_types"]; ok {
+		spec.SupportedTransformInstanceTypes = expandStringList(v.([]interface{}))
+	}
+
+	return spec
+}
+
+func flattenSagemakerModelPackageInferenceSpecification(spec *sagemaker.InferenceSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"container":                      flattenSagemakerModelPackageContainers(spec.Containers),
+			"supported_content_types":        aws.StringValueSlice(spec.SupportedContentTypes),
+			"supported_response_mime_types":  aws.StringValueSlice(spec.SupportedResponseMIMETypes),
+			"supported_realtime_inference_instance_types": aws.StringValueSlice(spec.SupportedRealtimeInferenceInstanceTypes),
+			"supported_transform_instance_types":          aws.StringValueSlice(spec.SupportedTransformInstanceTypes),
+		},
+	}
+}
+
+func expandSagemakerModelPackageContainers(l []interface{}) []*sagemaker.ModelPackageContainerDefinition {
+	containers := make([]*sagemaker.ModelPackageContainerDefinition, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		container := &sagemaker.ModelPackageContainerDefinition{
+			Image: aws.String(m["image"].(string)),
+		}
+
+		if v, ok := m["model_data_url"]; ok && v.(string) != "" {
+			container.ModelDataUrl = aws.String(v.(string))
+		}
+		if v, ok := m["product_id"]; ok && v.(string) != "" {
+			container.ProductId = aws.String(v.(string))
+		}
+
+		containers = append(containers, container)
+	}
+
+	return containers
+}
+
+func flattenSagemakerModelPackageContainers(containers []*sagemaker.ModelPackageContainerDefinition) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(containers))
+
+	for _, c := range containers {
+		out = append(out, map[string]interface{}{
+			"image":          aws.StringValue(c.Image),
+			"model_data_url": aws.StringValue(c.ModelDataUrl),
+			"product_id":     aws.StringValue(c.ProductId),
+		})
+	}
+
+	return out
+}
+
+func expandSagemakerModelPackageSourceAlgorithmSpecification(l []interface{}) *sagemaker.SourceAlgorithmSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	algorithms := make([]*sagemaker.SourceAlgorithm, 0)
+	for _, raw := range m["source_algorithm"].([]interface{}) {
+		am := raw.(map[string]interface{})
+
+		algorithm := &sagemaker.SourceAlgorithm{
+			AlgorithmName: aws.String(am["algorithm_name"].(string)),
+		}
+
+		if v, ok := am["model_data_url"]; ok && v.(string) != "" {
+			algorithm.ModelDataUrl = aws.String(v.(string))
+		}
+
+		algorithms = append(algorithms, algorithm)
+	}
+
+	return &sagemaker.SourceAlgorithmSpecification{
+		SourceAlgorithms: algorithms,
+	}
+}
+
+func flattenSagemakerModelPackageSourceAlgorithmSpecification(spec *sagemaker.SourceAlgorithmSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	algorithms := make([]map[string]interface{}, 0, len(spec.SourceAlgorithms))
+	for _, a := range spec.SourceAlgorithms {
+		algorithms = append(algorithms, map[string]interface{}{
+			"algorithm_name": aws.StringValue(a.AlgorithmName),
+			"model_data_url": aws.StringValue(a.ModelDataUrl),
+		})
+	}
+
+	return []map[string]interface{}{
+		{
+			"source_algorithm": algorithms,
+		},
+	}
+}
+
+func expandSagemakerModelPackageValidationSpecification(l []interface{}) *sagemaker.ModelPackageValidationSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.ModelPackageValidationSpecification{
+		ValidationRole: aws.String(m["validation_role"].(string)),
+		ValidationProfiles: []*sagemaker.ModelPackageValidationProfile{
+			{
+				ProfileName: aws.String(m["validation_profile_name"].(string)),
+				TransformJobDefinition: &sagemaker.TransformJobDefinition{
+					TransformInput: &sagemaker.TransformInput{
+						DataSource: &sagemaker.TransformDataSource{
+							S3DataSource: &sagemaker.TransformS3DataSource{
+								S3DataType: aws.String(sagemaker.S3DataTypeS3Prefix),
+								S3Uri:      aws.String(m["transform_input_uri"].(string)),
+							},
+						},
+					},
+					TransformOutput: &sagemaker.TransformOutput{
+						S3OutputPath: aws.String(m["transform_output_s3_uri"].(string)),
+					},
+					TransformResources: &sagemaker.TransformResources{
+						InstanceType:  aws.String(m["transform_job_instance_type"].(string)),
+						InstanceCount: aws.Int64(int64(m["transform_job_instance_count"].(int))),
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenSagemakerModelPackageValidationSpecification(spec *sagemaker.ModelPackageValidationSpecification) []map[string]interface{} {
+	if spec == nil || len(spec.ValidationProfiles) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	profile := spec.ValidationProfiles[0]
+	def := profile.TransformJobDefinition
+
+	return []map[string]interface{}{
+		{
+			"validation_role":              aws.StringValue(spec.ValidationRole),
+			"validation_profile_name":      aws.StringValue(profile.ProfileName),
+			"transform_job_instance_type":  aws.StringValue(def.TransformResources.InstanceType),
+			"transform_job_instance_count": aws.Int64Value(def.TransformResources.InstanceCount),
+			"transform_input_uri":          aws.StringValue(def.TransformInput.DataSource.S3DataSource.S3Uri),
+			"transform_output_s3_uri":      aws.StringValue(def.TransformOutput.S3OutputPath),
+		},
+	}
+}
@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerModelPackageGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerModelPackageGroupCreate,
+		Read:   resourceAwsSagemakerModelPackageGroupRead,
+		Update: resourceAwsSagemakerModelPackageGroupUpdate,
+		Delete: resourceAwsSagemakerModelPackageGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"model_package_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"model_package_group_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerModelPackageGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("model_package_group_name").(string)
+
+	createOpts := &sagemaker.CreateModelPackageGroupInput{
+		ModelPackageGroupName: aws.String(name),
+	}
+
+	if v, ok := d.GetOk("model_package_group_description"); ok {
+		createOpts.ModelPackageGroupDescription = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Model Package Group create config: %#v", *createOpts)
+	if _, err := conn.CreateModelPackageGroup(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Model Package Group: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Model Package Group ID: %s", d.Id())
+
+	if err := waitSagemakerModelPackageGroupStatus(conn, name, d.Timeout(schema.TimeoutCreate), sagemaker.ModelPackageGroupStatusCompleted, sagemaker.ModelPackageGroupStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Model Package Group (%s) to be created: %s", name, err)
+	}
+
+	return resourceAwsSagemakerModelPackageGroupRead(d, meta)
+}
+
+func resourceAwsSagemakerModelPackageGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	group, err := conn.DescribeModelPackageGroup(&sagemaker.DescribeModelPackageGroupInput{
+		ModelPackageGroupName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker model package group %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("model_package_group_name", group.ModelPackageGroupName); err != nil {
+		return fmt.Errorf("error setting model_package_group_name for model package group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("model_package_group_description", group.ModelPackageGroupDescription); err != nil {
+		return fmt.Errorf("error setting model_package_group_description for model package group %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", group.ModelPackageGroupArn); err != nil {
+		return fmt.Errorf("error setting arn for model package group %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, group.ModelPackageGroupArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for model package group %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerModelPackageGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("model_package_group_description") {
+		updateOpts := &sagemaker.UpdateModelPackageGroupInput{
+			ModelPackageGroupName:        aws.String(d.Id()),
+			ModelPackageGroupDescription: aws.String(d.Get("model_package_group_description").(string)),
+		}
+
+		if _, err := conn.UpdateModelPackageGroup(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Model Package Group: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerModelPackageGroupRead(d, meta)
+}
+
+func resourceAwsSagemakerModelPackageGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteModelPackageGroup(&sagemaker.DeleteModelPackageGroupInput{
+		ModelPackageGroupName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Model Package Group: %s", err)
+	}
+
+	if err := waitSagemakerModelPackageGroupDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Model Package Group (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitSagemakerModelPackageGroupStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeModelPackageGroup(&sagemaker.DescribeModelPackageGroupInput{
+			ModelPackageGroupName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		for _, s := range desiredStatus {
+			if aws.StringValue(out.ModelPackageGroupStatus) == s {
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Model Package Group (%s) to be %s", name, desiredStatus))
+	})
+}
+
+func waitSagemakerModelPackageGroupDeleted(conn *sagemaker.SageMaker, name string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeModelPackageGroup(&sagemaker.DescribeModelPackageGroupInput{
+			ModelPackageGroupName: aws.String(name),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException") {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		if aws.StringValue(out.ModelPackageGroupStatus) == sagemaker.ModelPackageGroupStatusDeleting {
+			return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Model Package Group (%s) to be deleted", name))
+		}
+
+		return resource.NonRetryableError(fmt.Errorf("Sagemaker Model Package Group (%s) in unexpected status during delete: %s", name, aws.StringValue(out.ModelPackageGroupStatus)))
+	})
+}
@@ -0,0 +1,218 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerModel_basic(t *testing.T) {
+	var model sagemaker.DescribeModelOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_model.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerModelConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerModelExists(resourceName, &model),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "primary_container.0.image", "382416733822.dkr.ecr.us-east-1.amazonaws.com/linear-learner:1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerModelExists(n string, model *sagemaker.DescribeModelOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Model ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeModel(&sagemaker.DescribeModelInput{
+			ModelName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*model = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerModelDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_model" {
+			continue
+		}
+
+		_, err := conn.DescribeModel(&sagemaker.DescribeModelInput{
+			ModelName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Sagemaker Model %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerModelConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_model" "test" {
+  name               = %[1]q
+  execution_role_arn = aws_iam_role.test.arn
+
+  primary_container {
+    image = "382416733822.dkr.ecr.us-east-1.amazonaws.com/linear-learner:1"
+  }
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+`, rName)
+}
+
+// mockSagemakerModelDeleteAPI embeds sagemakeriface.SageMakerAPI so it
+// satisfies the full interface while only overriding DeleteModel, the only
+// call resourceAwsSagemakerModelDeleteWithRetry makes.
+type mockSagemakerModelDeleteAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	deleteModel func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error)
+}
+
+func (m *mockSagemakerModelDeleteAPI) DeleteModel(input *sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+	return m.deleteModel(input)
+}
+
+func TestResourceAwsSagemakerModelDeleteWithRetry(t *testing.T) {
+	inUseErr := awserr.New("ValidationException", "Cannot delete model my-model because it is being used by endpoint my-endpoint", nil)
+
+	t.Run("returns nil once DeleteModel succeeds", func(t *testing.T) {
+		conn := &mockSagemakerModelDeleteAPI{
+			deleteModel: func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+				return &sagemaker.DeleteModelOutput{}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerModelDeleteWithRetry(conn, "my-model", false); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("treats a not-found ValidationException as already deleted", func(t *testing.T) {
+		conn := &mockSagemakerModelDeleteAPI{
+			deleteModel: func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+				return nil, awserr.New("ValidationException", "RecordNotFound", nil)
+			},
+		}
+
+		if err := resourceAwsSagemakerModelDeleteWithRetry(conn, "my-model", false); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("without force_delete, names the blocking endpoint and does not retry", func(t *testing.T) {
+		calls := 0
+		conn := &mockSagemakerModelDeleteAPI{
+			deleteModel: func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+				calls++
+				return nil, inUseErr
+			},
+		}
+
+		err := resourceAwsSagemakerModelDeleteWithRetry(conn, "my-model", false)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "my-endpoint") {
+			t.Errorf("expected error to name the blocking endpoint, got: %s", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected exactly one DeleteModel call, got %d", calls)
+		}
+	})
+
+	t.Run("with force_delete, retries until the endpoint releases the model", func(t *testing.T) {
+		calls := 0
+		conn := &mockSagemakerModelDeleteAPI{
+			deleteModel: func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+				calls++
+				if calls < 3 {
+					return nil, inUseErr
+				}
+				return &sagemaker.DeleteModelOutput{}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerModelDeleteWithRetry(conn, "my-model", true); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 DeleteModel calls, got %d", calls)
+		}
+	})
+
+	t.Run("a non-dependency error is returned as an error", func(t *testing.T) {
+		conn := &mockSagemakerModelDeleteAPI{
+			deleteModel: func(*sagemaker.DeleteModelInput) (*sagemaker.DeleteModelOutput, error) {
+				return nil, awserr.New("InternalFailure", "boom", nil)
+			},
+		}
+
+		err := resourceAwsSagemakerModelDeleteWithRetry(conn, "my-model", false)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
@@ -0,0 +1,262 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerMonitoringSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerMonitoringScheduleCreate,
+		Read:   resourceAwsSagemakerMonitoringScheduleRead,
+		Update: resourceAwsSagemakerMonitoringScheduleUpdate,
+		Delete: resourceAwsSagemakerMonitoringScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"monitoring_schedule_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"monitoring_job_definition_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"monitoring_type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"schedule_config": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"schedule_expression": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerMonitoringScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateMonitoringScheduleInput{
+		MonitoringScheduleName:   aws.String(name),
+		MonitoringScheduleConfig: expandSagemakerMonitoringScheduleConfig(d.Get("monitoring_schedule_config").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Monitoring Schedule create config: %#v", *createOpts)
+	if _, err := conn.CreateMonitoringSchedule(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Monitoring Schedule: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Monitoring Schedule ID: %s", d.Id())
+
+	if err := waitSagemakerMonitoringScheduleStatus(conn, name, d.Timeout(schema.TimeoutCreate), sagemaker.ScheduleStatusScheduled, sagemaker.ScheduleStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Monitoring Schedule (%s) to be created: %s", name, err)
+	}
+
+	return resourceAwsSagemakerMonitoringScheduleRead(d, meta)
+}
+
+func resourceAwsSagemakerMonitoringScheduleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	schedule, err := conn.DescribeMonitoringSchedule(&sagemaker.DescribeMonitoringScheduleInput{
+		MonitoringScheduleName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker monitoring schedule %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if aws.StringValue(schedule.MonitoringScheduleStatus) == sagemaker.ScheduleStatusFailed {
+		log.Printf("[WARN] Sagemaker Monitoring Schedule %q is in Failed state: %s", d.Id(), aws.StringValue(schedule.FailureReason))
+	}
+
+	if err := d.Set("name", schedule.MonitoringScheduleName); err != nil {
+		return fmt.Errorf("error setting name for monitoring schedule %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", schedule.MonitoringScheduleArn); err != nil {
+		return fmt.Errorf("error setting arn for monitoring schedule %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("monitoring_schedule_config", flattenSagemakerMonitoringScheduleConfig(schedule.MonitoringScheduleConfig)); err != nil {
+		return fmt.Errorf("error setting monitoring_schedule_config for monitoring schedule %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, schedule.MonitoringScheduleArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for monitoring schedule %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerMonitoringScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("monitoring_schedule_config") {
+		updateOpts := &sagemaker.UpdateMonitoringScheduleInput{
+			MonitoringScheduleName:   aws.String(d.Id()),
+			MonitoringScheduleConfig: expandSagemakerMonitoringScheduleConfig(d.Get("monitoring_schedule_config").([]interface{})),
+		}
+
+		if _, err := conn.UpdateMonitoringSchedule(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Monitoring Schedule: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerMonitoringScheduleRead(d, meta)
+}
+
+func resourceAwsSagemakerMonitoringScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteMonitoringSchedule(&sagemaker.DeleteMonitoringScheduleInput{
+		MonitoringScheduleName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Monitoring Schedule: %s", err)
+	}
+
+	return nil
+}
+
+func waitSagemakerMonitoringScheduleStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeMonitoringSchedule(&sagemaker.DescribeMonitoringScheduleInput{
+			MonitoringScheduleName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(out.MonitoringScheduleStatus)
+		if status == sagemaker.ScheduleStatusFailed {
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Monitoring Schedule (%s) failed: %s", name, aws.StringValue(out.FailureReason)))
+		}
+
+		for _, s := range desiredStatus {
+			if status == s {
+				return nil
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Monitoring Schedule (%s) to be %s", name, desiredStatus))
+	})
+}
+
+func expandSagemakerMonitoringScheduleConfig(l []interface{}) *sagemaker.MonitoringScheduleConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.MonitoringScheduleConfig{
+		MonitoringJobDefinitionName: aws.String(m["monitoring_job_definition_name"].(string)),
+		MonitoringType:              aws.String(m["monitoring_type"].(string)),
+		ScheduleConfig:              expandSagemakerMonitoringScheduleScheduleConfig(m["schedule_config"].([]interface{})),
+	}
+}
+
+func flattenSagemakerMonitoringScheduleConfig(config *sagemaker.MonitoringScheduleConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"monitoring_job_definition_name": aws.StringValue(config.MonitoringJobDefinitionName),
+			"monitoring_type":                aws.StringValue(config.MonitoringType),
+			"schedule_config":                flattenSagemakerMonitoringScheduleScheduleConfig(config.ScheduleConfig),
+		},
+	}
+}
+
+func expandSagemakerMonitoringScheduleScheduleConfig(l []interface{}) *sagemaker.ScheduleConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.ScheduleConfig{
+		ScheduleExpression: aws.String(m["schedule_expression"].(string)),
+	}
+}
+
+func flattenSagemakerMonitoringScheduleScheduleConfig(config *sagemaker.ScheduleConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"schedule_expression": aws.StringValue(config.ScheduleExpression),
+		},
+	}
+}
@@ -1,25 +1,82 @@
 package aws
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
+// This resource still registers the legacy (non-context) Create/Read/Update/Delete
+// hooks because *schema.Resource here comes from the provider's pinned
+// "github.com/hashicorp/terraform/helper/schema" package, which predates
+// CreateContext/ReadContext/UpdateContext/DeleteContext -- those were added later,
+// in terraform-plugin-sdk. Moving this one resource off that interface without
+// moving the rest of the provider isn't workable, so instead the internal waiters
+// below take a context.Context and stop polling as soon as it's done, which is the
+// part of prompt cancellation that's actually reachable from inside a resource today.
 func resourceAwsSagemakerNotebookInstance() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsSagemakerNotebookInstanceCreate,
 		Read:   resourceAwsSagemakerNotebookInstanceRead,
 		Update: resourceAwsSagemakerNotebookInstanceUpdate,
 		Delete: resourceAwsSagemakerNotebookInstanceDelete,
+		// resourceAwsSagemakerNotebookInstanceImport checks the instance exists
+		// before seeding the ID, rather than using ImportStatePassthrough
+		// directly: a plain passthrough followed by the normal Read would treat
+		// a nonexistent name exactly like an instance deleted out-of-band and
+		// silently remove it from state, leaving the user with a successful
+		// but empty import instead of an error pointing at the typo.
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAwsSagemakerNotebookInstanceImport,
+		},
+
+		CustomizeDiff: resourceAwsSagemakerNotebookInstanceCustomizeDiff,
+
+		// The default Update/Delete timeouts are 30 minutes, wider than
+		// Create's, since stopping an instance runs any on_stop lifecycle
+		// hook and that can take considerably longer than the 10 minutes a
+		// plain start/stop usually needs. Override via a timeouts block for
+		// instances with especially heavy hooks -- GPU instance types
+		// running a CUDA-installing on-start hook are a common case; see
+		// resourceAwsSagemakerNotebookInstanceCustomizeDiffGPUTimeoutWarning.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(notebookInstanceDeletedTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -28,297 +85,5391 @@ func resourceAwsSagemakerNotebookInstance() *schema.Resource {
 				Computed: true,
 			},
 
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Computed:     false,
-				ForceNew:     true,
-				ValidateFunc: validateSagemakerName,
-			},
+			// name is also this resource's ID (see resourceAwsSagemakerNotebookInstanceCreate's
+			// d.SetId(name)): SageMaker notebook instance names are themselves
+			// globally unique and immutable short of a replace, so there's no
+			// separate opaque identifier to mint. That makes the ID stable
+			// under a for_each key rename as long as name itself doesn't
+			// change -- changing the map key alone doesn't touch name, and
+			// changing name is what ForceNew (correctly) treats as a replace.
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+				ValidateFunc:  validateSagemakerNotebookInstanceName,
+			},
+
+			// name_prefix is also what makes a blue/green platform upgrade
+			// possible with lifecycle { create_before_destroy = true }: a
+			// static name can't have two live notebook instances at once
+			// (the old one being destroyed and the new one being created)
+			// because SageMaker names are globally unique, but a
+			// resource.PrefixedUniqueId-generated name lets the replacement
+			// come up alongside the original under its own name. Combined
+			// with create_before_destroy, Terraform creates and waits for
+			// the replacement to reach InService (resourceAwsSagemakerNotebookInstanceCreate
+			// always waits for InService before returning) before the old
+			// instance's Delete ever runs, so there's no window where
+			// neither notebook is available.
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Computed:     false,
+				ValidateFunc: validateIamRoleArn,
+			},
+
+			// role_name is derived from role_arn so that callers referencing the
+			// instance (e.g. to attach an additional IAM policy to its role)
+			// don't each need to repeat the same ARN-parsing expression in HCL.
+			"role_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instance_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSagemakerNotebookInstanceType,
+			},
+
+			// subnet_id is ForceNew because UpdateNotebookInstanceInput has no
+			// subnet parameter: SageMaker does not support moving a notebook
+			// instance to a different subnet in place, so there's no
+			// stop/update/start path to wire up here. Changing it destroys and
+			// recreates the instance, which also destroys its EBS volume.
+			"subnet_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"subnet_ids"},
+				ValidateFunc:  validateSagemakerNotebookInstanceSubnetId,
+			},
+
+			// subnet_ids is an alternative to subnet_id for resilience against
+			// AZ-level capacity shortages: Create tries each candidate subnet
+			// in order, deleting and moving on to the next on an
+			// InsufficientCapacity-style failure, and records whichever one
+			// actually succeeded into subnet_id. ForceNew for the same reason
+			// as subnet_id: there's no in-place way to move a notebook
+			// instance to a different subnet.
+			"subnet_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"subnet_id"},
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSagemakerNotebookInstanceSubnetId},
+			},
+
+			// availability_zone is read from the subnet so users can reason
+			// about placement up front: instance_type availability varies by
+			// AZ, and a mismatch between subnet_id and instance_type
+			// otherwise only surfaces as a capacity-like failure during
+			// create.
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// vpc_id is resolved from subnet_id the same way
+			// availability_zone is above, so building e.g. security group
+			// rules against the notebook's VPC doesn't require a separate
+			// data.aws_subnet lookup in every module that uses this resource.
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// security_groups is ForceNew for the same reason as subnet_id:
+			// UpdateNotebookInstanceInput has no security group parameter, so
+			// there's no stop/update/start path that can change it in place.
+			//
+			// Set uses a normalizing hash rather than schema.HashString so
+			// that the same security group referenced two different ways in
+			// HCL (a literal ID vs. a resource attribute that resolves to
+			// the same ID with different case or incidental whitespace)
+			// still dedupes into one set member instead of producing a
+			// spurious diff.
+			"security_groups": {
+				Type:     schema.TypeSet,
+				MinItems: 1,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceAwsSagemakerNotebookInstanceSecurityGroupHash,
+			},
+
+			// has_vpc_config is a derived convenience for policy-as-code rules
+			// (Sentinel/OPA/conftest) that just need to ask "is this notebook
+			// in a VPC?" against plan/state JSON, instead of null-checking
+			// subnet_id directly.
+			"has_vpc_config": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// security_group_ids mirrors security_groups as a stable, sorted
+			// TypeList for consumers (policy review tooling, dashboards) that
+			// want a deterministic order to render rather than a TypeSet's
+			// hash-based one.
+			"security_group_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// network_posture is a read-only summary of the notebook's network
+			// exposure, derived from subnet_id/direct_internet_access, for quick
+			// policy review without cross-referencing both attributes.
+			"network_posture": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// cost_attributes is a read-only convenience aggregation of the
+			// cost-relevant configuration already exposed as individual
+			// attributes (instance_type, volume_size_in_gb, availability_zone),
+			// so a FinOps module can consume one map instead of wiring up
+			// three separate attribute references. It's purely derived in
+			// Read from values already in state, so it never itself causes
+			// drift.
+			"cost_attributes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// compute_summary is cost_attributes' sibling for the notebook's
+			// broader compute posture rather than just what drives cost: one
+			// flat map a dashboard can read instead of six separate
+			// attributes. Same shape, same reasoning: purely derived from
+			// values already in state, so it never itself causes drift.
+			"compute_summary": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// cloudwatch_namespace/cloudwatch_dimensions expose the exact
+			// CloudWatch namespace and dimension values SageMaker publishes
+			// notebook instance metrics under, so an aws_cloudwatch_metric_alarm
+			// can reference them (e.g. dimensions = aws_sagemaker_notebook_instance.this.cloudwatch_dimensions)
+			// instead of hardcoding "/aws/sagemaker/NotebookInstances" and the
+			// NotebookInstanceName dimension key, which is an easy copy-paste
+			// mistake to get wrong.
+			"cloudwatch_namespace": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// log_group_name/log_stream_prefix expose the exact CloudWatch
+			// Logs group SageMaker writes this notebook's logs to and the
+			// prefix its log streams share, so an
+			// aws_cloudwatch_log_metric_filter or subscription filter can
+			// reference them directly for log-based alerting instead of
+			// hardcoding "/aws/sagemaker/NotebookInstances" and the instance
+			// name.
+			"log_group_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"log_stream_prefix": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"kms_key_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentKmsKeyId,
+				ValidateFunc:     validateSagemakerNotebookInstanceKmsKeyId,
+			},
+
+			// volume_encrypted surfaces whether the notebook's EBS/root volume
+			// is encrypted, which SageMaker ties directly to kms_key_id being set.
+			"volume_encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// platform_identifier is not ForceNew directly in the schema; see
+			// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier.
+			"platform_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// volume_size_in_gb is not ForceNew directly in the schema: growing
+			// it is an online UpdateNotebookInstance call, but shrinking it is
+			// rejected by the API, so a decrease is forced to replace instead;
+			// see resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSizeShrink.
+			"volume_size_in_gb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(sagemakerNotebookInstanceVolumeSizeMinGB, sagemakerNotebookInstanceVolumeSizeMaxGB),
+			},
+
+			// skip_destroy stops the instance, rather than deleting it, when
+			// the resource is destroyed. The EBS volume backing the notebook
+			// is only freed on DeleteNotebookInstance, so this protects it
+			// from being lost to an accidental destroy; the instance is
+			// removed from state as usual.
+			//
+			// A real export-on-destroy (snapshotting that volume to S3 before
+			// deleting) was considered and rejected: SageMaker doesn't expose
+			// the volume as an EBS volume ID anywhere in the API, so there's
+			// nothing to point CreateSnapshot at, and copying the notebook's
+			// filesystem to S3 would need to run from inside the instance
+			// itself (e.g. another lifecycle hook), which can't be driven
+			// from Delete after the instance is already stopped. Keeping the
+			// volume around via skip_destroy is the supported safety net.
+			"skip_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// retain_on_replace is skip_destroy's behavior (stop, don't
+			// delete, remove from state) scoped in intent to the ForceNew
+			// replace case: a subnet_id/kms_key_id/etc change recreates the
+			// notebook, and without this the old instance's EBS volume is
+			// gone before there's a chance to migrate anything off it. It's
+			// honest about where that intent can't actually be enforced,
+			// though: this SDK's Delete has no way to tell a replace's
+			// destroy-half apart from an ordinary `terraform destroy`, so
+			// setting it means every destroy retains the instance, the same
+			// as skip_destroy. resourceAwsSagemakerNotebookInstanceCustomizeDiffRetainOnReplaceWarning
+			// warns at plan time specifically when a ForceNew field is about
+			// to force a replace and this isn't set, which is the one place
+			// the replace-vs-destroy distinction actually is visible.
+			"retain_on_replace": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// delete_drain_timeout gives any in-flight kernel/training work a
+			// chance to finish saving before Delete stops the instance out
+			// from under it. It comes out of the overall delete timeout, the
+			// same as the stop and final-deletion waits that follow it.
+			"delete_drain_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			// poll_interval_seconds lets accounts SageMaker throttles
+			// aggressively trade faster convergence for fewer DescribeNotebookInstance
+			// calls while this resource waits on a status transition
+			// (Create/Update InService, stop, delete). The default matches the
+			// interval this resource has always polled at.
+			"poll_interval_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      sagemakerNotebookInstanceDefaultPollIntervalSeconds,
+				ValidateFunc: validation.IntAtLeast(5),
+			},
+
+			// direct_internet_access is Computed rather than given an explicit
+			// Default of "Enabled": CreateNotebookInstance itself defaults to
+			// Enabled when the field is omitted, so leaving it Computed reads
+			// that back from the API instead of this provider hardcoding an
+			// assumption about AWS's default that could drift from reality.
+			"direct_internet_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemaker.DirectInternetAccessEnabled,
+					sagemaker.DirectInternetAccessDisabled,
+				}, false),
+			},
+
+			// lifecycle_config_name points at a separately managed
+			// aws_sagemaker_notebook_instance_lifecycle_configuration by name.
+			// It's mutually exclusive with inline_lifecycle_config, which
+			// manages an equivalent lifecycle config's content directly on
+			// this resource instead of requiring a second one; see
+			// track_lifecycle_config_content_sha below for detecting drift in
+			// a lifecycle config this field merely references.
+			"lifecycle_config_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSagemakerName,
+				ConflictsWith: []string{"inline_lifecycle_config"},
+			},
+
+			// track_lifecycle_config_content_sha opts into an extra
+			// DescribeNotebookInstanceLifecycleConfig call on every read so
+			// lifecycle_config_content_sha (below) can surface a diff when a
+			// separately-managed lifecycle config's content changes, since
+			// Terraform otherwise only tracks lifecycle_config_name and has
+			// no visibility into a config it doesn't manage.
+			"track_lifecycle_config_content_sha": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"lifecycle_config_content_sha": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// verify_efs_mount_target_for_file_system_id opts into a
+			// best-effort post-create check: does an EFS mount target exist
+			// in this notebook's subnet for the given file system? Lifecycle
+			// configs are opaque shell scripts, so this resource has no way
+			// to tell an EFS-mounting one apart from any other; giving it
+			// the file system ID directly avoids guessing from script
+			// content. A missing mount target only logs a warning -- it's a
+			// diagnostic aid for the "mount silently failed" case, not a
+			// guarantee the mount will succeed.
+			"verify_efs_mount_target_for_file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// inline_lifecycle_config is sugar over managing a separate
+			// aws_sagemaker_notebook_instance_lifecycle_configuration resource and
+			// pointing lifecycle_config_name at it: when set, this resource
+			// creates and owns an implicit lifecycle config named after the
+			// notebook itself, attaches it, and deletes it again once the
+			// notebook is. It's mutually exclusive with lifecycle_config_name
+			// since the two are different ways of arriving at the same
+			// CreateNotebookInstanceInput.LifecycleConfigName value.
+			"inline_lifecycle_config": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"lifecycle_config_name"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"on_create": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentBase64Content,
+						},
+
+						"on_start": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentBase64Content,
+						},
+					},
+				},
+			},
+
+			// default_ui has no direct CreateNotebookInstanceInput/
+			// UpdateNotebookInstanceInput field in the SageMaker API, so
+			// like inline_lifecycle_config it's implemented by creating and
+			// owning an implicit lifecycle config (see
+			// sagemakerNotebookInstanceDefaultUiOnStartScript), making it
+			// mutually exclusive with both inline_lifecycle_config and
+			// lifecycle_config_name for the same reason inline_lifecycle_config
+			// is. The Read-back parses the owned config's on_start script
+			// rather than just echoing config back, so drift from editing
+			// that script out-of-band is still caught.
+			"default_ui": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"inline_lifecycle_config", "lifecycle_config_name"},
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemakerNotebookInstanceDefaultUiJupyter,
+					sagemakerNotebookInstanceDefaultUiJupyterLab,
+				}, false),
+			},
+
+			// root_access is ForceNew because the real UpdateNotebookInstance
+			// API has no RootAccess field -- it can only be set at creation --
+			// so there is no in-place way to flip it on a running notebook
+			// instance; recreation is the only option.
+			"root_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemaker.RootAccessEnabled,
+					sagemaker.RootAccessDisabled,
+				}, false),
+			},
+
+			// root_access_disabled and network_isolation are convenience
+			// booleans derived from root_access/direct_internet_access so
+			// policy-as-code checks against state don't need to compare
+			// against the underlying SageMaker enum strings.
+			"root_access_disabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"network_isolation": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// url is the Jupyter notebook's access URL, only resolvable once
+			// the instance has started.
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// verify_url_reachable is opt-in: SageMaker can report InService
+			// while the Jupyter server behind url is actually unreachable
+			// (no SDK field surfaces this), so when set, Create does a
+			// best-effort HTTP HEAD against url once InService and warns,
+			// but never fails the apply, if it doesn't respond. Off by
+			// default since the Terraform host's network may not have
+			// egress to SageMaker's URL at all, which would otherwise turn
+			// an unrelated network boundary into a false positive.
+			"verify_url_reachable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// generate_presigned_url is opt-in: it costs an extra API call
+			// on every read and produces a sensitive, short-lived credential
+			// in state, so it's off by default. When set, Read calls
+			// CreatePresignedNotebookInstanceUrl while the instance is
+			// InService and stores the result in presigned_url; it isn't
+			// InService, presigned_url is left empty instead of failing the
+			// read.
+			"generate_presigned_url": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// presigned_url is refreshed on every read while
+			// generate_presigned_url is set and the instance is InService.
+			// SageMaker presigned notebook URLs expire a few minutes after
+			// they're issued, so treat this as a point-in-time credential,
+			// not a stable value -- re-run plan/apply (or refresh) to get a
+			// fresh one.
+			"presigned_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			// check_ssm_managed is opt-in: notebook instances aren't
+			// SSM-managed out of the box, so this only makes sense for a
+			// notebook whose lifecycle configuration installs and starts the
+			// SSM agent itself. When set, Create does a best-effort SSM
+			// DescribeInstanceInformation lookup once InService and sets
+			// ssm_managed from it, but never fails the apply if the lookup
+			// errors or finds nothing -- the agent can take a while to
+			// register after the instance comes up.
+			"check_ssm_managed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// ssm_managed reflects whether SSM reported this notebook
+			// instance as a managed instance as of the last check_ssm_managed
+			// lookup. Stays false when check_ssm_managed is off.
+			"ssm_managed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// verify_code_repo_secrets is opt-in: a default_code_repository
+			// or additional_code_repositories entry with a bad secret_arn
+			// still reaches InService -- the clone just fails silently
+			// underneath (see sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure),
+			// and DescribeNotebookInstance has no field surfacing it. When
+			// set, Create does a best-effort Secrets Manager DescribeSecret
+			// against each referenced aws_sagemaker_code_repository's
+			// secret_arn once InService and warns, but never fails the
+			// apply, if one isn't retrievable. Off by default since it
+			// costs extra API calls and requires secretsmanager:DescribeSecret
+			// on top of what a notebook instance otherwise needs.
+			"verify_code_repo_secrets": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// notify_sns_topic_arn is opt-in: when set, Create and Update
+			// publish a short message naming the notebook instance and its
+			// final status (InService or Failed) to this topic once create or
+			// update completes, for teams without full monitoring who just
+			// want a "notebook created/failed" signal. Publish failures are
+			// only logged -- never fatal to the apply -- since the resource
+			// itself already succeeded or failed on its own terms by the time
+			// this runs.
+			"notify_sns_topic_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateArn,
+			},
+
+			// validate_role_permissions is opt-in since it costs an extra
+			// IAM call and requires iam:SimulatePrincipalPolicy, which not
+			// every execution role's caller has: when set, CustomizeDiff
+			// simulates role_arn against the actions SageMaker itself needs
+			// to run the notebook instance, failing the plan up front if
+			// any of them would be denied rather than letting it surface
+			// later as an opaque AccessDenied once SageMaker tries to use
+			// the role.
+			"validate_role_permissions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// network_interface_id is the ENI backing the instance. SageMaker
+			// has no API that maps a notebook directly to the EBS volume
+			// behind it, but the ENI can be cross-referenced against the EC2
+			// instance it's attached to, and from there to its volumes, so
+			// this is exposed as the starting point for that correlation.
+			"network_interface_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// last_provision_duration_seconds is sourced from the waiter's
+			// own timing, not the API: it's the wall-clock time this
+			// resource's own Create/Update spent blocked on the wait for
+			// InService, reset on every create/update. It's informational
+			// only -- a way to chart provisioning latency on an SRE
+			// dashboard without scraping logs -- and isn't comparable across
+			// providers or to CreationTime.
+			"last_provision_duration_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			// Accepts either a git HTTPS URL or the name/ARN of an
+			// aws_sagemaker_code_repository. DescribeNotebookInstance always
+			// echoes back whatever form was stored, so referencing a
+			// repository by ARN while the name (or vice versa) round-trips
+			// through state produces a perpetual diff. DiffSuppressFunc has
+			// no access to meta to resolve that equivalence, so it's handled
+			// in CustomizeDiff instead; see
+			// resourceAwsSagemakerNotebookInstanceCustomizeDiff.
+			// Computed in addition to Optional so that a repository attached
+			// out-of-band (e.g. via the console, or by another tool) shows up
+			// in state on the next refresh instead of only ever reading back
+			// as what config says -- config left unset no longer means "no
+			// repository attached" as far as Terraform's view of the world
+			// goes, just "don't manage this".
+			"default_code_repository": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			// Computed for the same reason as default_code_repository above.
+			"additional_code_repositories": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				MaxItems: 3,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSagemakerCodeRepository,
+				},
+				Set: schema.HashString,
+			},
+
+			// TypeSet, not TypeList: AcceleratorTypes has no meaningful order
+			// of its own, but DescribeNotebookInstance doesn't promise to
+			// echo it back in the order it was configured, so modeling it as
+			// a list would churn the plan on a reordered-but-otherwise-equal
+			// config.
+			"accelerator_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSagemakerNotebookInstanceAcceleratorType,
+				},
+				Set: schema.HashString,
+			},
+
+			"instance_metadata_service_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"minimum_instance_metadata_service_version": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"1",
+								"2",
+							}, false),
+						},
+					},
+				},
+			},
+
+			// forbid_imds_downgrade is a purely local, opt-in plan-time guard,
+			// in the same vein as required_tag_keys and forbidden_tag_values:
+			// when set, CustomizeDiff errors if the plan would lower
+			// instance_metadata_service_configuration's minimum version (e.g.
+			// "2" -> "1"), so a security regression like that is caught in
+			// code review instead of reaching an apply.
+			"forbid_imds_downgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// default_view reflects which UI (classic Jupyter vs JupyterLab) the
+			// notebook instance's URL opens into by default. SageMaker has no
+			// explicit "default view" API field -- it's implied entirely by
+			// platform_identifier's version suffix, so this is derived rather
+			// than read directly off the describe output. See
+			// sagemakerNotebookInstanceDefaultView.
+			"default_view": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// instance_metadata_service_version mirrors
+			// instance_metadata_service_configuration's minimum version back
+			// as a plain top-level attribute, defaulting to "1" when the
+			// block is unset: SageMaker allows IMDSv1 unless a notebook is
+			// explicitly configured to require v2, so "1" is the version
+			// actually in effect on an instance with no configuration block
+			// at all. This is for fleet-wide auditing against state without
+			// having to reach into the nested block.
+			"instance_metadata_service_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// status mirrors DescribeNotebookInstanceOutput.NotebookInstanceStatus
+			// (e.g. InService, Stopped, Failed), which is particularly useful when
+			// restart_after_update is false and the instance is deliberately left
+			// Stopped after an update.
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// schedulable is a convenience for external schedulers that stop/
+			// start notebooks on a tag-driven schedule: it's true only when
+			// status is InService or Stopped, i.e. not mid-transition (Pending,
+			// Stopping, Updating, ...), so scheduler logic can check it before
+			// attempting a stop/start action instead of each one reimplementing
+			// the same status check against the status attribute above.
+			"schedulable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// failure_reason surfaces why the instance is Failed directly in
+			// state/outputs, instead of only in logs; it's empty for a
+			// healthy instance. A default_code_repository clone failure is a
+			// special case: it doesn't fail the instance or populate this
+			// from the API, so Read falls back to scanning the on-start
+			// lifecycle log for it; see
+			// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure.
+			"failure_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"restart_after_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// force_restart is a null_resource-style triggers map: its values are
+			// never sent to the API, but changing any of them forces the same
+			// stop/restart cycle an in-place update does, even if nothing else in
+			// config changed. This exists for cases Terraform otherwise can't
+			// detect, like a role_arn's trust policy being rotated out-of-band --
+			// the ARN itself is unchanged, so bumping a force_restart value (e.g.
+			// to a rotation timestamp) is the only way to get the notebook to pick
+			// up a fresh session. A force_restart change always restarts the
+			// instance if it was InService before the update, regardless of
+			// restart_after_update.
+			"force_restart": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// restart_triggers is the same null_resource.triggers-style
+			// mechanism as force_restart above -- a change to either forces
+			// the same stop/restart cycle -- under the name teams already
+			// familiar with null_resource.triggers tend to look for. The two
+			// are independent maps so either (or both) can be used; there's
+			// no need to keep them in sync with each other.
+			"restart_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// adopt_existing is opt-in for teams that apply the same config
+			// from more than one pipeline: when true, Create checks for an
+			// existing notebook instance with this name before calling
+			// CreateNotebookInstance and, if one exists, adopts it into state
+			// (as if imported) instead of failing on the name collision that
+			// isSagemakerNotebookInstanceNameConflictErr normally turns into
+			// a "terraform import" error message.
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// wait_for_in_service lets batch creation skip the ~5 minute wait for
+			// InService and return as soon as CreateNotebookInstance is accepted.
+			// The instance keeps transitioning through Pending in the background.
+			"wait_for_in_service": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// delete_on_create_failure defaults to false, preserving a notebook
+			// instance that reaches Failed during create so it can be inspected
+			// (e.g. its on-start lifecycle log) before being cleaned up. Setting
+			// it true instead deletes the failed instance and clears the ID
+			// before returning the create error, so the next apply starts from a
+			// clean slate rather than Terraform retrying against a tainted
+			// resource that's already known to be broken.
+			"delete_on_create_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// wait_for_code_repo_clone is opt-in and only meaningful alongside
+			// default_code_repository: there's no DescribeNotebookInstance field
+			// for clone status, since the instance reaches InService before the
+			// on-start lifecycle hook that does the clone finishes. When set,
+			// Create best-effort polls the on-start lifecycle log (the same log
+			// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure already
+			// reads) for a completion or failure marker for a bounded time, so
+			// automation that immediately opens the notebook is less likely to
+			// race an empty workspace. Never fails the apply: a clone that's
+			// still running, or a log that never shows a recognizable marker,
+			// just logs a warning and moves on.
+			"wait_for_code_repo_clone": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// desired_status lets Terraform be the source of truth for whether the
+			// instance is running, e.g. to stop it outside business hours. When
+			// unset, the instance is simply started on create as before.
+			"desired_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemaker.NotebookInstanceStatusInService,
+					sagemaker.NotebookInstanceStatusStopped,
+				}, false),
+			},
+
+			// CreateNotebookInstance always starts the instance; SageMaker has
+			// no API to create one directly into Stopped. start_on_create = false
+			// is purely a convenience for provisioning cost-consciously stopped
+			// from the start: Create still waits for InService as usual, then
+			// immediately issues the same stop-and-wait-for-Stopped that
+			// desired_status = "Stopped" does. It only applies at create time;
+			// use desired_status for ongoing start/stop management after that.
+			"start_on_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// skip_read_after_write skips the trailing DescribeNotebookInstance/
+			// ListTags round trip this resource otherwise always does after
+			// Create and Update, at the cost of leaving any attribute the API
+			// doesn't return from those calls (e.g. computed fields like url)
+			// stale in state until the next refresh.
+			"skip_read_after_write": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": tagsSchema(),
+
+			// tags_behavior controls how tags are reconciled on Update.
+			// "authoritative" (the default) matches every other SageMaker
+			// resource in this provider: tags removed from config are deleted on
+			// the next apply. "additive" only adds or updates the tags present in
+			// config and never deletes a tag absent from it, for teams whose tags
+			// are partly managed outside Terraform (e.g. by a separate tagging
+			// pipeline) and who don't want Terraform fighting that pipeline over
+			// tags it didn't set. This trades drift detection for the deleted
+			// tags away: Terraform will never show a diff for, or restore, a tag
+			// that was removed out-of-band.
+			"tags_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  sagemakerNotebookInstanceTagsBehaviorAuthoritative,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemakerNotebookInstanceTagsBehaviorAuthoritative,
+					sagemakerNotebookInstanceTagsBehaviorAdditive,
+				}, false),
+			},
+
+			// tags_read_mode controls what a ListTags failure in Read does.
+			// "lenient" (the default) is this resource's long-standing
+			// behavior: log a warning and leave tags as they were in state,
+			// so a transient or permissions problem listing tags doesn't
+			// fail an otherwise-healthy refresh. "strict" is for teams that
+			// would rather the apply fail loudly than silently drift from
+			// the tags actually on the resource.
+			"tags_read_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  sagemakerNotebookInstanceTagsReadModeLenient,
+				ValidateFunc: validation.StringInSlice([]string{
+					sagemakerNotebookInstanceTagsReadModeStrict,
+					sagemakerNotebookInstanceTagsReadModeLenient,
+				}, false),
+			},
+
+			// required_tag_keys is a purely local, opt-in plan-time guard: when
+			// set, CustomizeDiff errors out if any of these keys is missing from
+			// the effective tag set (resource tags plus the provider's
+			// default_tags) before the apply ever reaches AWS. Org-level tag
+			// policies catch the same gap server-side, but only after the API
+			// call, so this exists for operators who want the feedback during
+			// plan instead.
+			"required_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// forbidden_tag_values is required_tag_keys's counterpart: the same
+			// local, opt-in, plan-time guard, but rejecting a configured tag whose
+			// key matches a forbidden key/value pair instead of requiring one.
+			// Lets a dev account config assert e.g. env != "prod" without waiting
+			// for an org-level tag policy to reject the apply server-side.
+			"forbidden_tag_values": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// allowed_instance_types is a purely local, opt-in plan-time
+			// guard: when set, CustomizeDiff fails the plan if instance_type
+			// isn't in the list, so an accidental upsize to an expensive
+			// instance type is caught before the apply ever reaches AWS
+			// rather than only showing up later on the bill. Independent of
+			// -- and a fallback for accounts without -- an org-level SCP
+			// enforcing the same restriction server-side.
+			"allowed_instance_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// maintenance_window is a purely local, opt-in plan-time guard,
+			// same shape as allowed_instance_types/required_tag_keys above:
+			// when set, CustomizeDiff fails the plan if an instance_type or
+			// role_arn change -- both of which stop and restart the notebook,
+			// see resourceAwsSagemakerNotebookInstanceCustomizeDiffDowntimeWarning
+			// -- is attempted outside the given weekly window, so a disruptive
+			// change can't land during business hours by accident. The format
+			// is ddd:hh24:mi-ddd:hh24:mi (e.g. Mon:03:00-Mon:05:00), evaluated
+			// in UTC, the same shape RDS's preferred_maintenance_window uses.
+			"maintenance_window": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSagemakerNotebookInstanceMaintenanceWindow,
+			},
+
+			// auto_cost_tags, when true, injects computed cost-allocation tags
+			// (instance_type and, for a VPC notebook, availability_zone) into the
+			// applied tags on create/update, under the
+			// sagemakerNotebookInstanceCostTagPrefix namespace so teams can slice
+			// their cost reports by either without maintaining the values by hand.
+			// They're excluded from tags (but kept in tags_all) the same way
+			// default_tags are (see sagemakerNotebookInstanceAutoCostTags's
+			// callers in Read), so
+			// they never show up as drift against a config that doesn't mention
+			// them.
+			"auto_cost_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// inherit_role_tags opts into pulling the listed tag keys (e.g.
+			// CostCenter, Team) off role_arn's own IAM role tags and merging them
+			// into the applied tags on create/update (see
+			// resourceAwsSagemakerNotebookInstanceInheritRoleTags), so orgs that
+			// already tag cost ownership on the execution role don't have to
+			// duplicate it on every notebook that uses it. Like auto_cost_tags,
+			// the inherited values are excluded from tags (but kept in tags_all)
+			// so they never show up as drift against a config that doesn't
+			// mention them.
+			"inherit_role_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			// tags_all is the union of resource-level tags and the provider's
+			// default_tags, so that externally-applied defaults don't show up as
+			// perpetual drift against the resource-level tags map.
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerNotebookInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	var name string
+	if v, ok := d.GetOk("name"); ok {
+		name = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		name = resource.PrefixedUniqueId(v.(string))
+	} else {
+		name = resource.UniqueId()
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		adopted, err := resourceAwsSagemakerNotebookInstanceAdoptExisting(d, conn, name)
+		if err != nil {
+			return err
+		}
+		if adopted {
+			return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+		}
+	}
+
+	createOpts := &sagemaker.CreateNotebookInstanceInput{
+		NotebookInstanceName: aws.String(name),
+		RoleArn:              aws.String(d.Get("role_arn").(string)),
+		InstanceType:         aws.String(d.Get("instance_type").(string)),
+	}
+
+	if v, ok := d.GetOk("security_groups"); ok && v.(*schema.Set).Len() > 0 {
+		createOpts.SecurityGroupIds = expandStringSet(v.(*schema.Set))
+	}
+
+	var candidateSubnetIds []string
+	if v, ok := d.GetOk("subnet_ids"); ok {
+		candidateSubnetIds = expandStringList(v.([]interface{}))
+	} else if s, ok := d.GetOk("subnet_id"); ok {
+		createOpts.SubnetId = aws.String(s.(string))
+
+		if v, ok := d.GetOk("security_groups"); ok && v.(*schema.Set).Len() > 0 {
+			if err := resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(meta.(*AWSClient).ec2conn, s.(string), expandStringSet(v.(*schema.Set))); err != nil {
+				return err
+			}
+		}
+
+		resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability(meta.(*AWSClient).ec2conn, s.(string))
+	}
+
+	if k, ok := d.GetOk("kms_key_id"); ok {
+		keyId := k.(string)
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKey(meta.(*AWSClient).kmsconn, keyId); err != nil {
+			return err
+		}
+
+		createOpts.KmsKeyId = aws.String(keyId)
+	}
+
+	if v, ok := d.GetOk("direct_internet_access"); ok {
+		createOpts.DirectInternetAccess = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("volume_size_in_gb"); ok {
+		createOpts.VolumeSizeInGB = aws.Int64(int64(v.(int)))
+	}
+
+	if l, ok := d.GetOk("lifecycle_config_name"); ok {
+		createOpts.LifecycleConfigName = aws.String(l.(string))
+	}
+
+	if v, ok := d.GetOk("inline_lifecycle_config"); ok {
+		lifecycleConfigName, err := createSagemakerNotebookInstanceInlineLifecycleConfig(conn, name, v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		createOpts.LifecycleConfigName = aws.String(lifecycleConfigName)
+	}
+
+	if v, ok := d.GetOk("default_ui"); ok {
+		lifecycleConfigName, err := createSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, name, v.(string))
+		if err != nil {
+			return err
+		}
+		createOpts.LifecycleConfigName = aws.String(lifecycleConfigName)
+	}
+
+	if v, ok := d.GetOk("root_access"); ok {
+		createOpts.RootAccess = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("default_code_repository"); ok {
+		createOpts.DefaultCodeRepository = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("additional_code_repositories"); ok {
+		createOpts.AdditionalCodeRepositories = expandStringSet(v.(*schema.Set))
+	}
+
+	if v, ok := d.GetOk("accelerator_types"); ok && v.(*schema.Set).Len() > 0 {
+		createOpts.AcceleratorTypes = expandStringSet(v.(*schema.Set))
+	}
+
+	if v, ok := d.GetOk("instance_metadata_service_configuration"); ok {
+		createOpts.InstanceMetadataServiceConfiguration = expandSagemakerNotebookInstanceMetadataServiceConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("platform_identifier"); ok {
+		createOpts.PlatformIdentifier = aws.String(v.(string))
+	}
+
+	// Tags are always passed on createOpts.Tags and never applied via a
+	// separate AddTags call after the fact, so a tag-enforcement Lambda or
+	// SCP triggered by CreateNotebookInstance itself sees the final tag set
+	// immediately, before the instance starts transitioning toward
+	// InService, rather than racing a second API call.
+	createTags, err := sagemakerTagsForCreate(meta, d.Get("tags").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	createOpts.Tags = createTags
+
+	if d.Get("auto_cost_tags").(bool) {
+		availabilityZone := sagemakerNotebookInstanceAvailabilityZone(meta.(*AWSClient).ec2conn, createOpts.SubnetId)
+		for k, v := range sagemakerNotebookInstanceAutoCostTags(aws.StringValue(createOpts.InstanceType), availabilityZone) {
+			createOpts.Tags = append(createOpts.Tags, &sagemaker.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+	}
+
+	if inheritKeys := expandStringSet(d.Get("inherit_role_tags").(*schema.Set)); len(inheritKeys) > 0 {
+		inheritedTags, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(meta.(*AWSClient).iamconn, aws.StringValue(createOpts.RoleArn), inheritKeys)
+		if err != nil {
+			return fmt.Errorf("error inheriting role tags for Sagemaker Notebook Instance role %q: %s", aws.StringValue(createOpts.RoleArn), err)
+		}
+		for k, v := range inheritedTags {
+			createOpts.Tags = append(createOpts.Tags, &sagemaker.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+	}
+
+	log.Printf("[DEBUG] Sagemaker Notebook Instance create config: %#v", *createOpts)
+
+	var createOutput *sagemaker.CreateNotebookInstanceOutput
+	if len(candidateSubnetIds) > 0 {
+		out, chosenSubnetId, err := resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn, createOpts, candidateSubnetIds, d.Timeout(schema.TimeoutCreate), sagemakerNotebookInstancePollInterval(d))
+		if err != nil {
+			if _, ok := d.GetOk("inline_lifecycle_config"); ok {
+				if cleanupErr := deleteSagemakerNotebookInstanceInlineLifecycleConfig(conn, name); cleanupErr != nil {
+					log.Printf("[WARN] Error cleaning up inline lifecycle config for failed Sagemaker Notebook Instance %q: %s", name, cleanupErr)
+				}
+			}
+			if _, ok := d.GetOk("default_ui"); ok {
+				if cleanupErr := deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, name); cleanupErr != nil {
+					log.Printf("[WARN] Error cleaning up default_ui lifecycle config for failed Sagemaker Notebook Instance %q: %s", name, cleanupErr)
+				}
+			}
+			if isSagemakerResourceLimitExceededErr(err) {
+				return fmt.Errorf("error creating Sagemaker Notebook Instance (%s): account limit reached: %s\n\nThis is the account's \"Number of notebook instances\" Service Quota, not a capacity problem -- request an increase at https://console.aws.amazon.com/servicequotas/home/services/sagemaker/quotas before retrying.", name, err)
+			}
+			if isSagemakerNotebookInstanceNameConflictErr(err) {
+				if resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, name) {
+					return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+				}
+				return fmt.Errorf("Sagemaker Notebook Instance %q already exists; import it with `terraform import aws_sagemaker_notebook_instance.this %s`: %s", name, name, err)
+			}
+			return sagemakerNotebookInstanceApiError("Error creating", name, err)
+		}
+		createOutput = out
+		if err := d.Set("subnet_id", chosenSubnetId); err != nil {
+			return fmt.Errorf("error setting subnet_id for notebook instance %q: %s", name, err)
+		}
+
+		d.SetId(name)
+		log.Printf("[INFO] Sagemaker Notebook Instance ID: %s", d.Id())
+	} else {
+		err = resource.Retry(2*time.Minute, func() *resource.RetryError {
+			var out *sagemaker.CreateNotebookInstanceOutput
+			err := retryOnSagemakerThrottle(func() error {
+				var err error
+				out, err = conn.CreateNotebookInstance(createOpts)
+				return err
+			})
+			if err != nil {
+				if isSagemakerIamEventualConsistencyErr(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			createOutput = out
+			return nil
+		})
+		if err != nil {
+			// CreateNotebookInstance itself failed after the implicit lifecycle
+			// config above already succeeded -- clean that up too, best-effort,
+			// rather than leaving it behind with nothing in state pointing at it.
+			if _, ok := d.GetOk("inline_lifecycle_config"); ok {
+				if cleanupErr := deleteSagemakerNotebookInstanceInlineLifecycleConfig(conn, name); cleanupErr != nil {
+					log.Printf("[WARN] Error cleaning up inline lifecycle config for failed Sagemaker Notebook Instance %q: %s", name, cleanupErr)
+				}
+			}
+			if _, ok := d.GetOk("default_ui"); ok {
+				if cleanupErr := deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, name); cleanupErr != nil {
+					log.Printf("[WARN] Error cleaning up default_ui lifecycle config for failed Sagemaker Notebook Instance %q: %s", name, cleanupErr)
+				}
+			}
+
+			if isSagemakerResourceLimitExceededErr(err) {
+				return fmt.Errorf("error creating Sagemaker Notebook Instance (%s): account limit reached: %s\n\nThis is the account's \"Number of notebook instances\" Service Quota, not a capacity problem -- request an increase at https://console.aws.amazon.com/servicequotas/home/services/sagemaker/quotas before retrying.", name, err)
+			}
+			if isSagemakerNotebookInstanceNameConflictErr(err) {
+				if resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, name) {
+					return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+				}
+				return fmt.Errorf("Sagemaker Notebook Instance %q already exists; import it with `terraform import aws_sagemaker_notebook_instance.this %s`: %s", name, name, err)
+			}
+			return sagemakerNotebookInstanceApiError("Error creating", name, err)
+		}
+
+		d.SetId(name)
+		log.Printf("[INFO] Sagemaker Notebook Instance ID: %s", d.Id())
+	}
+
+	// Tags set at create are visible via ListTags eventually, not necessarily
+	// by the time the trailing Read below runs. Waiting here, rather than
+	// leaving the single-shot ListTags in Read to race it, keeps the create
+	// from depending on that race resolving in its favor. A failure to
+	// converge within the wait is only logged, not returned: the notebook
+	// instance itself was created successfully, and erroring out here would
+	// taint and recreate it over what's ultimately just a stale tag list that
+	// the next refresh's own ListTags call (below, in Read) will fix.
+	if _, err := sagemakerWaitForCreatedTags(conn, createOutput.NotebookInstanceArn, createOpts.Tags, 1*time.Minute); err != nil {
+		log.Printf("[WARN] Tags not yet consistent via ListTags for Sagemaker Notebook Instance (%s), continuing: %s", d.Id(), err)
+	}
+
+	// With a subnet_ids fallback list, the InService-or-Failed outcome was
+	// already determined by resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback
+	// above -- that's how it knows whether to move on to the next candidate --
+	// so there's nothing left to wait for here even if wait_for_in_service is set.
+	if len(candidateSubnetIds) == 0 {
+		if !d.Get("wait_for_in_service").(bool) {
+			return resourceAwsSagemakerNotebookInstancePostWriteRead(d, meta, createOutput.NotebookInstanceArn)
+		}
+
+		// Only InService is a success target here: Failed is not in
+		// waitSagemakerNotebookInstanceStatus's pending set either, so a
+		// Pending->Failed transition now returns an error directly from the wait
+		// itself instead of silently being treated as a second success target.
+		// The describe below only exists to enrich *why* it failed.
+		if err := sagemakerNotebookInstanceTimedWait(d, func() error {
+			return WaitNotebookInstanceInService(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemakerNotebookInstancePollInterval(d))
+		}); err != nil {
+			if notebookInstanceRaw, status, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); describeErr == nil && status == sagemaker.NotebookInstanceStatusFailed {
+				notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+				createErr := sagemakerNotebookInstanceCreateFailedError(d.Id(), d.Get("instance_type").(string), aws.StringValue(notebookInstance.FailureReason), sagemakerNotebookInstanceLifecycleLogTail(meta, d.Id(), "LifecycleConfigOnStart"))
+
+				sagemakerNotebookInstanceNotifyStatus(meta.(*AWSClient).snsconn, d.Get("notify_sns_topic_arn").(string), name, sagemaker.NotebookInstanceStatusFailed)
+
+				if d.Get("delete_on_create_failure").(bool) {
+					sagemakerNotebookInstanceDeleteAfterCreateFailure(conn, d.Id())
+					d.SetId("")
+				}
+
+				return createErr
+			}
+
+			if msg := sagemakerNotebookInstanceCreateTimeoutPendingMessage(d.Id(), err); msg != "" {
+				return errors.New(msg)
+			}
+
+			return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to start: %s", d.Id(), err)
+		}
+
+		sagemakerNotebookInstanceNotifyStatus(meta.(*AWSClient).snsconn, d.Get("notify_sns_topic_arn").(string), name, sagemaker.NotebookInstanceStatusInService)
+	}
+
+	if d.Get("default_code_repository").(string) != "" && d.Get("wait_for_code_repo_clone").(bool) {
+		sagemakerNotebookInstanceWaitForCodeRepoClone(meta, d.Id())
+	}
+
+	if d.Get("subnet_id").(string) != "" {
+		if notebookInstanceRaw, _, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); describeErr == nil && notebookInstanceRaw != nil {
+			if err := d.Set("network_interface_id", notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput).NetworkInterfaceId); err != nil {
+				return fmt.Errorf("error setting network_interface_id for notebook instance %q: %s", d.Id(), err)
+			}
+			resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(d, meta.(*AWSClient).ec2conn)
+		}
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKeyApplied(d, conn); err != nil {
+		return err
+	}
+
+	resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, meta.(*AWSClient).efsconn)
+
+	if d.Get("verify_url_reachable").(bool) {
+		resourceAwsSagemakerNotebookInstanceVerifyUrlReachable(d, conn)
+	}
+
+	if d.Get("check_ssm_managed").(bool) {
+		resourceAwsSagemakerNotebookInstanceCheckSsmManaged(d, meta.(*AWSClient).ssmconn)
+	}
+
+	if d.Get("verify_code_repo_secrets").(bool) {
+		resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d, conn, meta.(*AWSClient).secretsmanagerconn)
+	}
+
+	desiredStatus := d.Get("desired_status").(string)
+	stopAfterCreate := desiredStatus == sagemaker.NotebookInstanceStatusStopped ||
+		(!d.Get("start_on_create").(bool) && desiredStatus != sagemaker.NotebookInstanceStatusInService)
+	if stopAfterCreate {
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemakerNotebookInstancePollInterval(d)); err != nil {
+			return fmt.Errorf("error stopping Sagemaker Notebook Instance (%s) after create: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsSagemakerNotebookInstancePostWriteRead(d, meta, createOutput.NotebookInstanceArn)
+}
+
+// resourceAwsSagemakerNotebookInstancePostWriteRead is the trailing read
+// Create/Update normally do to pick up everything the API doesn't echo back
+// directly. When skip_read_after_write is set, that round trip (a
+// DescribeNotebookInstance plus a paginated ListTags) is skipped in favor of
+// just recording the one field the write calls do return, trading staleness
+// in the other computed attributes for fewer API calls.
+func resourceAwsSagemakerNotebookInstancePostWriteRead(d *schema.ResourceData, meta interface{}, arn *string) error {
+	if !d.Get("skip_read_after_write").(bool) {
+		return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+	}
+
+	if arn != nil {
+		if err := d.Set("arn", arn); err != nil {
+			return fmt.Errorf("error setting arn for notebook instance %q: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceNameAndRegionFromImportId accepts a plain notebook
+// instance name, a region-qualified "<region>:<name>" import ID, or a full
+// notebook instance ARN, and returns the bare name plus the region the
+// import ID was qualified with ("" if it wasn't). This provider has no
+// per-resource region override, so the region is only used to warn on a
+// mismatch with the provider's own configured region, not to switch
+// connections -- automation importing from a flat list of ARNs across
+// regions still needs one provider alias per region, but at least doesn't
+// need to pre-strip each ARN down to a bare name first.
+func sagemakerNotebookInstanceNameAndRegionFromImportId(id string) (name, region string, err error) {
+	if strings.HasPrefix(id, "arn:") {
+		parts := strings.Split(id, ":")
+		if len(parts) < 6 || parts[3] == "" {
+			return "", "", fmt.Errorf("unexpected Sagemaker Notebook Instance ARN format: %q", id)
+		}
+
+		resourcePart := parts[len(parts)-1]
+		nameParts := strings.SplitN(resourcePart, "notebook-instance/", 2)
+		if len(nameParts) != 2 || nameParts[1] == "" {
+			return "", "", fmt.Errorf("unexpected Sagemaker Notebook Instance ARN format: %q", id)
+		}
+
+		return nameParts[1], parts[3], nil
+	}
+
+	if parts := strings.SplitN(id, ":", 2); len(parts) == 2 {
+		region, name := parts[0], parts[1]
+		if region == "" || name == "" {
+			return "", "", fmt.Errorf("unexpected region-qualified import ID format, expected <region>:<name>: %q", id)
+		}
+		return name, region, nil
+	}
+
+	return id, "", nil
+}
+
+// resourceAwsSagemakerNotebookInstanceImport verifies the named notebook
+// instance exists before handing off to the normal passthrough import, so a
+// typo'd or nonexistent name fails the import outright instead of silently
+// producing an empty resource in state.
+func resourceAwsSagemakerNotebookInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name, region, err := sagemakerNotebookInstanceNameAndRegionFromImportId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if region != "" && region != meta.(*AWSClient).region {
+		log.Printf("[WARN] Import ID %q is for region %s, but this provider (or alias) is configured for %s; the notebook instance will be imported using this provider's region", d.Id(), region, meta.(*AWSClient).region)
+	}
+
+	d.SetId(name)
+
+	conn := meta.(*AWSClient).sagemakerconn
+
+	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+	if err != nil {
+		return nil, err
+	}
+
+	if notebookInstanceRaw == nil {
+		return nil, fmt.Errorf("no Sagemaker Notebook Instance found with name: %s", d.Id())
+	}
+
+	return schema.ImportStatePassthrough(d, meta)
+}
+
+func resourceAwsSagemakerNotebookInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+	if err != nil {
+		if finder.IsResourceNotFoundErr(err) {
+			d.SetId("")
+			log.Printf("[LOG] Unable to find SageMaker notebook instance %q; removing from state file", d.Id())
+			return nil
+		}
+		return err
+	}
+
+	if notebookInstanceRaw == nil {
+		d.SetId("")
+		log.Printf("[LOG] Unable to find SageMaker notebook instance %q; removing from state file", d.Id())
+		return nil
+	}
+
+	notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+
+	// DescribeNotebookInstance returns a nil SecurityGroups for a non-VPC
+	// notebook (no subnet_id), which flattenStringList would turn into a nil
+	// []interface{} rather than an empty one; schema.Set treats those two
+	// the same internally, but being explicit here keeps this resource from
+	// ever depending on that implementation detail to avoid a spurious diff.
+	var securityGroups []*string
+	if notebookInstance.SubnetId != nil {
+		securityGroups = notebookInstance.SecurityGroups
+	}
+	if err := d.Set("security_groups", flattenSagemakerNotebookInstanceSecurityGroups(securityGroups)); err != nil {
+		return fmt.Errorf("error setting security_groups for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("name", notebookInstance.NotebookInstanceName); err != nil {
+		return fmt.Errorf("error setting name for notebook instance %q: %s", d.Id(), err)
+	}
+	// role_arn and instance_type can come back from the API reflecting the
+	// *requested* update while status is still Updating and the restart that
+	// applies it hasn't completed yet, so a refresh mid-update would persist
+	// a half-applied value to state even though the running instance hasn't
+	// caught up. Leave whatever is already in state untouched until the
+	// instance is out of flux, the same as url/network_interface_id below.
+	if !isSagemakerNotebookInstanceStatusInFlux(aws.StringValue(notebookInstance.NotebookInstanceStatus)) {
+		if err := d.Set("role_arn", notebookInstance.RoleArn); err != nil {
+			return fmt.Errorf("error setting role_arn for notebook instance %q: %s", d.Id(), err)
+		}
+		if err := d.Set("role_name", sagemakerNotebookInstanceRoleNameFromArn(aws.StringValue(notebookInstance.RoleArn))); err != nil {
+			return fmt.Errorf("error setting role_name for notebook instance %q: %s", d.Id(), err)
+		}
+		if err := d.Set("instance_type", notebookInstance.InstanceType); err != nil {
+			return fmt.Errorf("error setting instance_type for notebook instance %q: %s", d.Id(), err)
+		}
+	}
+	if err := d.Set("subnet_id", notebookInstance.SubnetId); err != nil {
+		return fmt.Errorf("error setting subnet_id for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("has_vpc_config", notebookInstance.SubnetId != nil && len(securityGroups) > 0); err != nil {
+		return fmt.Errorf("error setting has_vpc_config for notebook instance %q: %s", d.Id(), err)
+	}
+
+	sortedSecurityGroupIds := aws.StringValueSlice(securityGroups)
+	sort.Strings(sortedSecurityGroupIds)
+	if err := d.Set("security_group_ids", sortedSecurityGroupIds); err != nil {
+		return fmt.Errorf("error setting security_group_ids for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("network_posture", sagemakerNotebookInstanceNetworkPosture(aws.StringValue(notebookInstance.SubnetId), aws.StringValue(notebookInstance.DirectInternetAccess))); err != nil {
+		return fmt.Errorf("error setting network_posture for notebook instance %q: %s", d.Id(), err)
+	}
+
+	availabilityZone := sagemakerNotebookInstanceAvailabilityZone(meta.(*AWSClient).ec2conn, notebookInstance.SubnetId)
+	if err := d.Set("availability_zone", availabilityZone); err != nil {
+		return fmt.Errorf("error setting availability_zone for notebook instance %q: %s", d.Id(), err)
+	}
+
+	vpcId := sagemakerNotebookInstanceVpcId(meta.(*AWSClient).ec2conn, notebookInstance.SubnetId)
+	if err := d.Set("vpc_id", vpcId); err != nil {
+		return fmt.Errorf("error setting vpc_id for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("kms_key_id", notebookInstance.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("volume_encrypted", aws.StringValue(notebookInstance.KmsKeyId) != ""); err != nil {
+		return fmt.Errorf("error setting volume_encrypted for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("direct_internet_access", notebookInstance.DirectInternetAccess); err != nil {
+		return fmt.Errorf("error setting direct_internet_access for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("volume_size_in_gb", notebookInstance.VolumeSizeInGB); err != nil {
+		return fmt.Errorf("error setting volume_size_in_gb for notebook instance %q: %s", d.Id(), err)
+	}
+
+	costAttributes := sagemakerNotebookInstanceCostAttributes(aws.StringValue(notebookInstance.InstanceType), aws.Int64Value(notebookInstance.VolumeSizeInGB), availabilityZone)
+	if err := d.Set("cost_attributes", costAttributes); err != nil {
+		return fmt.Errorf("error setting cost_attributes for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("cloudwatch_namespace", sagemakerNotebookInstanceCloudwatchNamespace); err != nil {
+		return fmt.Errorf("error setting cloudwatch_namespace for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("cloudwatch_dimensions", sagemakerNotebookInstanceCloudwatchDimensions(aws.StringValue(notebookInstance.NotebookInstanceName))); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("log_group_name", sagemakerNotebookInstanceLogGroupName); err != nil {
+		return fmt.Errorf("error setting log_group_name for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("log_stream_prefix", aws.StringValue(notebookInstance.NotebookInstanceName)); err != nil {
+		return fmt.Errorf("error setting log_stream_prefix for notebook instance %q: %s", d.Id(), err)
+	}
+
+	// An inline_lifecycle_config already in state owns the attached lifecycle
+	// config (named after this notebook instance, per
+	// sagemakerNotebookInstanceInlineLifecycleConfigName), so it's re-read
+	// from DescribeNotebookInstanceLifecycleConfig and lifecycle_config_name
+	// is left alone rather than overwritten with that same name -- the two
+	// are mutually exclusive in config, and leaving lifecycle_config_name set
+	// here would force inline_lifecycle_config back off on the next plan.
+	// There's no API-side marker distinguishing a lifecycle config this
+	// resource created inline from an unrelated one a user happens to have
+	// named identically, so this only recognizes ownership already recorded
+	// in state; importing a notebook whose lifecycle config was originally
+	// created this way will surface it as lifecycle_config_name instead.
+	if len(d.Get("inline_lifecycle_config").([]interface{})) > 0 {
+		if err := resourceAwsSagemakerNotebookInstanceReadInlineLifecycleConfig(d, conn); err != nil {
+			return err
+		}
+	} else if d.Get("default_ui").(string) != "" {
+		if err := resourceAwsSagemakerNotebookInstanceReadDefaultUi(d, conn); err != nil {
+			return err
+		}
+	} else if err := d.Set("lifecycle_config_name", notebookInstance.NotebookInstanceLifecycleConfigName); err != nil {
+		return fmt.Errorf("error setting lifecycle_config_name for notebook instance %q: %s", d.Id(), err)
+	}
+
+	lifecycleConfigContentSha := ""
+	if d.Get("track_lifecycle_config_content_sha").(bool) {
+		if lifecycleConfigName := d.Get("lifecycle_config_name").(string); lifecycleConfigName != "" {
+			sha, err := sagemakerNotebookInstanceLifecycleConfigContentSha(conn, lifecycleConfigName)
+			if err != nil {
+				return fmt.Errorf("error computing lifecycle_config_content_sha for notebook instance %q: %s", d.Id(), err)
+			}
+			lifecycleConfigContentSha = sha
+		}
+	}
+	if err := d.Set("lifecycle_config_content_sha", lifecycleConfigContentSha); err != nil {
+		return fmt.Errorf("error setting lifecycle_config_content_sha for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("root_access", notebookInstance.RootAccess); err != nil {
+		return fmt.Errorf("error setting root_access for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("root_access_disabled", aws.StringValue(notebookInstance.RootAccess) == sagemaker.RootAccessDisabled); err != nil {
+		return fmt.Errorf("error setting root_access_disabled for notebook instance %q: %s", d.Id(), err)
+	}
+	if err := d.Set("network_isolation", aws.StringValue(notebookInstance.DirectInternetAccess) == sagemaker.DirectInternetAccessDisabled); err != nil {
+		return fmt.Errorf("error setting network_isolation for notebook instance %q: %s", d.Id(), err)
+	}
+
+	// url and network_interface_id are only populated by the API once the
+	// instance reaches InService. While it's still Pending or Updating (e.g.
+	// importing mid-transition, or just created out-of-band) they come back
+	// nil, and setting them would produce a transient diff on the next plan
+	// once the instance finishes transitioning. Leave whatever is already in
+	// state untouched until then.
+	if !isSagemakerNotebookInstanceStatusInFlux(aws.StringValue(notebookInstance.NotebookInstanceStatus)) {
+		if err := d.Set("url", notebookInstance.NotebookInstanceUrl); err != nil {
+			return fmt.Errorf("error setting url for notebook instance %q: %s", d.Id(), err)
+		}
+
+		if err := d.Set("network_interface_id", notebookInstance.NetworkInterfaceId); err != nil {
+			return fmt.Errorf("error setting network_interface_id for notebook instance %q: %s", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("default_code_repository", notebookInstance.DefaultCodeRepository); err != nil {
+		return fmt.Errorf("error setting default_code_repository for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("additional_code_repositories", flattenSagemakerNotebookInstanceAdditionalCodeRepositories(conn, d.Get("additional_code_repositories").(*schema.Set).List(), notebookInstance.AdditionalCodeRepositories)); err != nil {
+		return fmt.Errorf("error setting additional_code_repositories for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("accelerator_types", flattenStringList(notebookInstance.AcceleratorTypes)); err != nil {
+		return fmt.Errorf("error setting accelerator_types for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("instance_metadata_service_configuration", flattenSagemakerNotebookInstanceMetadataServiceConfiguration(notebookInstance.InstanceMetadataServiceConfiguration)); err != nil {
+		return fmt.Errorf("error setting instance_metadata_service_configuration for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("instance_metadata_service_version", sagemakerNotebookInstanceMetadataServiceVersion(notebookInstance.InstanceMetadataServiceConfiguration)); err != nil {
+		return fmt.Errorf("error setting instance_metadata_service_version for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("platform_identifier", notebookInstance.PlatformIdentifier); err != nil {
+		return fmt.Errorf("error setting platform_identifier for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("default_view", sagemakerNotebookInstanceDefaultView(aws.StringValue(notebookInstance.PlatformIdentifier))); err != nil {
+		return fmt.Errorf("error setting default_view for notebook instance %q: %s", d.Id(), err)
+	}
+
+	computeSummary := sagemakerNotebookInstanceComputeSummary(
+		aws.StringValue(notebookInstance.InstanceType),
+		aws.Int64Value(notebookInstance.VolumeSizeInGB),
+		aws.StringValueSlice(notebookInstance.AcceleratorTypes),
+		aws.StringValue(notebookInstance.RootAccess),
+		aws.StringValue(notebookInstance.DirectInternetAccess),
+		aws.StringValue(notebookInstance.PlatformIdentifier),
+	)
+	if err := d.Set("compute_summary", computeSummary); err != nil {
+		return fmt.Errorf("error setting compute_summary for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("arn", notebookInstance.NotebookInstanceArn); err != nil {
+		return fmt.Errorf("error setting arn for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("status", notebookInstance.NotebookInstanceStatus); err != nil {
+		return fmt.Errorf("error setting status for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("schedulable", sagemakerNotebookInstanceSchedulable(aws.StringValue(notebookInstance.NotebookInstanceStatus))); err != nil {
+		return fmt.Errorf("error setting schedulable for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("failure_reason", notebookInstance.FailureReason); err != nil {
+		return fmt.Errorf("error setting failure_reason for notebook instance %q: %s", d.Id(), err)
+	}
+
+	if aws.StringValue(notebookInstance.FailureReason) == "" && d.Get("default_code_repository").(string) != "" {
+		if reason := sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure(meta, d.Id()); reason != "" {
+			if err := d.Set("failure_reason", reason); err != nil {
+				return fmt.Errorf("error setting failure_reason for notebook instance %q: %s", d.Id(), err)
+			}
+		}
+	}
+
+	if notebookInstance.CreationTime != nil {
+		if err := d.Set("creation_time", notebookInstance.CreationTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting creation_time for notebook instance %q: %s", d.Id(), err)
+		}
+	}
+
+	if notebookInstance.LastModifiedTime != nil {
+		if err := d.Set("last_modified_time", notebookInstance.LastModifiedTime.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("error setting last_modified_time for notebook instance %q: %s", d.Id(), err)
+		}
+	}
+
+	presignedUrl, err := resourceAwsSagemakerNotebookInstancePresignedUrl(d, conn, aws.StringValue(notebookInstance.NotebookInstanceStatus))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("presigned_url", presignedUrl); err != nil {
+		return fmt.Errorf("error setting presigned_url for notebook instance %q: %s", d.Id(), err)
+	}
+
+	allTagsOutput, err := sagemakerListAllTagsWithRetry(conn, notebookInstance.NotebookInstanceArn)
+	if err != nil {
+		return sagemakerNotebookInstanceHandleTagsReadError(d.Id(), d.Get("tags_read_mode").(string), err)
+	}
+
+	allTags := sagemakerTagsWithoutIgnored(meta, sagemakerTagsWithoutAwsManaged(tagsToMapSagemaker(allTagsOutput)))
+	if err := d.Set("tags_all", allTags); err != nil {
+		return fmt.Errorf("error setting tags_all for notebook instance %q: %s", d.Id(), err)
+	}
+	visibleTags := sagemakerNotebookInstanceTagsWithoutInheritedRoleTags(sagemakerNotebookInstanceTagsWithoutAutoCostTags(sagemakerTagsWithoutDefaults(meta, allTags)), expandStringSet(d.Get("inherit_role_tags").(*schema.Set)))
+	if err := d.Set("tags", visibleTags); err != nil {
+		return fmt.Errorf("error setting tags for notebook instance %q: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// sagemakerNotebookInstanceHandleTagsReadError is the tags_read_mode switch
+// behind the ListTags failure branch in Read. "strict" turns err into a hard
+// failure of the read; "lenient" (the default) is this resource's
+// long-standing behavior of logging a warning and leaving tags as they were
+// in state -- a transient tag-listing failure (e.g. throttling) shouldn't
+// discard the primary attributes Read already fetched, so only tags are
+// left unset. AccessDenied gets its own, more actionable message naming the
+// missing permission, since that's a configuration problem the user can
+// fix, rather than something that'll clear up on its own like throttling.
+func sagemakerNotebookInstanceHandleTagsReadError(id, readMode string, err error) error {
+	if readMode == sagemakerNotebookInstanceTagsReadModeStrict {
+		return fmt.Errorf("error listing tags for Sagemaker Notebook Instance (%s): %s", id, err)
+	}
+
+	if isSagemakerAccessDeniedErr(err) {
+		log.Printf("[WARN] Not authorized to list tags for Sagemaker Notebook Instance (%s), keeping existing tags in state: missing sagemaker:ListTags permission: %s", id, err)
+		return nil
+	}
+
+	log.Printf("[WARN] Error listing tags for Sagemaker Notebook Instance (%s), keeping existing tags in state: %s", id, err)
+	return nil
+}
+
+func resourceAwsSagemakerNotebookInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	d.Partial(true)
+
+	if err := sagemakerNotebookInstanceSetTags(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	if err := sagemakerNotebookInstanceSetAutoCostTags(conn, d, meta); err != nil {
+		return err
+	}
+	d.SetPartial("auto_cost_tags")
+
+	if err := sagemakerNotebookInstanceSetInheritedRoleTags(conn, d, meta); err != nil {
+		return err
+	}
+	d.SetPartial("inherit_role_tags")
+
+	hasChanged := false
+	updateOpts := &sagemaker.UpdateNotebookInstanceInput{
+		NotebookInstanceName: aws.String(d.Id()),
+	}
+
+	if d.HasChange("role_arn") {
+		updateOpts.RoleArn = aws.String(d.Get("role_arn").(string))
+		hasChanged = true
+	}
+
+	if d.HasChange("instance_type") {
+		updateOpts.InstanceType = aws.String(d.Get("instance_type").(string))
+		hasChanged = true
+	}
+
+	if d.HasChange("volume_size_in_gb") {
+		updateOpts.VolumeSizeInGB = aws.Int64(int64(d.Get("volume_size_in_gb").(int)))
+		hasChanged = true
+	}
+
+	if d.HasChange("lifecycle_config_name") {
+		if l, ok := d.GetOk("lifecycle_config_name"); ok {
+			updateOpts.LifecycleConfigName = aws.String(l.(string))
+		} else {
+			updateOpts.DisassociateLifecycleConfig = aws.Bool(true)
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("inline_lifecycle_config") {
+		oldRaw, newRaw := d.GetChange("inline_lifecycle_config")
+		oldConfig, newConfig := oldRaw.([]interface{}), newRaw.([]interface{})
+
+		switch {
+		case len(oldConfig) == 0 && len(newConfig) > 0:
+			// Newly inlined: create the implicit lifecycle config and attach it.
+			lifecycleConfigName, err := createSagemakerNotebookInstanceInlineLifecycleConfig(conn, d.Id(), newConfig)
+			if err != nil {
+				return err
+			}
+			updateOpts.LifecycleConfigName = aws.String(lifecycleConfigName)
+		case len(oldConfig) > 0 && len(newConfig) == 0:
+			// No longer inlined: detach, then clean up the implicit config
+			// this resource created for it.
+			updateOpts.DisassociateLifecycleConfig = aws.Bool(true)
+			if err := deleteSagemakerNotebookInstanceInlineLifecycleConfig(conn, d.Id()); err != nil {
+				return err
+			}
+		default:
+			// Still inlined, only on_create/on_start changed: push the new
+			// content to the same implicit config, no (re)attach needed.
+			if err := updateSagemakerNotebookInstanceInlineLifecycleConfig(conn, d.Id(), newConfig); err != nil {
+				return err
+			}
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("default_ui") {
+		oldRaw, newRaw := d.GetChange("default_ui")
+		oldUi, newUi := oldRaw.(string), newRaw.(string)
+
+		switch {
+		case oldUi == "" && newUi != "":
+			lifecycleConfigName, err := createSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, d.Id(), newUi)
+			if err != nil {
+				return err
+			}
+			updateOpts.LifecycleConfigName = aws.String(lifecycleConfigName)
+		case oldUi != "" && newUi == "":
+			updateOpts.DisassociateLifecycleConfig = aws.Bool(true)
+			if err := deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, d.Id()); err != nil {
+				return err
+			}
+		default:
+			if err := updateSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, d.Id(), newUi); err != nil {
+				return err
+			}
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("default_code_repository") {
+		if v, ok := d.GetOk("default_code_repository"); ok {
+			updateOpts.DefaultCodeRepository = aws.String(v.(string))
+		} else {
+			updateOpts.DisassociateDefaultCodeRepository = aws.Bool(true)
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("additional_code_repositories") {
+		if v, ok := d.GetOk("additional_code_repositories"); ok {
+			updateOpts.AdditionalCodeRepositories = expandStringSet(v.(*schema.Set))
+		} else {
+			updateOpts.DisassociateAdditionalCodeRepositories = aws.Bool(true)
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("accelerator_types") {
+		if v, ok := d.GetOk("accelerator_types"); ok && v.(*schema.Set).Len() > 0 {
+			updateOpts.AcceleratorTypes = expandStringSet(v.(*schema.Set))
+		} else {
+			updateOpts.DisassociateAcceleratorTypes = aws.Bool(true)
+		}
+		hasChanged = true
+	}
+
+	if d.HasChange("instance_metadata_service_configuration") {
+		updateOpts.InstanceMetadataServiceConfiguration = expandSagemakerNotebookInstanceMetadataServiceConfiguration(d.Get("instance_metadata_service_configuration").([]interface{}))
+		hasChanged = true
+	}
+
+	forceRestart := d.HasChange("force_restart") || d.HasChange("restart_triggers")
+
+	// additional_code_repositories is the one UpdateNotebookInstance field
+	// documented as usable against a running notebook instance -- unlike
+	// InstanceType, VolumeSizeInGB, LifecycleConfigName, and the rest, which
+	// the API rejects unless the instance is Stopped. This has only been
+	// confirmed by reading the API reference in this sandbox, not by an
+	// actual call against a live instance, so treat it as the documented
+	// behavior rather than a guarantee verified here.
+	onlyAdditionalCodeRepositoriesChanged := hasChanged && !forceRestart && d.HasChange("additional_code_repositories") &&
+		!d.HasChange("role_arn") && !d.HasChange("instance_type") && !d.HasChange("volume_size_in_gb") &&
+		!d.HasChange("lifecycle_config_name") && !d.HasChange("inline_lifecycle_config") && !d.HasChange("default_ui") &&
+		!d.HasChange("default_code_repository") && !d.HasChange("accelerator_types") &&
+		!d.HasChange("instance_metadata_service_configuration")
+
+	if onlyAdditionalCodeRepositoriesChanged {
+		if err := sagemakerNotebookInstanceUpdateWithRetry(conn, updateOpts); err != nil {
+			return sagemakerNotebookInstanceApiError("Error updating", d.Id(), err)
+		}
+		d.SetPartial("additional_code_repositories")
+	} else if hasChanged || forceRestart {
+		// Stop notebook
+		_, previousStatus, _ := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d)); err != nil {
+			return err
+		}
+
+		if hasChanged {
+			if err := sagemakerNotebookInstanceUpdateWithRetry(conn, updateOpts); err != nil {
+				// The stop above already succeeded, so a rejected update (e.g. an
+				// instance_type incompatible with the notebook's VPC/platform)
+				// would otherwise leave a previously-running notebook stopped and
+				// out of sync with config. Best-effort restart it before
+				// returning the original error.
+				if previousStatus == sagemaker.NotebookInstanceStatusInService {
+					sagemakerNotebookInstanceRestartAfterFailedUpdate(conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d))
+				}
+				return sagemakerNotebookInstanceApiError("Error updating", d.Id(), err)
+			}
+
+			// The update call above already committed these attributes; mark
+			// them as partial now so a restart failure below doesn't cause the
+			// next apply to redo an update that already succeeded.
+			for _, attr := range []string{
+				"role_arn",
+				"instance_type",
+				"volume_size_in_gb",
+				"lifecycle_config_name",
+				"default_code_repository",
+				"additional_code_repositories",
+				"accelerator_types",
+				"instance_metadata_service_configuration",
+			} {
+				if d.HasChange(attr) {
+					d.SetPartial(attr)
+				}
+			}
+		}
+
+		if forceRestart {
+			d.SetPartial("force_restart")
+			d.SetPartial("restart_triggers")
+		}
+
+		// Restart if needed. A force_restart or restart_triggers change
+		// always restarts a previously-InService instance, since that's the
+		// entire point of either trigger -- restart_after_update only gates
+		// restarts following an actual attribute update.
+		if previousStatus == sagemaker.NotebookInstanceStatusInService && (forceRestart || d.Get("restart_after_update").(bool)) {
+			startOpts := &sagemaker.StartNotebookInstanceInput{
+				NotebookInstanceName: aws.String(d.Id()),
+			}
+
+			if err := retryOnSagemakerThrottle(func() error {
+				_, err := conn.StartNotebookInstance(startOpts)
+				return err
+			}); err != nil {
+				return fmt.Errorf("error starting Sagemaker Notebook Instance %q: %s", d.Id(), err)
+			} else if err := sagemakerNotebookInstanceTimedWait(d, func() error {
+				return waitSagemakerNotebookInstanceStatus(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d), sagemaker.NotebookInstanceStatusInService)
+			}); err != nil {
+				// Only InService is a success target here, so a Updating->Failed
+				// transition lands here too instead of being silently treated as
+				// a second success target. The describe below exists only to
+				// enrich *why* it failed before attempting a rollback.
+				if notebookInstanceRaw, status, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); describeErr == nil && status == sagemaker.NotebookInstanceStatusFailed {
+					notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+					updateErr := sagemakerNotebookInstanceUpdateFailedError(d.Id(), aws.StringValue(notebookInstance.FailureReason))
+
+					oldInstanceType, newInstanceType := d.GetChange("instance_type")
+					sagemakerNotebookInstanceRollbackFailedStartAfterUpdate(conn, d.Id(), oldInstanceType.(string), newInstanceType.(string), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d))
+
+					return updateErr
+				}
+
+				return fmt.Errorf("error waiting for Sagemaker Notebook Instance %q to start: %s", d.Id(), err)
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("desired_status"); ok {
+		desiredStatus := v.(string)
+		_, currentStatus, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+		if err != nil {
+			return err
+		}
+
+		if currentStatus != desiredStatus {
+			switch desiredStatus {
+			case sagemaker.NotebookInstanceStatusInService:
+				if err := retryOnSagemakerThrottle(func() error {
+					_, err := conn.StartNotebookInstance(&sagemaker.StartNotebookInstanceInput{
+						NotebookInstanceName: aws.String(d.Id()),
+					})
+					return err
+				}); err != nil {
+					return fmt.Errorf("error starting Sagemaker Notebook Instance %q: %s", d.Id(), err)
+				}
+				if err := sagemakerNotebookInstanceTimedWait(d, func() error {
+					return waitSagemakerNotebookInstanceStatus(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d), sagemaker.NotebookInstanceStatusInService)
+				}); err != nil {
+					if notebookInstanceRaw, status, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); describeErr == nil && status == sagemaker.NotebookInstanceStatusFailed {
+						notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+						updateErr := sagemakerNotebookInstanceUpdateFailedError(d.Id(), aws.StringValue(notebookInstance.FailureReason))
+
+						oldInstanceType, newInstanceType := d.GetChange("instance_type")
+					sagemakerNotebookInstanceRollbackFailedStartAfterUpdate(conn, d.Id(), oldInstanceType.(string), newInstanceType.(string), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d))
+
+						return updateErr
+					}
+
+					return fmt.Errorf("error waiting for Sagemaker Notebook Instance %q to start: %s", d.Id(), err)
+				}
+			case sagemaker.NotebookInstanceStatusStopped:
+				if err := stopSagemakerNotebookInstance(context.Background(), conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemakerNotebookInstancePollInterval(d)); err != nil {
+					return fmt.Errorf("error stopping Sagemaker Notebook Instance %q to match desired_status: %s", d.Id(), err)
+				}
+			}
+		}
+	}
+
+	if topicArn := d.Get("notify_sns_topic_arn").(string); topicArn != "" {
+		if _, finalStatus, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); err == nil {
+			sagemakerNotebookInstanceNotifyStatus(meta.(*AWSClient).snsconn, topicArn, d.Id(), finalStatus)
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceAwsSagemakerNotebookInstancePostWriteRead(d, meta, nil)
+}
+
+func resourceAwsSagemakerNotebookInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	// Each sub-step below (drain, stop+wait Stopped, delete+wait gone) draws
+	// down the same overall delete timeout rather than each getting the full
+	// timeout to itself, so teardown timing stays bounded and predictable
+	// instead of a single step being able to consume it all.
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
+
+	_, status, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+	if err != nil {
+		return err
+	}
+
+	if status == "" {
+		log.Printf("[DEBUG] Sagemaker Notebook Instance %q already gone, nothing to delete", d.Id())
+		return nil
+	}
+
+	if drain := time.Duration(d.Get("delete_drain_timeout").(int)) * time.Second; status == sagemaker.NotebookInstanceStatusInService && drain > 0 {
+		log.Printf("[DEBUG] Draining Sagemaker Notebook Instance %q for up to %s before stopping", d.Id(), drain)
+		time.Sleep(drain)
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(context.Background(), conn, d.Id(), status, time.Until(deadline), sagemakerNotebookInstancePollInterval(d)); err != nil {
+		return err
+	}
+
+	if d.Get("skip_destroy").(bool) {
+		log.Printf("[DEBUG] skip_destroy is set; removing Sagemaker Notebook Instance %q from state without deleting it", d.Id())
+		return nil
+	}
+
+	if d.Get("retain_on_replace").(bool) {
+		log.Printf("[DEBUG] retain_on_replace is set; removing Sagemaker Notebook Instance %q from state without deleting it", d.Id())
+		return nil
+	}
+
+	deleteOpts := &sagemaker.DeleteNotebookInstanceInput{
+		NotebookInstanceName: aws.String(d.Id()),
+	}
+
+	// DeleteNotebookInstance can be called the instant stopSagemakerNotebookInstance
+	// above sees Stopped, but SageMaker itself can still be finishing the
+	// Stopping->Stopped transition internally for a moment afterward, during
+	// which DeleteNotebookInstance rejects the request as a conflict. That's
+	// retried here, bounded by the time remaining in the overall delete
+	// timeout, rather than failing the apply over what's really just a race
+	// with SageMaker's own internal state settling.
+	if err := resource.Retry(time.Until(deadline), func() *resource.RetryError {
+		err := retryOnSagemakerThrottle(func() error {
+			_, err := conn.DeleteNotebookInstance(deleteOpts)
+			return err
+		})
+		if err != nil {
+			if isSagemakerNotebookInstanceDeleteConflictErr(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	}); err != nil {
+		return sagemakerNotebookInstanceApiError("Error deleting", d.Id(), err)
+	}
+
+	pollInterval := sagemakerNotebookInstancePollInterval(d)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{sagemaker.NotebookInstanceStatusDeleting},
+		Target:     []string{},
+		Refresh:    SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id()),
+		Timeout:    time.Until(deadline),
+		Delay:      pollInterval,
+		MinTimeout: pollInterval,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		// The wait above can time out without the instance actually still
+		// being there -- DeleteNotebookInstance already succeeded, and this
+		// could just be the last poll missing the Deleting->gone transition
+		// before the deadline fired. A final direct check avoids reporting
+		// an error (and leaving the resource in state, requiring manual
+		// cleanup) over what's really a completed delete.
+		if notebookInstanceRaw, _, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())(); describeErr == nil && notebookInstanceRaw == nil {
+			return resourceAwsSagemakerNotebookInstanceDeleteImplicitLifecycleConfigs(d, conn)
+		}
+
+		return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerNotebookInstanceDeleteImplicitLifecycleConfigs(d, conn)
+}
+
+// resourceAwsSagemakerNotebookInstanceDeleteImplicitLifecycleConfigs cleans
+// up whichever implicit lifecycle config the notebook instance owns --
+// inline_lifecycle_config's or default_ui's, the two attributes that create
+// one -- once the notebook instance itself is actually gone. It's only
+// reached past the skip_destroy/retain_on_replace early returns above, which
+// intentionally leave the notebook instance (and so its attached lifecycle
+// config) in place.
+func resourceAwsSagemakerNotebookInstanceDeleteImplicitLifecycleConfigs(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI) error {
+	if len(d.Get("inline_lifecycle_config").([]interface{})) > 0 {
+		return deleteSagemakerNotebookInstanceInlineLifecycleConfig(conn, d.Id())
+	}
+
+	if d.Get("default_ui").(string) != "" {
+		return deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn, d.Id())
+	}
+
+	return nil
+}
+
+func SagemakerNotebookInstanceStateRefreshFunc(ctx context.Context, conn sagemakeriface.SageMakerAPI, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+
+		notebook, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, name)
+		if err != nil {
+			if finder.IsResourceNotFoundErr(err) {
+				notebook = nil
+			} else {
+				log.Printf("Error on SagemakerNotebookInstanceStateRefreshFunc: %s", err)
+				return nil, "", err
+			}
+		}
+
+		if notebook == nil {
+			return nil, "", nil
+		}
+
+		return notebook, *notebook.NotebookInstanceStatus, nil
+	}
+}
+
+// sagemakerNotebookInstanceDescribeWithThrottleFallback calls
+// finder.NotebookInstanceByName, retrying on throttling the same way the
+// rest of this resource does via retryOnSagemakerThrottle. If describe is
+// still throttled once those retries are exhausted, it falls back to
+// ListNotebookInstances (a separately-throttled API) with NameContains to
+// confirm whether the instance still exists, so a persistent
+// DescribeNotebookInstance throttle doesn't get misread as the resource
+// having been deleted and wipe it from state. The fallback is purely
+// existence/status confirmation -- it never returns a substitute describe
+// result, since ListNotebookInstances doesn't carry most of what Read needs.
+func sagemakerNotebookInstanceDescribeWithThrottleFallback(conn sagemakeriface.SageMakerAPI, name string) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	var notebook *sagemaker.DescribeNotebookInstanceOutput
+
+	err := retryOnSagemakerThrottle(func() error {
+		var describeErr error
+		notebook, describeErr = finder.NotebookInstanceByName(conn, name)
+		return describeErr
+	})
+	if err == nil || !isSagemakerThrottleErr(err) {
+		return notebook, err
+	}
+
+	exists, listErr := sagemakerNotebookInstanceExistsViaList(conn, name)
+	if listErr != nil {
+		// The fallback itself failed too; surface the original describe
+		// throttle error rather than the fallback's.
+		return nil, err
+	}
+
+	if !exists {
+		return nil, awserr.New("ResourceNotFoundException", fmt.Sprintf("SageMaker Notebook Instance %q not found", name), nil)
+	}
+
+	// ListNotebookInstances confirms the instance is still there, so this
+	// is a transient describe-only throttle. Surface the original error so
+	// the caller retries on the next refresh instead of treating it as
+	// not found.
+	return nil, err
+}
+
+// sagemakerNotebookInstanceExistsViaList reports whether name appears in
+// ListNotebookInstances' NameContains-filtered results. NameContains is a
+// substring match, so the returned names are checked for an exact match.
+func sagemakerNotebookInstanceExistsViaList(conn sagemakeriface.SageMakerAPI, name string) (bool, error) {
+	output, err := conn.ListNotebookInstances(&sagemaker.ListNotebookInstancesInput{
+		NameContains: aws.String(name),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, summary := range output.NotebookInstances {
+		if aws.StringValue(summary.NotebookInstanceName) == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sagemakerNotebookInstanceVolumeSizeMinGB/MaxGB are SageMaker's documented
+// global bounds for a notebook instance's EBS volume, regardless of instance_type.
+const (
+	sagemakerNotebookInstanceVolumeSizeMinGB = 5
+	sagemakerNotebookInstanceVolumeSizeMaxGB = 16384
+)
+
+// sagemakerNotebookInstanceDefaultPollIntervalSeconds is poll_interval_seconds'
+// default, matching the interval this resource's waiters have always used.
+const sagemakerNotebookInstanceDefaultPollIntervalSeconds = 10
+
+// sagemakerNotebookInstanceNotFoundGracePeriod bounds how long
+// waitSagemakerNotebookInstanceStatus tolerates a DescribeNotebookInstance
+// not-found response as transient eventual consistency rather than an
+// unexpected state, covering the brief window right after
+// CreateNotebookInstance returns.
+const sagemakerNotebookInstanceNotFoundGracePeriod = 30 * time.Second
+
+// notebookInstanceDeletedTimeout is the default Delete timeout: generous
+// enough for a notebook instance backed by a large EBS volume to stop and
+// tear down, while still bounded rather than blocking an apply indefinitely.
+// Users with even slower-deleting notebooks can still raise it via the
+// resource's timeouts block.
+const notebookInstanceDeletedTimeout = 30 * time.Minute
+
+// sagemakerNotebookInstancePollInterval reads poll_interval_seconds off d as
+// a time.Duration, for passing to the waiters below.
+func sagemakerNotebookInstancePollInterval(d *schema.ResourceData) time.Duration {
+	return time.Duration(d.Get("poll_interval_seconds").(int)) * time.Second
+}
+
+// sagemakerNotebookInstanceTagsBehaviorAuthoritative/Additive are the valid
+// values for the tags_behavior schema field. See its schema comment above
+// for what each mode does.
+const (
+	sagemakerNotebookInstanceTagsBehaviorAuthoritative = "authoritative"
+	sagemakerNotebookInstanceTagsBehaviorAdditive       = "additive"
+)
+
+// sagemakerNotebookInstanceTagsReadModeStrict/Lenient are the valid values
+// for the tags_read_mode schema field. See its schema comment above for
+// what each mode does.
+const (
+	sagemakerNotebookInstanceTagsReadModeStrict  = "strict"
+	sagemakerNotebookInstanceTagsReadModeLenient = "lenient"
+)
+
+// sagemakerNotebookInstanceCostTagPrefix namespaces the tag keys
+// auto_cost_tags injects. It's deliberately not "sagemaker:" -- that one may
+// turn out to be as reserved as "aws:" already is, and the cost tags are
+// this resource's own convention rather than an AWS-defined one.
+const sagemakerNotebookInstanceCostTagPrefix = "nb-cost:"
+
+// sagemakerNotebookInstanceAutoCostTags computes the tags auto_cost_tags
+// injects: the notebook's instance_type always, and its availability_zone
+// when known (a non-VPC notebook has none, so availabilityZone is "" and
+// that key is left out rather than set to an empty string).
+func sagemakerNotebookInstanceAutoCostTags(instanceType, availabilityZone string) map[string]string {
+	tags := map[string]string{
+		sagemakerNotebookInstanceCostTagPrefix + "instance_type": instanceType,
+	}
+	if availabilityZone != "" {
+		tags[sagemakerNotebookInstanceCostTagPrefix+"availability_zone"] = availabilityZone
+	}
+	return tags
+}
+
+// sagemakerNotebookInstanceCostAttributes computes cost_attributes: a
+// read-only aggregation of the notebook's cost-relevant configuration for
+// cost-reporting modules to consume as one map. It mirrors
+// sagemakerNotebookInstanceAutoCostTags's handling of availabilityZone being
+// "" for a non-VPC notebook -- that key is left out rather than set to an
+// empty string -- and similarly omits volume_size if volumeSizeInGB is 0,
+// which a DescribeNotebookInstance response without VolumeSizeInGB set would
+// decode to.
+func sagemakerNotebookInstanceCostAttributes(instanceType string, volumeSizeInGB int64, availabilityZone string) map[string]string {
+	attrs := map[string]string{
+		"instance_type": instanceType,
+	}
+	if volumeSizeInGB > 0 {
+		attrs["volume_size"] = strconv.FormatInt(volumeSizeInGB, 10)
+	}
+	if availabilityZone != "" {
+		attrs["availability_zone"] = availabilityZone
+	}
+	return attrs
+}
+
+// sagemakerNotebookInstanceComputeSummary aggregates the notebook's compute
+// posture -- instance_type, volume_size, accelerator_types, root_access,
+// direct_internet_access, and platform_identifier -- into the single flat
+// map compute_summary exposes, the same pattern as
+// sagemakerNotebookInstanceCostAttributes above. Each key is only included
+// when the underlying value is actually set, so e.g. a non-VPC or
+// non-accelerated notebook's summary doesn't carry empty placeholders.
+func sagemakerNotebookInstanceComputeSummary(instanceType string, volumeSizeInGB int64, acceleratorTypes []string, rootAccess, directInternetAccess, platformIdentifier string) map[string]string {
+	summary := map[string]string{}
+	if instanceType != "" {
+		summary["instance_type"] = instanceType
+	}
+	if volumeSizeInGB > 0 {
+		summary["volume_size"] = strconv.FormatInt(volumeSizeInGB, 10)
+	}
+	if len(acceleratorTypes) > 0 {
+		summary["accelerator_types"] = strings.Join(acceleratorTypes, ",")
+	}
+	if rootAccess != "" {
+		summary["root_access"] = rootAccess
+	}
+	if directInternetAccess != "" {
+		summary["direct_internet_access"] = directInternetAccess
+	}
+	if platformIdentifier != "" {
+		summary["platform_identifier"] = platformIdentifier
+	}
+	return summary
+}
+
+// sagemakerNotebookInstanceNetworkPosture summarizes network_posture from
+// whether the notebook is in a VPC (subnetId set) and whether direct internet
+// access is enabled: "public" (no VPC), "vpc-isolated" (VPC, no direct
+// internet), or "vpc-with-internet" (VPC, direct internet also enabled).
+func sagemakerNotebookInstanceNetworkPosture(subnetId, directInternetAccess string) string {
+	if subnetId == "" {
+		return "public"
+	}
+	if directInternetAccess == sagemaker.DirectInternetAccessEnabled {
+		return "vpc-with-internet"
+	}
+	return "vpc-isolated"
+}
+
+// sagemakerNotebookInstanceCloudwatchNamespace is the CloudWatch namespace
+// SageMaker publishes notebook instance metrics (CPUUtilization,
+// MemoryUtilization, DiskUtilization, etc.) under.
+const sagemakerNotebookInstanceCloudwatchNamespace = "/aws/sagemaker/NotebookInstances"
+
+// sagemakerNotebookInstanceCloudwatchDimensions returns the CloudWatch
+// dimension map identifying name's notebook instance metrics, for an
+// aws_cloudwatch_metric_alarm to reference directly instead of hardcoding
+// the NotebookInstanceName dimension key.
+func sagemakerNotebookInstanceCloudwatchDimensions(name string) map[string]string {
+	return map[string]string{
+		"NotebookInstanceName": name,
+	}
+}
+
+// sagemakerNotebookInstanceLogGroupName is the CloudWatch Logs group
+// SageMaker writes every notebook instance's logs to; unlike
+// sagemakerNotebookInstanceCloudwatchNamespace above (metrics), log group
+// names aren't per-notebook -- every instance shares this one group and is
+// distinguished only by its log stream prefix, exposed via log_stream_prefix.
+const sagemakerNotebookInstanceLogGroupName = "/aws/sagemaker/NotebookInstances"
+
+// sagemakerNotebookInstanceTagsWithoutAutoCostTags strips out any tag under
+// sagemakerNotebookInstanceCostTagPrefix, the same way
+// sagemakerTagsWithoutDefaults strips out default_tags: auto_cost_tags
+// injects these outside of config, so they're excluded from tags (but kept
+// in tags_all) to avoid a perpetual diff against a config that never
+// mentions them.
+func sagemakerNotebookInstanceTagsWithoutAutoCostTags(allTags map[string]string) map[string]string {
+	resourceTags := make(map[string]string)
+	for k, v := range allTags {
+		if !strings.HasPrefix(k, sagemakerNotebookInstanceCostTagPrefix) {
+			resourceTags[k] = v
+		}
+	}
+	return resourceTags
+}
+
+// sagemakerNotebookInstanceTagsWithoutInheritedRoleTags strips out any tag
+// whose key is listed in inheritKeys, the inherit_role_tags subset pulled in
+// from role_arn's own IAM role tags: like
+// sagemakerNotebookInstanceTagsWithoutAutoCostTags above, these are excluded
+// from tags (but kept in tags_all) so they never show up as drift against a
+// config that doesn't mention them.
+func sagemakerNotebookInstanceTagsWithoutInheritedRoleTags(allTags map[string]string, inheritKeys []*string) map[string]string {
+	if len(inheritKeys) == 0 {
+		return allTags
+	}
+
+	exclude := make(map[string]bool, len(inheritKeys))
+	for _, k := range inheritKeys {
+		exclude[aws.StringValue(k)] = true
+	}
+
+	resourceTags := make(map[string]string)
+	for k, v := range allTags {
+		if !exclude[k] {
+			resourceTags[k] = v
+		}
+	}
+	return resourceTags
+}
+
+// sagemakerNotebookInstanceSetAutoCostTags applies the auto_cost_tags tags
+// whenever they're enabled and something they're derived from (instance_type,
+// subnet_id, or auto_cost_tags itself) changed, so a notebook that moves
+// instance_type or subnet keeps accurate cost-allocation tags rather than
+// carrying forward stale ones from create. It only ever adds/updates these
+// two keys -- never a full SagemakerUpdateTags reconcile -- since they're not
+// part of the user's own tags diff.
+func sagemakerNotebookInstanceSetAutoCostTags(conn sagemakeriface.SageMakerAPI, d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("auto_cost_tags").(bool) {
+		return nil
+	}
+
+	if !d.HasChange("instance_type") && !d.HasChange("subnet_id") && !d.HasChange("auto_cost_tags") {
+		return nil
+	}
+
+	var subnetId *string
+	if s, ok := d.GetOk("subnet_id"); ok {
+		subnetId = aws.String(s.(string))
+	}
+	availabilityZone := sagemakerNotebookInstanceAvailabilityZone(meta.(*AWSClient).ec2conn, subnetId)
+
+	costTags := sagemakerNotebookInstanceAutoCostTags(d.Get("instance_type").(string), availabilityZone)
+	tags := make([]*sagemaker.Tag, 0, len(costTags))
+	for k, v := range costTags {
+		tags = append(tags, &sagemaker.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	if _, err := conn.AddTags(&sagemaker.AddTagsInput{
+		ResourceArn: aws.String(d.Get("arn").(string)),
+		Tags:        tags,
+	}); err != nil {
+		return fmt.Errorf("error setting auto_cost_tags for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceSetInheritedRoleTags applies inherit_role_tags
+// whenever it's non-empty and something it depends on (role_arn or
+// inherit_role_tags itself) changed, so a notebook that's reassigned to a
+// different execution role picks up that role's tags rather than carrying
+// forward the previous role's stale values. Like
+// sagemakerNotebookInstanceSetAutoCostTags, it only ever adds/updates the
+// inherited keys via AddTags -- never a full SagemakerUpdateTags reconcile --
+// since they're not part of the user's own tags diff.
+func sagemakerNotebookInstanceSetInheritedRoleTags(conn sagemakeriface.SageMakerAPI, d *schema.ResourceData, meta interface{}) error {
+	inheritKeys := expandStringSet(d.Get("inherit_role_tags").(*schema.Set))
+	if len(inheritKeys) == 0 {
+		return nil
+	}
+
+	if !d.HasChange("role_arn") && !d.HasChange("inherit_role_tags") {
+		return nil
+	}
+
+	inheritedTags, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(meta.(*AWSClient).iamconn, d.Get("role_arn").(string), inheritKeys)
+	if err != nil {
+		return fmt.Errorf("error inheriting role tags for notebook instance %q: %s", d.Id(), err)
+	}
+
+	tags := make([]*sagemaker.Tag, 0, len(inheritedTags))
+	for k, v := range inheritedTags {
+		tags = append(tags, &sagemaker.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := conn.AddTags(&sagemaker.AddTagsInput{
+		ResourceArn: aws.String(d.Get("arn").(string)),
+		Tags:        tags,
+	}); err != nil {
+		return fmt.Errorf("error setting inherit_role_tags for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceSetTags reconciles tags on Update according to the
+// tags_behavior field: "authoritative" deletes tags removed from config
+// (SagemakerUpdateTags's normal behavior, shared with every other SageMaker
+// resource), while "additive" only adds/updates configured tags and never
+// deletes one (SagemakerUpdateTagsAdditive).
+func sagemakerNotebookInstanceSetTags(conn sagemakeriface.SageMakerAPI, d *schema.ResourceData) error {
+	if !d.HasChange("tags") {
+		return nil
+	}
+
+	o, n := d.GetChange("tags")
+
+	if d.Get("tags_behavior").(string) == sagemakerNotebookInstanceTagsBehaviorAdditive {
+		return SagemakerUpdateTagsAdditive(conn, d.Get("arn").(string), o, n)
+	}
+
+	return SagemakerUpdateTags(conn, d.Get("arn").(string), o, n)
+}
+
+var sagemakerNotebookInstanceTypeShapeRegexp = regexp.MustCompile(`^ml\.[a-z0-9]+\.[a-z0-9]+$`)
+
+// sagemakerNotebookInstanceDeprecatedFamilies maps older notebook instance
+// families AWS has deprecated in newer regions to their recommended
+// replacement family, so this map is the one place to update as AWS
+// deprecates or recommends against additional families.
+var sagemakerNotebookInstanceDeprecatedFamilies = map[string]string{
+	"ml.t2": "ml.t3",
+	"ml.m4": "ml.m5",
+}
+
+// validateSagemakerNotebookInstanceType only hard-errors on strings that
+// can't possibly be a notebook instance type (missing the "ml." prefix or
+// otherwise malformed). A value that has the right shape but isn't in the
+// SDK's InstanceType enum is just warned about, since new instance types
+// regularly reach GA in some regions before the SDK is updated to know
+// about them. A value in a deprecated family is also just warned about,
+// since the family may still work in the configured region.
+func validateSagemakerNotebookInstanceType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !sagemakerNotebookInstanceTypeShapeRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a valid Sagemaker notebook instance type (e.g. ml.t2.medium), got: %s (did you mean %q?)", k, value, closestSagemakerNotebookInstanceType(value)))
+		return ws, errors
+	}
+
+	if family := strings.Join(strings.Split(value, ".")[:2], "."); sagemakerNotebookInstanceDeprecatedFamilies[family] != "" {
+		ws = append(ws, fmt.Sprintf("%q: instance family %q is deprecated in newer regions; consider %q instead", k, family, sagemakerNotebookInstanceDeprecatedFamilies[family]))
+	}
+
+	for _, t := range sagemaker.InstanceType_Values() {
+		if value == t {
+			return ws, errors
+		}
+	}
+
+	ws = append(ws, fmt.Sprintf("%q: %q is not a known Sagemaker instance type as of this provider version; if it was recently released, the create/update call may still succeed", k, value))
+	return ws, errors
+}
+
+// closestSagemakerNotebookInstanceType returns the valid notebook instance
+// type (per the SDK's InstanceType enum) with the smallest Levenshtein
+// distance to value, for the "did you mean" suggestion above. A malformed
+// value (the only case that reaches here) is usually just a typo'd or
+// truncated instance type, so this is worth the O(n) scan over the enum.
+func closestSagemakerNotebookInstanceType(value string) string {
+	var closest string
+	bestDistance := -1
+
+	for _, t := range sagemaker.InstanceType_Values() {
+		if distance := levenshteinDistance(value, t); bestDistance == -1 || distance < bestDistance {
+			closest = t
+			bestDistance = distance
+		}
+	}
+
+	return closest
+}
+
+// levenshteinDistance computes the edit distance between a and b -- the
+// minimum number of single-character insertions, deletions, or
+// substitutions needed to turn one into the other -- using the standard
+// Wagner-Fischer dynamic programming table with a rolling pair of rows
+// instead of the full matrix.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			deletion := prev[j] + 1
+			insertion := curr[j-1] + 1
+			substitution := prev[j-1] + cost
+
+			min := deletion
+			if insertion < min {
+				min = insertion
+			}
+			if substitution < min {
+				min = substitution
+			}
+			curr[j] = min
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// suppressEquivalentKmsKeyId suppresses the perpetual "forces replacement"
+// diff that occurs because DescribeNotebookInstance always returns the
+// resolved key ARN while users commonly configure kms_key_id as a bare key
+// ID or an "alias/..." name. A DiffSuppressFunc has no access to the KMS
+// client to resolve an alias itself, so an alias is trusted outright; a bare
+// key ID is compared against the trailing segment of the stored ARN.
+func suppressEquivalentKmsKeyId(k, old, new string, d *schema.ResourceData) bool {
+	if new == "" {
+		return old == new
+	}
+
+	return sagemakerNotebookInstanceKmsKeyIdsEquivalent(old, new)
+}
+
+// sagemakerNotebookInstanceKmsKeyIdsEquivalent is the comparison behind
+// suppressEquivalentKmsKeyId and resourceAwsSagemakerNotebookInstanceVerifyKmsKeyApplied:
+// stored is always the resolved key ARN DescribeNotebookInstance returns,
+// while configured is whatever form the user wrote kms_key_id in (a bare key
+// ID, a full ARN, or an "alias/..." name). An alias is trusted outright,
+// since resolving it would require a KMS call neither caller always has
+// reason to make; anything else is compared against the trailing segment of
+// the stored ARN.
+func sagemakerNotebookInstanceKmsKeyIdsEquivalent(stored, configured string) bool {
+	if stored == configured {
+		return true
+	}
+
+	if strings.HasPrefix(configured, "alias/") || strings.Contains(configured, ":alias/") {
+		return true
+	}
+
+	return strings.HasSuffix(stored, "/"+configured)
+}
+
+// isSagemakerNotebookInstanceNameConflictErr returns true when
+// CreateNotebookInstance failed because the name is already in use, so the
+// caller can point the user at `terraform import` instead of the API's
+// generic message.
+func isSagemakerNotebookInstanceNameConflictErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	if awsErr.Code() == "ResourceInUse" {
+		return true
+	}
+
+	return awsErr.Code() == "ValidationException" && strings.Contains(awsErr.Message(), "already exists")
+}
+
+// resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict handles
+// CreateNotebookInstance's lack of a client idempotency token: if a create
+// request actually succeeds on SageMaker's side but its response never
+// reaches Terraform (e.g. a network blip), the retried create fails with
+// isSagemakerNotebookInstanceNameConflictErr even though the existing
+// instance is exactly what this apply intended to create. This looks up
+// that existing instance and, only if its instance_type and role_arn
+// exactly match what's configured, adopts it into state instead of
+// erroring. Unlike the adopt_existing opt-in, this runs unconditionally
+// on a name conflict and never adopts on a mismatch, since a mismatch means
+// the conflict is a genuine naming collision with something else rather
+// than a safe-to-retry create.
+func resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI, name string) bool {
+	notebookInstance, err := finder.NotebookInstanceByName(conn, name)
+	if err != nil {
+		return false
+	}
+
+	if aws.StringValue(notebookInstance.InstanceType) != d.Get("instance_type").(string) {
+		return false
+	}
+	if aws.StringValue(notebookInstance.RoleArn) != d.Get("role_arn").(string) {
+		return false
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Notebook Instance %q already exists with a matching configuration; treating the create as a safe retry and adopting it into state", name)
+
+	return true
+}
+
+// resourceAwsSagemakerNotebookInstanceAdoptExisting is the adopt_existing
+// opt-in: it looks for a notebook instance already named name and, if one
+// exists, sets its ID into state instead of calling CreateNotebookInstance,
+// so a config applied from more than one pipeline converges onto the same
+// instance rather than failing on the name collision
+// isSagemakerNotebookInstanceNameConflictErr would otherwise turn into an
+// error. It warns, but does not fail, when the adopted instance's
+// instance_type or role_arn differs from what's declared, since Update will
+// reconcile that on the very next apply. Returns false (with no error) when
+// no existing instance is found, so the caller falls through to the normal
+// create path.
+func resourceAwsSagemakerNotebookInstanceAdoptExisting(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI, name string) (bool, error) {
+	notebookInstance, err := finder.NotebookInstanceByName(conn, name)
+	if err != nil {
+		if finder.IsResourceNotFoundErr(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error checking for an existing Sagemaker Notebook Instance %q to adopt: %s", name, err)
+	}
+
+	if v := d.Get("instance_type").(string); v != "" && v != aws.StringValue(notebookInstance.InstanceType) {
+		log.Printf("[WARN] Adopting existing Sagemaker Notebook Instance %q: configured instance_type %q differs from its current %q; plan again after apply to reconcile it", name, v, aws.StringValue(notebookInstance.InstanceType))
+	}
+
+	if v := d.Get("role_arn").(string); v != "" && v != aws.StringValue(notebookInstance.RoleArn) {
+		log.Printf("[WARN] Adopting existing Sagemaker Notebook Instance %q: configured role_arn %q differs from its current %q; plan again after apply to reconcile it", name, v, aws.StringValue(notebookInstance.RoleArn))
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Adopted existing Sagemaker Notebook Instance %q into state", name)
+
+	return true, nil
+}
+
+// isSagemakerNotebookInstanceDeleteConflictErr returns true when
+// DeleteNotebookInstance was rejected because the instance is still
+// transitioning through Stopping, so the caller can retry until SageMaker
+// finishes settling into Stopped instead of failing the delete outright.
+func isSagemakerNotebookInstanceDeleteConflictErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	if awsErr.Code() == "ResourceInUse" {
+		return true
+	}
+
+	return awsErr.Code() == "ValidationException" && strings.Contains(awsErr.Message(), "is not in a state from which it can be deleted")
+}
+
+// sagemakerNotebookInstanceApiError formats a failed SageMaker API call for
+// action against id. When err is an awserr.RequestFailure, the message
+// includes the status code and AWS request ID support engineers ask for,
+// rather than relying on those only showing up incidentally in err's own
+// Error() string.
+func sagemakerNotebookInstanceApiError(action, id string, err error) error {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return fmt.Errorf("%s Sagemaker Notebook Instance (%s): %s (status code: %d, request id: %s)", action, id, reqErr.Message(), reqErr.StatusCode(), reqErr.RequestID())
+	}
+
+	return fmt.Errorf("%s Sagemaker Notebook Instance (%s): %s", action, id, err)
+}
+
+// sagemakerNotebookInstanceInlineLifecycleConfigName is the name this
+// resource gives the implicit
+// aws_sagemaker_notebook_instance_lifecycle_configuration it creates and owns for inline_lifecycle_config: the notebook instance's
+// own name. Lifecycle config names only need to be unique per account, and
+// this keeps the implicit one trivially traceable back to the notebook that
+// owns it without a separate ID to track in state.
+func sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName string) string {
+	return notebookInstanceName
+}
+
+// expandSagemakerNotebookInstanceInlineLifecycleConfig builds the OnCreate/OnStart
+// hooks shared by Create/UpdateNotebookInstanceLifecycleConfigInput from an
+// inline_lifecycle_config block.
+func expandSagemakerNotebookInstanceInlineLifecycleConfig(l []interface{}) (onCreate, onStart []*sagemaker.NotebookInstanceLifecycleHook) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	if v, ok := m["on_create"].(string); ok && v != "" {
+		onCreate = []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String(v)}}
+	}
+
+	if v, ok := m["on_start"].(string); ok && v != "" {
+		onStart = []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String(v)}}
+	}
+
+	return onCreate, onStart
+}
+
+// resourceAwsSagemakerNotebookInstanceReadInlineLifecycleConfig re-reads the
+// implicit lifecycle config backing inline_lifecycle_config and sets it back
+// onto the block, mirroring resourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead's
+// own Content handling: a ResourceNotFoundException (the implicit config was
+// deleted out-of-band) clears the block instead of failing the read.
+func resourceAwsSagemakerNotebookInstanceReadInlineLifecycleConfig(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(d.Id())
+
+	lifecycleConfig, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return d.Set("inline_lifecycle_config", nil)
+		}
+		return fmt.Errorf("error reading inline lifecycle config for Sagemaker Notebook Instance %q: %s", d.Id(), err)
+	}
+
+	inline := map[string]interface{}{}
+	if len(lifecycleConfig.OnCreate) > 0 {
+		inline["on_create"] = aws.StringValue(lifecycleConfig.OnCreate[0].Content)
+	}
+	if len(lifecycleConfig.OnStart) > 0 {
+		inline["on_start"] = aws.StringValue(lifecycleConfig.OnStart[0].Content)
+	}
+
+	if err := d.Set("inline_lifecycle_config", []map[string]interface{}{inline}); err != nil {
+		return fmt.Errorf("error setting inline_lifecycle_config for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceLifecycleConfigContentSha describes the named
+// lifecycle config and returns a hex-encoded sha256 of its on_create/on_start
+// content, so resourceAwsSagemakerNotebookInstanceRead can surface a diff on
+// lifecycle_config_content_sha when a separately-managed lifecycle config's
+// content changes -- something lifecycle_config_name alone can't detect,
+// since Terraform only tracks the name, not the config's own content. A
+// ResourceNotFoundException (the config was deleted out-of-band) is reported
+// as an empty hash rather than an error, matching how lifecycle_config_name
+// itself tolerates that case elsewhere in this file.
+func sagemakerNotebookInstanceLifecycleConfigContentSha(conn sagemakeriface.SageMakerAPI, name string) (string, error) {
+	lifecycleConfig, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var onCreate, onStart string
+	if len(lifecycleConfig.OnCreate) > 0 {
+		onCreate = aws.StringValue(lifecycleConfig.OnCreate[0].Content)
+	}
+	if len(lifecycleConfig.OnStart) > 0 {
+		onStart = aws.StringValue(lifecycleConfig.OnStart[0].Content)
+	}
+
+	sum := sha256.Sum256([]byte(onCreate + onStart))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createSagemakerNotebookInstanceInlineLifecycleConfig creates the implicit
+// lifecycle config backing inline_lifecycle_config, returning its name for
+// CreateNotebookInstanceInput.LifecycleConfigName.
+func createSagemakerNotebookInstanceInlineLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName string, l []interface{}) (string, error) {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+	onCreate, onStart := expandSagemakerNotebookInstanceInlineLifecycleConfig(l)
+
+	_, err := conn.CreateNotebookInstanceLifecycleConfig(&sagemaker.CreateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+		OnCreate:                            onCreate,
+		OnStart:                             onStart,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating inline lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return name, nil
+}
+
+// updateSagemakerNotebookInstanceInlineLifecycleConfig pushes a changed
+// inline_lifecycle_config to the implicit lifecycle config already backing
+// it.
+func updateSagemakerNotebookInstanceInlineLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName string, l []interface{}) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+	onCreate, onStart := expandSagemakerNotebookInstanceInlineLifecycleConfig(l)
+
+	if _, err := conn.UpdateNotebookInstanceLifecycleConfig(&sagemaker.UpdateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+		OnCreate:                            onCreate,
+		OnStart:                             onStart,
+	}); err != nil {
+		return fmt.Errorf("error updating inline lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return nil
+}
+
+// deleteSagemakerNotebookInstanceInlineLifecycleConfig deletes the implicit
+// lifecycle config backing inline_lifecycle_config. A ResourceNotFoundException
+// is tolerated the same way resourceAwsSagemakerNotebookInstanceLifecycleConfigurationDelete
+// tolerates it elsewhere -- there's nothing left to clean up.
+func deleteSagemakerNotebookInstanceInlineLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName string) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+
+	if _, err := conn.DeleteNotebookInstanceLifecycleConfig(&sagemaker.DeleteNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return nil
+		}
+		return fmt.Errorf("error deleting inline lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return nil
+}
+
+const (
+	sagemakerNotebookInstanceDefaultUiJupyter    = "Jupyter"
+	sagemakerNotebookInstanceDefaultUiJupyterLab = "JupyterLab"
+)
+
+// sagemakerNotebookInstanceDefaultUiOnStartScript returns the on_start
+// lifecycle hook script that makes defaultUi the notebook instance's default
+// experience. There's no CreateNotebookInstanceInput/UpdateNotebookInstanceInput
+// field for this, so it's done the same way a human would: writing Jupyter's
+// own default_url setting into jupyter_notebook_config.py on every start, so
+// it takes effect (and keeps taking effect across stop/start cycles) without
+// depending on what was there from a previous AMI or lifecycle config.
+func sagemakerNotebookInstanceDefaultUiOnStartScript(defaultUi string) string {
+	defaultUrl := "/tree"
+	if defaultUi == sagemakerNotebookInstanceDefaultUiJupyterLab {
+		defaultUrl = "/lab"
+	}
+
+	return fmt.Sprintf(`#!/bin/bash
+set -e
+echo "c.NotebookApp.default_url = '%s'" >> /home/ec2-user/.jupyter/jupyter_notebook_config.py
+`, defaultUrl)
+}
+
+// sagemakerNotebookInstanceDefaultUiFromOnStartScript is the inverse of
+// sagemakerNotebookInstanceDefaultUiOnStartScript, recovering the default_ui
+// value from a lifecycle config's on_start content for Read. It returns ""
+// for content this resource didn't generate (out-of-band edits, or no
+// on_start at all), which surfaces as drift against the default_ui in state
+// rather than silently keeping the stale value.
+func sagemakerNotebookInstanceDefaultUiFromOnStartScript(script string) string {
+	switch {
+	case strings.Contains(script, "default_url = '/lab'"):
+		return sagemakerNotebookInstanceDefaultUiJupyterLab
+	case strings.Contains(script, "default_url = '/tree'"):
+		return sagemakerNotebookInstanceDefaultUiJupyter
+	default:
+		return ""
+	}
+}
+
+// createSagemakerNotebookInstanceDefaultUiLifecycleConfig creates the
+// implicit lifecycle config backing default_ui, returning its name for
+// CreateNotebookInstanceInput.LifecycleConfigName. It reuses
+// sagemakerNotebookInstanceInlineLifecycleConfigName's naming since
+// default_ui and inline_lifecycle_config are mutually exclusive
+// (ConflictsWith in the schema), so at most one of them ever owns the
+// notebook-instance-name-keyed implicit config at a time.
+func createSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName, defaultUi string) (string, error) {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+
+	_, err := conn.CreateNotebookInstanceLifecycleConfig(&sagemaker.CreateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+		OnStart: []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(sagemakerNotebookInstanceDefaultUiOnStartScript(defaultUi))},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating default_ui lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return name, nil
+}
+
+// updateSagemakerNotebookInstanceDefaultUiLifecycleConfig pushes a changed
+// default_ui to the implicit lifecycle config already backing it.
+func updateSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName, defaultUi string) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+
+	if _, err := conn.UpdateNotebookInstanceLifecycleConfig(&sagemaker.UpdateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+		OnStart: []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(sagemakerNotebookInstanceDefaultUiOnStartScript(defaultUi))},
+		},
+	}); err != nil {
+		return fmt.Errorf("error updating default_ui lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return nil
+}
+
+// deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig deletes the
+// implicit lifecycle config backing default_ui. A ResourceNotFoundException
+// is tolerated the same way deleteSagemakerNotebookInstanceInlineLifecycleConfig
+// tolerates it -- there's nothing left to clean up.
+func deleteSagemakerNotebookInstanceDefaultUiLifecycleConfig(conn sagemakeriface.SageMakerAPI, notebookInstanceName string) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(notebookInstanceName)
+
+	if _, err := conn.DeleteNotebookInstanceLifecycleConfig(&sagemaker.DeleteNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return nil
+		}
+		return fmt.Errorf("error deleting default_ui lifecycle config for Sagemaker Notebook Instance %q: %s", notebookInstanceName, err)
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceReadDefaultUi re-reads the implicit
+// lifecycle config backing default_ui and sets the recovered value back onto
+// it, mirroring resourceAwsSagemakerNotebookInstanceReadInlineLifecycleConfig's
+// own ResourceNotFoundException handling: if the implicit config was deleted
+// out-of-band, default_ui is cleared instead of failing the read.
+func resourceAwsSagemakerNotebookInstanceReadDefaultUi(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI) error {
+	name := sagemakerNotebookInstanceInlineLifecycleConfigName(d.Id())
+
+	lifecycleConfig, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return d.Set("default_ui", "")
+		}
+		return fmt.Errorf("error reading default_ui lifecycle config for Sagemaker Notebook Instance %q: %s", d.Id(), err)
+	}
+
+	defaultUi := ""
+	if len(lifecycleConfig.OnStart) > 0 {
+		defaultUi = sagemakerNotebookInstanceDefaultUiFromOnStartScript(aws.StringValue(lifecycleConfig.OnStart[0].Content))
+	}
+
+	if err := d.Set("default_ui", defaultUi); err != nil {
+		return fmt.Errorf("error setting default_ui for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// isSagemakerIamEventualConsistencyErr returns true for ValidationExceptions
+// caused by IAM's eventual consistency, which occur when role_arn was just
+// created in the same apply and has not yet propagated for SageMaker to assume.
+func isSagemakerIamEventualConsistencyErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "ValidationException" {
+		return false
+	}
+
+	return strings.Contains(awsErr.Message(), "cannot be assumed") || strings.Contains(awsErr.Message(), "does not have permissions")
+}
+
+// isSagemakerAccessDeniedErr returns true for AccessDeniedException/AccessDenied,
+// the error codes SageMaker and its dependencies (IAM, STS) use for an
+// authorization failure, as opposed to the resource simply not existing.
+func isSagemakerAccessDeniedErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	return awsErr.Code() == "AccessDeniedException" || awsErr.Code() == "AccessDenied"
+}
+
+// isSagemakerResourceLimitExceededErr returns true when CreateNotebookInstance
+// rejected the request because the account is already at its "Number of
+// notebook instances" Service Quota -- distinct from, and indistinguishable
+// by message alone from, an AZ running out of actual EC2 capacity for the
+// requested instance_type, which is what isSagemakerInsufficientCapacityErr
+// below detects instead.
+func isSagemakerResourceLimitExceededErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	return awsErr.Code() == "ResourceLimitExceeded"
+}
+
+// isSagemakerInsufficientCapacityErr returns true when a notebook instance's
+// FailureReason indicates AWS couldn't find capacity for the requested
+// instance_type, so the caller can point the user at a different
+// instance_type or region instead of just surfacing the raw API message.
+func isSagemakerInsufficientCapacityErr(failureReason string) bool {
+	return strings.Contains(failureReason, "InsufficientInstanceCapacity") || strings.Contains(failureReason, "insufficient capacity")
+}
+
+// resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback is the
+// subnet_ids counterpart of the single-subnet CreateNotebookInstance call in
+// Create: it tries each candidate subnet in order, mutating createOpts.SubnetId
+// in place between attempts, and only moves on to the next candidate when an
+// attempt fails specifically on insufficient capacity -- a bad subnet_id, IAM
+// problem, or any other failure is returned immediately rather than masked by
+// retrying against a different AZ. A capacity-failed instance is always in a
+// terminal Failed state by the time this can tell, so it's cleaned up with a
+// best-effort DeleteNotebookInstance before moving on, so it can't collide
+// with a later attempt.
+// Takes only conn and plain values, not meta, so it can be unit tested without
+// a real *AWSClient. Returns the successful CreateNotebookInstanceOutput and
+// the subnet_id that produced it, or the final candidate's error.
+func resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn sagemakeriface.SageMakerAPI, createOpts *sagemaker.CreateNotebookInstanceInput, candidateSubnetIds []string, timeout time.Duration, pollInterval time.Duration) (*sagemaker.CreateNotebookInstanceOutput, string, error) {
+	name := aws.StringValue(createOpts.NotebookInstanceName)
+
+	for i, subnetId := range candidateSubnetIds {
+		createOpts.SubnetId = aws.String(subnetId)
+
+		var createOutput *sagemaker.CreateNotebookInstanceOutput
+		err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+			var out *sagemaker.CreateNotebookInstanceOutput
+			err := retryOnSagemakerThrottle(func() error {
+				var err error
+				out, err = conn.CreateNotebookInstance(createOpts)
+				return err
+			})
+			if err != nil {
+				if isSagemakerIamEventualConsistencyErr(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			createOutput = out
+			return nil
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		waitErr := WaitNotebookInstanceInService(context.Background(), conn, name, timeout, pollInterval)
+		if waitErr == nil {
+			return createOutput, subnetId, nil
+		}
+
+		notebookInstanceRaw, status, describeErr := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, name)()
+		if describeErr != nil || status != sagemaker.NotebookInstanceStatusFailed {
+			return nil, "", fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to start on subnet %q: %s", name, subnetId, waitErr)
+		}
+
+		failureReason := aws.StringValue(notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput).FailureReason)
+		if !isSagemakerInsufficientCapacityErr(failureReason) || i == len(candidateSubnetIds)-1 {
+			return nil, "", fmt.Errorf("error creating Sagemaker Notebook Instance (%s) on subnet %q: %s", name, subnetId, failureReason)
+		}
+
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: insufficient capacity on subnet %q (%s), trying next candidate subnet", name, subnetId, failureReason)
+		if _, deleteErr := conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{NotebookInstanceName: aws.String(name)}); deleteErr != nil {
+			log.Printf("[WARN] Error deleting Sagemaker Notebook Instance %q after insufficient capacity on subnet %q: %s", name, subnetId, deleteErr)
+		}
+	}
+
+	// Unreachable: the loop above always returns on its final iteration, one
+	// way or another. Only here to satisfy the compiler.
+	return nil, "", fmt.Errorf("error creating Sagemaker Notebook Instance (%s): ran out of candidate subnets", name)
+}
+
+// sagemakerNotebookInstanceSchedulable is the pure check behind the
+// schedulable attribute: true only when status is one of the two steady
+// states a stop/start action actually applies to, false for every
+// mid-transition status (Pending, Stopping, Starting, Updating, Deleting,
+// Failed, ...), so a tag-driven scheduler can check it before attempting an
+// action that would otherwise fail against an instance that isn't settled.
+func sagemakerNotebookInstanceSchedulable(status string) bool {
+	return status == sagemaker.NotebookInstanceStatusInService || status == sagemaker.NotebookInstanceStatusStopped
+}
+
+// sagemakerNotebookInstanceAvailabilityZone looks up the availability zone
+// of subnetId via EC2. Lookup is best-effort: any error (including no
+// subnet configured) just leaves availability_zone unset rather than
+// failing the whole Read, since it's informational only.
+func sagemakerNotebookInstanceAvailabilityZone(conn ec2iface.EC2API, subnetId *string) string {
+	if aws.StringValue(subnetId) == "" {
+		return ""
+	}
+
+	out, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{subnetId},
+	})
+	if err != nil || len(out.Subnets) == 0 {
+		log.Printf("[WARN] Unable to look up availability zone for subnet %s: %s", aws.StringValue(subnetId), err)
+		return ""
+	}
+
+	return aws.StringValue(out.Subnets[0].AvailabilityZone)
+}
+
+// sagemakerNotebookInstanceVpcId looks up the VPC ID of subnetId via EC2,
+// the same best-effort way sagemakerNotebookInstanceAvailabilityZone looks
+// up the availability zone: any error (including no subnet configured, or a
+// permissions gap) just leaves vpc_id unset rather than failing Read, since
+// it's informational only.
+func sagemakerNotebookInstanceVpcId(conn ec2iface.EC2API, subnetId *string) string {
+	if aws.StringValue(subnetId) == "" {
+		return ""
+	}
+
+	out, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{subnetId},
+	})
+	if err != nil || len(out.Subnets) == 0 {
+		log.Printf("[WARN] Unable to look up VPC ID for subnet %s: %s", aws.StringValue(subnetId), err)
+		return ""
+	}
+
+	return aws.StringValue(out.Subnets[0].VpcId)
+}
+
+// resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability is a
+// best-effort preflight, run only when subnet_id is set: recreating a VPC
+// notebook deletes the old ENI and creates a new one, which can briefly hold
+// both IPs at once, so a small subnet can run critically low on addresses
+// right when the new ENI is created. This looks up the subnet's available IP
+// count and logs a warning if it's critically low; any lookup failure is
+// itself just logged, since this is informational only and must never block
+// a create.
+func resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability(conn ec2iface.EC2API, subnetId string) {
+	out, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{aws.String(subnetId)},
+	})
+	if err != nil || len(out.Subnets) == 0 {
+		log.Printf("[WARN] Unable to check available IP addresses for subnet %s: %s", subnetId, err)
+		return
+	}
+
+	if msg := sagemakerNotebookInstanceSubnetIpExhaustionWarning(subnetId, aws.Int64Value(out.Subnets[0].AvailableIpAddressCount)); msg != "" {
+		log.Printf("[WARN] %s", msg)
+	}
+}
+
+// sagemakerNotebookInstanceSubnetIpExhaustionWarning returns a non-empty
+// warning message when availableIpAddressCount is critically low, pulled out
+// of resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability so the
+// threshold logic is unit-testable without a real EC2 client.
+func sagemakerNotebookInstanceSubnetIpExhaustionWarning(subnetId string, availableIpAddressCount int64) string {
+	if availableIpAddressCount >= 2 {
+		return ""
+	}
+
+	return fmt.Sprintf("subnet %s has only %d available IP address(es); recreating this notebook instance's ENI may fail if the subnet runs out of addresses", subnetId, availableIpAddressCount)
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyKmsKey is a best-effort pre-flight
+// check, run only when kms_key_id is set, so unencrypted notebooks don't pay
+// for an extra API call: it describes the key and fails fast with a clear
+// error if it's missing or disabled, rather than letting CreateNotebookInstance
+// fail with a more cryptic error once SageMaker actually tries to use the key.
+// DescribeKey doesn't reveal whether the execution role has a usable grant --
+// that's only known once SageMaker itself attempts the encrypt/decrypt call --
+// so an AccessDenied here (the caller's own permissions, not the execution
+// role's) is treated as inconclusive and logged rather than failed, to
+// avoid blocking a create the role is otherwise fully able to complete.
+func resourceAwsSagemakerNotebookInstanceVerifyKmsKey(kmsconn kmsiface.KMSAPI, keyId string) error {
+	out, err := kmsconn.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyId),
+	})
+	if err != nil {
+		if isSagemakerAccessDeniedErr(err) {
+			log.Printf("[WARN] Not authorized to describe KMS key %q, skipping pre-flight check: missing kms:DescribeKey permission: %s", keyId, err)
+			return nil
+		}
+		return fmt.Errorf("error verifying kms_key_id %q before creating Sagemaker Notebook Instance: %s", keyId, err)
+	}
+
+	if !aws.BoolValue(out.KeyMetadata.Enabled) {
+		return fmt.Errorf("kms_key_id %q is disabled; SageMaker cannot encrypt the notebook instance's EBS volume with a disabled key", keyId)
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceSecurityGroupHash normalizes a
+// security group ID before hashing it into the security_groups set, so two
+// HCL expressions that resolve to the same ID (e.g. a literal ID typed with
+// different case, or a resource reference with incidental surrounding
+// whitespace from an interpolation) collapse into the same set member
+// instead of producing a spurious diff.
+func resourceAwsSagemakerNotebookInstanceSecurityGroupHash(v interface{}) int {
+	return hashcode.String(strings.ToLower(strings.TrimSpace(v.(string))))
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc is a
+// pre-flight check, run only when both subnet_id and security_groups are
+// set so the extra describes aren't paid for a notebook without a VPC: it
+// resolves subnetId's VPC and each security group's VPC and fails fast,
+// naming the offending security group, if any of them don't match. Without
+// this, the same misconfiguration only surfaces once CreateNotebookInstance
+// itself rejects it with AWS's own, far less actionable, "security group is
+// not in the same VPC" error.
+func resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(ec2conn ec2iface.EC2API, subnetId string, securityGroupIds []*string) error {
+	subnetOut, err := ec2conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{aws.String(subnetId)},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing subnet %q before creating Sagemaker Notebook Instance: %s", subnetId, err)
+	}
+	if len(subnetOut.Subnets) == 0 {
+		return fmt.Errorf("subnet %q not found", subnetId)
+	}
+	subnetVpcId := aws.StringValue(subnetOut.Subnets[0].VpcId)
+
+	sgOut, err := ec2conn.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: securityGroupIds,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing security_groups before creating Sagemaker Notebook Instance: %s", err)
+	}
+
+	for _, sg := range sgOut.SecurityGroups {
+		if vpcId := aws.StringValue(sg.VpcId); vpcId != subnetVpcId {
+			return fmt.Errorf("security group %q is in VPC %q, but subnet_id %q is in VPC %q; security_groups must belong to the same VPC as subnet_id", aws.StringValue(sg.GroupId), vpcId, subnetId, subnetVpcId)
+		}
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture is a
+// best-effort post-create check for VPC notebooks: it describes the ENI
+// SageMaker attached (via network_interface_id) and warns -- without
+// failing the apply -- if its actual subnet or security groups don't match
+// what was configured. SageMaker is the one that provisions the ENI, so
+// this isn't expected to ever actually mismatch; it exists to surface a
+// silently-dropped security group or similar control-plane oddity instead
+// of leaving it to be discovered later as an unexplained connectivity or
+// access problem.
+func resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(d *schema.ResourceData, ec2conn ec2iface.EC2API) {
+	networkInterfaceId := d.Get("network_interface_id").(string)
+	if networkInterfaceId == "" {
+		return
+	}
+
+	out, err := ec2conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []*string{aws.String(networkInterfaceId)},
+	})
+	if err != nil || len(out.NetworkInterfaces) == 0 {
+		log.Printf("[WARN] Unable to verify ENI %s security posture for Sagemaker Notebook Instance %q: %s", networkInterfaceId, d.Id(), err)
+		return
+	}
+
+	eni := out.NetworkInterfaces[0]
+
+	if wantSubnetId := d.Get("subnet_id").(string); wantSubnetId != "" && aws.StringValue(eni.SubnetId) != wantSubnetId {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: ENI %s is in subnet %q, expected %q", d.Id(), networkInterfaceId, aws.StringValue(eni.SubnetId), wantSubnetId)
+	}
+
+	wantGroups := expandStringSet(d.Get("security_groups").(*schema.Set))
+	gotGroups := make(map[string]bool, len(eni.Groups))
+	for _, g := range eni.Groups {
+		gotGroups[aws.StringValue(g.GroupId)] = true
+	}
+
+	for _, want := range wantGroups {
+		if !gotGroups[aws.StringValue(want)] {
+			log.Printf("[WARN] Sagemaker Notebook Instance %q: ENI %s is missing expected security group %s", d.Id(), networkInterfaceId, aws.StringValue(want))
+		}
+	}
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget is the opt-in
+// best-effort check behind verify_efs_mount_target_for_file_system_id: it
+// lists the given EFS file system's mount targets and warns -- without
+// failing the apply -- if none of them is in this notebook's subnet, since
+// SageMaker can only reach EFS through a mount target in the same subnet
+// (or the same AZ, for One Zone file systems) as the notebook's ENI. A
+// missing mount target is the most common cause of a lifecycle config's EFS
+// mount silently failing, which otherwise only surfaces later as a missing
+// directory inside the notebook.
+func resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d *schema.ResourceData, efsconn efsiface.EFSAPI) {
+	fileSystemId := d.Get("verify_efs_mount_target_for_file_system_id").(string)
+	if fileSystemId == "" {
+		return
+	}
+
+	subnetId := d.Get("subnet_id").(string)
+	if subnetId == "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: verify_efs_mount_target_for_file_system_id is set but the notebook has no subnet_id, so it cannot reach any EFS mount target", d.Id())
+		return
+	}
+
+	out, err := efsconn.DescribeMountTargets(&efs.DescribeMountTargetsInput{
+		FileSystemId: aws.String(fileSystemId),
+	})
+	if err != nil {
+		log.Printf("[WARN] Unable to verify EFS mount target for file system %q for Sagemaker Notebook Instance %q: %s", fileSystemId, d.Id(), err)
+		return
+	}
+
+	for _, mountTarget := range out.MountTargets {
+		if aws.StringValue(mountTarget.SubnetId) == subnetId {
+			return
+		}
+	}
+
+	log.Printf("[WARN] Sagemaker Notebook Instance %q: no mount target for EFS file system %q was found in subnet %q; any lifecycle config that mounts it will likely fail silently", d.Id(), fileSystemId, subnetId)
+}
+
+// sagemakerNotebookInstanceUrlReachableTimeout bounds the best-effort HTTP
+// check in sagemakerNotebookInstanceUrlReachable, kept short since this runs
+// synchronously during Create and a hung request shouldn't delay the apply.
+const sagemakerNotebookInstanceUrlReachableTimeout = 5 * time.Second
+
+// sagemakerNotebookInstanceUrlReachableClient issues the HEAD request behind
+// sagemakerNotebookInstanceUrlReachable. It's a package var, not a literal
+// http.Client, purely so tests can swap it out.
+var sagemakerNotebookInstanceUrlReachableClient = &http.Client{Timeout: sagemakerNotebookInstanceUrlReachableTimeout}
+
+// sagemakerNotebookInstanceUrlReachable does a best-effort HTTP HEAD against
+// url (an https:// URL with no scheme, as returned by
+// DescribeNotebookInstance's Url field) and returns an error describing why
+// it doesn't look reachable -- a request error, or a 5xx response, which is
+// what an InService-but-degraded Jupyter server returns.
+func sagemakerNotebookInstanceUrlReachable(url string) error {
+	if url == "" {
+		return fmt.Errorf("no url available yet")
+	}
+
+	resp, err := sagemakerNotebookInstanceUrlReachableClient.Head("https://" + url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("received HTTP status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyKmsKeyApplied is a post-create
+// check, run only when kms_key_id is set, that the EBS volume actually ended
+// up encrypted with the configured key rather than SageMaker silently
+// falling back to a different one. Unlike the best-effort Verify* checks
+// above, this one returns a hard error: a silently-applied default key
+// instead of the configured one is a compliance violation, not a cosmetic
+// mismatch to warn about and move on from.
+func resourceAwsSagemakerNotebookInstanceVerifyKmsKeyApplied(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI) error {
+	keyId := d.Get("kms_key_id").(string)
+	if keyId == "" {
+		return nil
+	}
+
+	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("error verifying kms_key_id was applied to Sagemaker Notebook Instance %q: %s", d.Id(), err)
+	}
+	if notebookInstanceRaw == nil {
+		return fmt.Errorf("error verifying kms_key_id was applied to Sagemaker Notebook Instance %q: instance not found", d.Id())
+	}
+
+	gotKeyId := aws.StringValue(notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput).KmsKeyId)
+	return sagemakerNotebookInstanceVerifyKmsKeyIdMatches(d.Id(), keyId, gotKeyId)
+}
+
+// sagemakerNotebookInstanceVerifyKmsKeyIdMatches is the pure check behind
+// resourceAwsSagemakerNotebookInstanceVerifyKmsKeyApplied.
+func sagemakerNotebookInstanceVerifyKmsKeyIdMatches(id, configuredKeyId, gotKeyId string) error {
+	if sagemakerNotebookInstanceKmsKeyIdsEquivalent(gotKeyId, configuredKeyId) {
+		return nil
+	}
+
+	return fmt.Errorf("Sagemaker Notebook Instance %q: configured kms_key_id %q was not applied to the EBS volume; DescribeNotebookInstance reports %q instead, which means SageMaker silently fell back to a different key", id, configuredKeyId, gotKeyId)
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyUrlReachable is the
+// verify_url_reachable opt-in: it re-describes the notebook instance to get
+// its current Url and warns, but never fails the apply, if
+// sagemakerNotebookInstanceUrlReachable reports it's not reachable. Errors
+// from the describe call itself are also only logged: this check is purely
+// advisory and the notebook has already successfully reached InService by
+// the time it runs.
+func resourceAwsSagemakerNotebookInstanceVerifyUrlReachable(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI) {
+	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, d.Id())()
+	if err != nil || notebookInstanceRaw == nil {
+		log.Printf("[WARN] Unable to verify url reachability for Sagemaker Notebook Instance %q: %s", d.Id(), err)
+		return
+	}
+
+	url := aws.StringValue(notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput).Url)
+	if err := sagemakerNotebookInstanceUrlReachable(url); err != nil {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: url %q does not appear reachable: %s", d.Id(), url, err)
+	}
+}
+
+// sagemakerNotebookInstanceNotifyStatus publishes a short status message to
+// topicArn for notify_sns_topic_arn, once Create or Update completes with a
+// final status (InService or Failed). A no-op when topicArn is empty. Any
+// publish failure is only logged: by the time this runs the resource has
+// already succeeded or failed on its own terms, and erroring out over a
+// failed notification would bury that real outcome.
+func sagemakerNotebookInstanceNotifyStatus(snsconn snsiface.SNSAPI, topicArn, name, status string) {
+	if topicArn == "" {
+		return
+	}
+
+	if _, err := snsconn.Publish(&sns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String(sagemakerNotebookInstanceStatusNotificationMessage(name, status)),
+	}); err != nil {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: failed to publish status notification to %s: %s", name, topicArn, err)
+	}
+}
+
+// sagemakerNotebookInstanceStatusNotificationMessage builds the message body
+// sagemakerNotebookInstanceNotifyStatus publishes, extracted out so its
+// content is testable without an snsiface.SNSAPI.
+func sagemakerNotebookInstanceStatusNotificationMessage(name, status string) string {
+	return fmt.Sprintf("Sagemaker Notebook Instance %q: %s", name, status)
+}
+
+// resourceAwsSagemakerNotebookInstancePresignedUrl is the
+// generate_presigned_url opt-in: while it's set and the notebook instance is
+// InService, it calls CreatePresignedNotebookInstanceUrl and returns the
+// resulting URL for Read to store; otherwise it returns an empty string so
+// presigned_url doesn't hold a stale credential for an instance that can no
+// longer redeem it.
+func resourceAwsSagemakerNotebookInstancePresignedUrl(d *schema.ResourceData, conn sagemakeriface.SageMakerAPI, status string) (string, error) {
+	if !d.Get("generate_presigned_url").(bool) || status != sagemaker.NotebookInstanceStatusInService {
+		return "", nil
+	}
+
+	output, err := conn.CreatePresignedNotebookInstanceUrl(&sagemaker.CreatePresignedNotebookInstanceUrlInput{
+		NotebookInstanceName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating presigned URL for Sagemaker Notebook Instance (%s): %s", d.Id(), err)
+	}
+
+	return aws.StringValue(output.AuthorizedUrl), nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCheckSsmManaged is the
+// check_ssm_managed opt-in: it looks the notebook instance up in SSM's
+// managed instance inventory and sets ssm_managed from whether it was
+// found. Neither an error from the lookup nor not finding the instance
+// fails the apply -- the instance only shows up in SSM once its
+// lifecycle-configuration-installed agent has had time to register, which
+// can lag well behind InService.
+func resourceAwsSagemakerNotebookInstanceCheckSsmManaged(d *schema.ResourceData, conn ssmiface.SSMAPI) {
+	output, err := conn.DescribeInstanceInformation(&ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{
+			{
+				Key:    aws.String("InstanceIds"),
+				Values: []*string{aws.String(d.Id())},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("[WARN] Unable to check SSM-managed status for Sagemaker Notebook Instance %q: %s", d.Id(), err)
+		return
+	}
+
+	managed := sagemakerNotebookInstanceSsmManaged(output)
+	if err := d.Set("ssm_managed", managed); err != nil {
+		log.Printf("[WARN] Unable to set ssm_managed for Sagemaker Notebook Instance %q: %s", d.Id(), err)
+	}
+}
+
+// sagemakerNotebookInstanceSsmManaged is the pure check behind
+// resourceAwsSagemakerNotebookInstanceCheckSsmManaged: the notebook instance
+// is considered SSM-managed when DescribeInstanceInformation returned at
+// least one matching entry.
+func sagemakerNotebookInstanceSsmManaged(output *ssm.DescribeInstanceInformationOutput) bool {
+	return output != nil && len(output.InstanceInformationList) > 0
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets is the
+// verify_code_repo_secrets opt-in: for each configured
+// default_code_repository/additional_code_repositories reference that
+// resolves to an aws_sagemaker_code_repository with a secret_arn, it
+// confirms the secret is still retrievable via Secrets Manager DescribeSecret
+// and warns, but never fails the apply, if it isn't -- a bad secret
+// otherwise only surfaces as a silent clone failure once the notebook
+// actually tries to use it (see
+// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure).
+func resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d *schema.ResourceData, sagemakerconn sagemakeriface.SageMakerAPI, secretsmanagerconn secretsmanageriface.SecretsManagerAPI) {
+	var refs []string
+	if v := d.Get("default_code_repository").(string); v != "" {
+		refs = append(refs, v)
+	}
+	for _, v := range d.Get("additional_code_repositories").(*schema.Set).List() {
+		refs = append(refs, v.(string))
+	}
+
+	for _, ref := range refs {
+		name, ok := sagemakerCodeRepositoryNameFromRef(sagemakerconn, ref)
+		if !ok {
+			log.Printf("[WARN] Sagemaker Notebook Instance %q: unable to resolve code repository reference %q to verify its secret_arn", d.Id(), ref)
+			continue
+		}
+
+		out, err := sagemakerconn.DescribeCodeRepository(&sagemaker.DescribeCodeRepositoryInput{
+			CodeRepositoryName: aws.String(name),
+		})
+		if err != nil {
+			log.Printf("[WARN] Sagemaker Notebook Instance %q: unable to describe code repository %q to verify its secret_arn: %s", d.Id(), name, err)
+			continue
+		}
+
+		if out.GitConfig == nil || aws.StringValue(out.GitConfig.SecretArn) == "" {
+			continue
+		}
+
+		secretArn := aws.StringValue(out.GitConfig.SecretArn)
+		if _, err := secretsmanagerconn.DescribeSecret(&secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(secretArn),
+		}); err != nil {
+			log.Printf("[WARN] Sagemaker Notebook Instance %q: code repository %q secret_arn %q is not retrievable, clones using it may fail: %s", d.Id(), name, secretArn, err)
+		}
+	}
+}
+
+// isSagemakerNotebookInstanceStatusInFlux reports whether status is a
+// transitional state in which the API has not yet populated fields like
+// NotebookInstanceUrl and NetworkInterfaceId, which only become meaningful
+// once the instance reaches InService.
+func isSagemakerNotebookInstanceStatusInFlux(status string) bool {
+	switch status {
+	case sagemaker.NotebookInstanceStatusPending, sagemaker.NotebookInstanceStatusUpdating:
+		return true
+	default:
+		return false
+	}
+}
+
+// sagemakerNotebookInstanceRestartAfterFailedUpdate tries to start a notebook
+// instance back up after UpdateNotebookInstance failed on an instance that
+// was InService before the update began. It only logs on failure rather than
+// returning an error, since the caller is already about to return the
+// original update error and a failed restart attempt shouldn't mask it.
+func sagemakerNotebookInstanceRestartAfterFailedUpdate(conn sagemakeriface.SageMakerAPI, id string, timeout, pollInterval time.Duration) {
+	if err := retryOnSagemakerThrottle(func() error {
+		_, err := conn.StartNotebookInstance(&sagemaker.StartNotebookInstanceInput{
+			NotebookInstanceName: aws.String(id),
+		})
+		return err
+	}); err != nil {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: failed to restart after a failed update: %s", id, err)
+		return
+	}
+
+	if err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, id, timeout, pollInterval, sagemaker.NotebookInstanceStatusInService, sagemaker.NotebookInstanceStatusFailed); err != nil {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: failed to restart after a failed update: %s", id, err)
+	}
+}
+
+// sagemakerNotebookInstanceUpdateFailedError builds the error
+// resourceAwsSagemakerNotebookInstanceUpdate returns when the post-update
+// restart transitions the notebook to Failed instead of InService, naming
+// the id and the DescribeNotebookInstance FailureReason so the message gives
+// more to act on than a bare wait timeout would.
+func sagemakerNotebookInstanceUpdateFailedError(id, failureReason string) error {
+	if failureReason == "" {
+		return fmt.Errorf("Sagemaker Notebook Instance (%s) transitioned to Failed after update", id)
+	}
+	return fmt.Errorf("Sagemaker Notebook Instance (%s) transitioned to Failed after update: %s", id, failureReason)
+}
+
+// sagemakerNotebookInstanceRollbackFailedStartAfterUpdate best-effort
+// attempts to restore the prior running state after an in-place update's
+// post-update restart transitions the notebook to Failed: if instance_type
+// changed -- the UpdateNotebookInstance field most likely to make a notebook
+// unstartable against its current VPC/platform -- it reverts to
+// oldInstanceType, then restarts either way. Any failure here is only
+// logged: the caller is already about to return the original Failed error,
+// and erroring out over a failed rollback attempt would bury it.
+func sagemakerNotebookInstanceRollbackFailedStartAfterUpdate(conn sagemakeriface.SageMakerAPI, id, oldInstanceType, newInstanceType string, timeout, pollInterval time.Duration) {
+	if oldInstanceType != "" && oldInstanceType != newInstanceType {
+		if err := sagemakerNotebookInstanceUpdateWithRetry(conn, &sagemaker.UpdateNotebookInstanceInput{
+			NotebookInstanceName: aws.String(id),
+			InstanceType:         aws.String(oldInstanceType),
+		}); err != nil {
+			log.Printf("[WARN] Sagemaker Notebook Instance %q: failed to roll back instance_type after a failed post-update start: %s", id, err)
+			return
+		}
+	}
+
+	sagemakerNotebookInstanceRestartAfterFailedUpdate(conn, id, timeout, pollInterval)
+}
+
+// sagemakerNotebookInstanceDeleteAfterCreateFailure best-effort deletes a
+// notebook instance that just reached Failed during create, for
+// delete_on_create_failure. A Failed instance is already stopped in all but
+// name, so unlike resourceAwsSagemakerNotebookInstanceDelete this skips
+// straight to DeleteNotebookInstance without a stop step. Any failure here is
+// only logged: the caller is already about to return the original create
+// error, and erroring out over a failed cleanup attempt would bury it.
+func sagemakerNotebookInstanceDeleteAfterCreateFailure(conn sagemakeriface.SageMakerAPI, id string) {
+	if err := retryOnSagemakerThrottle(func() error {
+		_, err := conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{
+			NotebookInstanceName: aws.String(id),
+		})
+		return err
+	}); err != nil {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: delete_on_create_failure cleanup failed: %s", id, err)
+	}
+}
+
+// sagemakerNotebookInstanceRoleNameFromArn extracts the role name from an IAM
+// role ARN (arn:aws:iam::123456789012:role/path/to/my-role -> my-role).
+// Returns "" if roleArn doesn't look like a role ARN.
+func sagemakerNotebookInstanceRoleNameFromArn(roleArn string) string {
+	parts := strings.SplitN(roleArn, ":role/", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+
+	if idx := strings.LastIndex(parts[1], "/"); idx != -1 {
+		return parts[1][idx+1:]
+	}
+
+	return parts[1]
+}
+
+// resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded decides whether
+// Delete needs to call StopNotebookInstance before DeleteNotebookInstance,
+// branching on the notebook instance's status at the start of delete:
+// InService needs an explicit stop; Stopped and Failed can go straight to
+// DeleteNotebookInstance, since StopNotebookInstance itself rejects a Failed
+// instance as an invalid state transition; and Pending/Updating are
+// transitional, so this waits for one of those three terminal statuses
+// before deciding.
+func resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(ctx context.Context, conn sagemakeriface.SageMakerAPI, id, status string, timeout, pollInterval time.Duration) error {
+	switch status {
+	case sagemaker.NotebookInstanceStatusPending, sagemaker.NotebookInstanceStatusUpdating:
+		if err := waitSagemakerNotebookInstanceStatus(ctx, conn, id, timeout, pollInterval,
+			sagemaker.NotebookInstanceStatusInService, sagemaker.NotebookInstanceStatusStopped, sagemaker.NotebookInstanceStatusFailed); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to reach a terminal state before deleting: %s", id, err)
+		}
+
+		_, refreshedStatus, err := SagemakerNotebookInstanceStateRefreshFunc(ctx, conn, id)()
+		if err != nil {
+			return err
+		}
+		status = refreshedStatus
+	}
+
+	if status != sagemaker.NotebookInstanceStatusInService {
+		return nil
+	}
+
+	return stopSagemakerNotebookInstance(ctx, conn, id, timeout, pollInterval)
+}
+
+// sagemakerNotebookInstanceConcurrentModificationRetryWindow bounds how long
+// stopSagemakerNotebookInstance and sagemakerNotebookInstanceUpdateWithRetry
+// retry a concurrent-modification error (see
+// isSagemakerNotebookInstanceConcurrentModificationErr) before giving up, the
+// same way sagemakerNotebookInstanceUpdateWithRetry already bounds its
+// invalid-state retry.
+const sagemakerNotebookInstanceConcurrentModificationRetryWindow = 2 * time.Minute
+
+func stopSagemakerNotebookInstance(ctx context.Context, conn sagemakeriface.SageMakerAPI, id string, timeout, pollInterval time.Duration) error {
+	stopOpts := &sagemaker.StopNotebookInstanceInput{
+		NotebookInstanceName: aws.String(id),
+	}
+
+	// Re-read status immediately before calling StopNotebookInstance, rather
+	// than trusting a status a caller read earlier: if another run against
+	// this same notebook instance already stopped it (or is in the middle of
+	// doing so) between then and now, there's nothing left to do here.
+	if _, status, err := SagemakerNotebookInstanceStateRefreshFunc(ctx, conn, id)(); err == nil && status == sagemaker.NotebookInstanceStatusStopped {
+		return nil
+	}
+
+	if err := resource.Retry(sagemakerNotebookInstanceConcurrentModificationRetryWindow, func() *resource.RetryError {
+		err := retryOnSagemakerThrottle(func() error {
+			_, err := conn.StopNotebookInstance(stopOpts)
+			return err
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !isSagemakerNotebookInstanceConcurrentModificationErr(err) {
+			return resource.NonRetryableError(err)
+		}
+
+		// A concurrent run may have raced this one to Stopped already -- that
+		// counts as success, not a reason to keep retrying a Stop that would
+		// now be rejected as redundant.
+		if _, status, refreshErr := SagemakerNotebookInstanceStateRefreshFunc(ctx, conn, id)(); refreshErr == nil && status == sagemaker.NotebookInstanceStatusStopped {
+			return nil
+		}
+
+		return resource.RetryableError(err)
+	}); err != nil {
+		return sagemakerNotebookInstanceApiError("Error stopping", id, err)
+	}
+
+	return WaitNotebookInstanceStopped(ctx, conn, id, timeout, pollInterval)
+}
+
+// isSagemakerNotebookInstanceConcurrentModificationErr returns true when a
+// notebook instance API call is rejected because another operation -- most
+// commonly a concurrent apply against the same notebook instance from a
+// second pipeline run -- already has it transitioning, so the caller can
+// back off and retry rather than failing outright over a race between two
+// runs converging on the same notebook instance.
+func isSagemakerNotebookInstanceConcurrentModificationErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	if awsErr.Code() == "ConflictException" || awsErr.Code() == "ResourceInUse" {
+		return true
+	}
+
+	return awsErr.Code() == "ValidationException" && strings.Contains(strings.ToLower(awsErr.Message()), "in use")
+}
+
+// isSagemakerNotebookInstanceInvalidStateErr matches the ValidationException
+// UpdateNotebookInstance returns when the notebook instance isn't fully
+// Stopped yet. stopSagemakerNotebookInstance already waits for
+// WaitNotebookInstanceStopped before this is called, but SageMaker can still
+// be settling Stopping->Stopped internally for a moment afterward, the same
+// race DeleteNotebookInstance is retried for above.
+func isSagemakerNotebookInstanceInvalidStateErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	return awsErr.Code() == "ValidationException" && strings.Contains(awsErr.Message(), "is not in a valid state")
+}
+
+// sagemakerNotebookInstanceUpdateWithRetry calls UpdateNotebookInstance,
+// briefly retrying both if SageMaker still considers the notebook instance
+// to be in an invalid state right after stopSagemakerNotebookInstance
+// reported it Stopped, and if a concurrent apply against the same notebook
+// instance (see isSagemakerNotebookInstanceConcurrentModificationErr) has it
+// transitioning at the same moment -- so one run backs off within
+// sagemakerNotebookInstanceConcurrentModificationRetryWindow while the other
+// completes, instead of failing outright over the race.
+func sagemakerNotebookInstanceUpdateWithRetry(conn sagemakeriface.SageMakerAPI, input *sagemaker.UpdateNotebookInstanceInput) error {
+	return resource.Retry(sagemakerNotebookInstanceConcurrentModificationRetryWindow, func() *resource.RetryError {
+		err := retryOnSagemakerThrottle(func() error {
+			_, err := conn.UpdateNotebookInstance(input)
+			return err
+		})
+		if err != nil {
+			if isSagemakerNotebookInstanceInvalidStateErr(err) || isSagemakerNotebookInstanceConcurrentModificationErr(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
+// sagemakerNotebookInstanceLifecycleLogTail best-effort tails the CloudWatch
+// Logs stream SageMaker writes a lifecycle config hook's output to, so a
+// "Failed" notebook instance's error message can point at why the hook
+// failed instead of just that it did. Any error reading the logs (stream not
+// yet created, no permissions, log group retention already expired it) is
+// swallowed so a missing log never masks the original failure.
+func sagemakerNotebookInstanceLifecycleLogTail(meta interface{}, notebookInstanceName, hookName string) string {
+	conn := meta.(*AWSClient).cloudwatchlogsconn
+
+	out, err := conn.GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("/aws/sagemaker/NotebookInstances"),
+		LogStreamName: aws.String(fmt.Sprintf("%s/%s", notebookInstanceName, hookName)),
+		Limit:         aws.Int64(10),
+		StartFromHead: aws.Bool(false),
+	})
+	if err != nil {
+		log.Printf("[DEBUG] Unable to read lifecycle config logs for Sagemaker Notebook Instance %s: %s", notebookInstanceName, err)
+		return ""
+	}
+
+	lines := make([]string, 0, len(out.Events))
+	for _, event := range out.Events {
+		lines = append(lines, aws.StringValue(event.Message))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailureMarker is the
+// substring git's own error output contains when a clone fails, which is
+// what ends up in the on-start lifecycle log when SageMaker fails to clone
+// default_code_repository. DescribeNotebookInstance has no dedicated field
+// for this -- the instance still reaches InService regardless -- so the log
+// is the only place the failure shows up at all.
+const sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailureMarker = "fatal: "
+
+// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure returns the
+// on-start lifecycle log tail if it looks like a failed default_code_repository
+// clone, or "" if nothing matched (including if the log couldn't be read at
+// all). This is a best-effort fallback for Read, used only when
+// FailureReason came back empty from the API.
+func sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailure(meta interface{}, notebookInstanceName string) string {
+	logTail := sagemakerNotebookInstanceLifecycleLogTail(meta, notebookInstanceName, "LifecycleConfigOnStart")
+	if !strings.Contains(logTail, sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailureMarker) {
+		return ""
+	}
+
+	return fmt.Sprintf("default_code_repository clone failed:\n%s", logTail)
+}
+
+// sagemakerNotebookInstanceCodeRepoCloneCompleteMarker is git's own trailer
+// line for a clone with at least one commit, which is what the on-start
+// lifecycle log shows once a default_code_repository clone has actually
+// finished rather than just started. This is a heuristic, not a documented
+// SageMaker guarantee -- there's no dedicated clone-status API -- so it's
+// only ever used to stop polling early, never to fail anything.
+const sagemakerNotebookInstanceCodeRepoCloneCompleteMarker = "Resolving deltas"
+
+// sagemakerNotebookInstanceCodeRepoCloneWaitTimeout bounds how long
+// wait_for_code_repo_clone polls the on-start lifecycle log for a completion
+// or failure marker before giving up and moving on.
+const sagemakerNotebookInstanceCodeRepoCloneWaitTimeout = 5 * time.Minute
+
+// sagemakerNotebookInstanceCodeRepoCloneWaitPollInterval is how often
+// sagemakerNotebookInstanceWaitForCodeRepoClone re-reads the on-start
+// lifecycle log while polling.
+const sagemakerNotebookInstanceCodeRepoCloneWaitPollInterval = 10 * time.Second
+
+// sagemakerNotebookInstanceWaitForCodeRepoClone is the wait_for_code_repo_clone
+// implementation: it best-effort polls the on-start lifecycle log for either
+// sagemakerNotebookInstanceCodeRepoCloneCompleteMarker or
+// sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailureMarker, for up to
+// sagemakerNotebookInstanceCodeRepoCloneWaitTimeout. It never returns an
+// error: a clone that's still running after the window, or a log that never
+// shows a recognizable marker at all, is only logged, since there's nothing
+// more authoritative than this heuristic to fall back on.
+func sagemakerNotebookInstanceWaitForCodeRepoClone(meta interface{}, notebookInstanceName string) {
+	ticker := time.NewTicker(sagemakerNotebookInstanceCodeRepoCloneWaitPollInterval)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(sagemakerNotebookInstanceCodeRepoCloneWaitTimeout)
+	for {
+		logTail := sagemakerNotebookInstanceLifecycleLogTail(meta, notebookInstanceName, "LifecycleConfigOnStart")
+		if strings.Contains(logTail, sagemakerNotebookInstanceCodeRepoCloneCompleteMarker) {
+			log.Printf("[DEBUG] default_code_repository clone appears complete for Sagemaker Notebook Instance (%s)", notebookInstanceName)
+			return
+		}
+		if strings.Contains(logTail, sagemakerNotebookInstanceDefaultCodeRepositoryCloneFailureMarker) {
+			log.Printf("[DEBUG] default_code_repository clone appears to have failed for Sagemaker Notebook Instance (%s); see failure_reason after the next refresh", notebookInstanceName)
+			return
+		}
+
+		if time.Now().After(deadline) {
+			log.Printf("[WARN] Timed out after %s waiting for a default_code_repository clone completion marker in Sagemaker Notebook Instance (%s) on-start lifecycle logs; the clone may still be running", sagemakerNotebookInstanceCodeRepoCloneWaitTimeout, notebookInstanceName)
+			return
+		}
+
+		<-ticker.C
+	}
+}
+
+// waitSagemakerNotebookInstanceStatus polls DescribeNotebookInstance until the
+// status reaches one of desiredStatus, every pollInterval (10s, matching the
+// old resource.StateChangeConf-based poll, when pollInterval is not positive),
+// but checks ctx between ticks so a
+// cancelled context (e.g. a cancelled apply, once this resource's CRUD hooks
+// are able to supply one) stops the wait promptly instead of running out the
+// full timeout. pollInterval is the caller's poll_interval_seconds, letting
+// accounts SageMaker throttles aggressively trade slower convergence for
+// fewer DescribeNotebookInstance calls.
+func waitSagemakerNotebookInstanceStatus(ctx context.Context, conn sagemakeriface.SageMakerAPI, id string, timeout, pollInterval time.Duration, desiredStatus ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := map[string]bool{"Pending": true, "Updating": true, "Stopping": true, "Starting": true, "Deleting": true}
+	target := make(map[string]bool, len(desiredStatus))
+	for _, s := range desiredStatus {
+		target[s] = true
+	}
+
+	refresh := SagemakerNotebookInstanceStateRefreshFunc(ctx, conn, id)
+
+	if pollInterval <= 0 {
+		pollInterval = sagemakerNotebookInstanceDefaultPollIntervalSeconds * time.Second
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	var lastStatus string
+
+	for {
+		_, status, err := refresh()
+		if err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to be %s: %s", id, desiredStatus, err)
+		}
+
+		if status != lastStatus {
+			if lastStatus == "" {
+				log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) status is %s", id, status)
+			} else {
+				log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) status changed %s->%s after %s", id, lastStatus, status, time.Since(start).Round(time.Second))
+			}
+			lastStatus = status
+		}
+
+		if target[status] {
+			return nil
+		}
+
+		// DescribeNotebookInstance can briefly still return not-found just
+		// after CreateNotebookInstance returns, from eventual consistency on
+		// the read path rather than the instance actually having been
+		// deleted. "" (not found) is only ever a legitimate pending state
+		// within this grace period -- target[""] above already handles the
+		// case where not-found is itself the desired status (WaitNotebookInstanceDeleted).
+		if status == "" && time.Since(start) < sagemakerNotebookInstanceNotFoundGracePeriod {
+			log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) not found yet, tolerating as transient eventual consistency (%s into the wait)", id, time.Since(start).Round(time.Second))
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to be %s: %s", id, desiredStatus, ctx.Err())
+			case <-ticker.C:
+			}
+			continue
+		}
+
+		if !pending[status] {
+			return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to be %s: unexpected state %q", id, desiredStatus, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			// A deadline exceeded on the timeout this func derived above, as
+			// opposed to a cancellation propagated in from the caller's ctx,
+			// is the one case with a useful last-observed status to report, so
+			// it gets the typed *resource.TimeoutError instead of a plain error.
+			if ctx.Err() == context.DeadlineExceeded {
+				return &resource.TimeoutError{
+					LastError:     fmt.Errorf("Sagemaker Notebook Instance (%s) still %q, want %s", id, lastStatus, desiredStatus),
+					LastState:     lastStatus,
+					Timeout:       timeout,
+					ExpectedState: desiredStatus,
+				}
+			}
+			return fmt.Errorf("error waiting for Sagemaker Notebook Instance (%s) to be %s: %s", id, desiredStatus, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// sagemakerNotebookInstanceCreateFailedError builds the error Create returns
+// once it's confirmed via DescribeNotebookInstance that the wait for
+// InService failed because the notebook instance itself transitioned to
+// Failed, rather than because the wait merely timed out. Pulled out of
+// Create as a pure function, with failureReason and logTail already resolved
+// by the caller, so this formatting -- including the insufficient-capacity
+// special case -- is unit-testable without a real SageMaker client. The ID
+// is set in state by the time this ever runs, so Terraform still reports the
+// create as failed -- not silently succeeded -- while leaving the Failed
+// instance in state for a subsequent destroy to clean up.
+func sagemakerNotebookInstanceCreateFailedError(id, instanceType, failureReason, logTail string) error {
+	if isSagemakerInsufficientCapacityErr(failureReason) {
+		return fmt.Errorf("error creating Sagemaker Notebook Instance (%s): insufficient capacity for instance_type %q: %s\n\nTry a different instance_type or region, or retry later.", id, instanceType, failureReason)
+	}
+
+	if logTail != "" {
+		failureReason = fmt.Sprintf("%s\n\nlast lifecycle config log lines:\n%s", failureReason, logTail)
+	}
+
+	return fmt.Errorf("error creating Sagemaker Notebook Instance (%s): %s", id, failureReason)
+}
+
+// sagemakerNotebookInstanceCreateTimeoutPendingMessage returns a message
+// explaining that create timed out while the notebook instance was still
+// Pending -- as opposed to stuck in some other non-terminal state, or
+// failing outright -- so the caller can return an error that says so
+// explicitly rather than the wait's generic timeout message. The ID is
+// already set in state by the time create's wait for InService runs, so a
+// Pending instance that eventually comes up is picked up by the next
+// apply/refresh instead of being left orphaned. Returns "" for any other
+// error, including a timeout whose LastState isn't Pending.
+func sagemakerNotebookInstanceCreateTimeoutPendingMessage(id string, err error) string {
+	var timeoutErr *resource.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.LastState != sagemaker.NotebookInstanceStatusPending {
+		return ""
+	}
+
+	return fmt.Sprintf("Sagemaker Notebook Instance (%s) is still Pending after the create timeout; it may still come up on its own -- run `terraform apply` again once it does to pick it up", id)
+}
+
+// sagemakerNotebookInstanceTimedWait runs wait and, if it succeeds, records
+// its wall-clock duration in last_provision_duration_seconds. It's used to
+// instrument this resource's own Create/Update waits for InService without
+// duplicating the timing at every call site (restart-after-update and
+// desired_status both wait for InService in addition to the main Create/
+// Update path).
+func sagemakerNotebookInstanceTimedWait(d *schema.ResourceData, wait func() error) error {
+	start := time.Now()
+
+	err := wait()
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("last_provision_duration_seconds", int(time.Since(start).Seconds())); err != nil {
+		return fmt.Errorf("error setting last_provision_duration_seconds for notebook instance %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// WaitNotebookInstanceInService blocks until the named Sagemaker Notebook
+// Instance reaches InService, or ctx/timeout runs out first. It's the
+// single-target case of waitSagemakerNotebookInstanceStatus above, exported
+// so any other resource or data source in the package that needs to block on
+// a notebook instance reaching InService (an autoscaling integration, say,
+// or a data source that wants to read back values only available once
+// InService) can reuse this resource's waiter instead of polling
+// DescribeNotebookInstance on its own. Failed is not in the waiter's pending
+// set, so a Failed transition ends the wait with an error right away; this
+// resource's own Create and Update then describe the instance to tell that
+// apart from a timeout and add its FailureReason to the error. pollInterval governs how often DescribeNotebookInstance
+// is polled; pass 0 to get this resource's own poll_interval_seconds default.
+func WaitNotebookInstanceInService(ctx context.Context, conn sagemakeriface.SageMakerAPI, name string, timeout, pollInterval time.Duration) error {
+	return waitSagemakerNotebookInstanceStatus(ctx, conn, name, timeout, pollInterval, sagemaker.NotebookInstanceStatusInService)
+}
+
+// WaitNotebookInstanceStopped blocks until the named Sagemaker Notebook
+// Instance reaches Stopped, or ctx/timeout runs out first. See
+// WaitNotebookInstanceInService above for why this is exported and what
+// pollInterval does.
+func WaitNotebookInstanceStopped(ctx context.Context, conn sagemakeriface.SageMakerAPI, name string, timeout, pollInterval time.Duration) error {
+	return waitSagemakerNotebookInstanceStatus(ctx, conn, name, timeout, pollInterval, sagemaker.NotebookInstanceStatusStopped)
+}
+
+// WaitNotebookInstanceDeleted blocks until the named Sagemaker Notebook
+// Instance no longer exists, or ctx/timeout runs out first. "" is the status
+// SagemakerNotebookInstanceStateRefreshFunc reports once DescribeNotebookInstance
+// starts returning ResourceNotFound, so it doubles here as the "gone" target.
+// See WaitNotebookInstanceInService above for why this is exported and what
+// pollInterval does.
+func WaitNotebookInstanceDeleted(ctx context.Context, conn sagemakeriface.SageMakerAPI, name string, timeout, pollInterval time.Duration) error {
+	return waitSagemakerNotebookInstanceStatus(ctx, conn, name, timeout, pollInterval, "")
+}
+
+func expandSagemakerNotebookInstanceMetadataServiceConfiguration(l []interface{}) *sagemaker.InstanceMetadataServiceConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.InstanceMetadataServiceConfiguration{
+		MinimumInstanceMetadataServiceVersion: aws.String(m["minimum_instance_metadata_service_version"].(string)),
+	}
+}
+
+func flattenSagemakerNotebookInstanceMetadataServiceConfiguration(config *sagemaker.InstanceMetadataServiceConfiguration) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"minimum_instance_metadata_service_version": aws.StringValue(config.MinimumInstanceMetadataServiceVersion),
+		},
+	}
+}
+
+// sagemakerNotebookInstanceMetadataServiceVersionDefault is the IMDS version
+// SageMaker leaves in effect on a notebook instance with no
+// instance_metadata_service_configuration block at all.
+const sagemakerNotebookInstanceMetadataServiceVersionDefault = "1"
+
+// sagemakerNotebookInstanceMetadataServiceVersion mirrors config's minimum
+// version as a plain string, or sagemakerNotebookInstanceMetadataServiceVersionDefault
+// when config is nil.
+func sagemakerNotebookInstanceMetadataServiceVersion(config *sagemaker.InstanceMetadataServiceConfiguration) string {
+	if config == nil {
+		return sagemakerNotebookInstanceMetadataServiceVersionDefault
+	}
+
+	return aws.StringValue(config.MinimumInstanceMetadataServiceVersion)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiff clears the
+// default_code_repository diff when the old and new values are different
+// string forms (URL, name, or ARN) of the same aws_sagemaker_code_repository,
+// so switching how the repository is referenced in config doesn't force a
+// plan-time change that the API would never actually apply, separately
+// validates volume_size_in_gb against instance_type, forces recreation when
+// volume_size_in_gb shrinks, warns about downtime, ARM/accelerator
+// platform_identifier mismatches, and data loss from a kms_key_id change,
+// forces recreation on an explicit platform_identifier change, warns that a
+// GPU instance_type may need a longer create timeout, rejects
+// security_groups configured without subnet_id, rejects accelerator_types
+// on an instance_type Elastic Inference can't attach to, rejects
+// direct_internet_access = "Disabled" outside a VPC before it reaches AWS,
+// warns when a ForceNew field is changing without retain_on_replace set,
+// warns when instance_type's family is known to be unavailable in the
+// configured region, warns when platform_identifier is set to a platform
+// AWS has deprecated, rejects instance_type outside allowed_instance_types
+// when that guardrail is set, rejects a disruptive instance_type or
+// role_arn change attempted outside maintenance_window when that guardrail
+// is set, rejects a resource + default_tags combination that exceeds
+// SageMaker's 50-tag limit, and warns when accelerator_types is combined
+// with direct_internet_access = "Disabled" and no subnet_id, which can cut
+// off the Elastic Inference accelerator's endpoint access.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffDefaultCodeRepository(d, meta); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffFamilyVolumeSizeDefault(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSize(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSizeShrink(d); err != nil {
+		return err
+	}
+
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffDowntimeWarning(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformCompatibility(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffGpuDriverCompatibility(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyIdWarning(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyDeletionWarning(d, meta)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffDeprecatedPlatformWarning(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffGPUTimeoutWarning(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffRetainOnReplaceWarning(d)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffInstanceTypeRegionAvailability(d, meta)
+	resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorEiEndpointAccess(d)
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffSecurityGroupsRequireSubnet(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorTypes(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffAllowedInstanceTypes(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffMaintenanceWindow(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffRequiredTagKeys(d, meta); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffForbiddenTagValues(d, meta); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffTagLimit(d, meta); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffForbidImdsDowngrade(d); err != nil {
+		return err
+	}
+
+	if err := resourceAwsSagemakerNotebookInstanceCustomizeDiffValidateRolePermissions(d, meta); err != nil {
+		return err
+	}
+
+	return resourceAwsSagemakerNotebookInstanceCustomizeDiffDirectInternetAccess(d)
+}
+
+// sagemakerNotebookInstanceRequiredRolePermissions is the set of actions
+// SageMaker itself needs role_arn to be able to perform in order to create,
+// run, and network a notebook instance. It doesn't attempt to be
+// exhaustive -- e.g. it omits permissions only needed by opt-in features
+// like default_code_repository -- just the baseline every notebook instance
+// depends on.
+var sagemakerNotebookInstanceRequiredRolePermissions = []string{
+	"sagemaker:CreatePresignedNotebookInstanceUrl",
+	"ec2:CreateNetworkInterface",
+	"ec2:DeleteNetworkInterface",
+	"ec2:DescribeNetworkInterfaces",
+	"ec2:DescribeSubnets",
+	"ec2:DescribeSecurityGroups",
+	"ec2:DescribeVpcs",
+	"kms:DescribeKey",
+	"kms:CreateGrant",
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffValidateRolePermissions is
+// the validate_role_permissions opt-in: gated behind that flag and a
+// non-empty role_arn (which, being Required, is only empty mid-plan against
+// an interpolated value not yet known), it simulates role_arn against
+// sagemakerNotebookInstanceRequiredRolePermissions and fails the plan if any
+// of them would be denied.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffValidateRolePermissions(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_role_permissions").(bool) {
+		return nil
+	}
+
+	roleArn := d.Get("role_arn").(string)
+	if roleArn == "" {
+		return nil
+	}
+
+	return resourceAwsSagemakerNotebookInstanceVerifyRolePermissions(meta.(*AWSClient).iamconn, roleArn)
+}
+
+// resourceAwsSagemakerNotebookInstanceVerifyRolePermissions is the check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffValidateRolePermissions.
+// As with resourceAwsSagemakerNotebookInstanceVerifyKmsKey above, an
+// AccessDenied calling SimulatePrincipalPolicy itself (the caller's own
+// permissions, not role_arn's) is treated as inconclusive and logged rather
+// than failed, since that's not evidence role_arn itself is missing
+// anything.
+func resourceAwsSagemakerNotebookInstanceVerifyRolePermissions(iamconn iamiface.IAMAPI, roleArn string) error {
+	out, err := iamconn.SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(roleArn),
+		ActionNames:     aws.StringSlice(sagemakerNotebookInstanceRequiredRolePermissions),
+	})
+	if err != nil {
+		if isSagemakerAccessDeniedErr(err) {
+			log.Printf("[WARN] Not authorized to simulate policy for role %q, skipping validate_role_permissions check: missing iam:SimulatePrincipalPolicy permission: %s", roleArn, err)
+			return nil
+		}
+		return fmt.Errorf("error validating role_arn %q permissions: %s", roleArn, err)
+	}
+
+	return sagemakerNotebookInstanceDeniedRolePermissions(out.EvaluationResults, roleArn)
+}
+
+// sagemakerNotebookInstanceDeniedRolePermissions is the pure check behind
+// resourceAwsSagemakerNotebookInstanceVerifyRolePermissions, kept separate
+// so it's testable without constructing an iamiface.IAMAPI.
+func sagemakerNotebookInstanceDeniedRolePermissions(results []*iam.EvaluationResult, roleArn string) error {
+	var denied []string
+	for _, r := range results {
+		if aws.StringValue(r.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+			denied = append(denied, aws.StringValue(r.EvalActionName))
+		}
+	}
+
+	if len(denied) == 0 {
+		return nil
+	}
+
+	sort.Strings(denied)
+	return fmt.Errorf("role_arn %q is missing permissions required by SageMaker notebook instances: %s", roleArn, strings.Join(denied, ", "))
+}
+
+// resourceAwsSagemakerNotebookInstanceInheritRoleTags looks up role_arn's IAM
+// role tags via ListRoleTags and filters them down to inheritKeys via
+// sagemakerNotebookInstanceInheritedRoleTags, for inherit_role_tags. Unlike
+// the best-effort lookups elsewhere in this file (availability_zone, vpc_id),
+// a failure here is returned as a hard error rather than logged:
+// inherit_role_tags is an explicit opt-in, so silently applying a notebook's
+// tags without the inherited subset would leave out tags the config expects.
+func resourceAwsSagemakerNotebookInstanceInheritRoleTags(iamconn iamiface.IAMAPI, roleArn string, inheritKeys []*string) (map[string]string, error) {
+	roleName := sagemakerNotebookInstanceRoleNameFromArn(roleArn)
+	if roleName == "" {
+		return nil, fmt.Errorf("unable to determine role name from role_arn %q", roleArn)
+	}
+
+	var roleTags []*iam.Tag
+	var marker *string
+	for {
+		out, err := iamconn.ListRoleTags(&iam.ListRoleTagsInput{
+			RoleName: aws.String(roleName),
+			Marker:   marker,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing tags for role %q: %s", roleName, err)
+		}
+
+		roleTags = append(roleTags, out.Tags...)
+
+		if !aws.BoolValue(out.IsTruncated) {
+			break
+		}
+		marker = out.Marker
+	}
+
+	roleTagMap := make(map[string]string, len(roleTags))
+	for _, t := range roleTags {
+		roleTagMap[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return sagemakerNotebookInstanceInheritedRoleTags(roleTagMap, inheritKeys), nil
+}
+
+// sagemakerNotebookInstanceInheritedRoleTags filters roleTags down to just
+// the keys listed in inheritKeys, the inherit_role_tags subset. Kept separate
+// from resourceAwsSagemakerNotebookInstanceInheritRoleTags so the filtering
+// is testable without an iamiface.IAMAPI.
+func sagemakerNotebookInstanceInheritedRoleTags(roleTags map[string]string, inheritKeys []*string) map[string]string {
+	result := make(map[string]string, len(inheritKeys))
+	for _, k := range inheritKeys {
+		key := aws.StringValue(k)
+		if v, ok := roleTags[key]; ok {
+			result[key] = v
+		}
+	}
+	return result
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffAllowedInstanceTypes is an
+// opt-in guard: when allowed_instance_types is set, it fails the plan if
+// instance_type isn't in the list, catching an accidental upsize to an
+// expensive instance type locally instead of only after the bill arrives.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffAllowedInstanceTypes(d *schema.ResourceDiff) error {
+	allowed := d.Get("allowed_instance_types").(*schema.Set)
+	if allowed.Len() == 0 {
+		return nil
+	}
+
+	return validateSagemakerNotebookInstanceAllowedInstanceTypes(d.Get("instance_type").(string), allowed.List())
+}
+
+// validateSagemakerNotebookInstanceAllowedInstanceTypes is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffAllowedInstanceTypes,
+// kept separate so it's testable without constructing a *schema.ResourceDiff.
+func validateSagemakerNotebookInstanceAllowedInstanceTypes(instanceType string, allowed []interface{}) error {
+	for _, v := range allowed {
+		if v.(string) == instanceType {
+			return nil
+		}
+	}
+
+	allowedStrs := make([]string, len(allowed))
+	for i, v := range allowed {
+		allowedStrs[i] = v.(string)
+	}
+
+	return fmt.Errorf("instance_type %q is not in allowed_instance_types: %s", instanceType, strings.Join(allowedStrs, ", "))
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffMaintenanceWindow is an
+// opt-in guard: when maintenance_window is set, it fails the plan if
+// instance_type or role_arn is changing and the current time falls outside
+// the configured window, so a disruptive stop/start update can't land
+// during business hours by accident.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffMaintenanceWindow(d *schema.ResourceDiff) error {
+	window := d.Get("maintenance_window").(string)
+	if window == "" {
+		return nil
+	}
+
+	if !d.HasChange("instance_type") && !d.HasChange("role_arn") {
+		return nil
+	}
+
+	return validateSagemakerNotebookInstanceMaintenanceWindowNow(window, time.Now())
+}
+
+// validateSagemakerNotebookInstanceMaintenanceWindowNow is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffMaintenanceWindow,
+// kept separate -- and taking now as an explicit parameter rather than
+// calling time.Now() itself -- so it's testable with an injected clock
+// instead of depending on wall-clock time.
+func validateSagemakerNotebookInstanceMaintenanceWindowNow(window string, now time.Time) error {
+	inWindow, err := sagemakerNotebookInstanceInMaintenanceWindow(window, now)
+	if err != nil {
+		return err
+	}
+
+	if !inWindow {
+		return fmt.Errorf("instance_type and role_arn changes stop and restart the notebook instance; the current time (%s UTC) is outside maintenance_window %q", now.UTC().Format("Mon 15:04"), window)
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceWeekdayAbbreviations maps the three-letter day
+// abbreviations maintenance_window accepts to their time.Weekday value.
+var sagemakerNotebookInstanceWeekdayAbbreviations = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// sagemakerNotebookInstanceInMaintenanceWindow parses a maintenance_window
+// (already checked against sagemakerNotebookInstanceMaintenanceWindowRegexp
+// by the schema's ValidateFunc) and reports whether now falls within it, in
+// UTC. The window can wrap across the week boundary (e.g. Sat:22:00-Sun:02:00).
+func sagemakerNotebookInstanceInMaintenanceWindow(window string, now time.Time) (bool, error) {
+	parts := strings.SplitN(window, "-", 2)
+	if len(parts) != 2 {
+		return false, fmt.Errorf("maintenance_window %q is not in the format ddd:hh24:mi-ddd:hh24:mi", window)
+	}
+
+	start, err := sagemakerNotebookInstanceWeekMinute(parts[0])
+	if err != nil {
+		return false, fmt.Errorf("maintenance_window %q: %s", window, err)
+	}
+
+	end, err := sagemakerNotebookInstanceWeekMinute(parts[1])
+	if err != nil {
+		return false, fmt.Errorf("maintenance_window %q: %s", window, err)
+	}
+
+	minute := int(now.UTC().Weekday())*24*60 + now.UTC().Hour()*60 + now.UTC().Minute()
+
+	if start <= end {
+		return minute >= start && minute <= end, nil
+	}
+
+	return minute >= start || minute <= end, nil
+}
+
+// sagemakerNotebookInstanceWeekMinute parses a single "ddd:hh24:mi" endpoint
+// into a minute-of-the-week offset from Sunday 00:00 UTC.
+func sagemakerNotebookInstanceWeekMinute(endpoint string) (int, error) {
+	parts := strings.SplitN(endpoint, ":", 3)
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("%q is not in the format ddd:hh24:mi", endpoint)
+	}
+
+	day, ok := sagemakerNotebookInstanceWeekdayAbbreviations[strings.ToLower(parts[0])]
+	if !ok {
+		return 0, fmt.Errorf("%q is not a recognized day abbreviation", parts[0])
+	}
+
+	hour, err := strconv.Atoi(parts[1])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, fmt.Errorf("%q is not a valid hour", parts[1])
+	}
+
+	minute, err := strconv.Atoi(parts[2])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("%q is not a valid minute", parts[2])
+	}
+
+	return int(day)*24*60 + hour*60 + minute, nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffRequiredTagKeys is an
+// opt-in guard: when required_tag_keys is set, it fails the plan if any of
+// those keys is absent from the effective tag set (resource tags merged
+// with the provider's default_tags), rather than letting an untagged
+// resource reach AWS and get caught by an org-level tag policy after the
+// fact.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffRequiredTagKeys(d *schema.ResourceDiff, meta interface{}) error {
+	required := d.Get("required_tag_keys").(*schema.Set)
+	if required.Len() == 0 {
+		return nil
+	}
+
+	effectiveTags := sagemakerTagsWithDefaults(meta, d.Get("tags").(map[string]interface{}))
+
+	return validateSagemakerNotebookInstanceRequiredTagKeys(required.List(), effectiveTags)
+}
+
+// validateSagemakerNotebookInstanceRequiredTagKeys is the pure check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffRequiredTagKeys, kept
+// separate so it's testable without constructing a *schema.ResourceDiff or
+// an *AWSClient.
+func validateSagemakerNotebookInstanceRequiredTagKeys(requiredKeys []interface{}, effectiveTags []*sagemaker.Tag) error {
+	present := make(map[string]bool, len(effectiveTags))
+	for _, t := range effectiveTags {
+		present[aws.StringValue(t.Key)] = true
+	}
+
+	var missing []string
+	for _, k := range requiredKeys {
+		if !present[k.(string)] {
+			missing = append(missing, k.(string))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required tag keys: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffForbiddenTagValues is an
+// opt-in guard: when forbidden_tag_values is set, it fails the plan if any
+// key in the effective tag set (resource tags merged with the provider's
+// default_tags) has a value matching the forbidden key's entry, e.g.
+// forbidden_tag_values = { env = "prod" } rejects a config tagging this
+// notebook instance env = "prod".
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffForbiddenTagValues(d *schema.ResourceDiff, meta interface{}) error {
+	forbidden := d.Get("forbidden_tag_values").(map[string]interface{})
+	if len(forbidden) == 0 {
+		return nil
+	}
+
+	effectiveTags := sagemakerTagsWithDefaults(meta, d.Get("tags").(map[string]interface{}))
+
+	return validateSagemakerNotebookInstanceForbiddenTagValues(forbidden, effectiveTags)
+}
+
+// validateSagemakerNotebookInstanceForbiddenTagValues is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffForbiddenTagValues,
+// kept separate so it's testable without constructing a *schema.ResourceDiff
+// or an *AWSClient.
+func validateSagemakerNotebookInstanceForbiddenTagValues(forbidden map[string]interface{}, effectiveTags []*sagemaker.Tag) error {
+	var violations []string
+	for _, t := range effectiveTags {
+		key := aws.StringValue(t.Key)
+		if forbiddenValue, ok := forbidden[key]; ok && aws.StringValue(t.Value) == forbiddenValue.(string) {
+			violations = append(violations, fmt.Sprintf("%s=%s", key, aws.StringValue(t.Value)))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("forbidden tag values configured: %s", strings.Join(violations, ", "))
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffTagLimit counts the
+// effective tags -- resource tags plus any provider default_tags -- and
+// fails the plan if the total exceeds SageMaker's 50-tag limit. This
+// duplicates part of what validateSagemakerTags already checks in
+// sagemakerTagsForCreate, but that check only sees the resource's own tags
+// map, not default_tags merged in, and only runs once CreateNotebookInstance
+// is actually called; catching the merged total here surfaces the same
+// problem at plan time instead of as an apply-time API failure.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffTagLimit(d *schema.ResourceDiff, meta interface{}) error {
+	effectiveTags := sagemakerTagsWithDefaults(meta, d.Get("tags").(map[string]interface{}))
+
+	return validateSagemakerNotebookInstanceTagLimit(effectiveTags)
+}
+
+// validateSagemakerNotebookInstanceTagLimit is the pure check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffTagLimit, kept separate so
+// it's testable without constructing a *schema.ResourceDiff or an
+// *AWSClient.
+func validateSagemakerNotebookInstanceTagLimit(effectiveTags []*sagemaker.Tag) error {
+	if len(effectiveTags) > sagemakerTagMaxCount {
+		return fmt.Errorf("too many tags: %d configured (including provider default_tags), SageMaker allows a maximum of %d", len(effectiveTags), sagemakerTagMaxCount)
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffForbidImdsDowngrade is an
+// opt-in guard: when forbid_imds_downgrade is set, it fails the plan if
+// instance_metadata_service_configuration's minimum version would be lowered
+// (e.g. "2" -> "1"), rather than letting a security regression like that
+// through on a reviewer's nod.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffForbidImdsDowngrade(d *schema.ResourceDiff) error {
+	if !d.Get("forbid_imds_downgrade").(bool) {
+		return nil
+	}
+
+	if !d.HasChange("instance_metadata_service_configuration") {
+		return nil
+	}
 
-			"role_arn": {
-				Type:     schema.TypeString,
-				Required: true,
-				Computed: false,
-			},
+	oldRaw, newRaw := d.GetChange("instance_metadata_service_configuration")
+	oldVersion := sagemakerNotebookInstanceMetadataServiceVersionFromConfig(oldRaw.([]interface{}))
+	newVersion := sagemakerNotebookInstanceMetadataServiceVersionFromConfig(newRaw.([]interface{}))
 
-			"instance_type": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
+	return validateSagemakerNotebookInstanceImdsNotDowngraded(oldVersion, newVersion)
+}
 
-			"subnet_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
+// sagemakerNotebookInstanceMetadataServiceVersionFromConfig mirrors
+// sagemakerNotebookInstanceMetadataServiceVersion, but against the
+// instance_metadata_service_configuration list as it appears in config/diff
+// (old or new side of a GetChange) rather than against the API's
+// InstanceMetadataServiceConfiguration struct.
+func sagemakerNotebookInstanceMetadataServiceVersionFromConfig(l []interface{}) string {
+	if len(l) == 0 || l[0] == nil {
+		return sagemakerNotebookInstanceMetadataServiceVersionDefault
+	}
 
-			"security_groups": {
-				Type:     schema.TypeSet,
-				MinItems: 1,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
-			},
+	m := l[0].(map[string]interface{})
+	return m["minimum_instance_metadata_service_version"].(string)
+}
 
-			"kms_key_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
+// validateSagemakerNotebookInstanceImdsNotDowngraded is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffForbidImdsDowngrade,
+// kept separate so it's testable without constructing a *schema.ResourceDiff.
+// A plain string comparison is enough to detect a downgrade since the only
+// valid values are "1" and "2".
+func validateSagemakerNotebookInstanceImdsNotDowngraded(oldVersion, newVersion string) error {
+	if newVersion >= oldVersion {
+		return nil
+	}
 
-			"tags": tagsSchema(),
-		},
+	return fmt.Errorf("forbid_imds_downgrade is set: instance_metadata_service_configuration's minimum_instance_metadata_service_version would be lowered from %q to %q", oldVersion, newVersion)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier forces
+// recreation only when platform_identifier changes between two non-empty
+// values. platform_identifier is Computed as well as Optional, since AWS
+// defaults it server-side when config leaves it unset; a static
+// ForceNew: true on the schema would treat that server-assigned default
+// showing up on the next read as a config change and force a replacement
+// nobody asked for. Requiring both the old and new values to be non-empty
+// here means only an explicit switch from one platform_identifier to
+// another -- which SageMaker genuinely can't apply in place -- forces
+// recreation.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier(d *schema.ResourceDiff) {
+	if !d.HasChange("platform_identifier") {
+		return
+	}
+
+	oldRaw, newRaw := d.GetChange("platform_identifier")
+	if sagemakerNotebookInstancePlatformIdentifierDrifted(oldRaw.(string), newRaw.(string)) {
+		d.ForceNew("platform_identifier")
 	}
 }
 
-func resourceAwsSagemakerNotebookInstanceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).sagemakerconn
+// sagemakerNotebookInstancePlatformIdentifierDrifted reports whether a
+// platform_identifier change between the value on state (oldPlatform) and
+// the configured value (newPlatform) forces recreation, extracted out of
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier so the
+// non-empty-old-and-new check is testable without a schema.ResourceDiff.
+// This is also what protects an explicitly pinned platform_identifier from
+// silently drifting: SageMaker has no UpdateNotebookInstance field to
+// re-assert it on a stop/start cycle, so if the notebook instance ever comes
+// back up on a different platform than configured, the next Read populates
+// state with the observed platform, oldPlatform and newPlatform disagree
+// here, and the plan reconciles it back to the configured value by replacing
+// the notebook instance -- the only way SageMaker supports changing it.
+func sagemakerNotebookInstancePlatformIdentifierDrifted(oldPlatform, newPlatform string) bool {
+	return oldPlatform != "" && newPlatform != ""
+}
 
-	name := d.Get("name").(string)
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffSecurityGroupsRequireSubnet
+// requires subnet_id whenever security_groups is configured: security groups
+// only apply to a notebook instance placed in a VPC, so SageMaker silently
+// ignores them outside one. Without this check, configuring security_groups
+// alone produces no error -- it just never takes effect, and the value
+// computed back from the API (the default SageMaker-managed security group,
+// or none at all) perpetually disagrees with what's in config.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffSecurityGroupsRequireSubnet(d *schema.ResourceDiff) error {
+	sgs, ok := d.GetOk("security_groups")
+	securityGroupCount := 0
+	if ok {
+		securityGroupCount = sgs.(*schema.Set).Len()
+	}
 
-	createOpts := &sagemaker.CreateNotebookInstanceInput{
-		SecurityGroupIds:     expandStringSet(d.Get("security_groups").(*schema.Set)),
-		NotebookInstanceName: aws.String(name),
-		RoleArn:              aws.String(d.Get("role_arn").(string)),
-		InstanceType:         aws.String(d.Get("instance_type").(string)),
+	return validateSagemakerNotebookInstanceSecurityGroupsRequireSubnet(securityGroupCount, d.Get("subnet_id").(string))
+}
+
+// validateSagemakerNotebookInstanceSecurityGroupsRequireSubnet is the pure
+// check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffSecurityGroupsRequireSubnet,
+// kept separate so it's testable without constructing a *schema.ResourceDiff.
+func validateSagemakerNotebookInstanceSecurityGroupsRequireSubnet(securityGroupCount int, subnetID string) error {
+	if securityGroupCount == 0 || subnetID != "" {
+		return nil
 	}
 
-	if s, ok := d.GetOk("subnet_id"); ok {
-		createOpts.SubnetId = aws.String(s.(string))
+	return fmt.Errorf("security_groups requires subnet_id to be set; SageMaker ignores security_groups on a notebook instance outside a VPC")
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffDowntimeWarning logs that
+// changing instance_type or role_arn will stop and restart the notebook
+// instance, so operators can schedule the change instead of being surprised
+// by the downtime. This legacy helper/schema SDK has no plan-time
+// diagnostics API for a non-blocking, always-visible warning the way newer
+// provider SDKs do -- a [WARN] log line (visible with TF_LOG=WARN or higher)
+// is the closest equivalent available here, and CustomizeDiff can't fail the
+// plan over something that isn't actually an error.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffDowntimeWarning(d *schema.ResourceDiff) {
+	if d.HasChange("instance_type") || d.HasChange("role_arn") {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: changing instance_type or role_arn stops and restarts the notebook, causing downtime", d.Id())
 	}
+}
 
-	if k, ok := d.GetOk("kms_key_id"); ok {
-		createOpts.KmsKeyId = aws.String(k.(string))
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformCompatibility logs
+// that instance_type selects an ARM/accelerator-specialized family without
+// a platform_identifier known to support it. As with the downtime warning
+// above, this legacy SDK has no non-blocking plan-time diagnostics API, so a
+// [WARN] log line is the closest equivalent to a true plan warning.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformCompatibility(d *schema.ResourceDiff) {
+	if msg := sagemakerNotebookInstancePlatformCompatibilityWarning(d.Get("instance_type").(string), d.Get("platform_identifier").(string)); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
+	}
+}
+
+// sagemakerNotebookInstanceDeprecatedPlatforms are platform_identifier
+// values AWS has deprecated: existing notebook instances keep running on
+// them, but UpdateNotebookInstance rejects most changes, so the only way
+// off a deprecated platform is recreation (see
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier, which
+// already forces that recreation on an explicit switch).
+var sagemakerNotebookInstanceDeprecatedPlatforms = map[string]string{
+	"notebook-al1-v1": "notebook-al2-v1",
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffDeprecatedPlatformWarning
+// logs when platform_identifier is set to a deprecated platform, naming the
+// supported replacement. As with the other CustomizeDiff warnings above,
+// this legacy SDK has no non-blocking plan-time diagnostics API, so a
+// [WARN] log line is the closest equivalent to a true plan warning.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffDeprecatedPlatformWarning(d *schema.ResourceDiff) {
+	if msg := sagemakerNotebookInstanceDeprecatedPlatformWarning(d.Get("platform_identifier").(string)); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
 	}
+}
 
-	if v, ok := d.GetOk("tags"); ok {
-		tagsIn := v.(map[string]interface{})
-		createOpts.Tags = tagsFromMapSagemaker(tagsIn)
+// sagemakerNotebookInstanceDeprecatedPlatformWarning is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffDeprecatedPlatformWarning.
+func sagemakerNotebookInstanceDeprecatedPlatformWarning(platformIdentifier string) string {
+	replacement, deprecated := sagemakerNotebookInstanceDeprecatedPlatforms[platformIdentifier]
+	if !deprecated {
+		return ""
 	}
 
-	log.Printf("[DEBUG] Sagemaker Notebook Instance create config: %#v", *createOpts)
-	_, err := conn.CreateNotebookInstance(createOpts)
-	if err != nil {
-		return fmt.Errorf("Error creating Sagemaker Notebook Instance: %s", err)
+	return fmt.Sprintf("platform_identifier %q is deprecated; migrate to %q, which requires recreating the notebook instance", platformIdentifier, replacement)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffGPUTimeoutWarning logs
+// that a GPU instance_type may need a create timeout longer than the 10
+// minute default: an on-start lifecycle script installing CUDA/GPU
+// dependencies on a ml.p*/ml.g* instance can easily miss that window, and
+// AWS fails the start rather than extending it server-side. The create
+// waiter already honors whatever timeouts.create is configured to (see
+// waitSagemakerNotebookInstanceStatus), so raising it is all that's needed
+// -- this just makes sure that's not missed.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffGPUTimeoutWarning(d *schema.ResourceDiff) {
+	if msg := sagemakerNotebookInstanceGPUTimeoutWarning(d.Get("instance_type").(string)); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
 	}
+}
 
-	d.SetId(name)
-	log.Printf("[INFO] Sagemaker Notebook Instance ID: %s", d.Id())
+// sagemakerNotebookInstanceGPUTimeoutWarning is the pure check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffGPUTimeoutWarning.
+func sagemakerNotebookInstanceGPUTimeoutWarning(instanceType string) string {
+	if !sagemakerNotebookInstanceIsGpuInstanceType(instanceType) {
+		return ""
+	}
+
+	return fmt.Sprintf("instance_type %q is GPU-accelerated; if an on-start lifecycle script installs CUDA or other GPU dependencies, consider raising timeouts.create above the 10 minute default to avoid a spurious start failure", instanceType)
+}
+
+// sagemakerNotebookInstanceRegionUnavailableFamilies maps a region to the
+// instance type families SageMaker does not offer there, each paired with
+// the family to suggest instead. This is necessarily a small, manually
+// maintained subset covering known surprises (e.g. t2 not being offered in
+// every region SageMaker supports); add to it as new gaps are reported
+// rather than trying to enumerate every region/family combination up front.
+var sagemakerNotebookInstanceRegionUnavailableFamilies = map[string]map[string]string{
+	"eu-west-3": {
+		"t2": "t3",
+	},
+	"eu-north-1": {
+		"t2": "t3",
+	},
+	"ap-northeast-3": {
+		"t2": "t3",
+	},
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffInstanceTypeRegionAvailability
+// logs that instance_type's family is known to be unavailable in the
+// provider's configured region, suggesting the available alternative family
+// from sagemakerNotebookInstanceRegionUnavailableFamilies. As with the other
+// warnings here, a [WARN] log line is the closest this legacy SDK has to a
+// non-blocking plan diagnostic.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffInstanceTypeRegionAvailability(d *schema.ResourceDiff, meta interface{}) {
+	if msg := sagemakerNotebookInstanceRegionAvailabilityWarning(d.Get("instance_type").(string), meta.(*AWSClient).region); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
+	}
+}
 
-	if err := waitSagemakerNotebookInstanceStatus(conn, d.Id(), "InService", "Failed"); err != nil {
-		log.Printf("[ERR] Sagemaker Notebook Instance (%s) did not start", d.Id())
+// sagemakerNotebookInstanceRegionAvailabilityWarning is the pure check
+// behind resourceAwsSagemakerNotebookInstanceCustomizeDiffInstanceTypeRegionAvailability.
+func sagemakerNotebookInstanceRegionAvailabilityWarning(instanceType, region string) string {
+	unavailable, ok := sagemakerNotebookInstanceRegionUnavailableFamilies[region]
+	if !ok {
+		return ""
 	}
 
-	return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+	family := strings.TrimPrefix(instanceType, "ml.")
+	family = strings.SplitN(family, ".", 2)[0]
+
+	alternative, ok := unavailable[family]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("instance_type %q is not available in region %q; consider an ml.%s instance type instead", instanceType, region, alternative)
 }
 
-func resourceAwsSagemakerNotebookInstanceRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).sagemakerconn
+// sagemakerNotebookInstanceIsGpuInstanceType reports whether instanceType is
+// in one of the GPU-accelerated families (ml.p*, ml.g*).
+func sagemakerNotebookInstanceIsGpuInstanceType(instanceType string) bool {
+	family := strings.TrimPrefix(instanceType, "ml.")
+	return strings.HasPrefix(family, "p") || strings.HasPrefix(family, "g")
+}
 
-	notebookInstanceRaw, _, err := SagemakerNotebookInstanceStateRefreshFunc(conn, d.Id())()
-	if err != nil {
-		if awserr, ok := err.(awserr.Error); ok {
-			if awserr.Code() == "ResourceNotFoundException" {
-				d.SetId("")
-				log.Printf("[LOG] Unable to find SageMaker notebook instance %q; removing from state file", d.Id())
-				return nil
-			}
+// sagemakerNotebookInstanceForceNewFields are the top-level schema fields
+// that force recreation on change, whether via a static ForceNew: true
+// (subnet_id, security_groups, direct_internet_access, root_access,
+// kms_key_id) or conditionally via one of the CustomizeDiff functions above
+// (platform_identifier). volume_size_in_gb, which forces recreation only on a
+// shrink, is not in the list. Kept as an explicit
+// list rather than derived from the schema because this SDK's
+// *schema.ResourceDiff doesn't expose a field's own ForceNew setting back to
+// CustomizeDiff -- only d.HasChange and d.ForceNew(field) to set one.
+var sagemakerNotebookInstanceForceNewFields = []string{
+	"subnet_id", "security_groups", "direct_internet_access", "root_access", "kms_key_id", "platform_identifier",
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffRetainOnReplaceWarning
+// warns, once per plan, when a ForceNew field is changing and
+// retain_on_replace isn't set: the replacement's destroy half is about to
+// take the EBS volume with it. CustomizeDiff is the one point in this
+// resource's lifecycle where a replace is actually distinguishable from a
+// plain destroy -- by the time Delete runs, that information is gone, which
+// is why retain_on_replace itself can only approximate "replace" with
+// "any destroy" there. See the retain_on_replace schema comment above.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffRetainOnReplaceWarning(d *schema.ResourceDiff) {
+	if d.Get("retain_on_replace").(bool) {
+		return
+	}
+
+	var changing []string
+	for _, field := range sagemakerNotebookInstanceForceNewFields {
+		if d.HasChange(field) {
+			changing = append(changing, field)
 		}
-		return err
 	}
 
-	if notebookInstanceRaw == nil {
-		d.SetId("")
-		log.Printf("[LOG] Unable to find SageMaker notebook instance %q; removing from state file", d.Id())
+	if len(changing) == 0 {
+		return
+	}
+
+	log.Printf("[WARN] Sagemaker Notebook Instance %q: change to %s will force a replacement, destroying the EBS volume backing the current instance; set retain_on_replace to stop it instead of deleting it", d.Id(), strings.Join(changing, ", "))
+}
+
+// sagemakerNotebookInstanceEiIncompatibleFamilies is the (deliberately
+// small) list of instance families Elastic Inference accelerators can't be
+// attached to: P2/P3/G4dn/G5 already ship their own GPU, and Inf1/Trn1 are
+// purpose-built accelerator instances, so AWS rejects accelerator_types on
+// any of them at apply time. See
+// https://docs.aws.amazon.com/sagemaker/latest/dg/ei.html for the full,
+// occasionally-updated compatibility matrix; this only covers the families
+// actually used with notebook instances.
+var sagemakerNotebookInstanceEiIncompatibleFamilies = []string{
+	"p2", "p3", "g4dn", "g5", "inf1", "trn1",
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorTypes rejects
+// accelerator_types at plan time when instance_type is one Elastic
+// Inference can't attach to, instead of letting CreateNotebookInstance or
+// UpdateNotebookInstance fail with it.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorTypes(d *schema.ResourceDiff) error {
+	return validateSagemakerNotebookInstanceAcceleratorTypes(d.Get("instance_type").(string), d.Get("accelerator_types").(*schema.Set).List())
+}
+
+// validateSagemakerNotebookInstanceAcceleratorTypes is the pure check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorTypes.
+func validateSagemakerNotebookInstanceAcceleratorTypes(instanceType string, acceleratorTypes []interface{}) error {
+	if len(acceleratorTypes) == 0 {
 		return nil
 	}
 
-	notebookInstance := notebookInstanceRaw.(*sagemaker.DescribeNotebookInstanceOutput)
+	family := strings.TrimPrefix(instanceType, "ml.")
+	if idx := strings.Index(family, "."); idx != -1 {
+		family = family[:idx]
+	}
 
-	d.Set("security_groups", flattenStringList(notebookInstance.SecurityGroups))
-	if err := d.Set("name", notebookInstance.NotebookInstanceName); err != nil {
-		return fmt.Errorf("error setting name for notebook instance %q: %s", d.Id(), err)
+	for _, f := range sagemakerNotebookInstanceEiIncompatibleFamilies {
+		if family == f {
+			return fmt.Errorf("accelerator_types is not supported on instance_type %q: Elastic Inference accelerators can't be attached to the %s family", instanceType, family)
+		}
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorEiEndpointAccess
+// warns when accelerator_types is set alongside direct_internet_access =
+// "Disabled" with no subnet_id configured: Elastic Inference needs to reach
+// its accelerator over a VPC endpoint or the internet, and with no subnet
+// there's no VPC endpoint route available either, so the accelerator is
+// unreachable once internet access is cut off. This is advisory only --
+// subnet_id is already required whenever direct_internet_access = "Disabled"
+// by resourceAwsSagemakerNotebookInstanceCustomizeDiffDirectInternetAccess,
+// but a subnet alone doesn't guarantee the VPC endpoint for SageMaker's
+// Elastic Inference service actually exists, so this can't be a hard error.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorEiEndpointAccess(d *schema.ResourceDiff) {
+	if msg := sagemakerNotebookInstanceAcceleratorEiEndpointAccessWarning(
+		d.Get("accelerator_types").(*schema.Set).List(),
+		d.Get("direct_internet_access").(string),
+		d.Get("subnet_id").(string),
+	); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
 	}
-	if err := d.Set("role_arn", notebookInstance.RoleArn); err != nil {
-		return fmt.Errorf("error setting role_arn for notebook instance %q: %s", d.Id(), err)
+}
+
+// sagemakerNotebookInstanceAcceleratorEiEndpointAccessWarning is the pure
+// check behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffAcceleratorEiEndpointAccess.
+func sagemakerNotebookInstanceAcceleratorEiEndpointAccessWarning(acceleratorTypes []interface{}, directInternetAccess, subnetId string) string {
+	if len(acceleratorTypes) == 0 {
+		return ""
 	}
-	if err := d.Set("instance_type", notebookInstance.InstanceType); err != nil {
-		return fmt.Errorf("error setting instance_type for notebook instance %q: %s", d.Id(), err)
+
+	if directInternetAccess != sagemaker.DirectInternetAccessDisabled {
+		return ""
 	}
-	if err := d.Set("subnet_id", notebookInstance.SubnetId); err != nil {
-		return fmt.Errorf("error setting subnet_id for notebook instance %q: %s", d.Id(), err)
+
+	if subnetId != "" {
+		return ""
 	}
 
-	if err := d.Set("kms_key_id", notebookInstance.KmsKeyId); err != nil {
-		return fmt.Errorf("error setting kms_key_id for notebook instance %q: %s", d.Id(), err)
+	return "accelerator_types is set with direct_internet_access = \"Disabled\" and no subnet_id configured; Elastic Inference needs internet or VPC endpoint access to reach the accelerator"
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyIdWarning logs that
+// changing kms_key_id will destroy and recreate the notebook instance along
+// with its EBS volume, losing any data on it. kms_key_id stays ForceNew
+// rather than moving to an in-place update path because
+// UpdateNotebookInstanceInput has no KmsKeyId parameter: SageMaker does not
+// support rotating an existing notebook's EBS volume encryption key, only
+// setting one at creation. As with the other warnings here, a [WARN] log
+// line is the closest this legacy SDK has to a non-blocking plan diagnostic.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyIdWarning(d *schema.ResourceDiff) {
+	if d.Id() != "" && d.HasChange("kms_key_id") {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: changing kms_key_id will destroy and recreate the notebook instance, losing any data on its EBS volume; SageMaker has no API to rotate the encryption key of an existing volume", d.Id())
 	}
+}
 
-	if err := d.Set("arn", notebookInstance.NotebookInstanceArn); err != nil {
-		return fmt.Errorf("error setting arn for notebook instance %q: %s", d.Id(), err)
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyDeletionWarning is a
+// best-effort plan-time warning, gated behind kms_key_id being set, that
+// describes the key and warns if it's PendingDeletion or Disabled -- either
+// of which will fail CreateNotebookInstance/StartNotebookInstance outright
+// once SageMaker actually tries to use the key to encrypt or decrypt the
+// notebook's EBS volume. A DescribeKey error (missing permission, key gone)
+// is logged and otherwise ignored, since this is only a foot-gun warning and
+// not a check this resource depends on for correctness; as with the other
+// warnings here, a [WARN] log line is the closest this legacy SDK has to a
+// non-blocking plan diagnostic.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyDeletionWarning(d *schema.ResourceDiff, meta interface{}) {
+	keyId, ok := d.GetOk("kms_key_id")
+	if !ok {
+		return
 	}
-	// d.Set("tags", tagsToMap()) TODO tags SageMaker functions
-	tagsOutput, err := conn.ListTags(&sagemaker.ListTagsInput{
-		ResourceArn: notebookInstance.NotebookInstanceArn,
+
+	sagemakerNotebookInstanceKmsKeyDeletionWarning(meta.(*AWSClient).kmsconn, d.Id(), keyId.(string))
+}
+
+// sagemakerNotebookInstanceKmsKeyDeletionWarning does the actual DescribeKey
+// call and logging behind
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffKmsKeyDeletionWarning, kept
+// separate so it can be unit tested against a mock kmsiface.KMSAPI without
+// constructing a *schema.ResourceDiff or *AWSClient.
+func sagemakerNotebookInstanceKmsKeyDeletionWarning(kmsconn kmsiface.KMSAPI, id, keyId string) {
+	out, err := kmsconn.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyId),
 	})
 	if err != nil {
-		log.Printf("[ERR] Error reading tags: %s", err)
-		return err
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: unable to check kms_key_id %q state, skipping pre-flight check: %s", id, keyId, err)
+		return
 	}
 
-	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
-		return fmt.Errorf("error setting tags for notebook instance %q: %s", d.Id(), err)
+	switch aws.StringValue(out.KeyMetadata.KeyState) {
+	case kms.KeyStatePendingDeletion:
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: kms_key_id %q is scheduled for deletion; the notebook instance will fail to start once the key is deleted", id, keyId)
+	case kms.KeyStateDisabled:
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: kms_key_id %q is disabled; the notebook instance will fail to start until it's re-enabled", id, keyId)
 	}
-	return nil
 }
 
-func resourceAwsSagemakerNotebookInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).sagemakerconn
+// sagemakerNotebookInstanceSpecializedFamilies maps notebook instance
+// families that require a specific platform_identifier prefix (e.g. AL2
+// rather than the Amazon Linux 1-based default) to the platform prefix they
+// require, so this map is the one place to update as AWS adds new
+// Graviton/Trainium/Inferentia families.
+var sagemakerNotebookInstanceSpecializedFamilies = map[string]string{
+	"ml.g5g":  "notebook-al2-",
+	"ml.trn1": "notebook-al2-",
+	"ml.inf2": "notebook-al2-",
+}
 
-	d.Partial(true)
+// sagemakerNotebookInstanceGpuDriverPlatformFamilies lists GPU instance
+// families whose current-generation NVIDIA drivers ship only for the
+// AL2-based platform_identifier; starting one of these on the legacy
+// AL1-based default can fail with a driver/CUDA mismatch. This is a
+// narrower, GPU-driver-specific list than
+// sagemakerNotebookInstanceSpecializedFamilies above (which covers non-x86
+// and AL2-only families regardless of GPU), and is deliberately a warning
+// rather than a hard validation error: AWS can add driver support for
+// additional platforms without this list being updated in lockstep, and a
+// hard block would then erroneously reject a combination that actually
+// works.
+var sagemakerNotebookInstanceGpuDriverPlatformFamilies = map[string]bool{
+	"ml.p4d":  true,
+	"ml.p4de": true,
+	"ml.p5":   true,
+	"ml.g5":   true,
+}
 
-	if err := setSagemakerTags(conn, d); err != nil {
-		return err
-	} else {
-		d.SetPartial("tags")
+// sagemakerNotebookInstanceGpuDriverCompatibilityWarning returns a
+// non-empty warning message when instanceType belongs to a family in
+// sagemakerNotebookInstanceGpuDriverPlatformFamilies but platformIdentifier
+// isn't set to (or doesn't start with) the AL2-based platform those
+// families' drivers require, or is empty. It's a pure function, separate
+// from resourceAwsSagemakerNotebookInstanceCustomizeDiffGpuDriverCompatibility,
+// so the family table can be unit tested without constructing a
+// *schema.ResourceDiff.
+func sagemakerNotebookInstanceGpuDriverCompatibilityWarning(instanceType, platformIdentifier string) string {
+	parts := strings.Split(instanceType, ".")
+	if len(parts) < 2 {
+		return ""
 	}
 
-	hasChanged := false
-	// Update
-	updateOpts := &sagemaker.UpdateNotebookInstanceInput{
-		NotebookInstanceName: aws.String(d.Get("name").(string)),
+	family := strings.Join(parts[:2], ".")
+
+	if !sagemakerNotebookInstanceGpuDriverPlatformFamilies[family] {
+		return ""
 	}
 
-	if d.HasChange("role_arn") {
-		updateOpts.RoleArn = aws.String(d.Get("role_arn").(string))
-		hasChanged = true
+	if strings.HasPrefix(platformIdentifier, "notebook-al2") {
+		return ""
 	}
 
-	if d.HasChange("instance_type") {
-		updateOpts.InstanceType = aws.String(d.Get("instance_type").(string))
-		hasChanged = true
+	return fmt.Sprintf("instance_type %q is a GPU family whose current NVIDIA drivers are packaged only for an AL2-based platform_identifier; %q may fail to load the GPU driver on start", instanceType, platformIdentifier)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffGpuDriverCompatibility
+// logs sagemakerNotebookInstanceGpuDriverCompatibilityWarning's warning via
+// the closest thing this legacy SDK has to a non-blocking plan diagnostic,
+// the same pattern the other CustomizeDiff warnings here use.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffGpuDriverCompatibility(d *schema.ResourceDiff) {
+	if msg := sagemakerNotebookInstanceGpuDriverCompatibilityWarning(d.Get("instance_type").(string), d.Get("platform_identifier").(string)); msg != "" {
+		log.Printf("[WARN] Sagemaker Notebook Instance %q: %s", d.Id(), msg)
 	}
+}
 
-	if hasChanged {
-		// Stop notebook
-		_, previousStatus, _ := SagemakerNotebookInstanceStateRefreshFunc(conn, d.Id())()
-		if err := stopSagemakerNotebookInstance(conn, d.Id()); err != nil {
-			return fmt.Errorf("Error stopping Sagemaker Notebook Instance: %s", err)
-		}
+// sagemakerNotebookInstancePlatformCompatibilityWarning returns a non-empty
+// warning message when instanceType belongs to a family in
+// sagemakerNotebookInstanceSpecializedFamilies but platformIdentifier isn't
+// set to (or doesn't start with) that family's required platform, or is
+// empty. It's a pure function, separate from
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformCompatibility, so
+// the family/platform compatibility table can be unit tested without
+// constructing a *schema.ResourceDiff.
+func sagemakerNotebookInstancePlatformCompatibilityWarning(instanceType, platformIdentifier string) string {
+	parts := strings.Split(instanceType, ".")
+	if len(parts) < 2 {
+		return ""
+	}
 
-		if _, err := conn.UpdateNotebookInstance(updateOpts); err != nil {
-			return fmt.Errorf("Error updating Sagemaker Notebook Instance: %s", err)
-		}
+	family := strings.Join(parts[:2], ".")
 
-		// Restart if needed
-		if previousStatus == sagemaker.NotebookInstanceStatusInService {
-			startOpts := &sagemaker.StartNotebookInstanceInput{
-				NotebookInstanceName: aws.String(d.Id()),
-			}
+	requiredPlatform, ok := sagemakerNotebookInstanceSpecializedFamilies[family]
+	if !ok {
+		return ""
+	}
 
-			if _, err := conn.StartNotebookInstance(startOpts); err != nil {
-				return fmt.Errorf("error starting Sagemaker Notebook Instance %q: %s", d.Id(), err)
-			} else if err := waitSagemakerNotebookInstanceStatus(conn, d.Id(), sagemaker.NotebookInstanceStatusInService, sagemaker.NotebookInstanceStatusFailed); err != nil {
-				return fmt.Errorf("error waiting for Sagemaker Notebook Instance %q to start: %s", d.Id(), err)
-			}
-		}
+	if strings.HasPrefix(platformIdentifier, requiredPlatform) {
+		return ""
 	}
 
-	d.Partial(false)
+	return fmt.Sprintf("instance_type %q requires a platform_identifier starting with %q; got %q", instanceType, requiredPlatform, platformIdentifier)
+}
 
-	return resourceAwsSagemakerNotebookInstanceRead(d, meta)
+// sagemakerNotebookInstanceDefaultViewVersions maps the version suffix of a
+// notebook instance's platform_identifier (e.g. "notebook-al2-v2" -> "v2")
+// to the UI it opens into by default. AWS introduced the "v2" generation of
+// platform identifiers specifically to switch the default from JupyterLab 1
+// to JupyterLab 3; every "v1" still opens into JupyterLab 1, and a platform
+// identifier with no version suffix at all opens into classic Jupyter.
+var sagemakerNotebookInstanceDefaultViewVersions = map[string]string{
+	"v1": "JupyterLab1",
+	"v2": "JupyterLab3",
 }
 
-func resourceAwsSagemakerNotebookInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+// sagemakerNotebookInstanceDefaultView derives the UI a notebook instance's
+// url opens into by default from its platform_identifier, since
+// DescribeNotebookInstance has no field that states this directly. An empty
+// platformIdentifier means AWS resolved it to its own current default at
+// create time, which today is the latest "v2"-generation platform; that may
+// change in a future AWS release this provider version doesn't know about.
+func sagemakerNotebookInstanceDefaultView(platformIdentifier string) string {
+	if platformIdentifier == "" {
+		return sagemakerNotebookInstanceDefaultViewVersions["v2"]
+	}
+
+	idx := strings.LastIndex(platformIdentifier, "-v")
+	if idx == -1 {
+		return "Jupyter"
+	}
+
+	version := platformIdentifier[idx+1:]
+	if view, ok := sagemakerNotebookInstanceDefaultViewVersions[version]; ok {
+		return view
+	}
+
+	return "Jupyter"
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSizeShrink forces
+// recreation when volume_size_in_gb decreases: SageMaker's EBS volume can be
+// grown in place via UpdateNotebookInstance, but it rejects shrinking the
+// same volume, so a shrink has to be handled as a replace instead of failing
+// the apply after everything else has already been updated.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSizeShrink(d *schema.ResourceDiff) error {
+	if !d.HasChange("volume_size_in_gb") {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange("volume_size_in_gb")
+	if newRaw.(int) < oldRaw.(int) {
+		return d.ForceNew("volume_size_in_gb")
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffDefaultCodeRepository(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("default_code_repository") {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange("default_code_repository")
+	oldVal, newVal := oldRaw.(string), newRaw.(string)
+	if oldVal == "" || newVal == "" || oldVal == newVal {
+		return nil
+	}
+
 	conn := meta.(*AWSClient).sagemakerconn
 
-	if err := stopSagemakerNotebookInstance(conn, d.Id()); err != nil {
-		return err
+	if sagemakerCodeRepositoryRefsEquivalent(conn, oldVal, newVal) {
+		return d.Clear("default_code_repository")
 	}
 
-	deleteOpts := &sagemaker.DeleteNotebookInstanceInput{
-		NotebookInstanceName: aws.String(d.Id()),
+	return nil
+}
+
+// sagemakerNotebookInstanceFamilyDefaultVolumeSizeGB maps GPU instance
+// families known to fill the schema's 5GB volume_size_in_gb default almost
+// immediately (framework/CUDA layers, pulled container images) to a more
+// realistic default. Deliberately small and limited to GPU families --
+// every other family is fine with the global 5GB default.
+var sagemakerNotebookInstanceFamilyDefaultVolumeSizeGB = map[string]int{
+	"ml.p3":   50,
+	"ml.p3dn": 50,
+	"ml.p4d":  50,
+	"ml.p4de": 50,
+	"ml.p5":   50,
+	"ml.g4dn": 50,
+	"ml.g5":   50,
+}
+
+// sagemakerNotebookInstanceFamilyDefaultVolumeSize returns the
+// family-appropriate default for volume_size_in_gb given configuredSize (the
+// value already in the diff, schema default included). If configuredSize is
+// anything other than the schema's own default, it's returned unchanged --
+// that's the field's ValidateFunc-enforced explicit override and this never
+// touches it. This can't distinguish "left at 5 by the schema default" from
+// "explicitly configured to 5" (the legacy SDK this provider is on doesn't
+// expose raw config presence to CustomizeDiff the way newer SDKs do), so an
+// instance_type in the table explicitly configured at exactly 5GB will still
+// be raised -- an acceptable tradeoff given the alternative is a GPU
+// notebook immediately filling a 5GB volume.
+func sagemakerNotebookInstanceFamilyDefaultVolumeSize(instanceType string, configuredSize int) int {
+	if configuredSize != sagemakerNotebookInstanceVolumeSizeMinGB {
+		return configuredSize
 	}
 
-	if _, err := conn.DeleteNotebookInstance(deleteOpts); err != nil {
-		return err
+	parts := strings.Split(instanceType, ".")
+	if len(parts) < 2 {
+		return configuredSize
 	}
 
-	return resource.Retry(10*time.Minute, func() *resource.RetryError {
-		_, status, _ := SagemakerNotebookInstanceStateRefreshFunc(conn, d.Id())()
+	family := strings.Join(parts[:2], ".")
 
-		if status == "" {
-			log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) deleted", d.Id())
-			return nil
-		}
+	if familyDefault, ok := sagemakerNotebookInstanceFamilyDefaultVolumeSizeGB[family]; ok {
+		return familyDefault
+	}
 
-		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Notebook Instance (%s) to be deleted", d.Id()))
-	})
+	return configuredSize
 }
 
-func SagemakerNotebookInstanceStateRefreshFunc(conn *sagemaker.SageMaker, name string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		describeNotebookInput := &sagemaker.DescribeNotebookInstanceInput{
-			NotebookInstanceName: aws.String(name),
-		}
-		notebook, err := conn.DescribeNotebookInstance(describeNotebookInput)
-		if err != nil {
-			if sagemakerErr, ok := err.(awserr.Error); ok && sagemakerErr.Code() == "ResourceNotFound" {
-				notebook = nil
-			} else {
-				log.Printf("Error on SagemakerNotebookInstanceStateRefreshFunc: %s", err)
-				return nil, "", err
-			}
-		}
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffFamilyVolumeSizeDefault
+// raises volume_size_in_gb's effective value from the schema's global 5GB
+// default to a family-appropriate one for GPU families, via
+// sagemakerNotebookInstanceFamilyDefaultVolumeSize. It runs before
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSize below so the
+// raised value (not the stale 5GB one) is what gets bounds-checked.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffFamilyVolumeSizeDefault(d *schema.ResourceDiff) error {
+	instanceType := d.Get("instance_type").(string)
+	configuredSize := d.Get("volume_size_in_gb").(int)
 
-		if notebook == nil {
-			return nil, "", nil
-		}
+	newSize := sagemakerNotebookInstanceFamilyDefaultVolumeSize(instanceType, configuredSize)
+	if newSize == configuredSize {
+		return nil
+	}
 
-		return notebook, *notebook.NotebookInstanceStatus, nil
+	return d.SetNew("volume_size_in_gb", newSize)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSize re-checks
+// volume_size_in_gb against SageMaker's documented global bounds (5GB-16384GB),
+// the same bounds already enforced by the field's ValidateFunc. A per-family
+// table of smaller EBS caps by instance_type isn't maintained here: SageMaker
+// doesn't document per-family notebook volume limits the way EC2 documents
+// per-family EBS throughput limits, so a hand-maintained table would just be
+// guessing. This exists mainly to surface the bound alongside instance_type
+// in the error, since a ValidateFunc can't see other fields.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSize(d *schema.ResourceDiff) error {
+	if !d.HasChange("volume_size_in_gb") {
+		return nil
 	}
+
+	return validateSagemakerNotebookInstanceVolumeSize(d.Get("volume_size_in_gb").(int), d.Get("instance_type").(string))
 }
 
-func stopSagemakerNotebookInstance(conn *sagemaker.SageMaker, id string) error {
-	stopOpts := &sagemaker.StopNotebookInstanceInput{
-		NotebookInstanceName: aws.String(id),
+// validateSagemakerNotebookInstanceVolumeSize checks volume_size_in_gb
+// against SageMaker's documented global bounds, pulled out of
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffVolumeSize so it's
+// testable without constructing a *schema.ResourceDiff.
+func validateSagemakerNotebookInstanceVolumeSize(size int, instanceType string) error {
+	if size >= sagemakerNotebookInstanceVolumeSizeMinGB && size <= sagemakerNotebookInstanceVolumeSizeMaxGB {
+		return nil
 	}
 
-	if _, err := conn.StopNotebookInstance(stopOpts); err != nil {
-		return fmt.Errorf("Error stopping Sagemaker Notebook Instance: %s", err)
+	return fmt.Errorf(
+		"volume_size_in_gb (%d) for instance_type %q is outside SageMaker's supported range of %d-%dGB",
+		size, instanceType, sagemakerNotebookInstanceVolumeSizeMinGB, sagemakerNotebookInstanceVolumeSizeMaxGB,
+	)
+}
+
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffDirectInternetAccess
+// requires a subnet_id whenever direct_internet_access is set to Disabled:
+// a notebook instance outside a VPC has no other route to the internet, so
+// AWS rejects that combination at create time anyway -- this just surfaces
+// the same error at plan time instead of after an apply has already failed.
+func resourceAwsSagemakerNotebookInstanceCustomizeDiffDirectInternetAccess(d *schema.ResourceDiff) error {
+	if d.Get("direct_internet_access").(string) != sagemaker.DirectInternetAccessDisabled {
+		return nil
+	}
+
+	if d.Get("subnet_id").(string) != "" {
+		return nil
 	}
 
-	return waitSagemakerNotebookInstanceStatus(conn, id, "Stopped")
+	return fmt.Errorf("direct_internet_access = %q requires subnet_id to be set", sagemaker.DirectInternetAccessDisabled)
 }
 
-func waitSagemakerNotebookInstanceStatus(conn *sagemaker.SageMaker, id string, desiredStatus ...string) error {
-	return resource.Retry(10*time.Minute, func() *resource.RetryError {
-		_, status, err := SagemakerNotebookInstanceStateRefreshFunc(conn, id)()
+// sagemakerCodeRepositoryRefsEquivalent reports whether a and b both refer to
+// the same aws_sagemaker_code_repository, resolving ARNs to their repository
+// name and git URLs to the repository that was created from them.
+func sagemakerCodeRepositoryRefsEquivalent(conn sagemakeriface.SageMakerAPI, a, b string) bool {
+	aName, ok := sagemakerCodeRepositoryNameFromRef(conn, a)
+	if !ok {
+		return false
+	}
 
-		if err == nil {
-			if status == "" {
-				log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) not found", id)
-				return nil
-			}
+	bName, ok := sagemakerCodeRepositoryNameFromRef(conn, b)
+	if !ok {
+		return false
+	}
 
-			for _, s := range desiredStatus {
-				if status == s {
-					log.Printf("[DEBUG] Sagemaker Notebook Instance (%s) is %s", id, s)
-					return nil
-				}
+	return aName == bName
+}
+
+// sagemakerCodeRepositoryNameFromRef resolves a default_code_repository value
+// to the underlying aws_sagemaker_code_repository name. ARNs are resolved
+// locally; git URLs require a ListCodeRepositories scan since the API has no
+// lookup by URL. Values that are already a bare name are returned as-is.
+func sagemakerCodeRepositoryNameFromRef(conn sagemakeriface.SageMakerAPI, ref string) (string, bool) {
+	if strings.HasPrefix(ref, "arn:") {
+		parts := strings.SplitN(ref, "code-repository/", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return "", false
+		}
+		return parts[1], true
+	}
+
+	if !strings.Contains(ref, "://") {
+		return ref, true
+	}
+
+	var name string
+	err := conn.ListCodeRepositoriesPages(&sagemaker.ListCodeRepositoriesInput{}, func(page *sagemaker.ListCodeRepositoriesOutput, lastPage bool) bool {
+		for _, c := range page.CodeRepositorySummaryList {
+			if c.GitConfig != nil && aws.StringValue(c.GitConfig.RepositoryUrl) == ref {
+				name = aws.StringValue(c.CodeRepositoryName)
+				return false
 			}
 		}
+		return !lastPage
+	})
+	if err != nil || name == "" {
+		return "", false
+	}
 
-		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Notebook Instance (%s) to be %s", id, desiredStatus))
+	return name, true
+}
+
+// flattenSagemakerNotebookInstanceSecurityGroups sorts securityGroups before
+// converting it to the []interface{} form schema.Set expects. security_groups
+// is a TypeSet, so Terraform itself doesn't preserve any particular element
+// order, but sorting here makes the value Read produces deterministic across
+// refreshes instead of depending on whatever order DescribeNotebookInstance
+// happens to return.
+func flattenSagemakerNotebookInstanceSecurityGroups(securityGroups []*string) []interface{} {
+	sorted := make([]*string, len(securityGroups))
+	copy(sorted, securityGroups)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return aws.StringValue(sorted[i]) < aws.StringValue(sorted[j])
 	})
+
+	return flattenStringList(sorted)
 }
 
-func fromStringPSliceToStringSliceSagemaker(sgs *[]*string) *[]string {
-	result := make([]string, 0, len(*sgs))
-	for _, sg := range *sgs {
-		result = append(result, *sg)
+// flattenSagemakerNotebookInstanceAdditionalCodeRepositories reconciles
+// DescribeNotebookInstance's AdditionalCodeRepositories against the
+// configured set, which may reference the same code repository by name,
+// ARN, or git URL (see sagemakerCodeRepositoryNameFromRef above). Each stored
+// repo that resolves to the same underlying repository as a configured
+// value is reported back in the user's configured form, so switching
+// between equivalent representations (e.g. name -> ARN) doesn't produce a
+// spurious diff. Stored repos that don't match anything configured --
+// attached out-of-band, outside this resource -- are reported as-is.
+func flattenSagemakerNotebookInstanceAdditionalCodeRepositories(conn sagemakeriface.SageMakerAPI, configured []interface{}, stored []*string) []interface{} {
+	configuredByName := make(map[string]string, len(configured))
+	for _, c := range configured {
+		ref := c.(string)
+		if name, ok := sagemakerCodeRepositoryNameFromRef(conn, ref); ok {
+			configuredByName[name] = ref
+		}
+	}
+
+	result := make([]interface{}, 0, len(stored))
+	for _, s := range stored {
+		storedRef := aws.StringValue(s)
+
+		if name, ok := sagemakerCodeRepositoryNameFromRef(conn, storedRef); ok {
+			if ref, matched := configuredByName[name]; matched {
+				result = append(result, ref)
+				continue
+			}
+		}
+
+		result = append(result, storedRef)
 	}
-	return &result
+
+	return result
 }
@@ -0,0 +1,498 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsSagemakerNotebookInstanceLifecycleConfiguration manages a
+// lifecycle config independently of any particular notebook instance, via
+// CreateNotebookInstanceLifecycleConfig/Update/Delete/Describe, so the same
+// config can be referenced by name from multiple
+// aws_sagemaker_notebook_instance resources' lifecycle_config_name.
+func resourceAwsSagemakerNotebookInstanceLifecycleConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCreate,
+		Read:   resourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead,
+		Update: resourceAwsSagemakerNotebookInstanceLifecycleConfigurationUpdate,
+		Delete: resourceAwsSagemakerNotebookInstanceLifecycleConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			// on_create and on_start are base64-encoded shell scripts. AWS
+			// stores them verbatim, so a file built with base64encode(file(...))
+			// can differ from the stored value by only a trailing newline;
+			// the DiffSuppressFunc ignores that difference.
+			"on_create": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"on_create_s3_uri"},
+				DiffSuppressFunc: suppressEquivalentBase64Content,
+				ValidateFunc:     validateSagemakerNotebookInstanceLifecycleHookContent,
+			},
+
+			"on_start": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"on_start_s3_uri"},
+				DiffSuppressFunc: suppressEquivalentBase64Content,
+				ValidateFunc:     validateSagemakerNotebookInstanceLifecycleHookContent,
+			},
+
+			// on_create_s3_uri/on_start_s3_uri let teams keep lifecycle
+			// scripts in S3 instead of inlining them in HCL; the object is
+			// fetched and base64-encoded at apply time into the same
+			// on_create/on_start fields the API expects, so Read always
+			// reflects the content the API actually stored.
+			"on_create_s3_uri": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"on_create"},
+				ValidateFunc:  validateSagemakerNotebookInstanceLifecycleHookS3Uri,
+			},
+
+			"on_start_s3_uri": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"on_start"},
+				ValidateFunc:  validateSagemakerNotebookInstanceLifecycleHookS3Uri,
+			},
+
+			// validate_script is opt-in because the syntax heuristic below
+			// can only ever be a pure-Go approximation of `bash -n` (no
+			// script is ever executed, or even shelled out to a real
+			// interpreter) and so can false-positive on legitimate scripts
+			// using quoting this heuristic doesn't understand.
+			"validate_script": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+	s3conn := meta.(*AWSClient).s3conn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(name),
+	}
+
+	onCreate, err := resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent(s3conn, d, "on_create", "on_create_s3_uri")
+	if err != nil {
+		return fmt.Errorf("error resolving on_create content: %s", err)
+	}
+	if onCreate != "" {
+		createOpts.OnCreate = []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(onCreate)},
+		}
+	}
+
+	onStart, err := resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent(s3conn, d, "on_start", "on_start_s3_uri")
+	if err != nil {
+		return fmt.Errorf("error resolving on_start content: %s", err)
+	}
+	if onStart != "" {
+		createOpts.OnStart = []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(onStart)},
+		}
+	}
+
+	log.Printf("[DEBUG] Sagemaker Notebook Instance Lifecycle Configuration create config: %#v", *createOpts)
+	output, err := conn.CreateNotebookInstanceLifecycleConfig(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Notebook Instance Lifecycle Configuration: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Notebook Instance Lifecycle Configuration ID: %s", d.Id())
+
+	// CreateNotebookInstanceLifecycleConfig doesn't accept tags directly, so
+	// they're applied via AddTags against the ARN the create call returns.
+	if v, ok := d.GetOk("tags"); ok {
+		tagsIn := v.(map[string]interface{})
+		if _, err := conn.AddTags(&sagemaker.AddTagsInput{
+			ResourceArn: output.NotebookInstanceLifecycleConfigArn,
+			Tags:        tagsFromMapSagemaker(tagsIn),
+		}); err != nil {
+			return fmt.Errorf("error tagging Sagemaker Notebook Instance Lifecycle Configuration (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead(d, meta)
+}
+
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	request := &sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(d.Id()),
+	}
+
+	lifecycleConfig, err := conn.DescribeNotebookInstanceLifecycleConfig(request)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFoundException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker notebook instance lifecycle configuration %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("name", lifecycleConfig.NotebookInstanceLifecycleConfigName); err != nil {
+		return fmt.Errorf("error setting name for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	// DescribeNotebookInstanceLifecycleConfig returns Content already
+	// base64-encoded, the same form base64encode() in config produces, so it
+	// is stored as-is rather than decoded/re-encoded; doing either would
+	// double-encode it on the next plan, including right after import.
+	if len(lifecycleConfig.OnCreate) > 0 {
+		if err := d.Set("on_create", lifecycleConfig.OnCreate[0].Content); err != nil {
+			return fmt.Errorf("error setting on_create for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+		}
+	}
+
+	if len(lifecycleConfig.OnStart) > 0 {
+		if err := d.Set("on_start", lifecycleConfig.OnStart[0].Content); err != nil {
+			return fmt.Errorf("error setting on_start for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("arn", lifecycleConfig.NotebookInstanceLifecycleConfigArn); err != nil {
+		return fmt.Errorf("error setting arn for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, lifecycleConfig.NotebookInstanceLifecycleConfigArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for notebook instance lifecycle configuration %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+	s3conn := meta.(*AWSClient).s3conn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	updateOpts := &sagemaker.UpdateNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(d.Id()),
+	}
+
+	onCreate, err := resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent(s3conn, d, "on_create", "on_create_s3_uri")
+	if err != nil {
+		return fmt.Errorf("error resolving on_create content: %s", err)
+	}
+	if onCreate != "" {
+		updateOpts.OnCreate = []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(onCreate)},
+		}
+	}
+
+	onStart, err := resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent(s3conn, d, "on_start", "on_start_s3_uri")
+	if err != nil {
+		return fmt.Errorf("error resolving on_start content: %s", err)
+	}
+	if onStart != "" {
+		updateOpts.OnStart = []*sagemaker.NotebookInstanceLifecycleHook{
+			{Content: aws.String(onStart)},
+		}
+	}
+
+	if _, err := conn.UpdateNotebookInstanceLifecycleConfig(updateOpts); err != nil {
+		return fmt.Errorf("Error updating Sagemaker Notebook Instance Lifecycle Configuration: %s", err)
+	}
+
+	return resourceAwsSagemakerNotebookInstanceLifecycleConfigurationRead(d, meta)
+}
+
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	deleteOpts := &sagemaker.DeleteNotebookInstanceLifecycleConfigInput{
+		NotebookInstanceLifecycleConfigName: aws.String(d.Id()),
+	}
+
+	if _, err := conn.DeleteNotebookInstanceLifecycleConfig(deleteOpts); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFoundException" {
+				return nil
+			}
+		}
+		return fmt.Errorf("Error deleting Sagemaker Notebook Instance Lifecycle Configuration: %s", err)
+	}
+
+	return nil
+}
+
+// resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCustomizeDiff
+// warns when on_create or on_start decodes to a script missing a
+// #!/bin/bash-style shebang or containing Windows CRLF line endings -- the
+// two most common reasons a lifecycle script silently fails to run. This is
+// advisory only: the legacy SDK has no non-blocking plan diagnostics API, so
+// a [WARN] log line is the closest equivalent to a true plan warning. Only
+// the inline on_create/on_start fields can be checked here; on_create_s3_uri
+// and on_start_s3_uri resolve their content at apply time, once the object
+// is actually fetched.
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	for _, hook := range []string{"on_create", "on_start"} {
+		content := d.Get(hook).(string)
+
+		if msg := sagemakerNotebookInstanceLifecycleHookScriptWarning(content); msg != "" {
+			log.Printf("[WARN] Sagemaker Notebook Instance Lifecycle Configuration %q: %s %s", d.Id(), hook, msg)
+		}
+
+		if d.Get("validate_script").(bool) {
+			if msg := sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning(content); msg != "" {
+				log.Printf("[WARN] Sagemaker Notebook Instance Lifecycle Configuration %q: %s %s", d.Id(), hook, msg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sagemakerNotebookInstanceLifecycleHookScriptWarning is the pure check
+// behind resourceAwsSagemakerNotebookInstanceLifecycleConfigurationCustomizeDiff.
+// content is base64-encoded, as stored in on_create/on_start; a value that
+// fails to decode or is empty produces no warning, since that's either not
+// a script yet or something validateSagemakerNotebookInstanceLifecycleHookContent
+// already rejects.
+func sagemakerNotebookInstanceLifecycleHookScriptWarning(content string) string {
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil || len(decoded) == 0 {
+		return ""
+	}
+
+	script := string(decoded)
+
+	var problems []string
+	if !strings.HasPrefix(script, "#!") {
+		problems = append(problems, "is missing a #!/bin/bash shebang")
+	}
+	if strings.Contains(script, "\r\n") {
+		problems = append(problems, "contains Windows CRLF line endings")
+	}
+
+	if len(problems) == 0 {
+		return ""
+	}
+
+	return "script " + strings.Join(problems, " and ")
+}
+
+// sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning is the pure
+// check behind validate_script. It never executes the script -- there is no
+// sandbox to run `bash -n` in here -- so it's limited to a few cheap,
+// line-oblivious heuristics: unbalanced single/double quotes and unbalanced
+// (), {}, [] pairs, which are the syntax mistakes most likely to slip
+// through review in a copy-pasted or hand-edited script. content is
+// base64-encoded, as stored in on_create/on_start; a value that fails to
+// decode or is empty produces no warning, for the same reason
+// sagemakerNotebookInstanceLifecycleHookScriptWarning doesn't warn on those.
+func sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning(content string) string {
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil || len(decoded) == 0 {
+		return ""
+	}
+
+	script := string(decoded)
+
+	var problems []string
+
+	if !sagemakerNotebookInstanceLifecycleHookQuotesBalanced(script, '\'') {
+		problems = append(problems, "has an unbalanced single quote")
+	}
+	if !sagemakerNotebookInstanceLifecycleHookQuotesBalanced(script, '"') {
+		problems = append(problems, "has an unbalanced double quote")
+	}
+
+	for _, pair := range []struct {
+		open, close rune
+		name        string
+	}{
+		{'(', ')', "parentheses"},
+		{'{', '}', "braces"},
+		{'[', ']', "brackets"},
+	} {
+		if depth := sagemakerNotebookInstanceLifecycleHookBracketDepth(script, pair.open, pair.close); depth != 0 {
+			problems = append(problems, fmt.Sprintf("has unbalanced %s", pair.name))
+		}
+	}
+
+	if len(problems) == 0 {
+		return ""
+	}
+
+	return "script " + strings.Join(problems, " and ")
+}
+
+// sagemakerNotebookInstanceLifecycleHookQuotesBalanced reports whether quote
+// appears an even number of times in script, ignoring any instance escaped
+// with a backslash. An odd count means a string was left open.
+func sagemakerNotebookInstanceLifecycleHookQuotesBalanced(script string, quote rune) bool {
+	count := 0
+	runes := []rune(script)
+	for i, r := range runes {
+		if r != quote {
+			continue
+		}
+		if i > 0 && runes[i-1] == '\\' {
+			continue
+		}
+		count++
+	}
+
+	return count%2 == 0
+}
+
+// sagemakerNotebookInstanceLifecycleHookBracketDepth returns the net depth
+// of open vs. close in script: zero means every open has a matching close,
+// a positive count means opens were left unclosed, and a negative count
+// means a close appeared with no matching open.
+func sagemakerNotebookInstanceLifecycleHookBracketDepth(script string, open, close rune) int {
+	depth := 0
+	for _, r := range script {
+		switch r {
+		case open:
+			depth++
+		case close:
+			depth--
+		}
+	}
+
+	return depth
+}
+
+// suppressEquivalentBase64Content decodes both sides of a base64-encoded
+// lifecycle script and compares them ignoring a single trailing newline, so
+// base64encode(file("script.sh")) doesn't produce a perpetual diff when the
+// stored content and the local file disagree only on a trailing newline.
+// Any value that fails to decode is left to the normal string comparison.
+func suppressEquivalentBase64Content(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	oldDecoded, err := base64.StdEncoding.DecodeString(old)
+	if err != nil {
+		return false
+	}
+
+	newDecoded, err := base64.StdEncoding.DecodeString(new)
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSuffix(string(oldDecoded), "\n") == strings.TrimSuffix(string(newDecoded), "\n")
+}
+
+// resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent
+// resolves a lifecycle hook's content, preferring the inline contentKey
+// field (already base64-encoded) and otherwise fetching and base64-encoding
+// the object at s3UriKey. ConflictsWith on the schema guarantees at most one
+// of the two is set. Returns an empty string if neither is set.
+func resourceAwsSagemakerNotebookInstanceLifecycleConfigurationHookContent(conn s3iface.S3API, d *schema.ResourceData, contentKey, s3UriKey string) (string, error) {
+	if v, ok := d.GetOk(contentKey); ok {
+		return v.(string), nil
+	}
+
+	v, ok := d.GetOk(s3UriKey)
+	if !ok {
+		return "", nil
+	}
+
+	return sagemakerNotebookInstanceLifecycleHookContentFromS3(conn, v.(string))
+}
+
+// sagemakerNotebookInstanceLifecycleHookContentFromS3 fetches the object at
+// uri (an s3://bucket/key URI already validated by
+// validateSagemakerNotebookInstanceLifecycleHookS3Uri) and base64-encodes its
+// contents, enforcing the same 16KB decoded size limit
+// validateSagemakerNotebookInstanceLifecycleHookContent checks for inline
+// content -- a limit that can only be enforced here, at apply time, since
+// the object's size isn't known at plan time.
+func sagemakerNotebookInstanceLifecycleHookContentFromS3(conn s3iface.S3API, uri string) (string, error) {
+	bucket, key, err := parseSagemakerNotebookInstanceLifecycleHookS3Uri(uri)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := conn.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error fetching %q: %s", uri, err)
+	}
+	defer output.Body.Close()
+
+	content, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading %q: %s", uri, err)
+	}
+
+	if len(content) > sagemakerNotebookInstanceLifecycleHookMaxContentBytes {
+		return "", fmt.Errorf("%q is %d bytes, which exceeds SageMaker's %d byte limit per lifecycle hook", uri, len(content), sagemakerNotebookInstanceLifecycleHookMaxContentBytes)
+	}
+
+	return base64.StdEncoding.EncodeToString(content), nil
+}
+
+// parseSagemakerNotebookInstanceLifecycleHookS3Uri splits an s3://bucket/key
+// URI into its bucket and key, assuming it already matches
+// sagemakerNotebookInstanceLifecycleHookS3UriRegexp.
+func parseSagemakerNotebookInstanceLifecycleHookS3Uri(uri string) (string, string, error) {
+	trimmed := strings.TrimPrefix(uri, "s3://")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%q is not a valid S3 URI (s3://bucket/key)", uri)
+	}
+
+	return parts[0], parts[1], nil
+}
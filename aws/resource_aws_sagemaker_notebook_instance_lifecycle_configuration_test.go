@@ -0,0 +1,473 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerNotebookInstanceLifecycleConfiguration_basic(t *testing.T) {
+	var config sagemaker.DescribeNotebookInstanceLifecycleConfigOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance_lifecycle_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationExists(resourceName, &config),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrSet(resourceName, "on_create"),
+					resource.TestCheckResourceAttrSet(resourceName, "on_start"),
+				),
+			},
+			{
+				// DescribeNotebookInstanceLifecycleConfig returns on_create/
+				// on_start content already base64-encoded, matching what
+				// base64encode() in config produces; importing and
+				// re-planning the same config must not double-encode it
+				// into a diff.
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstanceLifecycleConfiguration_s3Uri(t *testing.T) {
+	var config sagemaker.DescribeNotebookInstanceLifecycleConfigOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance_lifecycle_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfigS3Uri(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationExists(resourceName, &config),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrSet(resourceName, "on_create_s3_uri"),
+					resource.TestCheckResourceAttr(resourceName, "on_create", base64.StdEncoding.EncodeToString([]byte("echo on-create"))),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstanceLifecycleConfiguration_tags(t *testing.T) {
+	var config sagemaker.DescribeNotebookInstanceLifecycleConfigOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance_lifecycle_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfigTags(rName, "foo", "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationExists(resourceName, &config),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfigTags(rName, "foo2", "bar2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationExists(resourceName, &config),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo2", "bar2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationExists(n string, config *sagemaker.DescribeNotebookInstanceLifecycleConfigOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Notebook Instance Lifecycle Configuration ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+			NotebookInstanceLifecycleConfigName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*config = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceLifecycleConfigurationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_notebook_instance_lifecycle_configuration" {
+			continue
+		}
+
+		_, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+			NotebookInstanceLifecycleConfigName: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("Sagemaker Notebook Instance Lifecycle Configuration %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_notebook_instance_lifecycle_configuration" "test" {
+  name      = %[1]q
+  on_create = base64encode("echo on-create")
+  on_start  = base64encode("echo on-start")
+}
+`, rName)
+}
+
+func testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfigS3Uri(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "test" {
+  bucket        = %[1]q
+  force_destroy = true
+}
+
+resource "aws_s3_bucket_object" "test" {
+  bucket  = aws_s3_bucket.test.id
+  key     = "on-create.sh"
+  content = "echo on-create"
+}
+
+resource "aws_sagemaker_notebook_instance_lifecycle_configuration" "test" {
+  name             = %[1]q
+  on_create_s3_uri = "s3://${aws_s3_bucket.test.id}/${aws_s3_bucket_object.test.key}"
+}
+`, rName)
+}
+
+func testAccAWSSagemakerNotebookInstanceLifecycleConfigurationConfigTags(rName, tagKey, tagValue string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_notebook_instance_lifecycle_configuration" "test" {
+  name      = %[1]q
+  on_create = base64encode("echo on-create")
+  on_start  = base64encode("echo on-start")
+
+  tags = {
+    %[2]s = %[3]q
+  }
+}
+`, rName, tagKey, tagValue)
+}
+
+func TestSuppressEquivalentBase64Content(t *testing.T) {
+	testCases := []struct {
+		old        string
+		new        string
+		equivalent bool
+	}{
+		{
+			old:        base64.StdEncoding.EncodeToString([]byte("echo on-create\n")),
+			new:        base64.StdEncoding.EncodeToString([]byte("echo on-create")),
+			equivalent: true,
+		},
+		{
+			old:        base64.StdEncoding.EncodeToString([]byte("echo on-create")),
+			new:        base64.StdEncoding.EncodeToString([]byte("echo on-create")),
+			equivalent: true,
+		},
+		{
+			old:        base64.StdEncoding.EncodeToString([]byte("echo on-create")),
+			new:        base64.StdEncoding.EncodeToString([]byte("echo on-start")),
+			equivalent: false,
+		},
+		{
+			old:        base64.StdEncoding.EncodeToString([]byte("echo on-create")),
+			new:        "not-valid-base64!!",
+			equivalent: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		got := suppressEquivalentBase64Content("on_create", tc.old, tc.new, nil)
+		if got != tc.equivalent {
+			t.Errorf("test case %d: got %t, expected %t", i, got, tc.equivalent)
+		}
+	}
+}
+
+func TestSagemakerNotebookInstanceLifecycleHookScriptWarning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		script        string
+		expectWarning bool
+	}{
+		{
+			name:   "proper bash script is fine",
+			script: "#!/bin/bash\necho on-create\n",
+		},
+		{
+			name:          "missing shebang",
+			script:        "echo on-create\n",
+			expectWarning: true,
+		},
+		{
+			name:          "crlf line endings",
+			script:        "#!/bin/bash\r\necho on-create\r\n",
+			expectWarning: true,
+		},
+		{
+			name:          "missing shebang and crlf line endings",
+			script:        "echo on-create\r\n",
+			expectWarning: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := base64.StdEncoding.EncodeToString([]byte(tc.script))
+			msg := sagemakerNotebookInstanceLifecycleHookScriptWarning(content)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning, got none")
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning, got: %s", msg)
+			}
+		})
+	}
+
+	t.Run("empty content is fine", func(t *testing.T) {
+		if msg := sagemakerNotebookInstanceLifecycleHookScriptWarning(""); msg != "" {
+			t.Errorf("expected no warning, got: %s", msg)
+		}
+	})
+
+	t.Run("invalid base64 is left to validation, not this check", func(t *testing.T) {
+		if msg := sagemakerNotebookInstanceLifecycleHookScriptWarning("not valid base64!!"); msg != "" {
+			t.Errorf("expected no warning, got: %s", msg)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		script        string
+		expectWarning bool
+	}{
+		{
+			name:   "well-formed script is fine",
+			script: "#!/bin/bash\necho \"starting on-create for $(hostname)\"\n",
+		},
+		{
+			name:          "unbalanced double quote",
+			script:        "#!/bin/bash\necho \"starting on-create\n",
+			expectWarning: true,
+		},
+		{
+			name:          "unbalanced single quote",
+			script:        "#!/bin/bash\necho 'starting on-create\n",
+			expectWarning: true,
+		},
+		{
+			name:          "unbalanced parentheses",
+			script:        "#!/bin/bash\necho $(hostname\n",
+			expectWarning: true,
+		},
+		{
+			name:          "unbalanced braces",
+			script:        "#!/bin/bash\nif [ -f /tmp/x ]; then echo hi; fi\nfoo() { echo bar\n",
+			expectWarning: true,
+		},
+		{
+			name:   "escaped quote is not unbalanced",
+			script: "#!/bin/bash\necho \"it\\'s fine\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := base64.StdEncoding.EncodeToString([]byte(tc.script))
+			msg := sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning(content)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning, got none")
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning, got: %s", msg)
+			}
+		})
+	}
+
+	t.Run("empty content is fine", func(t *testing.T) {
+		if msg := sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning(""); msg != "" {
+			t.Errorf("expected no warning, got: %s", msg)
+		}
+	})
+
+	t.Run("invalid base64 is left to validation, not this check", func(t *testing.T) {
+		if msg := sagemakerNotebookInstanceLifecycleHookScriptSyntaxWarning("not valid base64!!"); msg != "" {
+			t.Errorf("expected no warning, got: %s", msg)
+		}
+	})
+}
+
+// mockS3API embeds s3iface.S3API so it satisfies the full interface while
+// only overriding GetObject, the only method
+// sagemakerNotebookInstanceLifecycleHookContentFromS3 calls.
+type mockS3API struct {
+	s3iface.S3API
+
+	getObject func(*s3.GetObjectInput) (*s3.GetObjectOutput, error)
+}
+
+func (m *mockS3API) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return m.getObject(input)
+}
+
+func TestSagemakerNotebookInstanceLifecycleHookContentFromS3(t *testing.T) {
+	t.Run("fetches and base64-encodes the object", func(t *testing.T) {
+		conn := &mockS3API{
+			getObject: func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				if aws.StringValue(input.Bucket) != "my-bucket" || aws.StringValue(input.Key) != "scripts/on-start.sh" {
+					t.Fatalf("unexpected GetObject input: %#v", input)
+				}
+				return &s3.GetObjectOutput{
+					Body: ioutil.NopCloser(strings.NewReader("echo on-start")),
+				}, nil
+			},
+		}
+
+		content, err := sagemakerNotebookInstanceLifecycleHookContentFromS3(conn, "s3://my-bucket/scripts/on-start.sh")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := base64.StdEncoding.EncodeToString([]byte("echo on-start"))
+		if content != want {
+			t.Errorf("got %q, want %q", content, want)
+		}
+	})
+
+	t.Run("rejects an object over the 16KB limit", func(t *testing.T) {
+		conn := &mockS3API{
+			getObject: func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return &s3.GetObjectOutput{
+					Body: ioutil.NopCloser(bytes.NewReader(make([]byte, sagemakerNotebookInstanceLifecycleHookMaxContentBytes+1))),
+				}, nil
+			},
+		}
+
+		if _, err := sagemakerNotebookInstanceLifecycleHookContentFromS3(conn, "s3://my-bucket/big-script.sh"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+
+	t.Run("wraps a GetObject error", func(t *testing.T) {
+		conn := &mockS3API{
+			getObject: func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return nil, awserr.New("NoSuchKey", "The specified key does not exist.", nil)
+			},
+		}
+
+		if _, err := sagemakerNotebookInstanceLifecycleHookContentFromS3(conn, "s3://my-bucket/missing.sh"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+
+	t.Run("rejects a malformed uri", func(t *testing.T) {
+		conn := &mockS3API{}
+
+		if _, err := sagemakerNotebookInstanceLifecycleHookContentFromS3(conn, "s3://my-bucket"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+}
+
+func TestParseSagemakerNotebookInstanceLifecycleHookS3Uri(t *testing.T) {
+	testCases := []struct {
+		name       string
+		uri        string
+		wantBucket string
+		wantKey    string
+		expectErr  bool
+	}{
+		{
+			name:       "simple key",
+			uri:        "s3://my-bucket/my-script.sh",
+			wantBucket: "my-bucket",
+			wantKey:    "my-script.sh",
+		},
+		{
+			name:       "nested key",
+			uri:        "s3://my-bucket/scripts/on-start.sh",
+			wantBucket: "my-bucket",
+			wantKey:    "scripts/on-start.sh",
+		},
+		{
+			name:      "missing key",
+			uri:       "s3://my-bucket",
+			expectErr: true,
+		},
+		{
+			name:      "missing key with trailing slash",
+			uri:       "s3://my-bucket/",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket, key, err := parseSagemakerNotebookInstanceLifecycleHookS3Uri(tc.uri)
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if bucket != tc.wantBucket || key != tc.wantKey {
+				t.Errorf("got bucket %q key %q, want bucket %q key %q", bucket, key, tc.wantBucket, tc.wantKey)
+			}
+		})
+	}
+}
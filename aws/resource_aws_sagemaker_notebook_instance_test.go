@@ -0,0 +1,6793 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/finder"
+	"github.com/fabiomatavelli/terraform-provider-aws/aws/internal/service/sagemaker/waiter"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerNotebookInstance_instanceTypeUpdateInPlace(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "instance_type", "ml.t2.medium"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t3.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "instance_type", "ml.t3.medium"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+					resource.TestCheckResourceAttr(resourceName, "failure_reason", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_instanceTypeUpdateWhileStopped(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceTypeStopped(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusStopped),
+				),
+			},
+			{
+				// Instance was Stopped before this plan; the update should leave
+				// it Stopped rather than starting it back up.
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceTypeStopped(rName, "ml.t3.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "instance_type", "ml.t3.medium"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusStopped),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_volumeSizeGrow(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigVolumeSize(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "volume_size_in_gb", "5"),
+				),
+			},
+			{
+				// Growing volume_size_in_gb is an online UpdateNotebookInstance
+				// call (stop -> update -> restart), not a ForceNew replace, so
+				// the notebook instance keeps the same ID.
+				Config: testAccAWSSagemakerNotebookInstanceConfigVolumeSize(rName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "id", rName),
+					resource.TestCheckResourceAttr(resourceName, "volume_size_in_gb", "20"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigVolumeSize(rName string, volumeSizeInGb int) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name              = %[1]q
+  role_arn          = aws_iam_role.test.arn
+  instance_type     = "ml.t2.medium"
+  volume_size_in_gb = %[2]d
+}
+`, rName, volumeSizeInGb)
+}
+
+func TestAccAWSSagemakerNotebookInstance_configurableTimeouts(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// A wider create timeout than the 10 minute default, for
+				// instance types that can take longer than that to reach
+				// InService (e.g. ml.p3); this only needs to not be rejected
+				// by the provider and to still reach InService within it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigTimeouts(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigTimeouts(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  timeouts {
+    create = "20m"
+    update = "45m"
+    delete = "20m"
+  }
+}
+`, rName)
+}
+
+func TestAccAWSSagemakerNotebookInstance_startOnCreateFalse(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigStartOnCreateFalse(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "start_on_create", "false"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusStopped),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigStartOnCreateFalse(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name            = %[1]q
+  role_arn        = aws_iam_role.test.arn
+  instance_type   = "ml.t2.medium"
+  start_on_create = false
+}
+`, rName)
+}
+
+func TestAccAWSSagemakerNotebookInstance_subnetIdForcesNewResource(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigSubnetId(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					// Both are correlation identifiers for tracing cost back to
+					// the underlying EC2 instance (and from there its EBS
+					// volume) that SageMaker doesn't otherwise expose directly.
+					resource.TestCheckResourceAttrSet(resourceName, "network_interface_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
+					// url is only populated once the instance reaches InService,
+					// which this step's default start_on_create already waits for.
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
+				),
+			},
+			{
+				// SageMaker has no API to move a notebook instance between
+				// subnets, so this is expected to destroy and recreate it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigSubnetId(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceRecreated(&notebook1, &notebook2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceRecreated(before, after *sagemaker.DescribeNotebookInstanceOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before, after := aws.TimeValue(before.CreationTime), aws.TimeValue(after.CreationTime); before.Equal(after) {
+			return fmt.Errorf("Sagemaker Notebook Instance not recreated, creation time unchanged: %s", before)
+		}
+
+		return nil
+	}
+}
+
+func TestAccAWSSagemakerNotebookInstance_rootAccessForcesNewResource(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigRootAccess(rName, sagemaker.RootAccessEnabled),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "root_access", sagemaker.RootAccessEnabled),
+				),
+			},
+			{
+				// The real UpdateNotebookInstance API has no RootAccess field,
+				// so root_access is ForceNew: this is expected to destroy and
+				// recreate the notebook instance rather than update it in place.
+				Config: testAccAWSSagemakerNotebookInstanceConfigRootAccess(rName, sagemaker.RootAccessDisabled),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "root_access", sagemaker.RootAccessDisabled),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigRootAccess(rName, rootAccess string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+  root_access   = %[2]q
+}
+`, rName, rootAccess)
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigSubnetId(rName string, subnetIndex int) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  count = 2
+
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = cidrsubnet(aws_vpc.test.cidr_block, 8, count.index)
+  availability_zone = data.aws_availability_zones.available.names[count.index]
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+data "aws_availability_zones" "available" {
+  state = "available"
+
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+  subnet_id     = aws_subnet.test[%[2]d].id
+}
+`, rName, subnetIndex)
+}
+
+func TestAccAWSSagemakerNotebookInstance_networkIsolationReadback(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigDirectInternetAccess(rName, sagemaker.DirectInternetAccessDisabled),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "direct_internet_access", sagemaker.DirectInternetAccessDisabled),
+					resource.TestCheckResourceAttr(resourceName, "network_isolation", "true"),
+					resource.TestCheckResourceAttr(resourceName, "has_vpc_config", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigDirectInternetAccess(rName, directInternetAccess string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = cidrsubnet(aws_vpc.test.cidr_block, 8, 0)
+  availability_zone = data.aws_availability_zones.available.names[0]
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+data "aws_availability_zones" "available" {
+  state = "available"
+
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name                   = %[1]q
+  role_arn               = aws_iam_role.test.arn
+  instance_type          = "ml.t2.medium"
+  subnet_id              = aws_subnet.test.id
+  direct_internet_access = %[2]q
+}
+`, rName, directInternetAccess)
+}
+
+func TestAccAWSSagemakerNotebookInstance_kmsKeyIdForcesNewResource(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigKmsKeyId(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+				),
+			},
+			{
+				// SageMaker has no API to rotate the EBS volume encryption
+				// key of an existing notebook instance, so this is expected
+				// to destroy and recreate it, losing the volume's data.
+				Config: testAccAWSSagemakerNotebookInstanceConfigKmsKeyId(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceRecreated(&notebook1, &notebook2),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigKmsKeyId(rName string, keyIndex int) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  count = 2
+
+  description             = "%[1]s-${count.index}"
+  deletion_window_in_days = 7
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+  kms_key_id    = aws_kms_key.test[%[2]d].arn
+}
+`, rName, keyIndex)
+}
+
+func TestAccAWSSagemakerNotebookInstance_securityGroupsForcesNewResource(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigSecurityGroups(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+				),
+			},
+			{
+				// UpdateNotebookInstanceInput has no security group
+				// parameter, so this is expected to destroy and recreate.
+				Config: testAccAWSSagemakerNotebookInstanceConfigSecurityGroups(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceRecreated(&notebook1, &notebook2),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_blueGreenReplacement exercises the
+// name_prefix + lifecycle { create_before_destroy = true } pattern this
+// resource supports for a zero-interruption platform upgrade: a ForceNew
+// change (here, platform_identifier) recreates the notebook under a fresh
+// name_prefix-generated name instead of erroring on a name collision, and
+// Create always waits for the replacement to reach InService before
+// returning, so the old instance's Delete never runs until the new one is
+// up.
+func TestAccAWSSagemakerNotebookInstance_blueGreenReplacement(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rNamePrefix := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigBlueGreen(rNamePrefix, "notebook-al2-v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "platform_identifier", "notebook-al2-v1"),
+					resource.TestMatchResourceAttr(resourceName, "name", regexp.MustCompile("^"+rNamePrefix)),
+				),
+			},
+			{
+				// platform_identifier is ForceNew (see
+				// resourceAwsSagemakerNotebookInstanceCustomizeDiffPlatformIdentifier).
+				// With create_before_destroy set in config, the replacement
+				// must come up under its own name_prefix-generated name
+				// before the original is destroyed.
+				Config: testAccAWSSagemakerNotebookInstanceConfigBlueGreen(rNamePrefix, "notebook-al2-v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "platform_identifier", "notebook-al2-v2"),
+					resource.TestMatchResourceAttr(resourceName, "name", regexp.MustCompile("^"+rNamePrefix)),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigBlueGreen(rNamePrefix, platformIdentifier string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name_prefix = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name_prefix          = %[1]q
+  role_arn             = aws_iam_role.test.arn
+  instance_type        = "ml.t2.medium"
+  platform_identifier  = %[2]q
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, rNamePrefix, platformIdentifier)
+}
+
+func TestAccAWSSagemakerNotebookInstance_securityGroupsSetDedupesEquivalentReferences(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The same security group is listed twice: once as a direct
+				// resource reference and once as that same ID upper-cased,
+				// simulating an interpolation that resolves to an
+				// equivalent but differently-cased value. The set hash
+				// normalizes both before hashing, so they must dedupe to a
+				// single member instead of a spurious two-element diff.
+				Config: testAccAWSSagemakerNotebookInstanceConfigSecurityGroupsDedupe(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigSecurityGroupsDedupe(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_security_group" "test" {
+  name   = %[1]q
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  security_groups = [
+    aws_security_group.test.id,
+    upper(aws_security_group.test.id),
+  ]
+}
+`, rName)
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigSecurityGroups(rName string, securityGroupIndex int) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_security_group" "test" {
+  count = 2
+
+  name   = "%[1]s-${count.index}"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name            = %[1]q
+  role_arn        = aws_iam_role.test.arn
+  instance_type   = "ml.t2.medium"
+  security_groups = [aws_security_group.test[%[2]d].id]
+}
+`, rName, securityGroupIndex)
+}
+
+func TestAccAWSSagemakerNotebookInstance_lifecycleConfigNameDrift(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigLifecycleConfigName(rName, "aws_sagemaker_notebook_instance_lifecycle_configuration.test1.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttrPair(resourceName, "lifecycle_config_name", "aws_sagemaker_notebook_instance_lifecycle_configuration.test1", "name"),
+				),
+			},
+			{
+				// Simulate someone attaching a different lifecycle config via
+				// the console: the next plan must pick this up as drift
+				// against the configured value instead of silently ignoring it.
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+					if _, err := conn.UpdateNotebookInstance(&sagemaker.UpdateNotebookInstanceInput{
+						NotebookInstanceName: aws.String(rName),
+						LifecycleConfigName:  aws.String(rName + "-2"),
+					}); err != nil {
+						t.Fatalf("error updating notebook instance lifecycle config out of band: %s", err)
+					}
+
+					if _, err := waiter.NotebookInstanceInService(conn, rName, 10*time.Minute); err != nil {
+						t.Fatalf("error waiting for notebook instance to settle after out-of-band update: %s", err)
+					}
+				},
+				Config:             testAccAWSSagemakerNotebookInstanceConfigLifecycleConfigName(rName, "aws_sagemaker_notebook_instance_lifecycle_configuration.test1.name"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_roleArnDrift confirms that role_arn --
+// Required, not Computed -- correctly surfaces drift when the notebook's
+// role is changed out-of-band (e.g. via the console), the same way
+// TestAccAWSSagemakerNotebookInstance_lifecycleConfigNameDrift above does
+// for lifecycle_config_name.
+func TestAccAWSSagemakerNotebookInstance_roleArnDrift(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	var role2Arn string
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigRoleArnDrift(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttrPair(resourceName, "role_arn", "aws_iam_role.test", "arn"),
+					func(s *terraform.State) error {
+						rs, ok := s.RootModule().Resources["aws_iam_role.test2"]
+						if !ok {
+							return fmt.Errorf("Not found: aws_iam_role.test2")
+						}
+						role2Arn = rs.Primary.Attributes["arn"]
+						return nil
+					},
+				),
+			},
+			{
+				// Simulate someone re-pointing the notebook at a different
+				// role via the console: the next plan must pick this up as
+				// drift against the configured role_arn instead of silently
+				// keeping whatever role the describe returns.
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+					notebookInstance, err := conn.DescribeNotebookInstance(&sagemaker.DescribeNotebookInstanceInput{
+						NotebookInstanceName: aws.String(rName),
+					})
+					if err != nil {
+						t.Fatalf("error describing notebook instance before out-of-band role change: %s", err)
+					}
+
+					if aws.StringValue(notebookInstance.NotebookInstanceStatus) == sagemaker.NotebookInstanceStatusInService {
+						if _, err := conn.StopNotebookInstance(&sagemaker.StopNotebookInstanceInput{
+							NotebookInstanceName: aws.String(rName),
+						}); err != nil {
+							t.Fatalf("error stopping notebook instance before out-of-band role change: %s", err)
+						}
+						if _, err := waiter.NotebookInstanceStopped(conn, rName, 10*time.Minute); err != nil {
+							t.Fatalf("error waiting for notebook instance to stop before out-of-band role change: %s", err)
+						}
+					}
+
+					if _, err := conn.UpdateNotebookInstance(&sagemaker.UpdateNotebookInstanceInput{
+						NotebookInstanceName: aws.String(rName),
+						RoleArn:              aws.String(role2Arn),
+					}); err != nil {
+						t.Fatalf("error updating notebook instance role out of band: %s", err)
+					}
+
+					if _, err := conn.StartNotebookInstance(&sagemaker.StartNotebookInstanceInput{
+						NotebookInstanceName: aws.String(rName),
+					}); err != nil {
+						t.Fatalf("error restarting notebook instance after out-of-band role change: %s", err)
+					}
+					if _, err := waiter.NotebookInstanceInService(conn, rName, 10*time.Minute); err != nil {
+						t.Fatalf("error waiting for notebook instance to settle after out-of-band role change: %s", err)
+					}
+				},
+				Config:             testAccAWSSagemakerNotebookInstanceConfigRoleArnDrift(rName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigRoleArnDrift(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role" "test2" {
+  name = "%[1]s-2"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  depends_on = [aws_iam_role.test2]
+}
+`, rName)
+}
+
+func TestAccAWSSagemakerNotebookInstance_lifecycleConfigNameDetach(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigLifecycleConfigName(rName, "aws_sagemaker_notebook_instance_lifecycle_configuration.test1.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttrPair(resourceName, "lifecycle_config_name", "aws_sagemaker_notebook_instance_lifecycle_configuration.test1", "name"),
+				),
+			},
+			{
+				// Clearing lifecycle_config_name must actually detach the
+				// config via DisassociateLifecycleConfig, not just drop it
+				// from config while AWS keeps the old one attached.
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_config_name", ""),
+					func(s *terraform.State) error {
+						if name := aws.StringValue(notebook.NotebookInstanceLifecycleConfigName); name != "" {
+							return fmt.Errorf("expected no lifecycle config attached after detaching, got: %s", name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_importLifecycleConfigName(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigLifecycleConfigName(rName, "aws_sagemaker_notebook_instance_lifecycle_configuration.test1.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttrPair(resourceName, "lifecycle_config_name", "aws_sagemaker_notebook_instance_lifecycle_configuration.test1", "name"),
+				),
+			},
+			{
+				// Simulates importing a brownfield notebook that already has a
+				// lifecycle config attached out-of-band: import must read
+				// lifecycle_config_name straight off DescribeNotebookInstance,
+				// the same path Read takes on every refresh.
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"url", "network_interface_id"},
+			},
+			{
+				// Re-planning without lifecycle_config_name in config must
+				// propose detaching the config import picked up, rather than
+				// silently leaving it attached and out of sync with state.
+				Config:             testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigLifecycleConfigName(rName, lifecycleConfigNameRef string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance_lifecycle_configuration" "test1" {
+  name = %[1]q
+}
+
+resource "aws_sagemaker_notebook_instance_lifecycle_configuration" "test2" {
+  name = "%[1]s-2"
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name                  = %[1]q
+  role_arn              = aws_iam_role.test.arn
+  instance_type         = "ml.t2.medium"
+  lifecycle_config_name = %[2]s
+}
+`, rName, lifecycleConfigNameRef)
+}
+
+// TestAccAWSSagemakerNotebookInstance_inlineLifecycleConfig covers both
+// lifecycle_config_name (a reference to a separately managed
+// aws_sagemaker_notebook_instance_lifecycle_configuration, exercised by
+// TestAccAWSSagemakerNotebookInstance_lifecycleConfigNameDrift above) and
+// inline_lifecycle_config, the mutually exclusive alternative that creates
+// and owns an implicit one named after the notebook instance itself.
+func TestAccAWSSagemakerNotebookInstance_inlineLifecycleConfig(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInlineLifecycleConfig(rName, "echo on-create"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "inline_lifecycle_config.#", "1"),
+					testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists(rName),
+				),
+			},
+			{
+				// Changing the inlined script pushes new content to the same
+				// implicit lifecycle config rather than replacing it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigInlineLifecycleConfig(rName, "echo updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists(rName),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists confirms
+// the implicit lifecycle config inline_lifecycle_config creates -- named
+// after the notebook instance -- actually exists.
+func testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists(notebookInstanceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+		if _, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+			NotebookInstanceLifecycleConfigName: aws.String(notebookInstanceName),
+		}); err != nil {
+			return fmt.Errorf("expected an implicit lifecycle config named %q for inline_lifecycle_config, got: %s", notebookInstanceName, err)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigDestroy
+// confirms destroying the notebook instance also cleaned up the implicit
+// lifecycle config it owned, not just the notebook instance itself.
+func testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigDestroy(s *terraform.State) error {
+	if err := testAccCheckAWSSagemakerNotebookInstanceDestroy(s); err != nil {
+		return err
+	}
+
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_notebook_instance" {
+			continue
+		}
+
+		if _, err := conn.DescribeNotebookInstanceLifecycleConfig(&sagemaker.DescribeNotebookInstanceLifecycleConfigInput{
+			NotebookInstanceLifecycleConfigName: aws.String(rs.Primary.ID),
+		}); err == nil {
+			return fmt.Errorf("implicit lifecycle config %q still exists after its Sagemaker Notebook Instance was destroyed", rs.Primary.ID)
+		} else if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ResourceNotFoundException" {
+			return fmt.Errorf("error confirming implicit lifecycle config %q was cleaned up: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigInlineLifecycleConfig(rName, onCreateScript string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  inline_lifecycle_config {
+    on_create = base64encode(%[2]q)
+  }
+}
+`, rName, onCreateScript)
+}
+
+// TestAccAWSSagemakerNotebookInstance_defaultUi covers default_ui, which --
+// like inline_lifecycle_config -- creates and owns an implicit lifecycle
+// config named after the notebook instance, since SageMaker has no direct
+// Create/UpdateNotebookInstanceInput field for the default Jupyter UI.
+func TestAccAWSSagemakerNotebookInstance_defaultUi(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigDefaultUi(rName, "JupyterLab"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "default_ui", "JupyterLab"),
+					testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists(rName),
+				),
+			},
+			{
+				// Switching the default UI pushes new content to the same
+				// implicit lifecycle config rather than replacing it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigDefaultUi(rName, "Jupyter"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "default_ui", "Jupyter"),
+					testAccCheckAWSSagemakerNotebookInstanceInlineLifecycleConfigExists(rName),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigDefaultUi(rName, defaultUi string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+  default_ui    = %[2]q
+}
+`, rName, defaultUi)
+}
+
+func TestSagemakerNotebookInstanceDefaultUiOnStartScript(t *testing.T) {
+	testCases := []struct {
+		name      string
+		defaultUi string
+		want      string
+	}{
+		{name: "Jupyter", defaultUi: "Jupyter", want: "/tree"},
+		{name: "JupyterLab", defaultUi: "JupyterLab", want: "/lab"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			script := sagemakerNotebookInstanceDefaultUiOnStartScript(tc.defaultUi)
+			if !strings.Contains(script, tc.want) {
+				t.Errorf("expected script for %q to contain %q, got: %s", tc.defaultUi, tc.want, script)
+			}
+
+			if got := sagemakerNotebookInstanceDefaultUiFromOnStartScript(script); got != tc.defaultUi {
+				t.Errorf("round trip: sagemakerNotebookInstanceDefaultUiFromOnStartScript(sagemakerNotebookInstanceDefaultUiOnStartScript(%q)) = %q, want %q", tc.defaultUi, got, tc.defaultUi)
+			}
+		})
+	}
+
+	t.Run("unrecognized script yields empty string", func(t *testing.T) {
+		if got := sagemakerNotebookInstanceDefaultUiFromOnStartScript("#!/bin/bash\necho hello\n"); got != "" {
+			t.Errorf("expected empty string for an unrecognized script, got %q", got)
+		}
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_noVpcConfig(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// No subnet_id/security_groups in config: create must omit
+				// SecurityGroupIds entirely rather than sending an empty
+				// slice, which the API rejects for a non-VPC notebook.
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "subnet_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "has_vpc_config", "false"),
+				),
+			},
+			{
+				// A second plan against the same non-VPC config must be
+				// empty: security_groups should read back as an explicit
+				// empty set every time, not flip-flop against a nil value.
+				Config:   testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_acceleratorTypesRemoval(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, `["ml.eia1.medium"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "accelerator_types.#", "1"),
+				),
+			},
+			{
+				// Removing the set entirely must send DisassociateAcceleratorTypes
+				// in the update, otherwise AWS keeps the old accelerator attached.
+				Config: testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "accelerator_types.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_acceleratorTypesReorderNoDiff guards
+// accelerator_types being modeled as a TypeSet: DescribeNotebookInstance
+// doesn't promise to echo AcceleratorTypes back in configuration order, so
+// reapplying the same set of accelerators listed in a different order must
+// produce an empty plan instead of a spurious diff.
+func TestAccAWSSagemakerNotebookInstance_acceleratorTypesReorderNoDiff(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, `["ml.eia1.medium", "ml.eia1.large"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "accelerator_types.#", "2"),
+				),
+			},
+			{
+				Config:             testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, `["ml.eia1.large", "ml.eia1.medium"]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, acceleratorTypes string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name              = %[1]q
+  role_arn          = aws_iam_role.test.arn
+  instance_type     = "ml.t2.medium"
+  accelerator_types = %[2]s
+}
+`, rName, acceleratorTypes)
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, instanceType string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = %[2]q
+}
+`, rName, instanceType)
+}
+
+func TestAccAWSSagemakerNotebookInstance_forceRestart(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigForceRestart(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+			{
+				// Nothing else in config changed, but bumping force_restart
+				// should still drive the notebook through a stop/restart
+				// cycle and leave it InService again, without recreating it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigForceRestart(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+					resource.TestCheckResourceAttr(resourceName, "force_restart.revision", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigForceRestart(rName, revision string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  force_restart = {
+    revision = %[2]q
+  }
+}
+`, rName, revision)
+}
+
+func TestAccAWSSagemakerNotebookInstance_restartTriggers(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigRestartTriggers(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+			{
+				// Nothing else in config changed, but bumping restart_triggers
+				// should still drive the notebook through a stop/restart
+				// cycle and leave it InService again, without recreating it.
+				Config: testAccAWSSagemakerNotebookInstanceConfigRestartTriggers(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+					resource.TestCheckResourceAttr(resourceName, "restart_triggers.revision", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigRestartTriggers(rName, revision string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  restart_triggers = {
+    revision = %[2]q
+  }
+}
+`, rName, revision)
+}
+
+// TestAccAWSSagemakerNotebookInstance_tagsOnlyUpdateNoDiff guards against tag
+// reconciliation or the trailing Read (security_groups flattening in
+// particular) introducing spurious plan noise on an update that only
+// touches tags -- each step below is checked for a clean plan immediately
+// after apply as part of resource.Test's normal step handling.
+func TestAccAWSSagemakerNotebookInstance_tagsOnlyUpdateNoDiff(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigTags1(rName, "Environment", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "test"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigTags1(rName, "Environment", "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "prod"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_tagsPresentAtFirstRead confirms tags
+// are set on the create call itself, not reconciled afterward: the very
+// first Check after apply already sees them, and a config-only-tags second
+// apply with the same tags produces no plan, since nothing is waiting on a
+// trailing AddTags to converge.
+func TestAccAWSSagemakerNotebookInstance_tagsPresentAtFirstRead(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigTags1(rName, "Environment", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "test"),
+				),
+			},
+			{
+				Config:   testAccAWSSagemakerNotebookInstanceConfigTags1(rName, "Environment", "test"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigTags1(rName, tagKey, tagValue string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  tags = {
+    %[2]q = %[3]q
+  }
+}
+`, rName, tagKey, tagValue)
+}
+
+func TestAccAWSSagemakerNotebookInstance_tagsAllIncludesProviderDefaultTags(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigProviderDefaultTagsAndResourceTags(rName, "CostCenter", "1234", "Environment", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "test"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.CostCenter", "1234"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigProviderDefaultTagsAndResourceTags(rName, providerTagKey, providerTagValue, resourceTagKey, resourceTagValue string) string {
+	return fmt.Sprintf(`
+provider "aws" {
+  default_tags {
+    tags = {
+      %[2]q = %[3]q
+    }
+  }
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  tags = {
+    %[4]q = %[5]q
+  }
+}
+`, rName, providerTagKey, providerTagValue, resourceTagKey, resourceTagValue)
+}
+
+func TestAccAWSSagemakerNotebookInstance_skipDestroy(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceSkipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigSkipDestroy(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "skip_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSSagemakerNotebookInstanceSkipDestroy stands in for the usual
+// CheckDestroy: with skip_destroy set, `terraform destroy` only removes the
+// notebook instance from state, so it verifies the instance is still present
+// in the account and then deletes it directly so the test doesn't leak a
+// billable resource.
+func testAccCheckAWSSagemakerNotebookInstanceSkipDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_notebook_instance" {
+			continue
+		}
+
+		out, err := conn.DescribeNotebookInstance(&sagemaker.DescribeNotebookInstanceInput{
+			NotebookInstanceName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("expected Sagemaker Notebook Instance %q to still exist after destroy with skip_destroy set: %s", rs.Primary.ID, err)
+		}
+
+		// skip_destroy's safety mechanism is stopping the instance, not just
+		// leaving it in state without deleting it: a left-running notebook
+		// instance is still billing research teams for compute, which defeats
+		// the point of using skip_destroy over just not destroying at all.
+		if status := aws.StringValue(out.NotebookInstanceStatus); status != sagemaker.NotebookInstanceStatusStopped {
+			return fmt.Errorf("expected Sagemaker Notebook Instance %q to be Stopped after destroy with skip_destroy set, got: %s", rs.Primary.ID, status)
+		}
+
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, rs.Primary.ID, 30*time.Minute, 0); err != nil {
+			return err
+		}
+
+		if _, err := conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{
+			NotebookInstanceName: aws.String(rs.Primary.ID),
+		}); err != nil {
+			return fmt.Errorf("error cleaning up Sagemaker Notebook Instance %q left behind by skip_destroy: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigSkipDestroy(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+  skip_destroy  = true
+}
+`, rName)
+}
+
+// TestAccAWSSagemakerNotebookInstance_retainOnReplace exercises
+// retain_on_replace's approximation of "scoped to replacement": the second
+// step changes name, a ForceNew field, which destroys the original instance
+// as half of the replace. With retain_on_replace set, that destroy stops
+// rather than deletes it, so the original instance (tracked separately by
+// its original name, captured before the change) is still there afterward,
+// alongside the new one the replace created under the new name. The rename
+// (rather than e.g. root_access, which the replace could otherwise keep the
+// same name through) is deliberate: retain_on_replace only stops the old
+// instance, it doesn't also free its name, so reusing the same name on the
+// replacement would collide with the original it just kept around.
+func TestAccAWSSagemakerNotebookInstance_retainOnReplace(t *testing.T) {
+	var original, replacement sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	rNameReplacement := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceRetainOnReplaceDestroy(&original),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigRetainOnReplace(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &original),
+					resource.TestCheckResourceAttr(resourceName, "retain_on_replace", "true"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigRetainOnReplace(rName, rNameReplacement),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &replacement),
+					resource.TestCheckResourceAttr(resourceName, "name", rNameReplacement),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSSagemakerNotebookInstanceRetainOnReplaceDestroy stands in
+// for the usual CheckDestroy: original, captured by the test's first step
+// before the ForceNew replace, names the instance that replace's destroy
+// half should have only stopped, not deleted. It verifies that instance is
+// still present under its own ARN and then deletes it directly so the test
+// doesn't leak a billable resource.
+func testAccCheckAWSSagemakerNotebookInstanceRetainOnReplaceDestroy(original *sagemaker.DescribeNotebookInstanceOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+		name := aws.StringValue(original.NotebookInstanceName)
+
+		if _, err := conn.DescribeNotebookInstance(&sagemaker.DescribeNotebookInstanceInput{
+			NotebookInstanceName: aws.String(name),
+		}); err != nil {
+			return fmt.Errorf("expected original Sagemaker Notebook Instance %q to still exist after being replaced with retain_on_replace set: %s", name, err)
+		}
+
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, name, 30*time.Minute, 0); err != nil {
+			return err
+		}
+
+		if _, err := conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{
+			NotebookInstanceName: aws.String(name),
+		}); err != nil {
+			return fmt.Errorf("error cleaning up original Sagemaker Notebook Instance %q left behind by retain_on_replace: %s", name, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigRetainOnReplace(roleName, notebookName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name              = %[2]q
+  role_arn          = aws_iam_role.test.arn
+  instance_type     = "ml.t2.medium"
+  retain_on_replace = true
+}
+`, roleName, notebookName)
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigInstanceTypeStopped(rName, instanceType string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name           = %[1]q
+  role_arn       = aws_iam_role.test.arn
+  instance_type  = %[2]q
+  desired_status = "Stopped"
+}
+`, rName, instanceType)
+}
+
+func TestFlattenStringListSecurityGroups(t *testing.T) {
+	securityGroups := []*string{
+		aws.String("sg-1234abcd"),
+		aws.String("sg-5678efgh"),
+	}
+
+	got := flattenStringList(securityGroups)
+	want := []interface{}{"sg-1234abcd", "sg-5678efgh"}
+
+	if len(got) != len(want) {
+		t.Fatalf("flattenStringList(%v) = %v; want %v", securityGroups, got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("flattenStringList(%v)[%d] = %v; want %v", securityGroups, i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceType(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expectError bool
+		expectWarn  bool
+	}{
+		{
+			name:  "known instance type",
+			value: "ml.t3.medium",
+		},
+		{
+			name:       "unknown but well-shaped instance type",
+			value:      "ml.t4g.medium",
+			expectWarn: true,
+		},
+		{
+			name:       "deprecated family",
+			value:      "ml.t2.medium",
+			expectWarn: true,
+		},
+		{
+			name:        "missing ml. prefix",
+			value:       "t2.medium",
+			expectError: true,
+		},
+		{
+			name:        "malformed",
+			value:       "not-an-instance-type",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ws, errors := validateSagemakerNotebookInstanceType(tc.value, "instance_type")
+
+			if tc.expectError && len(errors) == 0 {
+				t.Errorf("validateSagemakerNotebookInstanceType(%q) expected an error, got none", tc.value)
+			}
+			if !tc.expectError && len(errors) != 0 {
+				t.Errorf("validateSagemakerNotebookInstanceType(%q) expected no error, got: %v", tc.value, errors)
+			}
+			if tc.expectWarn && len(ws) == 0 {
+				t.Errorf("validateSagemakerNotebookInstanceType(%q) expected a warning, got none", tc.value)
+			}
+			if !tc.expectWarn && len(ws) != 0 {
+				t.Errorf("validateSagemakerNotebookInstanceType(%q) expected no warning, got: %v", tc.value, ws)
+			}
+		})
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "equal strings", a: "ml.t3.medium", b: "ml.t3.medium", want: 0},
+		{name: "empty vs non-empty", a: "", b: "abc", want: 3},
+		{name: "single substitution", a: "ml.t3.medium", b: "ml.t3.mediun", want: 1},
+		{name: "single insertion", a: "ml.t3.mediu", b: "ml.t3.medium", want: 1},
+		{name: "single deletion", a: "ml.t3.medium", b: "ml.t3.mediu", want: 1},
+		{name: "kitten/sitting", a: "kitten", b: "sitting", want: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := levenshteinDistance(tc.a, tc.b); got != tc.want {
+				t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+			}
+
+			// Distance is symmetric.
+			if got := levenshteinDistance(tc.b, tc.a); got != tc.want {
+				t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tc.b, tc.a, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClosestSagemakerNotebookInstanceType(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "missing letter", value: "ml.t3.medum", want: "ml.t3.medium"},
+		{name: "missing prefix still matches by shape", value: "t3.medium", want: "ml.t3.medium"},
+		{name: "transposed letters in size", value: "ml.t3.meduim", want: "ml.t3.medium"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := closestSagemakerNotebookInstanceType(tc.value); got != tc.want {
+				t.Errorf("closestSagemakerNotebookInstanceType(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSagemakerNotebookInstancePostWriteRead(t *testing.T) {
+	t.Run("skip_read_after_write sets only arn", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("skip_read_after_write", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := resourceAwsSagemakerNotebookInstancePostWriteRead(d, nil, aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test")); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := d.Get("arn").(string); got != "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test" {
+			t.Errorf("got arn %q, want the passed-in arn", got)
+		}
+	})
+
+	t.Run("skip_read_after_write tolerates a nil arn", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("skip_read_after_write", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := resourceAwsSagemakerNotebookInstancePostWriteRead(d, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+}
+
+func TestValidateSagemakerNotebookInstanceVolumeSize(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size        int
+		expectError bool
+	}{
+		{name: "minimum is allowed", size: 5},
+		{name: "maximum is allowed", size: 16384},
+		{name: "below minimum is rejected", size: 4, expectError: true},
+		{name: "above maximum is rejected", size: 16385, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceVolumeSize(tc.size, "ml.t2.medium")
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+// failedNotebookInstanceAPI embeds sagemakeriface.SageMakerAPI so it
+// satisfies the full interface while only overriding DescribeNotebookInstance,
+// reporting the notebook instance as Failed on every call.
+type failedNotebookInstanceAPI struct {
+	sagemakeriface.SageMakerAPI
+}
+
+func (m *failedNotebookInstanceAPI) DescribeNotebookInstance(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	return &sagemaker.DescribeNotebookInstanceOutput{
+		NotebookInstanceName:   input.NotebookInstanceName,
+		NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusFailed),
+		FailureReason:          aws.String("insufficient capacity"),
+	}, nil
+}
+
+func TestWaitSagemakerNotebookInstanceStatusFailedIsTerminalError(t *testing.T) {
+	conn := &failedNotebookInstanceAPI{}
+
+	err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", time.Minute, 0, sagemaker.NotebookInstanceStatusInService)
+	if err == nil {
+		t.Fatal("expected a Pending->Failed transition to return an error, got nil")
+	}
+}
+
+// updatingThenInServiceNotebookInstanceAPI embeds sagemakeriface.SageMakerAPI
+// so it satisfies the full interface while only overriding
+// DescribeNotebookInstance, reporting Updating for the first few calls and
+// InService from then on.
+type updatingThenInServiceNotebookInstanceAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	updatingCalls int
+	calls         int
+}
+
+func (m *updatingThenInServiceNotebookInstanceAPI) DescribeNotebookInstance(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	status := sagemaker.NotebookInstanceStatusInService
+	if m.calls < m.updatingCalls {
+		status = sagemaker.NotebookInstanceStatusUpdating
+	}
+	m.calls++
+
+	return &sagemaker.DescribeNotebookInstanceOutput{
+		NotebookInstanceName:   input.NotebookInstanceName,
+		NotebookInstanceStatus: aws.String(status),
+	}, nil
+}
+
+func TestWaitSagemakerNotebookInstanceStatusReturnsPromptlyOnContextCancel(t *testing.T) {
+	conn := &updatingThenInServiceNotebookInstanceAPI{updatingCalls: 1000}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- waitSagemakerNotebookInstanceStatus(ctx, conn, "test", time.Minute, 0, sagemaker.NotebookInstanceStatusInService)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error after canceling the context, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waitSagemakerNotebookInstanceStatus did not return promptly after the context was canceled")
+	}
+}
+
+func TestWaitSagemakerNotebookInstanceStatusUpdatingToInService(t *testing.T) {
+	conn := &updatingThenInServiceNotebookInstanceAPI{updatingCalls: 1}
+
+	err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", 30*time.Second, 0, sagemaker.NotebookInstanceStatusInService)
+	if err != nil {
+		t.Fatalf("expected an Updating->InService transition to succeed, got: %s", err)
+	}
+}
+
+func TestWaitSagemakerNotebookInstanceStatusTimeoutError(t *testing.T) {
+	conn := &updatingThenInServiceNotebookInstanceAPI{updatingCalls: 1000}
+
+	err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", 50*time.Millisecond, 0, sagemaker.NotebookInstanceStatusInService)
+	if err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+
+	var timeoutErr *resource.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected err to wrap a *resource.TimeoutError, got: %#v", err)
+	}
+
+	if timeoutErr.LastState != sagemaker.NotebookInstanceStatusUpdating {
+		t.Errorf("expected LastState %q, got %q", sagemaker.NotebookInstanceStatusUpdating, timeoutErr.LastState)
+	}
+
+	if len(timeoutErr.ExpectedState) != 1 || timeoutErr.ExpectedState[0] != sagemaker.NotebookInstanceStatusInService {
+		t.Errorf("expected ExpectedState [%q], got %#v", sagemaker.NotebookInstanceStatusInService, timeoutErr.ExpectedState)
+	}
+}
+
+func TestResourceAwsSagemakerNotebookInstanceDefaultDeleteTimeout(t *testing.T) {
+	d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+
+	if got := d.Timeout(schema.TimeoutDelete); got != notebookInstanceDeletedTimeout {
+		t.Errorf("default Delete timeout = %s, want notebookInstanceDeletedTimeout (%s)", got, notebookInstanceDeletedTimeout)
+	}
+}
+
+func TestSagemakerNotebookInstancePollInterval(t *testing.T) {
+	d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+
+	if got := sagemakerNotebookInstancePollInterval(d); got != sagemakerNotebookInstanceDefaultPollIntervalSeconds*time.Second {
+		t.Errorf("sagemakerNotebookInstancePollInterval with no poll_interval_seconds set = %s, want the %ds default", got, sagemakerNotebookInstanceDefaultPollIntervalSeconds)
+	}
+
+	if err := d.Set("poll_interval_seconds", 30); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := sagemakerNotebookInstancePollInterval(d); got != 30*time.Second {
+		t.Errorf("sagemakerNotebookInstancePollInterval with poll_interval_seconds=30 = %s, want 30s", got)
+	}
+}
+
+func TestValidateSagemakerNotebookInstancePollIntervalSecondsMinimum(t *testing.T) {
+	if _, errors := validation.IntAtLeast(5)(4, "poll_interval_seconds"); len(errors) == 0 {
+		t.Error("expected an error for poll_interval_seconds below the 5s minimum, got none")
+	}
+
+	if _, errors := validation.IntAtLeast(5)(5, "poll_interval_seconds"); len(errors) != 0 {
+		t.Errorf("expected no error for poll_interval_seconds at the 5s minimum, got: %v", errors)
+	}
+}
+
+// TestWaitSagemakerNotebookInstanceStatusCustomPollInterval confirms a
+// non-zero pollInterval is actually honored by the ticker -- as opposed to
+// waitSagemakerNotebookInstanceStatus always falling back to the 10s
+// default regardless of what's passed in.
+func TestWaitSagemakerNotebookInstanceStatusCustomPollInterval(t *testing.T) {
+	var calls int
+
+	conn := &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			calls++
+			status := sagemaker.NotebookInstanceStatusPending
+			if calls >= 3 {
+				status = sagemaker.NotebookInstanceStatusInService
+			}
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceName:   input.NotebookInstanceName,
+				NotebookInstanceStatus: aws.String(status),
+			}, nil
+		},
+	}
+
+	start := time.Now()
+	if err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", 5*time.Second, 20*time.Millisecond, sagemaker.NotebookInstanceStatusInService); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitSagemakerNotebookInstanceStatus with a 20ms pollInterval took %s, expected it to finish quickly", elapsed)
+	}
+
+	if calls < 3 {
+		t.Errorf("got %d DescribeNotebookInstance calls, want at least 3", calls)
+	}
+}
+
+func TestWaitSagemakerNotebookInstanceStatusLogsTransitions(t *testing.T) {
+	statuses := []string{
+		sagemaker.NotebookInstanceStatusPending,
+		sagemaker.NotebookInstanceStatusPending,
+		sagemaker.NotebookInstanceStatusUpdating,
+		sagemaker.NotebookInstanceStatusUpdating,
+		sagemaker.NotebookInstanceStatusInService,
+	}
+	var calls int
+
+	conn := &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			status := statuses[calls]
+			if calls < len(statuses)-1 {
+				calls++
+			}
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceName:   input.NotebookInstanceName,
+				NotebookInstanceStatus: aws.String(status),
+			}, nil
+		},
+	}
+
+	var buf bytes.Buffer
+	orig := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(orig)
+
+	if err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", 5*time.Second, 5*time.Millisecond, sagemaker.NotebookInstanceStatusInService); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	out := buf.String()
+
+	if got := strings.Count(out, "status is Pending"); got != 1 {
+		t.Errorf("got %d initial-status log lines, want 1 (log output: %s)", got, out)
+	}
+	if !strings.Contains(out, "status changed Pending->Updating") {
+		t.Errorf("expected a Pending->Updating transition log line, got: %s", out)
+	}
+	if !strings.Contains(out, "status changed Updating->InService") {
+		t.Errorf("expected an Updating->InService transition log line, got: %s", out)
+	}
+	if got := strings.Count(out, "status changed"); got != 2 {
+		t.Errorf("got %d transition log lines, want 2 (repeated polls of the same status should not log); output: %s", got, out)
+	}
+}
+
+func TestWaitSagemakerNotebookInstanceStatusTreatsInitialNotFoundAsTransient(t *testing.T) {
+	var calls int
+
+	conn := &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			calls++
+			if calls == 1 {
+				return nil, awserr.New("ResourceNotFound", "Notebook Instance does not exist", nil)
+			}
+
+			status := sagemaker.NotebookInstanceStatusPending
+			if calls >= 3 {
+				status = sagemaker.NotebookInstanceStatusInService
+			}
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceName:   input.NotebookInstanceName,
+				NotebookInstanceStatus: aws.String(status),
+			}, nil
+		},
+	}
+
+	if err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, "test", 5*time.Second, 5*time.Millisecond, sagemaker.NotebookInstanceStatusInService); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls < 3 {
+		t.Errorf("got %d DescribeNotebookInstance calls, want at least 3", calls)
+	}
+}
+
+// TestWaitNotebookInstanceInServiceStoppedDeleted confirms the three exported
+// waiter variants delegate to waitSagemakerNotebookInstanceStatus correctly,
+// each with its own single desired status -- including WaitNotebookInstanceDeleted,
+// whose desired status is "", the one SagemakerNotebookInstanceStateRefreshFunc
+// reports once DescribeNotebookInstance starts returning ResourceNotFound.
+func TestWaitNotebookInstanceInServiceStoppedDeleted(t *testing.T) {
+	if err := WaitNotebookInstanceInService(context.Background(), &updatingThenInServiceNotebookInstanceAPI{updatingCalls: 1}, "test", 30*time.Second, 0); err != nil {
+		t.Errorf("WaitNotebookInstanceInService: unexpected error: %s", err)
+	}
+
+	if err := WaitNotebookInstanceStopped(context.Background(), &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceName:   input.NotebookInstanceName,
+				NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusStopped),
+			}, nil
+		},
+	}, "test", 30*time.Second, 0); err != nil {
+		t.Errorf("WaitNotebookInstanceStopped: unexpected error: %s", err)
+	}
+
+	if err := WaitNotebookInstanceDeleted(context.Background(), &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			return nil, awserr.New("ResourceNotFoundException", "RecordNotFound", nil)
+		},
+	}, "test", 30*time.Second, 0); err != nil {
+		t.Errorf("WaitNotebookInstanceDeleted: unexpected error: %s", err)
+	}
+}
+
+// TestWaitNotebookInstanceFailedIsTerminalError confirms Failed is never
+// mistaken for success: WaitNotebookInstanceInService and
+// WaitNotebookInstanceStopped both single-target waits, so neither lists
+// Failed among pending statuses, meaning a Pending/Stopping->Failed
+// transition falls straight through to waitSagemakerNotebookInstanceStatus's
+// "unexpected state" error rather than being silently treated as done.
+func TestWaitNotebookInstanceFailedIsTerminalError(t *testing.T) {
+	failedAPI := &mockSagemakerAPI{
+		describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+			return &sagemaker.DescribeNotebookInstanceOutput{
+				NotebookInstanceName:   input.NotebookInstanceName,
+				NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusFailed),
+			}, nil
+		},
+	}
+
+	if err := WaitNotebookInstanceInService(context.Background(), failedAPI, "test", 30*time.Second, 0); err == nil {
+		t.Error("WaitNotebookInstanceInService: expected an error when the instance is Failed, got none")
+	}
+
+	if err := WaitNotebookInstanceStopped(context.Background(), failedAPI, "test", 30*time.Second, 0); err == nil {
+		t.Error("WaitNotebookInstanceStopped: expected an error when the instance is Failed, got none")
+	}
+}
+
+func TestResourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(t *testing.T) {
+	t.Run("InService stops before delete", func(t *testing.T) {
+		var stopCalls int
+		conn := &mockSagemakerAPI{
+			stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+				stopCalls++
+				return &sagemaker.StopNotebookInstanceOutput{}, nil
+			},
+			describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceName:   input.NotebookInstanceName,
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusStopped),
+				}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(context.Background(), conn, "test", sagemaker.NotebookInstanceStatusInService, 5*time.Second, 0); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if stopCalls != 1 {
+			t.Errorf("got %d StopNotebookInstance calls, want 1", stopCalls)
+		}
+	})
+
+	for _, status := range []string{sagemaker.NotebookInstanceStatusStopped, sagemaker.NotebookInstanceStatusFailed} {
+		t.Run(status+" deletes directly without stopping", func(t *testing.T) {
+			conn := &mockSagemakerAPI{
+				stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+					t.Fatal("StopNotebookInstance should not be called")
+					return nil, nil
+				},
+			}
+
+			if err := resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(context.Background(), conn, "test", status, 5*time.Second, 0); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	for _, status := range []string{sagemaker.NotebookInstanceStatusPending, sagemaker.NotebookInstanceStatusUpdating} {
+		t.Run(status+" waits for a terminal state, then stops only if it lands InService", func(t *testing.T) {
+			var calls int
+			var stopCalls int
+			conn := &mockSagemakerAPI{
+				describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+					calls++
+					current := status
+					if calls >= 2 {
+						current = sagemaker.NotebookInstanceStatusInService
+					}
+					return &sagemaker.DescribeNotebookInstanceOutput{
+						NotebookInstanceName:   input.NotebookInstanceName,
+						NotebookInstanceStatus: aws.String(current),
+					}, nil
+				},
+				stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+					stopCalls++
+					return &sagemaker.StopNotebookInstanceOutput{}, nil
+				},
+			}
+
+			if err := resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(context.Background(), conn, "test", status, 5*time.Second, 5*time.Millisecond); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if stopCalls != 1 {
+				t.Errorf("got %d StopNotebookInstance calls, want 1 once the wait lands on InService", stopCalls)
+			}
+		})
+
+		t.Run(status+" landing on Failed after the wait skips stopping", func(t *testing.T) {
+			var calls int
+			conn := &mockSagemakerAPI{
+				describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+					calls++
+					current := status
+					if calls >= 2 {
+						current = sagemaker.NotebookInstanceStatusFailed
+					}
+					return &sagemaker.DescribeNotebookInstanceOutput{
+						NotebookInstanceName:   input.NotebookInstanceName,
+						NotebookInstanceStatus: aws.String(current),
+					}, nil
+				},
+				stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+					t.Fatal("StopNotebookInstance should not be called")
+					return nil, nil
+				},
+			}
+
+			if err := resourceAwsSagemakerNotebookInstanceDeleteStopIfNeeded(context.Background(), conn, "test", status, 5*time.Second, 5*time.Millisecond); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// startRecordingNotebookInstanceAPI embeds sagemakeriface.SageMakerAPI so it
+// satisfies the full interface while overriding StartNotebookInstance (to
+// record whether a restart was attempted), UpdateNotebookInstance (to record
+// the last instance_type a rollback reverted to), and DescribeNotebookInstance
+// (to report InService immediately, so the post-start waiter returns right
+// away).
+type startRecordingNotebookInstanceAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	started                bool
+	lastUpdateInstanceType string
+}
+
+func (m *startRecordingNotebookInstanceAPI) StartNotebookInstance(input *sagemaker.StartNotebookInstanceInput) (*sagemaker.StartNotebookInstanceOutput, error) {
+	m.started = true
+	return &sagemaker.StartNotebookInstanceOutput{}, nil
+}
+
+func (m *startRecordingNotebookInstanceAPI) UpdateNotebookInstance(input *sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error) {
+	m.lastUpdateInstanceType = aws.StringValue(input.InstanceType)
+	return &sagemaker.UpdateNotebookInstanceOutput{}, nil
+}
+
+func (m *startRecordingNotebookInstanceAPI) DescribeNotebookInstance(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	return &sagemaker.DescribeNotebookInstanceOutput{
+		NotebookInstanceName:   input.NotebookInstanceName,
+		NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+	}, nil
+}
+
+func TestSagemakerNotebookInstanceRestartAfterFailedUpdate(t *testing.T) {
+	conn := &startRecordingNotebookInstanceAPI{}
+
+	sagemakerNotebookInstanceRestartAfterFailedUpdate(conn, "test", time.Minute, 0)
+
+	if !conn.started {
+		t.Error("expected a restart attempt after a failed update, got none")
+	}
+}
+
+func TestSagemakerNotebookInstanceUpdateFailedError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failureReason string
+		want          string
+	}{
+		{name: "with a failure reason", failureReason: "instance type not supported in this VPC", want: "Sagemaker Notebook Instance (my-notebook) transitioned to Failed after update: instance type not supported in this VPC"},
+		{name: "without a failure reason", failureReason: "", want: "Sagemaker Notebook Instance (my-notebook) transitioned to Failed after update"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerNotebookInstanceUpdateFailedError("my-notebook", tc.failureReason).Error(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceRollbackFailedStartAfterUpdate(t *testing.T) {
+	t.Run("reverts instance_type when it changed, then restarts", func(t *testing.T) {
+		conn := &startRecordingNotebookInstanceAPI{}
+
+		sagemakerNotebookInstanceRollbackFailedStartAfterUpdate(conn, "test", "ml.t2.medium", "ml.t2.large", time.Minute, 0)
+
+		if conn.lastUpdateInstanceType != "ml.t2.medium" {
+			t.Errorf("expected rollback to revert instance_type to %q, got %q", "ml.t2.medium", conn.lastUpdateInstanceType)
+		}
+		if !conn.started {
+			t.Error("expected a restart attempt after the rollback, got none")
+		}
+	})
+
+	t.Run("does nothing to revert when instance_type did not change", func(t *testing.T) {
+		conn := &startRecordingNotebookInstanceAPI{}
+
+		sagemakerNotebookInstanceRollbackFailedStartAfterUpdate(conn, "test", "ml.t2.medium", "ml.t2.medium", time.Minute, 0)
+
+		if conn.lastUpdateInstanceType != "" {
+			t.Errorf("expected no UpdateNotebookInstance call, got instance_type %q", conn.lastUpdateInstanceType)
+		}
+		if !conn.started {
+			t.Error("expected a restart attempt regardless, got none")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceMetadataServiceVersion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *sagemaker.InstanceMetadataServiceConfiguration
+		expected string
+	}{
+		{
+			name:     "nil config defaults to 1",
+			config:   nil,
+			expected: "1",
+		},
+		{
+			name: "configured minimum version 2",
+			config: &sagemaker.InstanceMetadataServiceConfiguration{
+				MinimumInstanceMetadataServiceVersion: aws.String("2"),
+			},
+			expected: "2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceMetadataServiceVersion(tc.config)
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceSecurityGroupsRequireSubnet(t *testing.T) {
+	testCases := []struct {
+		name               string
+		securityGroupCount int
+		subnetID           string
+		expectError        bool
+	}{
+		{
+			name:               "no security groups, no subnet is fine",
+			securityGroupCount: 0,
+			subnetID:           "",
+			expectError:        false,
+		},
+		{
+			name:               "security groups with a subnet is fine",
+			securityGroupCount: 2,
+			subnetID:           "subnet-12345",
+			expectError:        false,
+		},
+		{
+			name:               "security groups without a subnet errors",
+			securityGroupCount: 1,
+			subnetID:           "",
+			expectError:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceSecurityGroupsRequireSubnet(tc.securityGroupCount, tc.subnetID)
+			if tc.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceHandleTagsReadError(t *testing.T) {
+	listTagsErr := errors.New("boom")
+
+	t.Run("strict mode fails the read", func(t *testing.T) {
+		err := sagemakerNotebookInstanceHandleTagsReadError("test", sagemakerNotebookInstanceTagsReadModeStrict, listTagsErr)
+		if err == nil {
+			t.Fatal("expected an error in strict mode, got none")
+		}
+	})
+
+	t.Run("lenient mode swallows the error", func(t *testing.T) {
+		err := sagemakerNotebookInstanceHandleTagsReadError("test", sagemakerNotebookInstanceTagsReadModeLenient, listTagsErr)
+		if err != nil {
+			t.Errorf("expected lenient mode to swallow the error, got: %s", err)
+		}
+	})
+
+	t.Run("lenient mode swallows an access denied error too", func(t *testing.T) {
+		accessDeniedErr := awserr.New("AccessDeniedException", "not authorized to list tags", nil)
+		err := sagemakerNotebookInstanceHandleTagsReadError("test", sagemakerNotebookInstanceTagsReadModeLenient, accessDeniedErr)
+		if err != nil {
+			t.Errorf("expected lenient mode to swallow the error, got: %s", err)
+		}
+	})
+
+	t.Run("strict mode fails even on an access denied error", func(t *testing.T) {
+		accessDeniedErr := awserr.New("AccessDeniedException", "not authorized to list tags", nil)
+		err := sagemakerNotebookInstanceHandleTagsReadError("test", sagemakerNotebookInstanceTagsReadModeStrict, accessDeniedErr)
+		if err == nil {
+			t.Fatal("expected strict mode to fail even on access denied, got none")
+		}
+	})
+}
+
+func TestValidateSagemakerNotebookInstanceAllowedInstanceTypes(t *testing.T) {
+	testCases := []struct {
+		name         string
+		instanceType string
+		allowed      []interface{}
+		expectError  bool
+	}{
+		{
+			name:         "an allowed instance type is fine",
+			instanceType: "ml.t3.medium",
+			allowed:      []interface{}{"ml.t3.medium", "ml.t3.large"},
+		},
+		{
+			name:         "a disallowed instance type errors",
+			instanceType: "ml.p3.2xlarge",
+			allowed:      []interface{}{"ml.t3.medium", "ml.t3.large"},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceAllowedInstanceTypes(tc.instanceType, tc.allowed)
+			if tc.expectError && err == nil {
+				t.Error("expected an error, got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceInMaintenanceWindow(t *testing.T) {
+	// 2026-08-03 is a Monday.
+	testCases := []struct {
+		name    string
+		window  string
+		now     time.Time
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "within a simple window",
+			window: "Mon:03:00-Mon:05:00",
+			now:    time.Date(2026, 8, 3, 4, 0, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "before a simple window",
+			window: "Mon:03:00-Mon:05:00",
+			now:    time.Date(2026, 8, 3, 2, 59, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "after a simple window",
+			window: "Mon:03:00-Mon:05:00",
+			now:    time.Date(2026, 8, 3, 5, 1, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "within a window that wraps the week boundary",
+			window: "Sat:22:00-Sun:02:00",
+			now:    time.Date(2026, 8, 2, 0, 30, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "outside a window that wraps the week boundary",
+			window: "Sat:22:00-Sun:02:00",
+			now:    time.Date(2026, 8, 3, 4, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:    "malformed window returns an error",
+			window:  "not-a-window",
+			now:     time.Date(2026, 8, 3, 4, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sagemakerNotebookInstanceInMaintenanceWindow(tc.window, tc.now)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("sagemakerNotebookInstanceInMaintenanceWindow(%q, %s) = %v, want %v", tc.window, tc.now, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceMaintenanceWindowNow(t *testing.T) {
+	// An injected clock rather than time.Now() so the pass/fail boundary
+	// doesn't depend on when the test happens to run.
+	inWindow := time.Date(2026, 8, 3, 4, 0, 0, 0, time.UTC)
+	outsideWindow := time.Date(2026, 8, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("inside the window is fine", func(t *testing.T) {
+		if err := validateSagemakerNotebookInstanceMaintenanceWindowNow("Mon:03:00-Mon:05:00", inWindow); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("outside the window errors", func(t *testing.T) {
+		err := validateSagemakerNotebookInstanceMaintenanceWindowNow("Mon:03:00-Mon:05:00", outsideWindow)
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+		if !strings.Contains(err.Error(), "maintenance_window") {
+			t.Errorf("expected error to mention maintenance_window, got: %s", err)
+		}
+	})
+}
+
+func TestValidateSagemakerNotebookInstanceRequiredTagKeys(t *testing.T) {
+	testCases := []struct {
+		name         string
+		requiredKeys []interface{}
+		tags         []*sagemaker.Tag
+		expectError  bool
+	}{
+		{
+			name:         "no required keys is fine",
+			requiredKeys: []interface{}{},
+			tags:         []*sagemaker.Tag{},
+			expectError:  false,
+		},
+		{
+			name:         "required key present is fine",
+			requiredKeys: []interface{}{"CostCenter"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("CostCenter"), Value: aws.String("1234")},
+			},
+			expectError: false,
+		},
+		{
+			name:         "required key missing errors",
+			requiredKeys: []interface{}{"CostCenter"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("Name"), Value: aws.String("test")},
+			},
+			expectError: true,
+		},
+		{
+			name:         "one of several required keys missing errors",
+			requiredKeys: []interface{}{"CostCenter", "Name"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("Name"), Value: aws.String("test")},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceRequiredTagKeys(tc.requiredKeys, tc.tags)
+			if tc.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceForbiddenTagValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		forbidden   map[string]interface{}
+		tags        []*sagemaker.Tag
+		expectError bool
+	}{
+		{
+			name:        "no forbidden values is fine",
+			forbidden:   map[string]interface{}{},
+			tags:        []*sagemaker.Tag{},
+			expectError: false,
+		},
+		{
+			name:      "forbidden key with a different value is fine",
+			forbidden: map[string]interface{}{"env": "prod"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("env"), Value: aws.String("dev")},
+			},
+			expectError: false,
+		},
+		{
+			name:      "tag key not listed as forbidden is fine",
+			forbidden: map[string]interface{}{"env": "prod"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("CostCenter"), Value: aws.String("1234")},
+			},
+			expectError: false,
+		},
+		{
+			name:      "forbidden key/value pair errors",
+			forbidden: map[string]interface{}{"env": "prod"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("env"), Value: aws.String("prod")},
+			},
+			expectError: true,
+		},
+		{
+			name:      "one of several forbidden pairs matching errors",
+			forbidden: map[string]interface{}{"env": "prod", "tier": "critical"},
+			tags: []*sagemaker.Tag{
+				{Key: aws.String("env"), Value: aws.String("dev")},
+				{Key: aws.String("tier"), Value: aws.String("critical")},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceForbiddenTagValues(tc.forbidden, tc.tags)
+			if tc.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceTagLimit(t *testing.T) {
+	tagsN := func(n int) []*sagemaker.Tag {
+		tags := make([]*sagemaker.Tag, n)
+		for i := range tags {
+			tags[i] = &sagemaker.Tag{Key: aws.String(fmt.Sprintf("key%d", i)), Value: aws.String("value")}
+		}
+		return tags
+	}
+
+	testCases := []struct {
+		name        string
+		tags        []*sagemaker.Tag
+		expectError bool
+	}{
+		{
+			name:        "well under the limit is fine",
+			tags:        tagsN(5),
+			expectError: false,
+		},
+		{
+			name:        "exactly at the limit is fine",
+			tags:        tagsN(50),
+			expectError: false,
+		},
+		{
+			name:        "one over the limit errors",
+			tags:        tagsN(51),
+			expectError: true,
+		},
+		{
+			name:        "48 resource tags plus 3 default tags exceeds the limit",
+			tags:        tagsN(48 + 3),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceTagLimit(tc.tags)
+			if tc.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+			if tc.expectError && err != nil && !strings.Contains(err.Error(), fmt.Sprintf("%d", len(tc.tags))) {
+				t.Errorf("expected error to name the tag count %d, got: %s", len(tc.tags), err)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceImdsNotDowngraded(t *testing.T) {
+	testCases := []struct {
+		name        string
+		oldVersion  string
+		newVersion  string
+		expectError bool
+	}{
+		{
+			name:       "unchanged at 1",
+			oldVersion: "1",
+			newVersion: "1",
+		},
+		{
+			name:       "unchanged at 2",
+			oldVersion: "2",
+			newVersion: "2",
+		},
+		{
+			name:       "upgrade from 1 to 2 is fine",
+			oldVersion: "1",
+			newVersion: "2",
+		},
+		{
+			name:        "downgrade from 2 to 1 errors",
+			oldVersion:  "2",
+			newVersion:  "1",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceImdsNotDowngraded(tc.oldVersion, tc.newVersion)
+			if tc.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceApiError(t *testing.T) {
+	t.Run("includes status code and request id for a RequestFailure", func(t *testing.T) {
+		origErr := awserr.New("ValidationException", "1 validation error(s) found.", nil)
+		reqErr := awserr.NewRequestFailure(origErr, 400, "abcd-1234")
+
+		err := sagemakerNotebookInstanceApiError("Error creating", "my-notebook", reqErr)
+
+		got := err.Error()
+		for _, want := range []string{"my-notebook", "1 validation error(s) found.", "status code: 400", "request id: abcd-1234"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("falls back to the plain error for a non-AWS error", func(t *testing.T) {
+		err := sagemakerNotebookInstanceApiError("Error creating", "my-notebook", errors.New("connection reset"))
+
+		got := err.Error()
+		for _, want := range []string{"my-notebook", "connection reset"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceDefaultView(t *testing.T) {
+	testCases := []struct {
+		name               string
+		platformIdentifier string
+		expected           string
+	}{
+		{
+			name:               "empty platform_identifier defaults to current AWS default",
+			platformIdentifier: "",
+			expected:           "JupyterLab3",
+		},
+		{
+			name:               "v1 platform opens classic-default JupyterLab1",
+			platformIdentifier: "notebook-al2-v1",
+			expected:           "JupyterLab1",
+		},
+		{
+			name:               "v2 platform opens JupyterLab3",
+			platformIdentifier: "notebook-al2-v2",
+			expected:           "JupyterLab3",
+		},
+		{
+			name:               "platform identifier with no version suffix opens classic Jupyter",
+			platformIdentifier: "notebook-al1",
+			expected:           "Jupyter",
+		},
+		{
+			name:               "unrecognized version suffix falls back to Jupyter",
+			platformIdentifier: "notebook-al2-v99",
+			expected:           "Jupyter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceDefaultView(tc.platformIdentifier)
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstancePlatformCompatibilityWarning(t *testing.T) {
+	testCases := []struct {
+		name               string
+		instanceType       string
+		platformIdentifier string
+		expectWarning      bool
+	}{
+		{
+			name:          "graviton family without platform_identifier warns",
+			instanceType:  "ml.g5g.xlarge",
+			expectWarning: true,
+		},
+		{
+			name:               "graviton family with al2 platform_identifier is fine",
+			instanceType:       "ml.g5g.xlarge",
+			platformIdentifier: "notebook-al2-v2",
+		},
+		{
+			name:               "trainium family with non-al2 platform_identifier warns",
+			instanceType:       "ml.trn1.2xlarge",
+			platformIdentifier: "notebook-al1-v1",
+			expectWarning:      true,
+		},
+		{
+			name:          "inferentia family without platform_identifier warns",
+			instanceType:  "ml.inf2.xlarge",
+			expectWarning: true,
+		},
+		{
+			name:         "ordinary family without platform_identifier is fine",
+			instanceType: "ml.t3.medium",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := sagemakerNotebookInstancePlatformCompatibilityWarning(tc.instanceType, tc.platformIdentifier)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning for instance_type %q / platform_identifier %q, got none", tc.instanceType, tc.platformIdentifier)
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning for instance_type %q / platform_identifier %q, got: %s", tc.instanceType, tc.platformIdentifier, msg)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceGpuDriverCompatibilityWarning(t *testing.T) {
+	testCases := []struct {
+		name               string
+		instanceType       string
+		platformIdentifier string
+		expectWarning      bool
+	}{
+		{
+			name:          "p4d family without platform_identifier warns",
+			instanceType:  "ml.p4d.24xlarge",
+			expectWarning: true,
+		},
+		{
+			name:               "p4d family with al2 platform_identifier is fine",
+			instanceType:       "ml.p4d.24xlarge",
+			platformIdentifier: "notebook-al2-v2",
+		},
+		{
+			name:               "g5 family with non-al2 platform_identifier warns",
+			instanceType:       "ml.g5.2xlarge",
+			platformIdentifier: "notebook-al1-v1",
+			expectWarning:      true,
+		},
+		{
+			name:          "p5 family without platform_identifier warns",
+			instanceType:  "ml.p5.48xlarge",
+			expectWarning: true,
+		},
+		{
+			name:         "ordinary family without platform_identifier is fine",
+			instanceType: "ml.t3.medium",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := sagemakerNotebookInstanceGpuDriverCompatibilityWarning(tc.instanceType, tc.platformIdentifier)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning for instance_type %q / platform_identifier %q, got none", tc.instanceType, tc.platformIdentifier)
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning for instance_type %q / platform_identifier %q, got: %s", tc.instanceType, tc.platformIdentifier, msg)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceCostAttributes(t *testing.T) {
+	testCases := []struct {
+		name             string
+		instanceType     string
+		volumeSizeInGB   int64
+		availabilityZone string
+		want             map[string]string
+	}{
+		{
+			name:             "all fields known",
+			instanceType:     "ml.t3.medium",
+			volumeSizeInGB:   50,
+			availabilityZone: "us-east-1a",
+			want: map[string]string{
+				"instance_type":     "ml.t3.medium",
+				"volume_size":       "50",
+				"availability_zone": "us-east-1a",
+			},
+		},
+		{
+			name:           "no VPC: availability_zone omitted",
+			instanceType:   "ml.t3.medium",
+			volumeSizeInGB: 5,
+			want: map[string]string{
+				"instance_type": "ml.t3.medium",
+				"volume_size":   "5",
+			},
+		},
+		{
+			name:         "volume size unknown: volume_size omitted",
+			instanceType: "ml.t3.medium",
+			want: map[string]string{
+				"instance_type": "ml.t3.medium",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceCostAttributes(tc.instanceType, tc.volumeSizeInGB, tc.availabilityZone)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("sagemakerNotebookInstanceCostAttributes(%q, %d, %q) = %#v, want %#v", tc.instanceType, tc.volumeSizeInGB, tc.availabilityZone, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceComputeSummary(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		instanceType         string
+		volumeSizeInGB       int64
+		acceleratorTypes     []string
+		rootAccess           string
+		directInternetAccess string
+		platformIdentifier   string
+		want                 map[string]string
+	}{
+		{
+			name:                 "all fields known",
+			instanceType:         "ml.t3.medium",
+			volumeSizeInGB:       50,
+			acceleratorTypes:     []string{"ml.eia1.medium", "ml.eia1.large"},
+			rootAccess:           "Enabled",
+			directInternetAccess: "Enabled",
+			platformIdentifier:   "notebook-al2-v2",
+			want: map[string]string{
+				"instance_type":          "ml.t3.medium",
+				"volume_size":            "50",
+				"accelerator_types":      "ml.eia1.medium,ml.eia1.large",
+				"root_access":            "Enabled",
+				"direct_internet_access": "Enabled",
+				"platform_identifier":    "notebook-al2-v2",
+			},
+		},
+		{
+			name:         "unset fields are omitted rather than zero-valued",
+			instanceType: "ml.t3.medium",
+			want: map[string]string{
+				"instance_type": "ml.t3.medium",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceComputeSummary(tc.instanceType, tc.volumeSizeInGB, tc.acceleratorTypes, tc.rootAccess, tc.directInternetAccess, tc.platformIdentifier)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("sagemakerNotebookInstanceComputeSummary(...) = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceNetworkPosture(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		subnetId             string
+		directInternetAccess string
+		want                 string
+	}{
+		{
+			name:                 "no VPC is public",
+			subnetId:             "",
+			directInternetAccess: sagemaker.DirectInternetAccessEnabled,
+			want:                 "public",
+		},
+		{
+			name:                 "VPC with direct internet enabled",
+			subnetId:             "subnet-0123456789abcdef0",
+			directInternetAccess: sagemaker.DirectInternetAccessEnabled,
+			want:                 "vpc-with-internet",
+		},
+		{
+			name:                 "VPC with direct internet disabled is isolated",
+			subnetId:             "subnet-0123456789abcdef0",
+			directInternetAccess: sagemaker.DirectInternetAccessDisabled,
+			want:                 "vpc-isolated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerNotebookInstanceNetworkPosture(tc.subnetId, tc.directInternetAccess); got != tc.want {
+				t.Errorf("sagemakerNotebookInstanceNetworkPosture(%q, %q) = %q, want %q", tc.subnetId, tc.directInternetAccess, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceCloudwatchDimensions(t *testing.T) {
+	want := map[string]string{"NotebookInstanceName": "my-notebook"}
+
+	got := sagemakerNotebookInstanceCloudwatchDimensions("my-notebook")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sagemakerNotebookInstanceCloudwatchDimensions(%q) = %#v, want %#v", "my-notebook", got, want)
+	}
+}
+
+func TestSagemakerNotebookInstanceFamilyDefaultVolumeSize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		instanceType   string
+		configuredSize int
+		want           int
+	}{
+		{
+			name:           "p3 family at the schema default is raised",
+			instanceType:   "ml.p3.2xlarge",
+			configuredSize: 5,
+			want:           50,
+		},
+		{
+			name:           "g5 family at the schema default is raised",
+			instanceType:   "ml.g5.xlarge",
+			configuredSize: 5,
+			want:           50,
+		},
+		{
+			name:           "p3 family with an explicit larger size is left alone",
+			instanceType:   "ml.p3.2xlarge",
+			configuredSize: 200,
+			want:           200,
+		},
+		{
+			name:           "ordinary family at the schema default is left alone",
+			instanceType:   "ml.t3.medium",
+			configuredSize: 5,
+			want:           5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerNotebookInstanceFamilyDefaultVolumeSize(tc.instanceType, tc.configuredSize); got != tc.want {
+				t.Errorf("sagemakerNotebookInstanceFamilyDefaultVolumeSize(%q, %d) = %d, want %d", tc.instanceType, tc.configuredSize, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceRoleNameFromArn(t *testing.T) {
+	cases := []struct {
+		name    string
+		roleArn string
+		want    string
+	}{
+		{
+			name:    "plain role arn",
+			roleArn: "arn:aws:iam::123456789012:role/my-role",
+			want:    "my-role",
+		},
+		{
+			name:    "role arn with path",
+			roleArn: "arn:aws:iam::123456789012:role/path/to/my-role",
+			want:    "my-role",
+		},
+		{
+			name:    "not a role arn",
+			roleArn: "arn:aws:iam::123456789012:user/my-user",
+			want:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceRoleNameFromArn(tc.roleArn)
+			if got != tc.want {
+				t.Errorf("sagemakerNotebookInstanceRoleNameFromArn(%q) = %q, want %q", tc.roleArn, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceGPUTimeoutWarning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		instanceType  string
+		expectWarning bool
+	}{
+		{
+			name:          "p family warns",
+			instanceType:  "ml.p3.2xlarge",
+			expectWarning: true,
+		},
+		{
+			name:          "g family warns",
+			instanceType:  "ml.g5.xlarge",
+			expectWarning: true,
+		},
+		{
+			name:         "ordinary family is fine",
+			instanceType: "ml.t3.medium",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := sagemakerNotebookInstanceGPUTimeoutWarning(tc.instanceType)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning for instance_type %q, got none", tc.instanceType)
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning for instance_type %q, got: %s", tc.instanceType, msg)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceDeprecatedPlatformWarning(t *testing.T) {
+	testCases := []struct {
+		name                string
+		platformIdentifier  string
+		expectWarning       bool
+		expectedReplacement string
+	}{
+		{
+			name:                "deprecated AL1 platform warns",
+			platformIdentifier:  "notebook-al1-v1",
+			expectWarning:       true,
+			expectedReplacement: "notebook-al2-v1",
+		},
+		{
+			name:               "current platform is fine",
+			platformIdentifier: "notebook-al2-v1",
+		},
+		{
+			name:               "unset platform is fine",
+			platformIdentifier: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := sagemakerNotebookInstanceDeprecatedPlatformWarning(tc.platformIdentifier)
+			if tc.expectWarning {
+				if msg == "" {
+					t.Errorf("expected a warning for platform_identifier %q, got none", tc.platformIdentifier)
+				}
+				if !strings.Contains(msg, tc.expectedReplacement) {
+					t.Errorf("expected warning to name replacement %q, got: %s", tc.expectedReplacement, msg)
+				}
+				return
+			}
+			if msg != "" {
+				t.Errorf("expected no warning for platform_identifier %q, got: %s", tc.platformIdentifier, msg)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstancePlatformIdentifierDrifted(t *testing.T) {
+	testCases := []struct {
+		name        string
+		oldPlatform string
+		newPlatform string
+		expectForce bool
+	}{
+		{
+			name:        "observed platform drifted from configured",
+			oldPlatform: "notebook-al2-v2",
+			newPlatform: "notebook-al2-v1",
+			expectForce: true,
+		},
+		{
+			name:        "explicit switch between two configured platforms",
+			oldPlatform: "notebook-al2-v1",
+			newPlatform: "notebook-al2-v2",
+			expectForce: true,
+		},
+		{
+			name:        "server-assigned default appearing when config unset",
+			oldPlatform: "",
+			newPlatform: "notebook-al2-v1",
+			expectForce: false,
+		},
+		{
+			name:        "config cleared, keeping the server-assigned default",
+			oldPlatform: "notebook-al2-v1",
+			newPlatform: "",
+			expectForce: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerNotebookInstancePlatformIdentifierDrifted(tc.oldPlatform, tc.newPlatform); got != tc.expectForce {
+				t.Errorf("sagemakerNotebookInstancePlatformIdentifierDrifted(%q, %q) = %v, want %v", tc.oldPlatform, tc.newPlatform, got, tc.expectForce)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceRegionAvailabilityWarning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		instanceType  string
+		region        string
+		expectWarning bool
+	}{
+		{
+			name:          "t2 family unavailable in eu-west-3",
+			instanceType:  "ml.t2.medium",
+			region:        "eu-west-3",
+			expectWarning: true,
+		},
+		{
+			name:          "t2 family unavailable in eu-north-1",
+			instanceType:  "ml.t2.medium",
+			region:        "eu-north-1",
+			expectWarning: true,
+		},
+		{
+			name:         "t3 family is fine in eu-west-3",
+			instanceType: "ml.t3.medium",
+			region:       "eu-west-3",
+		},
+		{
+			name:         "t2 family is fine in us-east-1",
+			instanceType: "ml.t2.medium",
+			region:       "us-east-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := sagemakerNotebookInstanceRegionAvailabilityWarning(tc.instanceType, tc.region)
+			if tc.expectWarning && msg == "" {
+				t.Errorf("expected a warning for instance_type %q in region %q, got none", tc.instanceType, tc.region)
+			}
+			if !tc.expectWarning && msg != "" {
+				t.Errorf("expected no warning for instance_type %q in region %q, got: %s", tc.instanceType, tc.region, msg)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceAcceleratorTypes(t *testing.T) {
+	testCases := []struct {
+		name             string
+		instanceType     string
+		acceleratorTypes []interface{}
+		expectErr        bool
+	}{
+		{
+			name:         "no accelerator_types is always fine",
+			instanceType: "ml.p3.2xlarge",
+		},
+		{
+			name:             "ordinary family with accelerator_types is fine",
+			instanceType:     "ml.t3.medium",
+			acceleratorTypes: []interface{}{"ml.eia2.medium"},
+		},
+		{
+			name:             "p family rejects accelerator_types",
+			instanceType:     "ml.p3.2xlarge",
+			acceleratorTypes: []interface{}{"ml.eia2.medium"},
+			expectErr:        true,
+		},
+		{
+			name:             "g4dn family rejects accelerator_types",
+			instanceType:     "ml.g4dn.xlarge",
+			acceleratorTypes: []interface{}{"ml.eia2.medium"},
+			expectErr:        true,
+		},
+		{
+			name:             "inf1 family rejects accelerator_types",
+			instanceType:     "ml.inf1.xlarge",
+			acceleratorTypes: []interface{}{"ml.eia2.medium"},
+			expectErr:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerNotebookInstanceAcceleratorTypes(tc.instanceType, tc.acceleratorTypes)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error for instance_type %q, got none", tc.instanceType)
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("expected no error for instance_type %q, got: %s", tc.instanceType, err)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceAcceleratorEiEndpointAccessWarning(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		acceleratorTypes     []interface{}
+		directInternetAccess string
+		subnetId             string
+		expectWarning        bool
+	}{
+		{
+			name:                 "no accelerator_types is fine",
+			acceleratorTypes:     nil,
+			directInternetAccess: sagemaker.DirectInternetAccessDisabled,
+			subnetId:             "",
+		},
+		{
+			name:                 "direct_internet_access Enabled is fine regardless of subnet_id",
+			acceleratorTypes:     []interface{}{"ml.eia2.medium"},
+			directInternetAccess: sagemaker.DirectInternetAccessEnabled,
+			subnetId:             "",
+		},
+		{
+			name:                 "Disabled with a subnet_id configured is fine",
+			acceleratorTypes:     []interface{}{"ml.eia2.medium"},
+			directInternetAccess: sagemaker.DirectInternetAccessDisabled,
+			subnetId:             "subnet-12345678",
+		},
+		{
+			name:                 "Disabled with no subnet_id warns",
+			acceleratorTypes:     []interface{}{"ml.eia2.medium"},
+			directInternetAccess: sagemaker.DirectInternetAccessDisabled,
+			subnetId:             "",
+			expectWarning:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sagemakerNotebookInstanceAcceleratorEiEndpointAccessWarning(tc.acceleratorTypes, tc.directInternetAccess, tc.subnetId)
+			if tc.expectWarning && got == "" {
+				t.Error("expected a warning, got none")
+			}
+			if !tc.expectWarning && got != "" {
+				t.Errorf("expected no warning, got: %s", got)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceAutoCostTags(t *testing.T) {
+	tags := sagemakerNotebookInstanceAutoCostTags("ml.t3.medium", "us-east-1a")
+	if got, want := tags["nb-cost:instance_type"], "ml.t3.medium"; got != want {
+		t.Errorf("got instance_type tag %q, want %q", got, want)
+	}
+	if got, want := tags["nb-cost:availability_zone"], "us-east-1a"; got != want {
+		t.Errorf("got availability_zone tag %q, want %q", got, want)
+	}
+
+	tags = sagemakerNotebookInstanceAutoCostTags("ml.t3.medium", "")
+	if _, ok := tags["nb-cost:availability_zone"]; ok {
+		t.Error("expected no availability_zone tag for a non-VPC notebook, got one")
+	}
+}
+
+func TestSagemakerNotebookInstanceTagsWithoutAutoCostTags(t *testing.T) {
+	got := sagemakerNotebookInstanceTagsWithoutAutoCostTags(map[string]string{
+		"Name":                      "test",
+		"nb-cost:instance_type":     "ml.t3.medium",
+		"nb-cost:availability_zone": "us-east-1a",
+	})
+
+	if len(got) != 1 || got["Name"] != "test" {
+		t.Errorf("got %#v, want only the Name tag to survive", got)
+	}
+}
+
+func TestAccAWSSagemakerNotebookInstance_autoCostTags(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAutoCostTags(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.nb-cost:instance_type", "ml.t2.medium"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigAutoCostTags(rName, instanceType string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name           = %[1]q
+  role_arn       = aws_iam_role.test.arn
+  instance_type  = %[2]q
+  auto_cost_tags = true
+}
+`, rName, instanceType)
+}
+
+func TestAccAWSSagemakerNotebookInstance_additionalCodeRepositoriesOrdering(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAdditionalCodeRepositories(rName, "https://example.com/repo-a.git", "https://example.com/repo-b.git"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "additional_code_repositories.#", "2"),
+				),
+			},
+			{
+				// Same repositories, reordered in config: since the field is a
+				// set, this must produce an empty plan.
+				Config:   testAccAWSSagemakerNotebookInstanceConfigAdditionalCodeRepositories(rName, "https://example.com/repo-b.git", "https://example.com/repo-a.git"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_additionalCodeRepositoriesUpdateInService(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAdditionalCodeRepositories(rName, "https://example.com/repo-a.git", "https://example.com/repo-b.git"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "additional_code_repositories.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+			{
+				// additional_code_repositories is the one
+				// UpdateNotebookInstance field documented as usable against
+				// a running instance, so changing only the repo list should
+				// apply in place and leave the instance InService the whole
+				// time, instead of going through the usual stop/update/
+				// restart cycle every other attribute change triggers.
+				Config: testAccAWSSagemakerNotebookInstanceConfigAdditionalCodeRepositories(rName, "https://example.com/repo-c.git", "https://example.com/repo-d.git"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					resource.TestCheckResourceAttr(resourceName, "additional_code_repositories.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status", sagemaker.NotebookInstanceStatusInService),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigAdditionalCodeRepositories(rName, repo1, repo2 string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  additional_code_repositories = [%[2]q, %[3]q]
+}
+`, rName, repo1, repo2)
+}
+
+func TestAccAWSSagemakerNotebookInstance_instanceMetadataServiceConfiguration(t *testing.T) {
+	var notebook1, notebook2 sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigImds(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook1),
+					resource.TestCheckResourceAttr(resourceName, "instance_metadata_service_configuration.0.minimum_instance_metadata_service_version", "1"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigImds(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook2),
+					resource.TestCheckResourceAttr(resourceName, "instance_metadata_service_configuration.0.minimum_instance_metadata_service_version", "2"),
+					testAccCheckAWSSagemakerNotebookInstanceNotRecreated(&notebook1, &notebook2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceNotRecreated(before, after *sagemaker.DescribeNotebookInstanceOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before, after := aws.StringValue(before.NotebookInstanceArn), aws.StringValue(after.NotebookInstanceArn); before != after {
+			return fmt.Errorf("Sagemaker Notebook Instance (%s) recreated, got %s", before, after)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceExists(n string, notebook *sagemaker.DescribeNotebookInstanceOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Notebook Instance ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeNotebookInstance(&sagemaker.DescribeNotebookInstanceInput{
+			NotebookInstanceName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*notebook = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerNotebookInstanceDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_notebook_instance" {
+			continue
+		}
+
+		_, err := conn.DescribeNotebookInstance(&sagemaker.DescribeNotebookInstanceInput{
+			NotebookInstanceName: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("Sagemaker Notebook Instance %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// TestAccAWSSagemakerNotebookInstance_disappears confirms destroy is a no-op
+// rather than an error when the notebook instance was already deleted
+// out-of-band before Terraform gets to it.
+func TestAccAWSSagemakerNotebookInstance_disappears(t *testing.T) {
+	var notebook sagemaker.DescribeNotebookInstanceOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerNotebookInstanceExists(resourceName, &notebook),
+					testAccCheckAWSSagemakerNotebookInstanceDisappears(&notebook),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSSagemakerNotebookInstanceDisappears deletes the notebook
+// instance directly via the API (bypassing Terraform) so the subsequent
+// destroy this test case runs automatically exercises Delete against an
+// already-gone instance.
+func testAccCheckAWSSagemakerNotebookInstanceDisappears(notebook *sagemaker.DescribeNotebookInstanceOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+		name := aws.StringValue(notebook.NotebookInstanceName)
+
+		if aws.StringValue(notebook.NotebookInstanceStatus) == sagemaker.NotebookInstanceStatusInService {
+			if _, err := conn.StopNotebookInstance(&sagemaker.StopNotebookInstanceInput{
+				NotebookInstanceName: aws.String(name),
+			}); err != nil {
+				return fmt.Errorf("error stopping notebook instance %q: %s", name, err)
+			}
+
+			if err := waitSagemakerNotebookInstanceStatus(context.Background(), conn, name, 10*time.Minute, 0, sagemaker.NotebookInstanceStatusStopped); err != nil {
+				return fmt.Errorf("error waiting for notebook instance %q to stop: %s", name, err)
+			}
+		}
+
+		if _, err := conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{
+			NotebookInstanceName: aws.String(name),
+		}); err != nil {
+			return fmt.Errorf("error deleting notebook instance %q: %s", name, err)
+		}
+
+		return resource.Retry(10*time.Minute, func() *resource.RetryError {
+			_, status, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, name)()
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if status != "" {
+				return resource.RetryableError(fmt.Errorf("waiting for notebook instance %q to be deleted", name))
+			}
+			return nil
+		})
+	}
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigImds(rName, version string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  name          = %[1]q
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+
+  instance_metadata_service_configuration {
+    minimum_instance_metadata_service_version = %[2]q
+  }
+}
+`, rName, version)
+}
+
+func TestIsSagemakerAccessDeniedErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "AccessDeniedException",
+			err:      awserr.New("AccessDeniedException", "User is not authorized to perform: sagemaker:ListTags", nil),
+			expected: true,
+		},
+		{
+			name:     "AccessDenied",
+			err:      awserr.New("AccessDenied", "Access Denied", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated error code",
+			err:      awserr.New("ThrottlingException", "Rate exceeded", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerAccessDeniedErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerAccessDeniedErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsSagemakerResourceLimitExceededErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ResourceLimitExceeded",
+			err:      awserr.New("ResourceLimitExceeded", "The account-level service limit 'Number of notebook instances' is 5 instances, with current utilization of 5 instances and a request delta of 1 instances.", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated error code",
+			err:      awserr.New("ValidationException", "bad input", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerResourceLimitExceededErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerResourceLimitExceededErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsSagemakerIamEventualConsistencyErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "role cannot be assumed",
+			err:      awserr.New("ValidationException", "The role 'arn:aws:iam::123456789012:role/test' cannot be assumed by SageMaker.", nil),
+			expected: true,
+		},
+		{
+			name:     "role does not have permissions",
+			err:      awserr.New("ValidationException", "The role does not have permissions to perform sagemaker:CreateNotebookInstance", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated validation exception",
+			err:      awserr.New("ValidationException", "Value at 'instanceType' failed to satisfy constraint", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerIamEventualConsistencyErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerIamEventualConsistencyErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsSagemakerNotebookInstanceNameConflictErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ResourceInUse code",
+			err:      awserr.New("ResourceInUse", "Notebook instance already exists", nil),
+			expected: true,
+		},
+		{
+			name:     "ValidationException mentioning already exists",
+			err:      awserr.New("ValidationException", "Notebook instance \"test\" already exists", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated validation exception",
+			err:      awserr.New("ValidationException", "Value at 'instanceType' failed to satisfy constraint", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerNotebookInstanceNameConflictErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerNotebookInstanceNameConflictErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsSagemakerNotebookInstanceDeleteConflictErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ResourceInUse code",
+			err:      awserr.New("ResourceInUse", "Notebook instance is still stopping", nil),
+			expected: true,
+		},
+		{
+			name:     "ValidationException mentioning state it can be deleted from",
+			err:      awserr.New("ValidationException", "Notebook instance \"test\" is not in a state from which it can be deleted", nil),
+			expected: true,
+		},
+		{
+			name:     "unrelated validation exception",
+			err:      awserr.New("ValidationException", "Value at 'instanceType' failed to satisfy constraint", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr error",
+			err:      errors.New("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerNotebookInstanceDeleteConflictErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerNotebookInstanceDeleteConflictErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentKmsKeyId(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "identical values",
+			old:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			new:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			expected: true,
+		},
+		{
+			name:     "configured alias against resolved arn",
+			old:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			new:      "alias/my-key",
+			expected: true,
+		},
+		{
+			name:     "configured bare key id against resolved arn",
+			old:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			new:      "1234abcd-12ab-34cd-56ef-1234567890ab",
+			expected: true,
+		},
+		{
+			name:     "unrelated key id",
+			old:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			new:      "deadbeef-0000-0000-0000-000000000000",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := suppressEquivalentKmsKeyId("kms_key_id", tc.old, tc.new, nil); got != tc.expected {
+				t.Errorf("suppressEquivalentKmsKeyId(%q, %q) = %t; want %t", tc.old, tc.new, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceVerifyKmsKeyIdMatches(t *testing.T) {
+	t.Run("matching key is fine", func(t *testing.T) {
+		err := sagemakerNotebookInstanceVerifyKmsKeyIdMatches(
+			"test",
+			"1234abcd-12ab-34cd-56ef-1234567890ab",
+			"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("silent fallback to a different key is a hard error", func(t *testing.T) {
+		err := sagemakerNotebookInstanceVerifyKmsKeyIdMatches(
+			"test",
+			"1234abcd-12ab-34cd-56ef-1234567890ab",
+			"arn:aws:kms:us-east-1:123456789012:key/deadbeef-0000-0000-0000-000000000000",
+		)
+		if err == nil {
+			t.Error("expected an error for a mismatched kms_key_id, got none")
+		}
+	})
+}
+
+func TestIsSagemakerInsufficientCapacityErr(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failureReason string
+		expected      bool
+	}{
+		{
+			name:          "InsufficientInstanceCapacity error code",
+			failureReason: "InsufficientInstanceCapacity: We currently do not have sufficient ml.p3.2xlarge capacity in this availability zone.",
+			expected:      true,
+		},
+		{
+			name:          "lowercase insufficient capacity message",
+			failureReason: "The requested instance type has insufficient capacity in this region",
+			expected:      true,
+		},
+		{
+			name:          "unrelated failure",
+			failureReason: "The IAM role provided does not have permission to assume the role",
+			expected:      false,
+		},
+		{
+			name:          "empty failure reason",
+			failureReason: "",
+			expected:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerInsufficientCapacityErr(tc.failureReason); got != tc.expected {
+				t.Errorf("isSagemakerInsufficientCapacityErr(%q) = %t; want %t", tc.failureReason, got, tc.expected)
+			}
+		})
+	}
+}
+
+// mockSagemakerAPI embeds sagemakeriface.SageMakerAPI so it satisfies the
+// full interface while only overriding the methods the state machine under
+// test actually calls.
+type mockSagemakerAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	describeNotebookInstance                func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error)
+	listCodeRepositoriesPages               func(*sagemaker.ListCodeRepositoriesInput, func(*sagemaker.ListCodeRepositoriesOutput, bool) bool) error
+	updateNotebookInstance                  func(*sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error)
+	describeNotebookInstanceLifecycleConfig func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error)
+	listNotebookInstances                   func(*sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error)
+	createNotebookInstance                  func(*sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error)
+	deleteNotebookInstance                  func(*sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error)
+	stopNotebookInstance                    func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error)
+	createPresignedNotebookInstanceUrl      func(*sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error)
+	describeCodeRepository                  func(*sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error)
+}
+
+func (m *mockSagemakerAPI) CreatePresignedNotebookInstanceUrl(input *sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error) {
+	return m.createPresignedNotebookInstanceUrl(input)
+}
+
+func (m *mockSagemakerAPI) DescribeCodeRepository(input *sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error) {
+	return m.describeCodeRepository(input)
+}
+
+func (m *mockSagemakerAPI) StopNotebookInstance(input *sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+	return m.stopNotebookInstance(input)
+}
+
+func (m *mockSagemakerAPI) DescribeNotebookInstance(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	return m.describeNotebookInstance(input)
+}
+
+func (m *mockSagemakerAPI) ListCodeRepositoriesPages(input *sagemaker.ListCodeRepositoriesInput, fn func(*sagemaker.ListCodeRepositoriesOutput, bool) bool) error {
+	return m.listCodeRepositoriesPages(input, fn)
+}
+
+func (m *mockSagemakerAPI) UpdateNotebookInstance(input *sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error) {
+	return m.updateNotebookInstance(input)
+}
+
+func (m *mockSagemakerAPI) DescribeNotebookInstanceLifecycleConfig(input *sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+	return m.describeNotebookInstanceLifecycleConfig(input)
+}
+
+func (m *mockSagemakerAPI) ListNotebookInstances(input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+	return m.listNotebookInstances(input)
+}
+
+func (m *mockSagemakerAPI) CreateNotebookInstance(input *sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error) {
+	return m.createNotebookInstance(input)
+}
+
+func (m *mockSagemakerAPI) DeleteNotebookInstance(input *sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+	return m.deleteNotebookInstance(input)
+}
+
+func TestSagemakerNotebookInstanceDescribeWithThrottleFallback(t *testing.T) {
+	throttleErr := awserr.New("ThrottlingException", "Rate exceeded", nil)
+
+	origSleep := sagemakerThrottleRetrySleep
+	sagemakerThrottleRetrySleep = func(time.Duration) {}
+	defer func() { sagemakerThrottleRetrySleep = origSleep }()
+
+	t.Run("returns describe's result once it succeeds", func(t *testing.T) {
+		want := &sagemaker.DescribeNotebookInstanceOutput{
+			NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+		}
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return want, nil
+			},
+		}
+
+		got, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("passes through a non-throttle error unchanged", func(t *testing.T) {
+		notFoundErr := awserr.New("ResourceNotFoundException", "RecordNotFound", nil)
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, notFoundErr
+			},
+		}
+
+		_, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, "test")
+		if err != notFoundErr {
+			t.Errorf("got %v, want %v", err, notFoundErr)
+		}
+	})
+
+	t.Run("falls back to ListNotebookInstances and confirms existence when describe stays throttled", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, throttleErr
+			},
+			listNotebookInstances: func(input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+				if aws.StringValue(input.NameContains) != "test" {
+					t.Fatalf("unexpected NameContains: %s", aws.StringValue(input.NameContains))
+				}
+				return &sagemaker.ListNotebookInstancesOutput{
+					NotebookInstances: []*sagemaker.NotebookInstanceSummary{
+						{NotebookInstanceName: aws.String("test")},
+					},
+				}, nil
+			},
+		}
+
+		_, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, "test")
+		if err != throttleErr {
+			t.Errorf("got %v, want the original throttle error %v so the caller retries instead of treating this as not found", err, throttleErr)
+		}
+	})
+
+	t.Run("reports not found when ListNotebookInstances confirms the instance is gone", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, throttleErr
+			},
+			listNotebookInstances: func(*sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+				return &sagemaker.ListNotebookInstancesOutput{}, nil
+			},
+		}
+
+		_, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, "test")
+		if !finder.IsResourceNotFoundErr(err) {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+
+	t.Run("surfaces the original throttle error when the fallback itself fails", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, throttleErr
+			},
+			listNotebookInstances: func(*sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+				return nil, awserr.New("ThrottlingException", "Rate exceeded", nil)
+			},
+		}
+
+		_, err := sagemakerNotebookInstanceDescribeWithThrottleFallback(conn, "test")
+		if err != throttleErr {
+			t.Errorf("got %v, want the original describe throttle error %v", err, throttleErr)
+		}
+	})
+}
+
+func TestResourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(t *testing.T) {
+	origSleep := sagemakerThrottleRetrySleep
+	sagemakerThrottleRetrySleep = func(time.Duration) {}
+	defer func() { sagemakerThrottleRetrySleep = origSleep }()
+
+	t.Run("falls through to the next candidate subnet on insufficient capacity", func(t *testing.T) {
+		var currentSubnet string
+		var deleteCalls []string
+
+		conn := &mockSagemakerAPI{
+			createNotebookInstance: func(input *sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error) {
+				currentSubnet = aws.StringValue(input.SubnetId)
+				return &sagemaker.CreateNotebookInstanceOutput{
+					NotebookInstanceArn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test"),
+				}, nil
+			},
+			deleteNotebookInstance: func(input *sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+				deleteCalls = append(deleteCalls, aws.StringValue(input.NotebookInstanceName))
+				return &sagemaker.DeleteNotebookInstanceOutput{}, nil
+			},
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				if currentSubnet == "subnet-a" {
+					return &sagemaker.DescribeNotebookInstanceOutput{
+						NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusFailed),
+						FailureReason:          aws.String("InsufficientInstanceCapacity: no capacity available in this AZ"),
+					}, nil
+				}
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+				}, nil
+			},
+		}
+
+		createOpts := &sagemaker.CreateNotebookInstanceInput{NotebookInstanceName: aws.String("test")}
+
+		out, chosenSubnetId, err := resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn, createOpts, []string{"subnet-a", "subnet-b"}, time.Minute, 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if chosenSubnetId != "subnet-b" {
+			t.Errorf("got chosen subnet %q, want subnet-b", chosenSubnetId)
+		}
+		if out == nil || aws.StringValue(out.NotebookInstanceArn) == "" {
+			t.Errorf("expected a CreateNotebookInstanceOutput to be returned, got %#v", out)
+		}
+		if len(deleteCalls) != 1 || deleteCalls[0] != "test" {
+			t.Errorf("expected the failed subnet-a attempt to be cleaned up with a single DeleteNotebookInstance call, got %#v", deleteCalls)
+		}
+	})
+
+	t.Run("returns the last candidate's error once every subnet has been tried", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			createNotebookInstance: func(input *sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error) {
+				return &sagemaker.CreateNotebookInstanceOutput{NotebookInstanceArn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test")}, nil
+			},
+			deleteNotebookInstance: func(*sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+				return &sagemaker.DeleteNotebookInstanceOutput{}, nil
+			},
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusFailed),
+					FailureReason:          aws.String("InsufficientInstanceCapacity: no capacity available in this AZ"),
+				}, nil
+			},
+		}
+
+		createOpts := &sagemaker.CreateNotebookInstanceInput{NotebookInstanceName: aws.String("test")}
+
+		if _, _, err := resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn, createOpts, []string{"subnet-a", "subnet-b"}, time.Minute, 10*time.Millisecond); err == nil {
+			t.Error("expected an error once every candidate subnet has failed, got nil")
+		}
+	})
+
+	t.Run("a non-capacity failure is returned immediately without trying further candidates", func(t *testing.T) {
+		var createCalls int
+
+		conn := &mockSagemakerAPI{
+			createNotebookInstance: func(input *sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error) {
+				createCalls++
+				return &sagemaker.CreateNotebookInstanceOutput{NotebookInstanceArn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test")}, nil
+			},
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusFailed),
+					FailureReason:          aws.String("some unrelated failure"),
+				}, nil
+			},
+		}
+
+		createOpts := &sagemaker.CreateNotebookInstanceInput{NotebookInstanceName: aws.String("test")}
+
+		if _, _, err := resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn, createOpts, []string{"subnet-a", "subnet-b"}, time.Minute, 10*time.Millisecond); err == nil {
+			t.Error("expected a non-capacity failure to be returned as an error")
+		}
+		if createCalls != 1 {
+			t.Errorf("got %d CreateNotebookInstance calls, want 1: a non-capacity failure should not try the next candidate", createCalls)
+		}
+	})
+
+	t.Run("a ResourceLimitExceeded error from CreateNotebookInstance itself is returned as-is for Create to classify", func(t *testing.T) {
+		limitErr := awserr.New("ResourceLimitExceeded", "The account-level service limit 'Number of notebook instances' is 5 instances, with current utilization of 5 instances and a request delta of 1 instances.", nil)
+
+		conn := &mockSagemakerAPI{
+			createNotebookInstance: func(*sagemaker.CreateNotebookInstanceInput) (*sagemaker.CreateNotebookInstanceOutput, error) {
+				return nil, limitErr
+			},
+		}
+
+		createOpts := &sagemaker.CreateNotebookInstanceInput{NotebookInstanceName: aws.String("test")}
+
+		_, _, err := resourceAwsSagemakerNotebookInstanceCreateWithSubnetFallback(conn, createOpts, []string{"subnet-a"}, time.Minute, 10*time.Millisecond)
+		if err != limitErr {
+			t.Fatalf("got %v, want the original ResourceLimitExceeded error %v", err, limitErr)
+		}
+		if !isSagemakerResourceLimitExceededErr(err) {
+			t.Errorf("expected the propagated error to still be classified as a ResourceLimitExceeded error")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceAdoptExisting(t *testing.T) {
+	withCapturedLog := func(fn func()) string {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+
+		fn()
+
+		return buf.String()
+	}
+
+	t.Run("adopts an existing instance into state", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					InstanceType: aws.String("ml.t3.medium"),
+					RoleArn:      aws.String("arn:aws:iam::123456789012:role/test"),
+				}, nil
+			},
+		}
+
+		adopted, err := resourceAwsSagemakerNotebookInstanceAdoptExisting(d, conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !adopted {
+			t.Fatal("expected the existing instance to be adopted")
+		}
+		if d.Id() != "test" {
+			t.Errorf("got id %q, want %q", d.Id(), "test")
+		}
+	})
+
+	t.Run("falls through to create when no existing instance is found", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, awserr.New("ResourceNotFoundException", "RecordNotFound", nil)
+			},
+		}
+
+		adopted, err := resourceAwsSagemakerNotebookInstanceAdoptExisting(d, conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if adopted {
+			t.Fatal("expected no adoption when no existing instance is found")
+		}
+		if d.Id() != "" {
+			t.Errorf("expected no id to be set, got %q", d.Id())
+		}
+	})
+
+	t.Run("adopts and warns when declared config differs from the existing instance", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					InstanceType: aws.String("ml.t3.large"),
+					RoleArn:      aws.String("arn:aws:iam::123456789012:role/other"),
+				}, nil
+			},
+		}
+
+		logOutput := withCapturedLog(func() {
+			adopted, err := resourceAwsSagemakerNotebookInstanceAdoptExisting(d, conn, "test")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !adopted {
+				t.Fatal("expected the existing instance to be adopted despite the config mismatch")
+			}
+		})
+
+		if !strings.Contains(logOutput, "instance_type") || !strings.Contains(logOutput, "role_arn") {
+			t.Errorf("expected a warning mentioning both mismatched fields, got: %s", logOutput)
+		}
+	})
+
+	t.Run("passes through a non-not-found error", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, awserr.New("ValidationException", "bad input", nil)
+			},
+		}
+
+		if _, err := resourceAwsSagemakerNotebookInstanceAdoptExisting(d, conn, "test"); err == nil {
+			t.Fatal("expected an error to be returned")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceAdoptOnNameConflict(t *testing.T) {
+	t.Run("adopts a retried create's existing instance when the config matches", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					InstanceType: aws.String("ml.t3.medium"),
+					RoleArn:      aws.String("arn:aws:iam::123456789012:role/test"),
+				}, nil
+			},
+		}
+
+		if !resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, "test") {
+			t.Fatal("expected the existing instance to be adopted")
+		}
+		if d.Id() != "test" {
+			t.Errorf("got id %q, want %q", d.Id(), "test")
+		}
+	})
+
+	t.Run("does not adopt when instance_type differs", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					InstanceType: aws.String("ml.t3.large"),
+					RoleArn:      aws.String("arn:aws:iam::123456789012:role/test"),
+				}, nil
+			},
+		}
+
+		if resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, "test") {
+			t.Fatal("expected no adoption when instance_type differs from the existing instance")
+		}
+		if d.Id() != "" {
+			t.Errorf("expected no id to be set, got %q", d.Id())
+		}
+	})
+
+	t.Run("does not adopt when role_arn differs", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					InstanceType: aws.String("ml.t3.medium"),
+					RoleArn:      aws.String("arn:aws:iam::123456789012:role/other"),
+				}, nil
+			},
+		}
+
+		if resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, "test") {
+			t.Fatal("expected no adoption when role_arn differs from the existing instance")
+		}
+	})
+
+	t.Run("does not adopt when the existing instance can't be found", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceAwsSagemakerNotebookInstance().Schema, map[string]interface{}{
+			"instance_type": "ml.t3.medium",
+			"role_arn":      "arn:aws:iam::123456789012:role/test",
+		})
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, awserr.New("ValidationException", "RecordNotFound", nil)
+			},
+		}
+
+		if resourceAwsSagemakerNotebookInstanceAdoptOnNameConflict(d, conn, "test") {
+			t.Fatal("expected no adoption when the existing instance can't be described")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceNameAndRegionFromImportId(t *testing.T) {
+	testCases := []struct {
+		name        string
+		id          string
+		wantName    string
+		wantRegion  string
+		expectError bool
+	}{
+		{
+			name:       "plain name",
+			id:         "my-notebook",
+			wantName:   "my-notebook",
+			wantRegion: "",
+		},
+		{
+			name:       "region-qualified name",
+			id:         "us-west-2:my-notebook",
+			wantName:   "my-notebook",
+			wantRegion: "us-west-2",
+		},
+		{
+			name:       "arn",
+			id:         "arn:aws:sagemaker:us-west-2:123456789012:notebook-instance/my-notebook",
+			wantName:   "my-notebook",
+			wantRegion: "us-west-2",
+		},
+		{
+			name:        "region-qualified with empty name errors",
+			id:          "us-west-2:",
+			expectError: true,
+		},
+		{
+			name:        "malformed arn errors",
+			id:          "arn:aws:sagemaker:us-west-2:123456789012:notebook-instance",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, region, err := sagemakerNotebookInstanceNameAndRegionFromImportId(tc.id)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if name != tc.wantName {
+				t.Errorf("got name %q, want %q", name, tc.wantName)
+			}
+			if region != tc.wantRegion {
+				t.Errorf("got region %q, want %q", region, tc.wantRegion)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceStateRefreshFunc(t *testing.T) {
+	t.Run("returns the current status", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusPending),
+				}, nil
+			},
+		}
+
+		_, status, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, "test")()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if status != sagemaker.NotebookInstanceStatusPending {
+			t.Errorf("got status %q, want %q", status, sagemaker.NotebookInstanceStatusPending)
+		}
+	})
+
+	t.Run("treats ResourceNotFoundException as gone", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, awserr.New("ResourceNotFoundException", "not found", nil)
+			},
+		}
+
+		notebook, status, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, "test")()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if notebook != nil {
+			t.Errorf("got non-nil notebook, want nil")
+		}
+		if status != "" {
+			t.Errorf("got status %q, want empty", status)
+		}
+	})
+
+	t.Run("propagates other errors", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(*sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return nil, awserr.New("ThrottlingException", "slow down", nil)
+			},
+		}
+
+		if _, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, "test")(); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}
+
+func TestIsSagemakerNotebookInstanceStatusInFlux(t *testing.T) {
+	testCases := []struct {
+		status   string
+		expected bool
+	}{
+		{sagemaker.NotebookInstanceStatusPending, true},
+		{sagemaker.NotebookInstanceStatusUpdating, true},
+		{sagemaker.NotebookInstanceStatusInService, false},
+		{sagemaker.NotebookInstanceStatusStopped, false},
+		{sagemaker.NotebookInstanceStatusFailed, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			if got := isSagemakerNotebookInstanceStatusInFlux(tc.status); got != tc.expected {
+				t.Errorf("isSagemakerNotebookInstanceStatusInFlux(%q) = %t; want %t", tc.status, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAccAWSSagemakerNotebookInstance_importNonexistent(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: rName + "-does-not-exist",
+				ExpectError:   regexp.MustCompile(`no Sagemaker Notebook Instance found with name`),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerNotebookInstance_importPending(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigInstanceType(rName, "ml.t2.medium"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// url and network_interface_id are only populated once the
+				// instance reaches InService; if the import races a Pending
+				// or Updating instance they're skipped rather than set to an
+				// empty value, so don't require them to match here.
+				ImportStateVerifyIgnore: []string{"url", "network_interface_id"},
+			},
+		},
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_importAcceleratorTypes guards against
+// accelerator_types regressing to an empty plan-time diff on import: Read
+// already populates it from DescribeNotebookInstanceOutput.AcceleratorTypes
+// on every refresh, including the one ImportStatePassthrough triggers, but
+// nothing exercised that combination until now, so a future change that
+// skipped it on the import path specifically wouldn't have been caught.
+func TestAccAWSSagemakerNotebookInstance_importAcceleratorTypes(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_notebook_instance.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigAcceleratorTypes(rName, `["ml.eia1.medium"]`),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{"url", "network_interface_id"},
+			},
+		},
+	})
+}
+
+type mockKMSAPI struct {
+	kmsiface.KMSAPI
+
+	describeKey func(*kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error)
+}
+
+func (m *mockKMSAPI) DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	return m.describeKey(input)
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyKmsKey(t *testing.T) {
+	t.Run("enabled key passes", func(t *testing.T) {
+		conn := &mockKMSAPI{
+			describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+				return &kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						KeyId:   input.KeyId,
+						Enabled: aws.Bool(true),
+					},
+				}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKey(conn, "my-key"); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("disabled key is rejected", func(t *testing.T) {
+		conn := &mockKMSAPI{
+			describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+				return &kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						KeyId:   input.KeyId,
+						Enabled: aws.Bool(false),
+					},
+				}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKey(conn, "my-key"); err == nil {
+			t.Error("expected an error for a disabled key, got none")
+		}
+	})
+
+	t.Run("missing key is rejected", func(t *testing.T) {
+		conn := &mockKMSAPI{
+			describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+				return nil, awserr.New("NotFoundException", "key does not exist", nil)
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKey(conn, "my-key"); err == nil {
+			t.Error("expected an error for a missing key, got none")
+		}
+	})
+
+	t.Run("access denied is treated as inconclusive, not fatal", func(t *testing.T) {
+		conn := &mockKMSAPI{
+			describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+				return nil, awserr.New("AccessDeniedException", "not authorized to describe key", nil)
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyKmsKey(conn, "my-key"); err != nil {
+			t.Errorf("expected AccessDenied to be tolerated, got error: %s", err)
+		}
+	})
+}
+
+// mockIAMAPI embeds iamiface.IAMAPI so it satisfies the full interface while
+// only overriding SimulatePrincipalPolicy and ListRoleTags.
+type mockIAMAPI struct {
+	iamiface.IAMAPI
+
+	simulatePrincipalPolicy func(*iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error)
+	listRoleTags            func(*iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error)
+}
+
+func (m *mockIAMAPI) SimulatePrincipalPolicy(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+	return m.simulatePrincipalPolicy(input)
+}
+
+func (m *mockIAMAPI) ListRoleTags(input *iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+	return m.listRoleTags(input)
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyRolePermissions(t *testing.T) {
+	t.Run("all actions allowed passes", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			simulatePrincipalPolicy: func(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+				var results []*iam.EvaluationResult
+				for _, action := range input.ActionNames {
+					results = append(results, &iam.EvaluationResult{
+						EvalActionName: action,
+						EvalDecision:   aws.String(iam.PolicyEvaluationDecisionTypeAllowed),
+					})
+				}
+				return &iam.SimulatePrincipalPolicyOutput{EvaluationResults: results}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyRolePermissions(conn, "arn:aws:iam::123456789012:role/test"); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("a denied action is rejected", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			simulatePrincipalPolicy: func(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+				return &iam.SimulatePrincipalPolicyOutput{
+					EvaluationResults: []*iam.EvaluationResult{
+						{EvalActionName: aws.String("kms:CreateGrant"), EvalDecision: aws.String(iam.PolicyEvaluationDecisionTypeExplicitDeny)},
+					},
+				}, nil
+			},
+		}
+
+		err := resourceAwsSagemakerNotebookInstanceVerifyRolePermissions(conn, "arn:aws:iam::123456789012:role/test")
+		if err == nil {
+			t.Fatal("expected an error for a denied action, got none")
+		}
+		if !strings.Contains(err.Error(), "kms:CreateGrant") {
+			t.Errorf("expected error to name the denied action, got: %s", err)
+		}
+	})
+
+	t.Run("access denied simulating the policy is treated as inconclusive, not fatal", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			simulatePrincipalPolicy: func(*iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+				return nil, awserr.New("AccessDenied", "not authorized to perform iam:SimulatePrincipalPolicy", nil)
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifyRolePermissions(conn, "arn:aws:iam::123456789012:role/test"); err != nil {
+			t.Errorf("expected AccessDenied to be tolerated, got error: %s", err)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceInheritedRoleTags(t *testing.T) {
+	roleTags := map[string]string{
+		"CostCenter": "1234",
+		"Team":       "ml-platform",
+		"Internal":   "do-not-inherit",
+	}
+
+	got := sagemakerNotebookInstanceInheritedRoleTags(roleTags, aws.StringSlice([]string{"CostCenter", "Team"}))
+
+	want := map[string]string{"CostCenter": "1234", "Team": "ml-platform"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestSagemakerNotebookInstanceInheritedRoleTagsMissingKey(t *testing.T) {
+	roleTags := map[string]string{"CostCenter": "1234"}
+
+	got := sagemakerNotebookInstanceInheritedRoleTags(roleTags, aws.StringSlice([]string{"CostCenter", "Team"}))
+
+	want := map[string]string{"CostCenter": "1234"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestSagemakerNotebookInstanceTagsWithoutInheritedRoleTags(t *testing.T) {
+	allTags := map[string]string{"CostCenter": "1234", "Team": "ml-platform", "Environment": "prod"}
+
+	got := sagemakerNotebookInstanceTagsWithoutInheritedRoleTags(allTags, aws.StringSlice([]string{"CostCenter", "Team"}))
+
+	want := map[string]string{"Environment": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	if got := sagemakerNotebookInstanceTagsWithoutInheritedRoleTags(allTags, nil); !reflect.DeepEqual(got, allTags) {
+		t.Errorf("with no inheritKeys, got %#v, want allTags unchanged %#v", got, allTags)
+	}
+}
+
+func TestResourceAwsSagemakerNotebookInstanceInheritRoleTags(t *testing.T) {
+	t.Run("filters role tags down to the configured subset", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			listRoleTags: func(input *iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+				if aws.StringValue(input.RoleName) != "my-role" {
+					t.Errorf("got RoleName %q, want my-role", aws.StringValue(input.RoleName))
+				}
+				return &iam.ListRoleTagsOutput{
+					Tags: []*iam.Tag{
+						{Key: aws.String("CostCenter"), Value: aws.String("1234")},
+						{Key: aws.String("Internal"), Value: aws.String("do-not-inherit")},
+					},
+				}, nil
+			},
+		}
+
+		got, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(conn, "arn:aws:iam::123456789012:role/my-role", aws.StringSlice([]string{"CostCenter"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{"CostCenter": "1234"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("pages through a truncated ListRoleTags response", func(t *testing.T) {
+		var calls int
+		conn := &mockIAMAPI{
+			listRoleTags: func(input *iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+				calls++
+				if calls == 1 {
+					return &iam.ListRoleTagsOutput{
+						Tags:        []*iam.Tag{{Key: aws.String("CostCenter"), Value: aws.String("1234")}},
+						IsTruncated: aws.Bool(true),
+						Marker:      aws.String("page2"),
+					}, nil
+				}
+				if aws.StringValue(input.Marker) != "page2" {
+					t.Errorf("got Marker %q, want page2", aws.StringValue(input.Marker))
+				}
+				return &iam.ListRoleTagsOutput{
+					Tags: []*iam.Tag{{Key: aws.String("Team"), Value: aws.String("ml-platform")}},
+				}, nil
+			},
+		}
+
+		got, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(conn, "arn:aws:iam::123456789012:role/my-role", aws.StringSlice([]string{"CostCenter", "Team"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{"CostCenter": "1234", "Team": "ml-platform"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("returns an error for a role_arn that isn't a role ARN", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			listRoleTags: func(*iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+				t.Fatal("ListRoleTags should not be called")
+				return nil, nil
+			},
+		}
+
+		if _, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(conn, "not-a-role-arn", aws.StringSlice([]string{"CostCenter"})); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+
+	t.Run("propagates a ListRoleTags error", func(t *testing.T) {
+		conn := &mockIAMAPI{
+			listRoleTags: func(*iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+				return nil, awserr.New("AccessDenied", "not authorized to perform: iam:ListRoleTags", nil)
+			},
+		}
+
+		if _, err := resourceAwsSagemakerNotebookInstanceInheritRoleTags(conn, "arn:aws:iam::123456789012:role/my-role", aws.StringSlice([]string{"CostCenter"})); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+}
+
+// mockEC2API embeds ec2iface.EC2API so it satisfies the full interface while
+// only overriding DescribeSubnets, DescribeNetworkInterfaces and
+// DescribeSecurityGroups.
+type mockEC2API struct {
+	ec2iface.EC2API
+
+	describeSubnets           func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	describeNetworkInterfaces func(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
+	describeSecurityGroups    func(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
+}
+
+func (m *mockEC2API) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return m.describeSubnets(input)
+}
+
+func (m *mockEC2API) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return m.describeNetworkInterfaces(input)
+}
+
+func (m *mockEC2API) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return m.describeSecurityGroups(input)
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(t *testing.T) {
+	withCapturedLog := func(fn func()) string {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+
+		fn()
+
+		return buf.String()
+	}
+
+	t.Run("no network_interface_id is a no-op", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeNetworkInterfaces: func(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+				t.Fatal("DescribeNetworkInterfaces should not be called with no network_interface_id")
+				return nil, nil
+			},
+		}
+
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(d, conn)
+	})
+
+	t.Run("matching subnet and security groups logs nothing", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("network_interface_id", "eni-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("subnet_id", "subnet-abc"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("security_groups", []interface{}{"sg-1"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockEC2API{
+			describeNetworkInterfaces: func(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+				return &ec2.DescribeNetworkInterfacesOutput{
+					NetworkInterfaces: []*ec2.NetworkInterface{
+						{
+							SubnetId: aws.String("subnet-abc"),
+							Groups:   []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+						},
+					},
+				}, nil
+			},
+		}
+
+		if out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(d, conn) }); out != "" {
+			t.Errorf("expected no warning, got: %s", out)
+		}
+	})
+
+	t.Run("mismatched subnet and missing security group both warn", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("network_interface_id", "eni-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("subnet_id", "subnet-abc"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("security_groups", []interface{}{"sg-1"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockEC2API{
+			describeNetworkInterfaces: func(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+				return &ec2.DescribeNetworkInterfacesOutput{
+					NetworkInterfaces: []*ec2.NetworkInterface{
+						{
+							SubnetId: aws.String("subnet-other"),
+							Groups:   []*ec2.GroupIdentifier{{GroupId: aws.String("sg-2")}},
+						},
+					},
+				}, nil
+			},
+		}
+
+		out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEniSecurityPosture(d, conn) })
+		if !strings.Contains(out, "subnet-other") || !strings.Contains(out, "subnet-abc") {
+			t.Errorf("expected a subnet mismatch warning, got: %s", out)
+		}
+		if !strings.Contains(out, "sg-1") {
+			t.Errorf("expected a missing security group warning naming sg-1, got: %s", out)
+		}
+	})
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(t *testing.T) {
+	t.Run("security groups in the subnet's VPC pass", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{VpcId: aws.String("vpc-abc")}}}, nil
+			},
+			describeSecurityGroups: func(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+				return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{
+					{GroupId: aws.String("sg-1"), VpcId: aws.String("vpc-abc")},
+				}}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(conn, "subnet-abc", []*string{aws.String("sg-1")}); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("a security group in a different VPC is rejected, naming the offending group", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{VpcId: aws.String("vpc-abc")}}}, nil
+			},
+			describeSecurityGroups: func(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+				return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{
+					{GroupId: aws.String("sg-1"), VpcId: aws.String("vpc-abc")},
+					{GroupId: aws.String("sg-2"), VpcId: aws.String("vpc-other")},
+				}}, nil
+			},
+		}
+
+		err := resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(conn, "subnet-abc", []*string{aws.String("sg-1"), aws.String("sg-2")})
+		if err == nil {
+			t.Fatal("expected an error for a security group in a different VPC, got none")
+		}
+		if !strings.Contains(err.Error(), "sg-2") {
+			t.Errorf("expected the error to name the offending security group sg-2, got: %s", err)
+		}
+	})
+
+	t.Run("a missing subnet is rejected", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{}, nil
+			},
+		}
+
+		if err := resourceAwsSagemakerNotebookInstanceVerifySecurityGroupsVpc(conn, "subnet-abc", []*string{aws.String("sg-1")}); err == nil {
+			t.Error("expected an error for a missing subnet, got none")
+		}
+	})
+}
+
+type mockEFSAPI struct {
+	efsiface.EFSAPI
+
+	describeMountTargets func(*efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error)
+}
+
+func (m *mockEFSAPI) DescribeMountTargets(input *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+	return m.describeMountTargets(input)
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(t *testing.T) {
+	withCapturedLog := func(fn func()) string {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+
+		fn()
+
+		return buf.String()
+	}
+
+	t.Run("no file system id is a no-op", func(t *testing.T) {
+		conn := &mockEFSAPI{
+			describeMountTargets: func(*efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+				t.Fatal("DescribeMountTargets should not be called with no file system id")
+				return nil, nil
+			},
+		}
+
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, conn)
+	})
+
+	t.Run("file system id set but no subnet_id warns without calling the API", func(t *testing.T) {
+		conn := &mockEFSAPI{
+			describeMountTargets: func(*efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+				t.Fatal("DescribeMountTargets should not be called with no subnet_id")
+				return nil, nil
+			},
+		}
+
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("verify_efs_mount_target_for_file_system_id", "fs-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, conn) })
+		if !strings.Contains(out, "no subnet_id") {
+			t.Errorf("expected a no-subnet warning, got: %s", out)
+		}
+	})
+
+	t.Run("matching mount target logs nothing", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("verify_efs_mount_target_for_file_system_id", "fs-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("subnet_id", "subnet-abc"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockEFSAPI{
+			describeMountTargets: func(input *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+				if aws.StringValue(input.FileSystemId) != "fs-123" {
+					t.Fatalf("unexpected FileSystemId: %s", aws.StringValue(input.FileSystemId))
+				}
+				return &efs.DescribeMountTargetsOutput{
+					MountTargets: []*efs.MountTargetDescription{
+						{SubnetId: aws.String("subnet-other")},
+						{SubnetId: aws.String("subnet-abc")},
+					},
+				}, nil
+			},
+		}
+
+		if out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, conn) }); out != "" {
+			t.Errorf("expected no warning, got: %s", out)
+		}
+	})
+
+	t.Run("no mount target in the notebook's subnet warns", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("verify_efs_mount_target_for_file_system_id", "fs-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("subnet_id", "subnet-abc"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockEFSAPI{
+			describeMountTargets: func(*efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+				return &efs.DescribeMountTargetsOutput{
+					MountTargets: []*efs.MountTargetDescription{
+						{SubnetId: aws.String("subnet-other")},
+					},
+				}, nil
+			},
+		}
+
+		out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, conn) })
+		if !strings.Contains(out, "fs-123") || !strings.Contains(out, "subnet-abc") {
+			t.Errorf("expected a missing mount target warning, got: %s", out)
+		}
+	})
+
+	t.Run("a DescribeMountTargets error is tolerated and warned", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if err := d.Set("verify_efs_mount_target_for_file_system_id", "fs-123"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := d.Set("subnet_id", "subnet-abc"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockEFSAPI{
+			describeMountTargets: func(*efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+				return nil, awserr.New("FileSystemNotFound", "file system not found", nil)
+			},
+		}
+
+		out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceVerifyEfsMountTarget(d, conn) })
+		if !strings.Contains(out, "fs-123") {
+			t.Errorf("expected a warning naming fs-123, got: %s", out)
+		}
+	})
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyUrlReachable(t *testing.T) {
+	t.Run("verify_url_reachable defaults to off", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if d.Get("verify_url_reachable").(bool) {
+			t.Error("expected verify_url_reachable to default to false")
+		}
+	})
+
+	origClient := sagemakerNotebookInstanceUrlReachableClient
+	defer func() { sagemakerNotebookInstanceUrlReachableClient = origClient }()
+
+	t.Run("a 200 response is reachable", func(t *testing.T) {
+		sagemakerNotebookInstanceUrlReachableClient = newTestHTTPHeadClient(200, nil)
+
+		if err := sagemakerNotebookInstanceUrlReachable("my-notebook.notebook.us-east-1.sagemaker.aws"); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("a 500 response is not reachable", func(t *testing.T) {
+		sagemakerNotebookInstanceUrlReachableClient = newTestHTTPHeadClient(500, nil)
+
+		if err := sagemakerNotebookInstanceUrlReachable("my-notebook.notebook.us-east-1.sagemaker.aws"); err == nil {
+			t.Error("expected an error for a 500 response")
+		}
+	})
+
+	t.Run("a request error is not reachable", func(t *testing.T) {
+		sagemakerNotebookInstanceUrlReachableClient = newTestHTTPHeadClient(0, errors.New("connection refused"))
+
+		if err := sagemakerNotebookInstanceUrlReachable("my-notebook.notebook.us-east-1.sagemaker.aws"); err == nil {
+			t.Error("expected an error when the request fails")
+		}
+	})
+
+	t.Run("an empty url is not reachable", func(t *testing.T) {
+		if err := sagemakerNotebookInstanceUrlReachable(""); err == nil {
+			t.Error("expected an error for an empty url")
+		}
+	})
+}
+
+// mockSSMAPI embeds ssmiface.SSMAPI so it satisfies the full interface
+// while only overriding DescribeInstanceInformation, the only call
+// resourceAwsSagemakerNotebookInstanceCheckSsmManaged makes.
+type mockSSMAPI struct {
+	ssmiface.SSMAPI
+
+	describeInstanceInformation func(*ssm.DescribeInstanceInformationInput) (*ssm.DescribeInstanceInformationOutput, error)
+}
+
+func (m *mockSSMAPI) DescribeInstanceInformation(input *ssm.DescribeInstanceInformationInput) (*ssm.DescribeInstanceInformationOutput, error) {
+	return m.describeInstanceInformation(input)
+}
+
+// mockSecretsManagerAPI embeds secretsmanageriface.SecretsManagerAPI so it
+// satisfies the full interface while only overriding DescribeSecret, the
+// only call resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets makes.
+type mockSecretsManagerAPI struct {
+	secretsmanageriface.SecretsManagerAPI
+
+	describeSecret func(*secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error)
+}
+
+func (m *mockSecretsManagerAPI) DescribeSecret(input *secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error) {
+	return m.describeSecret(input)
+}
+
+func TestResourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(t *testing.T) {
+	withCapturedLog := func(fn func()) string {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+
+		fn()
+
+		return buf.String()
+	}
+
+	t.Run("verify_code_repo_secrets defaults to off", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if d.Get("verify_code_repo_secrets").(bool) {
+			t.Error("expected verify_code_repo_secrets to default to false")
+		}
+	})
+
+	t.Run("no code repositories configured does nothing", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+
+		sagemakerConn := &mockSagemakerAPI{
+			describeCodeRepository: func(*sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error) {
+				t.Fatal("DescribeCodeRepository should not be called when no code repository is configured")
+				return nil, nil
+			},
+		}
+		secretsConn := &mockSecretsManagerAPI{}
+
+		resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d, sagemakerConn, secretsConn)
+	})
+
+	t.Run("a repository with no secret_arn is skipped", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("default_code_repository", "my-repo"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		sagemakerConn := &mockSagemakerAPI{
+			describeCodeRepository: func(*sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error) {
+				return &sagemaker.DescribeCodeRepositoryOutput{GitConfig: &sagemaker.GitConfig{}}, nil
+			},
+		}
+		secretsConn := &mockSecretsManagerAPI{
+			describeSecret: func(*secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error) {
+				t.Fatal("DescribeSecret should not be called when secret_arn is unset")
+				return nil, nil
+			},
+		}
+
+		resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d, sagemakerConn, secretsConn)
+	})
+
+	t.Run("a retrievable secret logs nothing", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("default_code_repository", "my-repo"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		sagemakerConn := &mockSagemakerAPI{
+			describeCodeRepository: func(*sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error) {
+				return &sagemaker.DescribeCodeRepositoryOutput{
+					GitConfig: &sagemaker.GitConfig{SecretArn: aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:my-secret")},
+				}, nil
+			},
+		}
+		secretsConn := &mockSecretsManagerAPI{
+			describeSecret: func(*secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error) {
+				return &secretsmanager.DescribeSecretOutput{}, nil
+			},
+		}
+
+		logged := withCapturedLog(func() {
+			resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d, sagemakerConn, secretsConn)
+		})
+
+		if strings.Contains(logged, "not retrievable") {
+			t.Errorf("expected no warning, got: %s", logged)
+		}
+	})
+
+	t.Run("an unretrievable secret logs a warning but does not panic", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("default_code_repository", "my-repo"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		sagemakerConn := &mockSagemakerAPI{
+			describeCodeRepository: func(*sagemaker.DescribeCodeRepositoryInput) (*sagemaker.DescribeCodeRepositoryOutput, error) {
+				return &sagemaker.DescribeCodeRepositoryOutput{
+					GitConfig: &sagemaker.GitConfig{SecretArn: aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:my-secret")},
+				}, nil
+			},
+		}
+		secretsConn := &mockSecretsManagerAPI{
+			describeSecret: func(*secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error) {
+				return nil, awserr.New("ResourceNotFoundException", "Secrets Manager can't find the specified secret", nil)
+			},
+		}
+
+		logged := withCapturedLog(func() {
+			resourceAwsSagemakerNotebookInstanceVerifyCodeRepoSecrets(d, sagemakerConn, secretsConn)
+		})
+
+		if !strings.Contains(logged, "not retrievable") {
+			t.Errorf("expected a warning about the unretrievable secret, got: %s", logged)
+		}
+	})
+}
+
+func TestResourceAwsSagemakerNotebookInstancePresignedUrl(t *testing.T) {
+	t.Run("generate_presigned_url defaults to off", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if d.Get("generate_presigned_url").(bool) {
+			t.Error("expected generate_presigned_url to default to false")
+		}
+	})
+
+	t.Run("off by default, never calls CreatePresignedNotebookInstanceUrl", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+
+		conn := &mockSagemakerAPI{
+			createPresignedNotebookInstanceUrl: func(*sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error) {
+				t.Fatal("CreatePresignedNotebookInstanceUrl should not be called when generate_presigned_url is off")
+				return nil, nil
+			},
+		}
+
+		url, err := resourceAwsSagemakerNotebookInstancePresignedUrl(d, conn, sagemaker.NotebookInstanceStatusInService)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if url != "" {
+			t.Errorf("expected an empty url, got: %s", url)
+		}
+	})
+
+	t.Run("on and InService, returns the presigned url", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("generate_presigned_url", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockSagemakerAPI{
+			createPresignedNotebookInstanceUrl: func(*sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error) {
+				return &sagemaker.CreatePresignedNotebookInstanceUrlOutput{
+					AuthorizedUrl: aws.String("https://my-notebook.notebook.us-east-1.sagemaker.aws?authToken=abc123"),
+				}, nil
+			},
+		}
+
+		url, err := resourceAwsSagemakerNotebookInstancePresignedUrl(d, conn, sagemaker.NotebookInstanceStatusInService)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if url != "https://my-notebook.notebook.us-east-1.sagemaker.aws?authToken=abc123" {
+			t.Errorf("unexpected url: %s", url)
+		}
+	})
+
+	t.Run("on but not InService, skips the call", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("generate_presigned_url", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockSagemakerAPI{
+			createPresignedNotebookInstanceUrl: func(*sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error) {
+				t.Fatal("CreatePresignedNotebookInstanceUrl should not be called while not InService")
+				return nil, nil
+			},
+		}
+
+		url, err := resourceAwsSagemakerNotebookInstancePresignedUrl(d, conn, sagemaker.NotebookInstanceStatusPending)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if url != "" {
+			t.Errorf("expected an empty url, got: %s", url)
+		}
+	})
+
+	t.Run("a CreatePresignedNotebookInstanceUrl error is returned", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+		if err := d.Set("generate_presigned_url", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		conn := &mockSagemakerAPI{
+			createPresignedNotebookInstanceUrl: func(*sagemaker.CreatePresignedNotebookInstanceUrlInput) (*sagemaker.CreatePresignedNotebookInstanceUrlOutput, error) {
+				return nil, awserr.New("ValidationException", "notebook instance is not InService", nil)
+			},
+		}
+
+		if _, err := resourceAwsSagemakerNotebookInstancePresignedUrl(d, conn, sagemaker.NotebookInstanceStatusInService); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceSsmManaged(t *testing.T) {
+	if sagemakerNotebookInstanceSsmManaged(nil) {
+		t.Error("expected a nil output to be unmanaged")
+	}
+
+	if sagemakerNotebookInstanceSsmManaged(&ssm.DescribeInstanceInformationOutput{}) {
+		t.Error("expected an empty result to be unmanaged")
+	}
+
+	managed := &ssm.DescribeInstanceInformationOutput{
+		InstanceInformationList: []*ssm.InstanceInformation{{}},
+	}
+	if !sagemakerNotebookInstanceSsmManaged(managed) {
+		t.Error("expected a non-empty result to be managed")
+	}
+}
+
+func TestResourceAwsSagemakerNotebookInstanceCheckSsmManaged(t *testing.T) {
+	withCapturedLog := func(fn func()) string {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+
+		fn()
+
+		return buf.String()
+	}
+
+	t.Run("check_ssm_managed defaults to off", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		if d.Get("check_ssm_managed").(bool) {
+			t.Error("expected check_ssm_managed to default to false")
+		}
+	})
+
+	t.Run("sets ssm_managed true when found", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+
+		conn := &mockSSMAPI{
+			describeInstanceInformation: func(*ssm.DescribeInstanceInformationInput) (*ssm.DescribeInstanceInformationOutput, error) {
+				return &ssm.DescribeInstanceInformationOutput{
+					InstanceInformationList: []*ssm.InstanceInformation{{}},
+				}, nil
+			},
+		}
+
+		resourceAwsSagemakerNotebookInstanceCheckSsmManaged(d, conn)
+
+		if !d.Get("ssm_managed").(bool) {
+			t.Error("expected ssm_managed to be set true")
+		}
+	})
+
+	t.Run("an error is logged, not fatal, and ssm_managed stays false", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("my-notebook")
+
+		conn := &mockSSMAPI{
+			describeInstanceInformation: func(*ssm.DescribeInstanceInformationInput) (*ssm.DescribeInstanceInformationOutput, error) {
+				return nil, awserr.New("InternalServerError", "boom", nil)
+			},
+		}
+
+		out := withCapturedLog(func() { resourceAwsSagemakerNotebookInstanceCheckSsmManaged(d, conn) })
+		if !strings.Contains(out, "my-notebook") {
+			t.Errorf("expected a warning naming my-notebook, got: %s", out)
+		}
+		if d.Get("ssm_managed").(bool) {
+			t.Error("expected ssm_managed to stay false")
+		}
+	})
+}
+
+// testHTTPHeadTransport implements http.RoundTripper, returning a canned
+// status code or error for every request, so
+// sagemakerNotebookInstanceUrlReachable can be tested without making a real
+// network call.
+type testHTTPHeadTransport struct {
+	statusCode int
+	err        error
+}
+
+func (t *testHTTPHeadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &http.Response{
+		StatusCode: t.statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil
+}
+
+func newTestHTTPHeadClient(statusCode int, err error) *http.Client {
+	return &http.Client{Transport: &testHTTPHeadTransport{statusCode: statusCode, err: err}}
+}
+
+func TestSagemakerNotebookInstanceSchedulable(t *testing.T) {
+	testCases := []struct {
+		status string
+		want   bool
+	}{
+		{status: sagemaker.NotebookInstanceStatusInService, want: true},
+		{status: sagemaker.NotebookInstanceStatusStopped, want: true},
+		{status: sagemaker.NotebookInstanceStatusPending, want: false},
+		{status: sagemaker.NotebookInstanceStatusStopping, want: false},
+		{status: sagemaker.NotebookInstanceStatusUpdating, want: false},
+		{status: sagemaker.NotebookInstanceStatusDeleting, want: false},
+		{status: sagemaker.NotebookInstanceStatusFailed, want: false},
+		{status: "", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			if got := sagemakerNotebookInstanceSchedulable(tc.status); got != tc.want {
+				t.Errorf("sagemakerNotebookInstanceSchedulable(%q) = %v, want %v", tc.status, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSagemakerNotebookInstanceAvailabilityZone(t *testing.T) {
+	t.Run("returns the subnet's availability zone", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{AvailabilityZone: aws.String("us-east-1a")},
+					},
+				}, nil
+			},
+		}
+
+		got := sagemakerNotebookInstanceAvailabilityZone(conn, aws.String("subnet-12345"))
+		if got != "us-east-1a" {
+			t.Errorf("got %q, want %q", got, "us-east-1a")
+		}
+	})
+
+	t.Run("returns empty string when subnet_id is unset", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				t.Fatal("DescribeSubnets should not be called when subnetId is empty")
+				return nil, nil
+			},
+		}
+
+		if got := sagemakerNotebookInstanceAvailabilityZone(conn, aws.String("")); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+
+	t.Run("returns empty string on lookup failure", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return nil, awserr.New("InvalidSubnetID.NotFound", "not found", nil)
+			},
+		}
+
+		if got := sagemakerNotebookInstanceAvailabilityZone(conn, aws.String("subnet-12345")); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceVpcId(t *testing.T) {
+	t.Run("returns the subnet's VPC ID", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{VpcId: aws.String("vpc-12345")},
+					},
+				}, nil
+			},
+		}
+
+		got := sagemakerNotebookInstanceVpcId(conn, aws.String("subnet-12345"))
+		if got != "vpc-12345" {
+			t.Errorf("got %q, want %q", got, "vpc-12345")
+		}
+	})
+
+	t.Run("returns empty string when subnet_id is unset", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				t.Fatal("DescribeSubnets should not be called when subnetId is empty")
+				return nil, nil
+			},
+		}
+
+		if got := sagemakerNotebookInstanceVpcId(conn, aws.String("")); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+
+	t.Run("returns empty string on a permissions gap", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return nil, awserr.New("UnauthorizedOperation", "not authorized to perform: ec2:DescribeSubnets", nil)
+			},
+		}
+
+		if got := sagemakerNotebookInstanceVpcId(conn, aws.String("subnet-12345")); got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceSubnetIpExhaustionWarning(t *testing.T) {
+	t.Run("warns when available IP count is critically low", func(t *testing.T) {
+		got := sagemakerNotebookInstanceSubnetIpExhaustionWarning("subnet-12345", 1)
+		if got == "" {
+			t.Error("expected a warning, got empty string")
+		}
+	})
+
+	t.Run("warns when no IP addresses are available", func(t *testing.T) {
+		got := sagemakerNotebookInstanceSubnetIpExhaustionWarning("subnet-12345", 0)
+		if got == "" {
+			t.Error("expected a warning, got empty string")
+		}
+	})
+
+	t.Run("returns empty string when available IP count is healthy", func(t *testing.T) {
+		got := sagemakerNotebookInstanceSubnetIpExhaustionWarning("subnet-12345", 5)
+		if got != "" {
+			t.Errorf("got %q, want empty string", got)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceCheckSubnetIpAvailability(t *testing.T) {
+	t.Run("does not panic on lookup failure", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return nil, awserr.New("InvalidSubnetID.NotFound", "not found", nil)
+			},
+		}
+
+		resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability(conn, "subnet-12345")
+	})
+
+	t.Run("does not panic when IP addresses are critically low", func(t *testing.T) {
+		conn := &mockEC2API{
+			describeSubnets: func(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{AvailableIpAddressCount: aws.Int64(1)},
+					},
+				}, nil
+			},
+		}
+
+		resourceAwsSagemakerNotebookInstanceCheckSubnetIpAvailability(conn, "subnet-12345")
+	})
+}
+
+func TestSagemakerCodeRepositoryRefsEquivalent(t *testing.T) {
+	reposByUrl := func(urls map[string]string) func(*sagemaker.ListCodeRepositoriesInput, func(*sagemaker.ListCodeRepositoriesOutput, bool) bool) error {
+		return func(input *sagemaker.ListCodeRepositoriesInput, fn func(*sagemaker.ListCodeRepositoriesOutput, bool) bool) error {
+			var summaries []*sagemaker.CodeRepositorySummary
+			for url, name := range urls {
+				summaries = append(summaries, &sagemaker.CodeRepositorySummary{
+					CodeRepositoryName: aws.String(name),
+					GitConfig:          &sagemaker.GitConfig{RepositoryUrl: aws.String(url)},
+				})
+			}
+			fn(&sagemaker.ListCodeRepositoriesOutput{CodeRepositorySummaryList: summaries}, true)
+			return nil
+		}
+	}
+
+	t.Run("name vs ARN of the same repository are equivalent", func(t *testing.T) {
+		conn := &mockSagemakerAPI{listCodeRepositoriesPages: reposByUrl(nil)}
+
+		if !sagemakerCodeRepositoryRefsEquivalent(conn, "my-repo", "arn:aws:sagemaker:us-east-1:123456789012:code-repository/my-repo") {
+			t.Error("expected name and ARN of the same repository to be equivalent")
+		}
+	})
+
+	t.Run("URL vs name mismatch resolves through ListCodeRepositories", func(t *testing.T) {
+		conn := &mockSagemakerAPI{listCodeRepositoriesPages: reposByUrl(map[string]string{
+			"https://github.com/example/my-repo.git": "my-repo",
+		})}
+
+		if !sagemakerCodeRepositoryRefsEquivalent(conn, "https://github.com/example/my-repo.git", "my-repo") {
+			t.Error("expected URL and name of the same repository to be equivalent")
+		}
+	})
+
+	t.Run("different repositories are not equivalent", func(t *testing.T) {
+		conn := &mockSagemakerAPI{listCodeRepositoriesPages: reposByUrl(map[string]string{
+			"https://github.com/example/my-repo.git": "my-repo",
+		})}
+
+		if sagemakerCodeRepositoryRefsEquivalent(conn, "https://github.com/example/my-repo.git", "other-repo") {
+			t.Error("expected different repositories to not be equivalent")
+		}
+	})
+
+	t.Run("unresolvable URL is not equivalent", func(t *testing.T) {
+		conn := &mockSagemakerAPI{listCodeRepositoriesPages: reposByUrl(nil)}
+
+		if sagemakerCodeRepositoryRefsEquivalent(conn, "https://github.com/example/unknown.git", "my-repo") {
+			t.Error("expected an unresolvable URL to not be equivalent to anything")
+		}
+	})
+}
+
+func TestFlattenSagemakerNotebookInstanceAdditionalCodeRepositories(t *testing.T) {
+	conn := &mockSagemakerAPI{
+		listCodeRepositoriesPages: func(input *sagemaker.ListCodeRepositoriesInput, fn func(*sagemaker.ListCodeRepositoriesOutput, bool) bool) error {
+			fn(&sagemaker.ListCodeRepositoriesOutput{CodeRepositorySummaryList: []*sagemaker.CodeRepositorySummary{
+				{
+					CodeRepositoryName: aws.String("url-repo"),
+					GitConfig:          &sagemaker.GitConfig{RepositoryUrl: aws.String("https://github.com/example/url-repo.git")},
+				},
+			}}, true)
+			return nil
+		},
+	}
+
+	configured := []interface{}{
+		"name-repo",
+		"arn:aws:sagemaker:us-east-1:123456789012:code-repository/arn-repo",
+		"https://github.com/example/url-repo.git",
+	}
+
+	stored := []*string{
+		aws.String("name-repo"),
+		aws.String("arn-repo"),
+		aws.String("url-repo"),
+		aws.String("out-of-band-repo"),
+	}
+
+	got := flattenSagemakerNotebookInstanceAdditionalCodeRepositories(conn, configured, stored)
+
+	want := []interface{}{
+		"name-repo",
+		"arn:aws:sagemaker:us-east-1:123456789012:code-repository/arn-repo",
+		"https://github.com/example/url-repo.git",
+		"out-of-band-repo",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestSagemakerNotebookInstanceUpdateWithRetry(t *testing.T) {
+	t.Run("retries past a transient invalid state error", func(t *testing.T) {
+		calls := 0
+		conn := &mockSagemakerAPI{
+			updateNotebookInstance: func(*sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error) {
+				calls++
+				if calls < 3 {
+					return nil, awserr.New("ValidationException", "Notebook Instance is not in a valid state to perform this operation", nil)
+				}
+				return &sagemaker.UpdateNotebookInstanceOutput{}, nil
+			},
+		}
+
+		err := sagemakerNotebookInstanceUpdateWithRetry(conn, &sagemaker.UpdateNotebookInstanceInput{
+			NotebookInstanceName: aws.String("test"),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Errorf("got %d calls, want 3", calls)
+		}
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		calls := 0
+		conn := &mockSagemakerAPI{
+			updateNotebookInstance: func(*sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error) {
+				calls++
+				return nil, awserr.New("ValidationException", "1 validation error(s) found.", nil)
+			},
+		}
+
+		err := sagemakerNotebookInstanceUpdateWithRetry(conn, &sagemaker.UpdateNotebookInstanceInput{
+			NotebookInstanceName: aws.String("test"),
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls != 1 {
+			t.Errorf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("retries past a concurrent modification error", func(t *testing.T) {
+		calls := 0
+		conn := &mockSagemakerAPI{
+			updateNotebookInstance: func(*sagemaker.UpdateNotebookInstanceInput) (*sagemaker.UpdateNotebookInstanceOutput, error) {
+				calls++
+				if calls < 3 {
+					return nil, awserr.New("ConflictException", "Notebook Instance is currently in use by another operation", nil)
+				}
+				return &sagemaker.UpdateNotebookInstanceOutput{}, nil
+			},
+		}
+
+		err := sagemakerNotebookInstanceUpdateWithRetry(conn, &sagemaker.UpdateNotebookInstanceInput{
+			NotebookInstanceName: aws.String("test"),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Errorf("got %d calls, want 3", calls)
+		}
+	})
+}
+
+func TestIsSagemakerNotebookInstanceConcurrentModificationErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"ConflictException is concurrent modification", awserr.New("ConflictException", "in use by another operation", nil), true},
+		{"ResourceInUse is concurrent modification", awserr.New("ResourceInUse", "Notebook Instance is in use", nil), true},
+		{"ValidationException mentioning in use is concurrent modification", awserr.New("ValidationException", "Notebook Instance test is IN USE by another update", nil), true},
+		{"unrelated ValidationException is not concurrent modification", awserr.New("ValidationException", "1 validation error(s) found.", nil), false},
+		{"non-awserr is not concurrent modification", fmt.Errorf("boom"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerNotebookInstanceConcurrentModificationErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerNotebookInstanceConcurrentModificationErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStopSagemakerNotebookInstance(t *testing.T) {
+	t.Run("retries past a concurrent modification error then waits for Stopped", func(t *testing.T) {
+		var stopCalls, describeCalls int
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				describeCalls++
+				status := sagemaker.NotebookInstanceStatusInService
+				if describeCalls > 2 {
+					status = sagemaker.NotebookInstanceStatusStopped
+				}
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceName:   input.NotebookInstanceName,
+					NotebookInstanceStatus: aws.String(status),
+				}, nil
+			},
+			stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+				stopCalls++
+				if stopCalls < 2 {
+					return nil, awserr.New("ConflictException", "Notebook Instance is currently in use by another operation", nil)
+				}
+				return &sagemaker.StopNotebookInstanceOutput{}, nil
+			},
+		}
+
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, "test", 30*time.Second, 10*time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if stopCalls != 2 {
+			t.Errorf("got %d StopNotebookInstance calls, want 2", stopCalls)
+		}
+	})
+
+	t.Run("a concurrent run that already stopped the instance is treated as success", func(t *testing.T) {
+		var stopCalls int
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceName:   input.NotebookInstanceName,
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusStopped),
+				}, nil
+			},
+			stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+				stopCalls++
+				return nil, awserr.New("ConflictException", "Notebook Instance is currently in use by another operation", nil)
+			},
+		}
+
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, "test", 30*time.Second, 10*time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if stopCalls != 0 {
+			t.Errorf("got %d StopNotebookInstance calls, want 0: the pre-flight status check should have already seen Stopped", stopCalls)
+		}
+	})
+
+	t.Run("does not retry an unrelated error", func(t *testing.T) {
+		var stopCalls int
+		conn := &mockSagemakerAPI{
+			describeNotebookInstance: func(input *sagemaker.DescribeNotebookInstanceInput) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceOutput{
+					NotebookInstanceName:   input.NotebookInstanceName,
+					NotebookInstanceStatus: aws.String(sagemaker.NotebookInstanceStatusInService),
+				}, nil
+			},
+			stopNotebookInstance: func(*sagemaker.StopNotebookInstanceInput) (*sagemaker.StopNotebookInstanceOutput, error) {
+				stopCalls++
+				return nil, awserr.New("ValidationException", "1 validation error(s) found.", nil)
+			},
+		}
+
+		if err := stopSagemakerNotebookInstance(context.Background(), conn, "test", 30*time.Second, 10*time.Millisecond); err == nil {
+			t.Error("expected an error")
+		}
+		if stopCalls != 1 {
+			t.Errorf("got %d StopNotebookInstance calls, want 1", stopCalls)
+		}
+	})
+}
+
+// TestAccAWSSagemakerNotebookInstance_forEach confirms a for_each over a map
+// of notebook specs gets one resource per map key with an ID (the notebook
+// name) that's stable across plans -- i.e. it's genuinely derived from name,
+// not some synthetic for_each-indexed value that could drift.
+func TestAccAWSSagemakerNotebookInstance_forEach(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerNotebookInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerNotebookInstanceConfigForEach(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_sagemaker_notebook_instance.test[\"a\"]", "id", rName+"-a"),
+					resource.TestCheckResourceAttr("aws_sagemaker_notebook_instance.test[\"a\"]", "name", rName+"-a"),
+					resource.TestCheckResourceAttr("aws_sagemaker_notebook_instance.test[\"b\"]", "id", rName+"-b"),
+					resource.TestCheckResourceAttr("aws_sagemaker_notebook_instance.test[\"b\"]", "name", rName+"-b"),
+				),
+			},
+			// Re-applying the same config is the real test here: if the ID
+			// were anything other than name itself, this plan would show a
+			// spurious diff instead of being a no-op.
+			{
+				Config:   testAccAWSSagemakerNotebookInstanceConfigForEach(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccAWSSagemakerNotebookInstanceConfigForEach(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+POLICY
+}
+
+resource "aws_sagemaker_notebook_instance" "test" {
+  for_each = { a = "%[1]s-a", b = "%[1]s-b" }
+
+  name          = each.value
+  role_arn      = aws_iam_role.test.arn
+  instance_type = "ml.t2.medium"
+}
+`, rName)
+}
+
+func TestSagemakerNotebookInstanceLifecycleConfigContentSha(t *testing.T) {
+	t.Run("same content hashes the same", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstanceLifecycleConfig: func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceLifecycleConfigOutput{
+					OnCreate: []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String("create")}},
+					OnStart:  []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String("start")}},
+				}, nil
+			},
+		}
+
+		got1, err := sagemakerNotebookInstanceLifecycleConfigContentSha(conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got2, err := sagemakerNotebookInstanceLifecycleConfigContentSha(conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got1 != got2 {
+			t.Errorf("got %q and %q, want identical hashes for identical content", got1, got2)
+		}
+		if got1 == "" {
+			t.Error("expected a non-empty hash")
+		}
+	})
+
+	t.Run("different content hashes differently", func(t *testing.T) {
+		connA := &mockSagemakerAPI{
+			describeNotebookInstanceLifecycleConfig: func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceLifecycleConfigOutput{
+					OnCreate: []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String("create-a")}},
+				}, nil
+			},
+		}
+		connB := &mockSagemakerAPI{
+			describeNotebookInstanceLifecycleConfig: func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+				return &sagemaker.DescribeNotebookInstanceLifecycleConfigOutput{
+					OnCreate: []*sagemaker.NotebookInstanceLifecycleHook{{Content: aws.String("create-b")}},
+				}, nil
+			},
+		}
+
+		gotA, err := sagemakerNotebookInstanceLifecycleConfigContentSha(connA, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		gotB, err := sagemakerNotebookInstanceLifecycleConfigContentSha(connB, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if gotA == gotB {
+			t.Errorf("got identical hashes %q for different content", gotA)
+		}
+	})
+
+	t.Run("config deleted out-of-band returns an empty hash, not an error", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstanceLifecycleConfig: func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+				return nil, awserr.New("ResourceNotFoundException", "lifecycle config not found", nil)
+			},
+		}
+
+		got, err := sagemakerNotebookInstanceLifecycleConfigContentSha(conn, "test")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "" {
+			t.Errorf("got %q, want empty hash", got)
+		}
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		conn := &mockSagemakerAPI{
+			describeNotebookInstanceLifecycleConfig: func(*sagemaker.DescribeNotebookInstanceLifecycleConfigInput) (*sagemaker.DescribeNotebookInstanceLifecycleConfigOutput, error) {
+				return nil, awserr.New("ThrottlingException", "rate exceeded", nil)
+			},
+		}
+
+		if _, err := sagemakerNotebookInstanceLifecycleConfigContentSha(conn, "test"); err == nil {
+			t.Error("expected an error, got none")
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceKmsKeyDeletionWarning(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keyState string
+	}{
+		{name: "enabled key", keyState: kms.KeyStateEnabled},
+		{name: "pending deletion key", keyState: kms.KeyStatePendingDeletion},
+		{name: "disabled key", keyState: kms.KeyStateDisabled},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := &mockKMSAPI{
+				describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+					return &kms.DescribeKeyOutput{
+						KeyMetadata: &kms.KeyMetadata{
+							KeyId:    input.KeyId,
+							KeyState: aws.String(tc.keyState),
+						},
+					}, nil
+				},
+			}
+
+			// Purely a logging path, so this just exercises each KeyState
+			// branch (and the DescribeKey-error branch below) without
+			// panicking; there's no return value to assert on.
+			sagemakerNotebookInstanceKmsKeyDeletionWarning(conn, "test", "my-key")
+		})
+	}
+
+	t.Run("describe error is tolerated", func(t *testing.T) {
+		conn := &mockKMSAPI{
+			describeKey: func(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+				return nil, awserr.New("AccessDeniedException", "not authorized to describe key", nil)
+			},
+		}
+
+		sagemakerNotebookInstanceKmsKeyDeletionWarning(conn, "test", "my-key")
+	})
+}
+
+func TestFlattenSagemakerNotebookInstanceSecurityGroups(t *testing.T) {
+	want := []interface{}{"sg-aaaa", "sg-bbbb", "sg-cccc"}
+
+	orderings := [][]*string{
+		aws.StringSlice([]string{"sg-aaaa", "sg-bbbb", "sg-cccc"}),
+		aws.StringSlice([]string{"sg-cccc", "sg-aaaa", "sg-bbbb"}),
+		aws.StringSlice([]string{"sg-bbbb", "sg-cccc", "sg-aaaa"}),
+	}
+
+	for _, securityGroups := range orderings {
+		got := flattenSagemakerNotebookInstanceSecurityGroups(securityGroups)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestSagemakerNotebookInstanceTimedWait(t *testing.T) {
+	t.Run("records elapsed time on success", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("test")
+
+		err := sagemakerNotebookInstanceTimedWait(d, func() error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := d.Get("last_provision_duration_seconds").(int); got < 0 {
+			t.Errorf("got last_provision_duration_seconds %d, want >= 0", got)
+		}
+	})
+
+	t.Run("leaves last_provision_duration_seconds unset on failure", func(t *testing.T) {
+		d := resourceAwsSagemakerNotebookInstance().TestResourceData()
+		d.SetId("test")
+
+		wantErr := fmt.Errorf("wait failed")
+		err := sagemakerNotebookInstanceTimedWait(d, func() error {
+			return wantErr
+		})
+		if err != wantErr {
+			t.Fatalf("got error %v, want %v", err, wantErr)
+		}
+
+		if got := d.Get("last_provision_duration_seconds").(int); got != 0 {
+			t.Errorf("got last_provision_duration_seconds %d, want 0", got)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceCreateFailedError(t *testing.T) {
+	t.Run("wraps an insufficient capacity failure with a distinct message", func(t *testing.T) {
+		err := sagemakerNotebookInstanceCreateFailedError("my-notebook", "ml.p3.2xlarge", "InsufficientInstanceCapacity: ...", "")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "insufficient capacity") || !strings.Contains(err.Error(), "ml.p3.2xlarge") {
+			t.Errorf("expected the instance_type and an insufficient capacity message, got: %s", err)
+		}
+	})
+
+	t.Run("appends the lifecycle log tail when present", func(t *testing.T) {
+		err := sagemakerNotebookInstanceCreateFailedError("my-notebook", "ml.t2.medium", "some other failure", "line one\nline two")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "some other failure") || !strings.Contains(err.Error(), "line one\nline two") {
+			t.Errorf("expected the failure reason and log tail, got: %s", err)
+		}
+	})
+
+	t.Run("names the notebook instance with no log tail", func(t *testing.T) {
+		err := sagemakerNotebookInstanceCreateFailedError("my-notebook", "ml.t2.medium", "some other failure", "")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "my-notebook") || !strings.Contains(err.Error(), "some other failure") {
+			t.Errorf("expected the id and failure reason, got: %s", err)
+		}
+	})
+}
+
+func TestSagemakerNotebookInstanceDeleteAfterCreateFailure(t *testing.T) {
+	t.Run("deletes the failed instance", func(t *testing.T) {
+		var deleted bool
+		api := &mockSagemakerAPI{
+			deleteNotebookInstance: func(input *sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+				deleted = true
+				if aws.StringValue(input.NotebookInstanceName) != "my-notebook" {
+					t.Errorf("expected delete for my-notebook, got: %s", aws.StringValue(input.NotebookInstanceName))
+				}
+				return &sagemaker.DeleteNotebookInstanceOutput{}, nil
+			},
+		}
+
+		sagemakerNotebookInstanceDeleteAfterCreateFailure(api, "my-notebook")
+
+		if !deleted {
+			t.Error("expected DeleteNotebookInstance to be called")
+		}
+	})
+
+	t.Run("a delete failure is swallowed, not returned", func(t *testing.T) {
+		api := &mockSagemakerAPI{
+			deleteNotebookInstance: func(input *sagemaker.DeleteNotebookInstanceInput) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+				return nil, awserr.New("ResourceNotFound", "not found", nil)
+			},
+		}
+
+		// Must not panic; the function has no error return to assert on.
+		sagemakerNotebookInstanceDeleteAfterCreateFailure(api, "my-notebook")
+	})
+}
+
+// mockSnsAPI embeds snsiface.SNSAPI so it satisfies the full interface while
+// only overriding Publish, the only call sagemakerNotebookInstanceNotifyStatus
+// makes.
+type mockSnsAPI struct {
+	snsiface.SNSAPI
+
+	publish func(*sns.PublishInput) (*sns.PublishOutput, error)
+}
+
+func (m *mockSnsAPI) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
+	return m.publish(input)
+}
+
+func TestSagemakerNotebookInstanceNotifyStatus(t *testing.T) {
+	t.Run("publishes the instance name and status to the given topic", func(t *testing.T) {
+		var published *sns.PublishInput
+		conn := &mockSnsAPI{
+			publish: func(input *sns.PublishInput) (*sns.PublishOutput, error) {
+				published = input
+				return &sns.PublishOutput{}, nil
+			},
+		}
+
+		sagemakerNotebookInstanceNotifyStatus(conn, "arn:aws:sns:us-east-1:123456789012:my-topic", "my-notebook", sagemaker.NotebookInstanceStatusInService)
+
+		if published == nil {
+			t.Fatal("expected Publish to be called")
+		}
+		if aws.StringValue(published.TopicArn) != "arn:aws:sns:us-east-1:123456789012:my-topic" {
+			t.Errorf("expected topic arn %q, got %q", "arn:aws:sns:us-east-1:123456789012:my-topic", aws.StringValue(published.TopicArn))
+		}
+		if !strings.Contains(aws.StringValue(published.Message), "my-notebook") || !strings.Contains(aws.StringValue(published.Message), sagemaker.NotebookInstanceStatusInService) {
+			t.Errorf("expected message to name the instance and status, got: %s", aws.StringValue(published.Message))
+		}
+	})
+
+	t.Run("a nil topic arn is a no-op", func(t *testing.T) {
+		conn := &mockSnsAPI{
+			publish: func(*sns.PublishInput) (*sns.PublishOutput, error) {
+				t.Fatal("expected Publish not to be called")
+				return nil, nil
+			},
+		}
+
+		sagemakerNotebookInstanceNotifyStatus(conn, "", "my-notebook", sagemaker.NotebookInstanceStatusFailed)
+	})
+
+	t.Run("a publish failure is swallowed, not returned", func(t *testing.T) {
+		conn := &mockSnsAPI{
+			publish: func(*sns.PublishInput) (*sns.PublishOutput, error) {
+				return nil, awserr.New("InternalFailure", "boom", nil)
+			},
+		}
+
+		// Must not panic; the function has no error return to assert on.
+		sagemakerNotebookInstanceNotifyStatus(conn, "arn:aws:sns:us-east-1:123456789012:my-topic", "my-notebook", sagemaker.NotebookInstanceStatusFailed)
+	})
+}
+
+func TestSagemakerNotebookInstanceCreateTimeoutPendingMessage(t *testing.T) {
+	t.Run("a timeout still Pending names the id and advises retrying", func(t *testing.T) {
+		err := &resource.TimeoutError{
+			LastError:     fmt.Errorf("Sagemaker Notebook Instance (my-notebook) still %q, want %s", sagemaker.NotebookInstanceStatusPending, []string{sagemaker.NotebookInstanceStatusInService}),
+			LastState:     sagemaker.NotebookInstanceStatusPending,
+			Timeout:       10 * time.Minute,
+			ExpectedState: []string{sagemaker.NotebookInstanceStatusInService},
+		}
+
+		msg := sagemakerNotebookInstanceCreateTimeoutPendingMessage("my-notebook", err)
+		if msg == "" {
+			t.Fatal("expected a message")
+		}
+		if !strings.Contains(msg, "my-notebook") || !strings.Contains(msg, "Pending") {
+			t.Errorf("expected message to name the id and Pending, got: %s", msg)
+		}
+	})
+
+	t.Run("a timeout in a different last state is left to the generic error", func(t *testing.T) {
+		err := &resource.TimeoutError{
+			LastState: sagemaker.NotebookInstanceStatusUpdating,
+		}
+
+		if msg := sagemakerNotebookInstanceCreateTimeoutPendingMessage("my-notebook", err); msg != "" {
+			t.Errorf("expected no message, got: %s", msg)
+		}
+	})
+
+	t.Run("a non-timeout error is left to the generic error", func(t *testing.T) {
+		if msg := sagemakerNotebookInstanceCreateTimeoutPendingMessage("my-notebook", fmt.Errorf("boom")); msg != "" {
+			t.Errorf("expected no message, got: %s", msg)
+		}
+	})
+}
@@ -0,0 +1,389 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerPartnerApp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerPartnerAppCreate,
+		Read:   resourceAwsSagemakerPartnerAppRead,
+		Update: resourceAwsSagemakerPartnerAppUpdate,
+		Delete: resourceAwsSagemakerPartnerAppDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.PartnerAppType_Values(), false),
+			},
+
+			"execution_role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIamRoleArn,
+			},
+
+			"tier": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"auth_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.PartnerAppAuthType_Values(), false),
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"maintenance_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"maintenance_window_start": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"application_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"admin_users": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"arguments": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"base_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerPartnerAppCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreatePartnerAppInput{
+		Name:             aws.String(name),
+		Type:             aws.String(d.Get("type").(string)),
+		ExecutionRoleArn: aws.String(d.Get("execution_role_arn").(string)),
+		Tier:             aws.String(d.Get("tier").(string)),
+		AuthType:         aws.String(d.Get("auth_type").(string)),
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		createOpts.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("maintenance_config"); ok {
+		createOpts.MaintenanceConfig = expandSagemakerPartnerAppMaintenanceConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("application_config"); ok {
+		createOpts.ApplicationConfig = expandSagemakerPartnerAppApplicationConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Partner App create config: %#v", *createOpts)
+	out, err := conn.CreatePartnerApp(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Partner App: %s", err)
+	}
+
+	d.SetId(aws.StringValue(out.Arn))
+	log.Printf("[INFO] Sagemaker Partner App ID: %s", d.Id())
+
+	if err := waitSagemakerPartnerAppStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.PartnerAppStatusAvailable); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Partner App (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerPartnerAppRead(d, meta)
+}
+
+func resourceAwsSagemakerPartnerAppRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	app, err := conn.DescribePartnerApp(&sagemaker.DescribePartnerAppInput{
+		Arn: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker partner app %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("name", app.Name); err != nil {
+		return fmt.Errorf("error setting name for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("type", app.Type); err != nil {
+		return fmt.Errorf("error setting type for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("execution_role_arn", app.ExecutionRoleArn); err != nil {
+		return fmt.Errorf("error setting execution_role_arn for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("tier", app.Tier); err != nil {
+		return fmt.Errorf("error setting tier for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("auth_type", app.AuthType); err != nil {
+		return fmt.Errorf("error setting auth_type for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("kms_key_id", app.KmsKeyId); err != nil {
+		return fmt.Errorf("error setting kms_key_id for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("maintenance_config", flattenSagemakerPartnerAppMaintenanceConfig(app.MaintenanceConfig)); err != nil {
+		return fmt.Errorf("error setting maintenance_config for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("application_config", flattenSagemakerPartnerAppApplicationConfig(app.ApplicationConfig)); err != nil {
+		return fmt.Errorf("error setting application_config for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", app.Arn); err != nil {
+		return fmt.Errorf("error setting arn for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("url", app.Url); err != nil {
+		return fmt.Errorf("error setting url for partner app %q: %s", d.Id(), err)
+	}
+	if err := d.Set("base_url", app.BaseUrl); err != nil {
+		return fmt.Errorf("error setting base_url for partner app %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, app.Arn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for partner app %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerPartnerAppUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("tier") || d.HasChange("maintenance_config") || d.HasChange("application_config") {
+		updateOpts := &sagemaker.UpdatePartnerAppInput{
+			Arn:  aws.String(d.Id()),
+			Tier: aws.String(d.Get("tier").(string)),
+		}
+
+		if v, ok := d.GetOk("maintenance_config"); ok {
+			updateOpts.MaintenanceConfig = expandSagemakerPartnerAppMaintenanceConfig(v.([]interface{}))
+		}
+
+		if v, ok := d.GetOk("application_config"); ok {
+			updateOpts.ApplicationConfig = expandSagemakerPartnerAppApplicationConfig(v.([]interface{}))
+		}
+
+		if _, err := conn.UpdatePartnerApp(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Partner App: %s", err)
+		}
+
+		if err := waitSagemakerPartnerAppStatus(conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemaker.PartnerAppStatusAvailable); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Partner App (%s) to be updated: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsSagemakerPartnerAppRead(d, meta)
+}
+
+func resourceAwsSagemakerPartnerAppDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeletePartnerApp(&sagemaker.DeletePartnerAppInput{
+		Arn: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Partner App: %s", err)
+	}
+
+	if err := waitSagemakerPartnerAppDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Partner App (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitSagemakerPartnerAppStatus(conn *sagemaker.SageMaker, arn string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribePartnerApp(&sagemaker.DescribePartnerAppInput{
+			Arn: aws.String(arn),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(out.Status)
+		for _, s := range desiredStatus {
+			if status == s {
+				return nil
+			}
+		}
+
+		if status == sagemaker.PartnerAppStatusFailed {
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Partner App (%s) failed", arn))
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Partner App (%s) to be %s", arn, desiredStatus))
+	})
+}
+
+func waitSagemakerPartnerAppDeleted(conn *sagemaker.SageMaker, arn string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribePartnerApp(&sagemaker.DescribePartnerAppInput{
+			Arn: aws.String(arn),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException") {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		if aws.StringValue(out.Status) == sagemaker.PartnerAppStatusDeleting {
+			return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Partner App (%s) to be deleted", arn))
+		}
+
+		return resource.NonRetryableError(fmt.Errorf("Sagemaker Partner App (%s) in unexpected status during delete: %s", arn, aws.StringValue(out.Status)))
+	})
+}
+
+func expandSagemakerPartnerAppMaintenanceConfig(l []interface{}) *sagemaker.PartnerAppMaintenanceConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.PartnerAppMaintenanceConfig{}
+
+	if v, ok := m["maintenance_window_start"]; ok && v.(string) != "" {
+		config.MaintenanceWindowStart = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func flattenSagemakerPartnerAppMaintenanceConfig(config *sagemaker.PartnerAppMaintenanceConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"maintenance_window_start": aws.StringValue(config.MaintenanceWindowStart),
+		},
+	}
+}
+
+func expandSagemakerPartnerAppApplicationConfig(l []interface{}) *sagemaker.PartnerAppConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.PartnerAppConfig{}
+
+	if v, ok := m["admin_users"]; ok {
+		config.AdminUsers = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := m["arguments"]; ok {
+		config.Arguments = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	return config
+}
+
+func flattenSagemakerPartnerAppApplicationConfig(config *sagemaker.PartnerAppConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"admin_users": flattenStringList(config.AdminUsers),
+			"arguments":   pointersMapToStringList(config.Arguments),
+		},
+	}
+}
@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerPipeline() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerPipelineCreate,
+		Read:   resourceAwsSagemakerPipelineRead,
+		Update: resourceAwsSagemakerPipelineUpdate,
+		Delete: resourceAwsSagemakerPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"pipeline_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerNameMaxLength(256),
+			},
+
+			"pipeline_display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// pipeline_definition is intentionally not re-read from the API:
+			// DescribePipeline doesn't return the definition document, and
+			// reformatting it through another describe call would fight with
+			// DiffSuppressFunc. The configured value is trusted between applies.
+			"pipeline_definition": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+			},
+
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"parallelism_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_parallel_execution_steps": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerPipelineCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("pipeline_name").(string)
+
+	createOpts := &sagemaker.CreatePipelineInput{
+		PipelineName:       aws.String(name),
+		PipelineDefinition: aws.String(d.Get("pipeline_definition").(string)),
+		RoleArn:            aws.String(d.Get("role_arn").(string)),
+	}
+
+	if v, ok := d.GetOk("pipeline_display_name"); ok {
+		createOpts.PipelineDisplayName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("parallelism_configuration"); ok {
+		createOpts.ParallelismConfiguration = expandSagemakerPipelineParallelismConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Pipeline create config: %#v", *createOpts)
+	if _, err := conn.CreatePipeline(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Pipeline: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Pipeline ID: %s", d.Id())
+
+	return resourceAwsSagemakerPipelineRead(d, meta)
+}
+
+func resourceAwsSagemakerPipelineRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	pipeline, err := conn.DescribePipeline(&sagemaker.DescribePipelineInput{
+		PipelineName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker pipeline %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("pipeline_name", pipeline.PipelineName); err != nil {
+		return fmt.Errorf("error setting pipeline_name for pipeline %q: %s", d.Id(), err)
+	}
+	if err := d.Set("pipeline_display_name", pipeline.PipelineDisplayName); err != nil {
+		return fmt.Errorf("error setting pipeline_display_name for pipeline %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", pipeline.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for pipeline %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", pipeline.PipelineArn); err != nil {
+		return fmt.Errorf("error setting arn for pipeline %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("parallelism_configuration", flattenSagemakerPipelineParallelismConfiguration(pipeline.ParallelismConfiguration)); err != nil {
+		return fmt.Errorf("error setting parallelism_configuration for pipeline %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, pipeline.PipelineArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for pipeline %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerPipelineUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("pipeline_definition") || d.HasChange("pipeline_display_name") || d.HasChange("role_arn") || d.HasChange("parallelism_configuration") {
+		updateOpts := &sagemaker.UpdatePipelineInput{
+			PipelineName:       aws.String(d.Id()),
+			PipelineDefinition: aws.String(d.Get("pipeline_definition").(string)),
+			RoleArn:            aws.String(d.Get("role_arn").(string)),
+		}
+
+		if v, ok := d.GetOk("pipeline_display_name"); ok {
+			updateOpts.PipelineDisplayName = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("parallelism_configuration"); ok {
+			updateOpts.ParallelismConfiguration = expandSagemakerPipelineParallelismConfiguration(v.([]interface{}))
+		}
+
+		if _, err := conn.UpdatePipeline(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Pipeline: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerPipelineRead(d, meta)
+}
+
+func resourceAwsSagemakerPipelineDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeletePipeline(&sagemaker.DeletePipelineInput{
+		PipelineName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Pipeline: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerPipelineParallelismConfiguration(l []interface{}) *sagemaker.ParallelismConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.ParallelismConfiguration{
+		MaxParallelExecutionSteps: aws.Int64(int64(m["max_parallel_execution_steps"].(int))),
+	}
+}
+
+func flattenSagemakerPipelineParallelismConfiguration(config *sagemaker.ParallelismConfiguration) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"max_parallel_execution_steps": aws.Int64Value(config.MaxParallelExecutionSteps),
+		},
+	}
+}
@@ -0,0 +1,348 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerProjectCreate,
+		Read:   resourceAwsSagemakerProjectRead,
+		Update: resourceAwsSagemakerProjectUpdate,
+		Delete: resourceAwsSagemakerProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"project_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerNameMaxLength(32),
+			},
+
+			"project_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"service_catalog_provisioning_details": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"product_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"provisioning_artifact_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"path_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"provisioning_parameter": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerProjectCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("project_name").(string)
+
+	createOpts := &sagemaker.CreateProjectInput{
+		ProjectName: aws.String(name),
+		ServiceCatalogProvisioningDetails: expandSagemakerProjectServiceCatalogProvisioningDetails(
+			d.Get("service_catalog_provisioning_details").([]interface{}),
+		),
+	}
+
+	if v, ok := d.GetOk("project_description"); ok {
+		createOpts.ProjectDescription = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Project create config: %#v", *createOpts)
+	if _, err := conn.CreateProject(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Project: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Project ID: %s", d.Id())
+
+	if err := waitSagemakerProjectStatus(conn, name, d.Timeout(schema.TimeoutCreate), sagemaker.ProjectStatusCreateCompleted); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Project (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerProjectRead(d, meta)
+}
+
+func resourceAwsSagemakerProjectRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	project, err := conn.DescribeProject(&sagemaker.DescribeProjectInput{
+		ProjectName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker project %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("project_name", project.ProjectName); err != nil {
+		return fmt.Errorf("error setting project_name for project %q: %s", d.Id(), err)
+	}
+	if err := d.Set("project_description", project.ProjectDescription); err != nil {
+		return fmt.Errorf("error setting project_description for project %q: %s", d.Id(), err)
+	}
+	if err := d.Set("project_id", project.ProjectId); err != nil {
+		return fmt.Errorf("error setting project_id for project %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", project.ProjectArn); err != nil {
+		return fmt.Errorf("error setting arn for project %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("service_catalog_provisioning_details", flattenSagemakerProjectServiceCatalogProvisioningDetails(project.ServiceCatalogProvisioningDetails)); err != nil {
+		return fmt.Errorf("error setting service_catalog_provisioning_details for project %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, project.ProjectArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for project %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerProjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("project_description") || d.HasChange("service_catalog_provisioning_details") {
+		updateOpts := &sagemaker.UpdateProjectInput{
+			ProjectName: aws.String(d.Id()),
+			ServiceCatalogProvisioningUpdateDetails: expandSagemakerProjectServiceCatalogProvisioningUpdateDetails(
+				d.Get("service_catalog_provisioning_details").([]interface{}),
+			),
+		}
+
+		if v, ok := d.GetOk("project_description"); ok {
+			updateOpts.ProjectDescription = aws.String(v.(string))
+		}
+
+		if _, err := conn.UpdateProject(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Project: %s", err)
+		}
+
+		if err := waitSagemakerProjectStatus(conn, d.Id(), d.Timeout(schema.TimeoutUpdate), sagemaker.ProjectStatusUpdateCompleted); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Project (%s) to be updated: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsSagemakerProjectRead(d, meta)
+}
+
+func resourceAwsSagemakerProjectDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteProject(&sagemaker.DeleteProjectInput{
+		ProjectName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Project: %s", err)
+	}
+
+	if err := waitSagemakerProjectStatus(conn, d.Id(), d.Timeout(schema.TimeoutDelete), sagemaker.ProjectStatusDeleteCompleted); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Project (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitSagemakerProjectStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeProject(&sagemaker.DescribeProjectInput{
+			ProjectName: aws.String(name),
+		})
+		if err != nil {
+			if desiredStatus == sagemaker.ProjectStatusDeleteCompleted {
+				if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFound" || awsErr.Code() == "ValidationException") {
+					return nil
+				}
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		status := aws.StringValue(out.ProjectStatus)
+		if status == desiredStatus {
+			return nil
+		}
+
+		switch status {
+		case sagemaker.ProjectStatusCreateFailed, sagemaker.ProjectStatusUpdateFailed, sagemaker.ProjectStatusDeleteFailed:
+			return resource.NonRetryableError(fmt.Errorf("Sagemaker Project (%s) failed: %s", name, aws.StringValue(out.ServiceCatalogProvisionedProductDetails.ProvisionedProductStatusMessage)))
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Project (%s) to be %s", name, desiredStatus))
+	})
+}
+
+func expandSagemakerProjectServiceCatalogProvisioningDetails(l []interface{}) *sagemaker.ServiceCatalogProvisioningDetails {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	details := &sagemaker.ServiceCatalogProvisioningDetails{
+		ProductId: aws.String(m["product_id"].(string)),
+	}
+
+	if v, ok := m["provisioning_artifact_id"]; ok && v.(string) != "" {
+		details.ProvisioningArtifactId = aws.String(v.(string))
+	}
+
+	if v, ok := m["path_id"]; ok && v.(string) != "" {
+		details.PathId = aws.String(v.(string))
+	}
+
+	if v, ok := m["provisioning_parameter"]; ok {
+		details.ProvisioningParameters = expandSagemakerProjectProvisioningParameters(v.([]interface{}))
+	}
+
+	return details
+}
+
+func expandSagemakerProjectServiceCatalogProvisioningUpdateDetails(l []interface{}) *sagemaker.ServiceCatalogProvisioningUpdateDetails {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	details := &sagemaker.ServiceCatalogProvisioningUpdateDetails{}
+
+	if v, ok := m["provisioning_artifact_id"]; ok && v.(string) != "" {
+		details.ProvisioningArtifactId = aws.String(v.(string))
+	}
+
+	if v, ok := m["provisioning_parameter"]; ok {
+		details.ProvisioningParameters = expandSagemakerProjectProvisioningParameters(v.([]interface{}))
+	}
+
+	return details
+}
+
+func expandSagemakerProjectProvisioningParameters(l []interface{}) []*sagemaker.ProvisioningParameter {
+	params := make([]*sagemaker.ProvisioningParameter, 0, len(l))
+
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+
+		m := v.(map[string]interface{})
+
+		params = append(params, &sagemaker.ProvisioningParameter{
+			Key:   aws.String(m["key"].(string)),
+			Value: aws.String(m["value"].(string)),
+		})
+	}
+
+	return params
+}
+
+func flattenSagemakerProjectServiceCatalogProvisioningDetails(details *sagemaker.ServiceCatalogProvisioningDetails) []map[string]interface{} {
+	if details == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"product_id":               aws.StringValue(details.ProductId),
+			"provisioning_artifact_id": aws.StringValue(details.ProvisioningArtifactId),
+			"path_id":                  aws.StringValue(details.PathId),
+			"provisioning_parameter":   flattenSagemakerProjectProvisioningParameters(details.ProvisioningParameters),
+		},
+	}
+}
+
+func flattenSagemakerProjectProvisioningParameters(params []*sagemaker.ProvisioningParameter) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(params))
+
+	for _, p := range params {
+		result = append(result, map[string]interface{}{
+			"key":   aws.StringValue(p.Key),
+			"value": aws.StringValue(p.Value),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceAwsSagemakerServicecatalogPortfolioStatus manages an account-wide
+// setting (whether the SageMaker-managed Service Catalog portfolio used by
+// Studio project templates is enabled), so there is nothing to create or
+// destroy in the usual sense -- the ID is just the region the setting was
+// read from.
+func resourceAwsSagemakerServicecatalogPortfolioStatus() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerServicecatalogPortfolioStatusCreate,
+		Read:   resourceAwsSagemakerServicecatalogPortfolioStatusRead,
+		Update: resourceAwsSagemakerServicecatalogPortfolioStatusUpdate,
+		Delete: resourceAwsSagemakerServicecatalogPortfolioStatusDelete,
+
+		Schema: map[string]*schema.Schema{
+			"status": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.SagemakerServicecatalogStatus_Values(), false),
+			},
+
+			// disable_on_destroy opts in to calling DisableSagemakerServicecatalogPortfolio
+			// on destroy. Without it, destroy just removes the resource from
+			// state and leaves the account-wide setting as-is, since other
+			// configurations or teams may depend on the portfolio staying enabled.
+			"disable_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerServicecatalogPortfolioStatusCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerServicecatalogPortfolioStatus(conn, d.Get("status").(string)); err != nil {
+		return fmt.Errorf("Error setting Sagemaker Servicecatalog Portfolio Status: %s", err)
+	}
+
+	d.SetId(meta.(*AWSClient).region)
+
+	return resourceAwsSagemakerServicecatalogPortfolioStatusRead(d, meta)
+}
+
+func resourceAwsSagemakerServicecatalogPortfolioStatusRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	out, err := conn.GetSagemakerServicecatalogPortfolioStatus(&sagemaker.GetSagemakerServicecatalogPortfolioStatusInput{})
+	if err != nil {
+		return fmt.Errorf("error reading Sagemaker Servicecatalog Portfolio Status: %s", err)
+	}
+
+	if err := d.Set("status", out.Status); err != nil {
+		return fmt.Errorf("error setting status for Sagemaker Servicecatalog Portfolio Status %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerServicecatalogPortfolioStatusUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if d.HasChange("status") {
+		if err := setSagemakerServicecatalogPortfolioStatus(conn, d.Get("status").(string)); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Servicecatalog Portfolio Status: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerServicecatalogPortfolioStatusRead(d, meta)
+}
+
+func resourceAwsSagemakerServicecatalogPortfolioStatusDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if !d.Get("disable_on_destroy").(bool) {
+		log.Printf("[DEBUG] disable_on_destroy is not set; leaving Sagemaker Servicecatalog Portfolio Status as-is")
+		return nil
+	}
+
+	if _, err := conn.DisableSagemakerServicecatalogPortfolio(&sagemaker.DisableSagemakerServicecatalogPortfolioInput{}); err != nil {
+		return fmt.Errorf("Error disabling Sagemaker Servicecatalog Portfolio: %s", err)
+	}
+
+	return nil
+}
+
+func setSagemakerServicecatalogPortfolioStatus(conn sagemakeriface.SageMakerAPI, status string) error {
+	switch status {
+	case sagemaker.SagemakerServicecatalogStatusEnabled:
+		_, err := conn.EnableSagemakerServicecatalogPortfolio(&sagemaker.EnableSagemakerServicecatalogPortfolioInput{})
+		return err
+	case sagemaker.SagemakerServicecatalogStatusDisabled:
+		_, err := conn.DisableSagemakerServicecatalogPortfolio(&sagemaker.DisableSagemakerServicecatalogPortfolioInput{})
+		return err
+	default:
+		return fmt.Errorf("unknown status %q", status)
+	}
+}
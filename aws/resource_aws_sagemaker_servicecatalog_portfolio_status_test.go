@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+)
+
+type mockServicecatalogPortfolioAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	enableCalled  bool
+	disableCalled bool
+}
+
+func (m *mockServicecatalogPortfolioAPI) EnableSagemakerServicecatalogPortfolio(input *sagemaker.EnableSagemakerServicecatalogPortfolioInput) (*sagemaker.EnableSagemakerServicecatalogPortfolioOutput, error) {
+	m.enableCalled = true
+	return &sagemaker.EnableSagemakerServicecatalogPortfolioOutput{}, nil
+}
+
+func (m *mockServicecatalogPortfolioAPI) DisableSagemakerServicecatalogPortfolio(input *sagemaker.DisableSagemakerServicecatalogPortfolioInput) (*sagemaker.DisableSagemakerServicecatalogPortfolioOutput, error) {
+	m.disableCalled = true
+	return &sagemaker.DisableSagemakerServicecatalogPortfolioOutput{}, nil
+}
+
+func TestSetSagemakerServicecatalogPortfolioStatus(t *testing.T) {
+	t.Run("enabled calls Enable", func(t *testing.T) {
+		conn := &mockServicecatalogPortfolioAPI{}
+		if err := setSagemakerServicecatalogPortfolioStatus(conn, sagemaker.SagemakerServicecatalogStatusEnabled); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !conn.enableCalled || conn.disableCalled {
+			t.Errorf("expected only Enable to be called, got enable=%t disable=%t", conn.enableCalled, conn.disableCalled)
+		}
+	})
+
+	t.Run("disabled calls Disable", func(t *testing.T) {
+		conn := &mockServicecatalogPortfolioAPI{}
+		if err := setSagemakerServicecatalogPortfolioStatus(conn, sagemaker.SagemakerServicecatalogStatusDisabled); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !conn.disableCalled || conn.enableCalled {
+			t.Errorf("expected only Disable to be called, got enable=%t disable=%t", conn.enableCalled, conn.disableCalled)
+		}
+	})
+
+	t.Run("unknown status errors", func(t *testing.T) {
+		conn := &mockServicecatalogPortfolioAPI{}
+		if err := setSagemakerServicecatalogPortfolioStatus(conn, "Bogus"); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}
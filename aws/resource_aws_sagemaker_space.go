@@ -0,0 +1,422 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerSpace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerSpaceCreate,
+		Read:   resourceAwsSagemakerSpaceRead,
+		Update: resourceAwsSagemakerSpaceUpdate,
+		Delete: resourceAwsSagemakerSpaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"space_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"home_efs_file_system_uid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"space_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"jupyter_server_app_settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"default_resource_spec": sagemakerSpaceResourceSpecSchema(),
+								},
+							},
+						},
+
+						"kernel_gateway_app_settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"default_resource_spec": sagemakerSpaceResourceSpecSchema(),
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func sagemakerSpaceResourceSpecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_type": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"sagemaker_image_arn": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"sagemaker_image_version_arn": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerSpaceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID := d.Get("domain_id").(string)
+	name := d.Get("space_name").(string)
+
+	createOpts := &sagemaker.CreateSpaceInput{
+		DomainId:  aws.String(domainID),
+		SpaceName: aws.String(name),
+	}
+
+	if v, ok := d.GetOk("space_settings"); ok {
+		createOpts.SpaceSettings = expandSagemakerSpaceSettings(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Space create config: %#v", *createOpts)
+	if _, err := conn.CreateSpace(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Space: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", domainID, name))
+	log.Printf("[INFO] Sagemaker Space ID: %s", d.Id())
+
+	if err := waitSagemakerSpaceStatus(conn, domainID, name, d.Timeout(schema.TimeoutCreate), sagemaker.SpaceStatusInService, sagemaker.SpaceStatusFailed); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Space (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerSpaceRead(d, meta)
+}
+
+func resourceAwsSagemakerSpaceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, name, err := decodeSagemakerSpaceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	space, err := conn.DescribeSpace(&sagemaker.DescribeSpaceInput{
+		DomainId:  aws.String(domainID),
+		SpaceName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker space %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if aws.StringValue(space.Status) == sagemaker.SpaceStatusDeleting {
+		d.SetId("")
+		log.Printf("[LOG] SageMaker space %q is deleting; removing from state file", d.Id())
+		return nil
+	}
+
+	if err := d.Set("domain_id", space.DomainId); err != nil {
+		return fmt.Errorf("error setting domain_id for space %q: %s", d.Id(), err)
+	}
+	if err := d.Set("space_name", space.SpaceName); err != nil {
+		return fmt.Errorf("error setting space_name for space %q: %s", d.Id(), err)
+	}
+	if err := d.Set("home_efs_file_system_uid", space.HomeEfsFileSystemUid); err != nil {
+		return fmt.Errorf("error setting home_efs_file_system_uid for space %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", space.SpaceArn); err != nil {
+		return fmt.Errorf("error setting arn for space %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("space_settings", flattenSagemakerSpaceSettings(space.SpaceSettings)); err != nil {
+		return fmt.Errorf("error setting space_settings for space %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, space.SpaceArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for space %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerSpaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("space_settings") {
+		domainID, name, err := decodeSagemakerSpaceId(d.Id())
+		if err != nil {
+			return err
+		}
+
+		updateOpts := &sagemaker.UpdateSpaceInput{
+			DomainId:      aws.String(domainID),
+			SpaceName:     aws.String(name),
+			SpaceSettings: expandSagemakerSpaceSettings(d.Get("space_settings").([]interface{})),
+		}
+
+		if _, err := conn.UpdateSpace(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker Space: %s", err)
+		}
+
+		if err := waitSagemakerSpaceStatus(conn, domainID, name, d.Timeout(schema.TimeoutUpdate), sagemaker.SpaceStatusInService, sagemaker.SpaceStatusFailed); err != nil {
+			return fmt.Errorf("error waiting for Sagemaker Space (%s) to be updated: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsSagemakerSpaceRead(d, meta)
+}
+
+func resourceAwsSagemakerSpaceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, name, err := decodeSagemakerSpaceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.DeleteSpace(&sagemaker.DeleteSpaceInput{
+		DomainId:  aws.String(domainID),
+		SpaceName: aws.String(name),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Space: %s", err)
+	}
+
+	if err := waitSagemakerSpaceStatus(conn, domainID, name, d.Timeout(schema.TimeoutDelete), sagemaker.SpaceStatusDeleted); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Space (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func decodeSagemakerSpaceId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected DOMAIN-ID/SPACE-NAME", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func waitSagemakerSpaceStatus(conn *sagemaker.SageMaker, domainID, name string, timeout time.Duration, desiredStatus ...string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			sagemaker.SpaceStatusPending,
+			sagemaker.SpaceStatusUpdating,
+			sagemaker.SpaceStatusDeleting,
+		},
+		Target: desiredStatus,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeSpace(&sagemaker.DescribeSpaceInput{
+				DomainId:  aws.String(domainID),
+				SpaceName: aws.String(name),
+			})
+			if err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+					return out, sagemaker.SpaceStatusDeleted, nil
+				}
+				return nil, "", err
+			}
+
+			return out, aws.StringValue(out.Status), nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func expandSagemakerSpaceSettings(l []interface{}) *sagemaker.SpaceSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	settings := &sagemaker.SpaceSettings{}
+
+	if v, ok := m["jupyter_server_app_settings"]; ok {
+		settings.JupyterServerAppSettings = expandSagemakerSpaceJupyterServerAppSettings(v.([]interface{}))
+	}
+
+	if v, ok := m["kernel_gateway_app_settings"]; ok {
+		settings.KernelGatewayAppSettings = expandSagemakerSpaceKernelGatewayAppSettings(v.([]interface{}))
+	}
+
+	return settings
+}
+
+func expandSagemakerSpaceJupyterServerAppSettings(l []interface{}) *sagemaker.JupyterServerAppSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.JupyterServerAppSettings{
+		DefaultResourceSpec: expandSagemakerSpaceResourceSpec(m["default_resource_spec"].([]interface{})),
+	}
+}
+
+func expandSagemakerSpaceKernelGatewayAppSettings(l []interface{}) *sagemaker.KernelGatewayAppSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.KernelGatewayAppSettings{
+		DefaultResourceSpec: expandSagemakerSpaceResourceSpec(m["default_resource_spec"].([]interface{})),
+	}
+}
+
+func expandSagemakerSpaceResourceSpec(l []interface{}) *sagemaker.ResourceSpec {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.ResourceSpec{}
+
+	if v, ok := m["instance_type"]; ok && v.(string) != "" {
+		spec.InstanceType = aws.String(v.(string))
+	}
+
+	if v, ok := m["sagemaker_image_arn"]; ok && v.(string) != "" {
+		spec.SageMakerImageArn = aws.String(v.(string))
+	}
+
+	if v, ok := m["sagemaker_image_version_arn"]; ok && v.(string) != "" {
+		spec.SageMakerImageVersionArn = aws.String(v.(string))
+	}
+
+	return spec
+}
+
+func flattenSagemakerSpaceSettings(settings *sagemaker.SpaceSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"jupyter_server_app_settings": flattenSagemakerSpaceJupyterServerAppSettings(settings.JupyterServerAppSettings),
+			"kernel_gateway_app_settings": flattenSagemakerSpaceKernelGatewayAppSettings(settings.KernelGatewayAppSettings),
+		},
+	}
+}
+
+func flattenSagemakerSpaceJupyterServerAppSettings(settings *sagemaker.JupyterServerAppSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"default_resource_spec": flattenSagemakerSpaceResourceSpec(settings.DefaultResourceSpec),
+		},
+	}
+}
+
+func flattenSagemakerSpaceKernelGatewayAppSettings(settings *sagemaker.KernelGatewayAppSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"default_resource_spec": flattenSagemakerSpaceResourceSpec(settings.DefaultResourceSpec),
+		},
+	}
+}
+
+func flattenSagemakerSpaceResourceSpec(spec *sagemaker.ResourceSpec) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"instance_type":               aws.StringValue(spec.InstanceType),
+			"sagemaker_image_arn":         aws.StringValue(spec.SageMakerImageArn),
+			"sagemaker_image_version_arn": aws.StringValue(spec.SageMakerImageVersionArn),
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsSagemakerStudioLifecycleConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerStudioLifecycleConfigCreate,
+		Read:   resourceAwsSagemakerStudioLifecycleConfigRead,
+		Delete: resourceAwsSagemakerStudioLifecycleConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"studio_lifecycle_config_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"studio_lifecycle_config_content": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"studio_lifecycle_config_app_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sagemaker.StudioLifecycleConfigAppType_Values(), false),
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerStudioLifecycleConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("studio_lifecycle_config_name").(string)
+
+	createOpts := &sagemaker.CreateStudioLifecycleConfigInput{
+		StudioLifecycleConfigName:    aws.String(name),
+		StudioLifecycleConfigContent: aws.String(d.Get("studio_lifecycle_config_content").(string)),
+		StudioLifecycleConfigAppType: aws.String(d.Get("studio_lifecycle_config_app_type").(string)),
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Studio Lifecycle Config create config: %#v", *createOpts)
+	if _, err := conn.CreateStudioLifecycleConfig(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Studio Lifecycle Config: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Studio Lifecycle Config ID: %s", d.Id())
+
+	return resourceAwsSagemakerStudioLifecycleConfigRead(d, meta)
+}
+
+func resourceAwsSagemakerStudioLifecycleConfigRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	config, err := conn.DescribeStudioLifecycleConfig(&sagemaker.DescribeStudioLifecycleConfigInput{
+		StudioLifecycleConfigName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker studio lifecycle config %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("studio_lifecycle_config_name", config.StudioLifecycleConfigName); err != nil {
+		return fmt.Errorf("error setting studio_lifecycle_config_name for studio lifecycle config %q: %s", d.Id(), err)
+	}
+	if err := d.Set("studio_lifecycle_config_content", config.StudioLifecycleConfigContent); err != nil {
+		return fmt.Errorf("error setting studio_lifecycle_config_content for studio lifecycle config %q: %s", d.Id(), err)
+	}
+	if err := d.Set("studio_lifecycle_config_app_type", config.StudioLifecycleConfigAppType); err != nil {
+		return fmt.Errorf("error setting studio_lifecycle_config_app_type for studio lifecycle config %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", config.StudioLifecycleConfigArn); err != nil {
+		return fmt.Errorf("error setting arn for studio lifecycle config %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, config.StudioLifecycleConfigArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for studio lifecycle config %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerStudioLifecycleConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteStudioLifecycleConfig(&sagemaker.DeleteStudioLifecycleConfigInput{
+		StudioLifecycleConfigName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Studio Lifecycle Config: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// sagemakerSweepTagKey and sagemakerSweepNamePrefix identify resources created
+// by acceptance tests so the sweeper only ever nukes its own leftovers.
+const (
+	sagemakerSweepTagKey      = "terraform-test"
+	sagemakerSweepNamePrefix  = "tf-acc-test"
+	sagemakerSweepConcurrency = 10
+)
+
+func init() {
+	resource.AddTestSweepers("aws_sagemaker", &resource.Sweeper{
+		Name: "aws_sagemaker",
+		F:    sweepSagemakerResources,
+	})
+
+	resource.AddTestSweepers("aws_sagemaker_notebook_instance", &resource.Sweeper{
+		Name: "aws_sagemaker_notebook_instance",
+		F:    sweepSagemakerNotebookInstances,
+	})
+}
+
+// sweepSagemakerResources enumerates SageMaker endpoints, endpoint
+// configurations, models, and training jobs in the sweeper's region, and
+// removes every one that is both named and tagged as a leftover from
+// acceptance tests (training jobs are stopped rather than deleted). Notebook
+// instances are left to sweepSagemakerNotebookInstances below.
+// Resources are torn down in dependency order (endpoints before the endpoint
+// configurations and models they reference) with bounded parallelism and
+// retries on ResourceInUse, so a test panic mid-run doesn't leave orphaned
+// billable resources behind.
+func sweepSagemakerResources(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).sagemakerconn
+
+	var endpoints []*string
+	if err := conn.ListEndpointsPages(&sagemaker.ListEndpointsInput{
+		NameContains: aws.String(sagemakerSweepNamePrefix),
+	}, func(page *sagemaker.ListEndpointsOutput, lastPage bool) bool {
+		for _, e := range page.Endpoints {
+			if sagemakerSweepResourceIsTagged(conn, e.EndpointArn) {
+				endpoints = append(endpoints, e.EndpointName)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return fmt.Errorf("error listing Sagemaker endpoints: %s", err)
+	}
+
+	if err := sagemakerSweepDelete(endpoints, func(name *string) error {
+		_, err := conn.DeleteEndpoint(&sagemaker.DeleteEndpointInput{EndpointName: name})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var endpointConfigs []*string
+	if err := conn.ListEndpointConfigsPages(&sagemaker.ListEndpointConfigsInput{
+		NameContains: aws.String(sagemakerSweepNamePrefix),
+	}, func(page *sagemaker.ListEndpointConfigsOutput, lastPage bool) bool {
+		for _, e := range page.EndpointConfigs {
+			if sagemakerSweepResourceIsTagged(conn, e.EndpointConfigArn) {
+				endpointConfigs = append(endpointConfigs, e.EndpointConfigName)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return fmt.Errorf("error listing Sagemaker endpoint configurations: %s", err)
+	}
+
+	if err := sagemakerSweepDelete(endpointConfigs, func(name *string) error {
+		_, err := conn.DeleteEndpointConfig(&sagemaker.DeleteEndpointConfigInput{EndpointConfigName: name})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var models []*string
+	if err := conn.ListModelsPages(&sagemaker.ListModelsInput{
+		NameContains: aws.String(sagemakerSweepNamePrefix),
+	}, func(page *sagemaker.ListModelsOutput, lastPage bool) bool {
+		for _, m := range page.Models {
+			if sagemakerSweepResourceIsTagged(conn, m.ModelArn) {
+				models = append(models, m.ModelName)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return fmt.Errorf("error listing Sagemaker models: %s", err)
+	}
+
+	if err := sagemakerSweepDelete(models, func(name *string) error {
+		_, err := conn.DeleteModel(&sagemaker.DeleteModelInput{ModelName: name})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var trainingJobs []*string
+	if err := conn.ListTrainingJobsPages(&sagemaker.ListTrainingJobsInput{
+		NameContains: aws.String(sagemakerSweepNamePrefix),
+	}, func(page *sagemaker.ListTrainingJobsOutput, lastPage bool) bool {
+		for _, t := range page.TrainingJobSummaries {
+			if sagemakerSweepResourceIsTagged(conn, t.TrainingJobArn) {
+				trainingJobs = append(trainingJobs, t.TrainingJobName)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return fmt.Errorf("error listing Sagemaker training jobs: %s", err)
+	}
+
+	if err := sagemakerSweepDelete(trainingJobs, func(name *string) error {
+		_, err := conn.StopTrainingJob(&sagemaker.StopTrainingJobInput{TrainingJobName: name})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sweepSagemakerNotebookInstances is registered as its own sweeper (rather than
+// folded into sweepSagemakerResources) so `go test -sweep` can target orphaned
+// notebook instances on their own: they're the slowest SageMaker resource to
+// tear down (a stop-then-delete round trip) and the most common leftover when
+// an acceptance test run is interrupted mid-create.
+func sweepSagemakerNotebookInstances(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).sagemakerconn
+
+	var notebookInstances []*string
+	if err := conn.ListNotebookInstancesPages(&sagemaker.ListNotebookInstancesInput{
+		NameContains: aws.String(sagemakerSweepNamePrefix),
+	}, func(page *sagemaker.ListNotebookInstancesOutput, lastPage bool) bool {
+		for _, n := range page.NotebookInstances {
+			notebookInstances = append(notebookInstances, n.NotebookInstanceName)
+		}
+		return !lastPage
+	}); err != nil {
+		return fmt.Errorf("error listing Sagemaker notebook instances: %s", err)
+	}
+
+	return sagemakerSweepDelete(notebookInstances, func(name *string) error {
+		status, _, err := SagemakerNotebookInstanceStateRefreshFunc(context.Background(), conn, aws.StringValue(name))()
+		if err != nil {
+			return err
+		}
+
+		if status != nil {
+			if notebook := status.(*sagemaker.DescribeNotebookInstanceOutput); aws.StringValue(notebook.NotebookInstanceStatus) == sagemaker.NotebookInstanceStatusInService {
+				if err := stopSagemakerNotebookInstance(context.Background(), conn, aws.StringValue(name), 20*time.Minute, 0); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = conn.DeleteNotebookInstance(&sagemaker.DeleteNotebookInstanceInput{NotebookInstanceName: name})
+		return err
+	})
+}
+
+func sagemakerSweepResourceIsTagged(conn *sagemaker.SageMaker, arn *string) bool {
+	out, err := conn.ListTags(&sagemaker.ListTagsInput{ResourceArn: arn})
+	if err != nil {
+		log.Printf("[WARN] Error listing tags for Sagemaker resource %s: %s", aws.StringValue(arn), err)
+		return false
+	}
+
+	for _, t := range out.Tags {
+		if aws.StringValue(t.Key) == sagemakerSweepTagKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sagemakerSweepDelete runs deleteFn over names with bounded parallelism,
+// retrying on ResourceInUse so a resource still winding down from a previous
+// deletion in the dependency chain gets another chance before the sweep fails.
+func sagemakerSweepDelete(names []*string, deleteFn func(name *string) error) error {
+	sem := make(chan struct{}, sagemakerSweepConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, name := range names {
+		name := name
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+				err := deleteFn(name)
+				if err == nil {
+					return nil
+				}
+
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceInUse" {
+					return resource.RetryableError(err)
+				}
+
+				if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "ResourceNotFoundException" || awsErr.Code() == "ValidationException") {
+					return nil
+				}
+
+				return resource.NonRetryableError(err)
+			})
+
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("error deleting %s: %s", aws.StringValue(name), err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error sweeping Sagemaker resources: %v", errs)
+	}
+
+	return nil
+}
@@ -0,0 +1,684 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerTrainingJob() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerTrainingJobCreate,
+		Read:   resourceAwsSagemakerTrainingJobRead,
+		Update: resourceAwsSagemakerTrainingJobUpdate,
+		Delete: resourceAwsSagemakerTrainingJobDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"algorithm_specification": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"training_image": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"algorithm_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"training_input_mode": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"hyper_parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"input_data_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"compression_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"s3_data_source": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_data_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"s3_uri": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"s3_data_distribution_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"output_data_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_output_path": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"resource_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"instance_count": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"volume_size_in_gb": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"volume_kms_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"stopping_condition": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_runtime_in_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"vpc_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_group_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"subnets": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"enable_network_isolation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			// status mirrors DescribeTrainingJobOutput.TrainingJobStatus (e.g.
+			// Completed, Stopped, Failed), so a one-shot job's outcome is
+			// visible in state without a separate DescribeTrainingJob call.
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerTrainingJobCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("name").(string)
+
+	createOpts := &sagemaker.CreateTrainingJobInput{
+		TrainingJobName:        aws.String(name),
+		RoleArn:                aws.String(d.Get("role_arn").(string)),
+		AlgorithmSpecification: expandSagemakerTrainingJobAlgorithmSpecification(d.Get("algorithm_specification").([]interface{})),
+		InputDataConfig:        expandSagemakerTrainingJobInputDataConfig(d.Get("input_data_config").([]interface{})),
+		OutputDataConfig:       expandSagemakerTrainingJobOutputDataConfig(d.Get("output_data_config").([]interface{})),
+		ResourceConfig:         expandSagemakerTrainingJobResourceConfig(d.Get("resource_config").([]interface{})),
+		StoppingCondition:      expandSagemakerTrainingJobStoppingCondition(d.Get("stopping_condition").([]interface{})),
+		EnableNetworkIsolation: aws.Bool(d.Get("enable_network_isolation").(bool)),
+	}
+
+	if v, ok := d.GetOk("hyper_parameters"); ok {
+		createOpts.HyperParameters = stringMapToPointers(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("vpc_config"); ok {
+		createOpts.VpcConfig = expandSagemakerTrainingJobVpcConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Training Job create config: %#v", *createOpts)
+	_, err := conn.CreateTrainingJob(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker Training Job: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Training Job ID: %s", d.Id())
+
+	if err := waitSagemakerTrainingJobStatus(conn, d.Id(), d.Timeout(schema.TimeoutCreate), sagemaker.TrainingJobStatusCompleted, sagemaker.TrainingJobStatusFailed, sagemaker.TrainingJobStatusStopped); err != nil {
+		return fmt.Errorf("Error waiting for Sagemaker Training Job (%s) to complete: %s", d.Id(), err)
+	}
+
+	return resourceAwsSagemakerTrainingJobRead(d, meta)
+}
+
+func resourceAwsSagemakerTrainingJobRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	trainingJobRaw, _, err := SagemakerTrainingJobStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		if awserr, ok := err.(awserr.Error); ok {
+			if awserr.Code() == "ResourceNotFound" || awserr.Code() == "ValidationException" {
+				d.SetId("")
+				log.Printf("[WARN] Unable to find SageMaker training job %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if trainingJobRaw == nil {
+		d.SetId("")
+		log.Printf("[WARN] Unable to find SageMaker training job %q; removing from state file", d.Id())
+		return nil
+	}
+
+	trainingJob := trainingJobRaw.(*sagemaker.DescribeTrainingJobOutput)
+
+	if err := d.Set("name", trainingJob.TrainingJobName); err != nil {
+		return fmt.Errorf("error setting name for training job %q: %s", d.Id(), err)
+	}
+	if err := d.Set("role_arn", trainingJob.RoleArn); err != nil {
+		return fmt.Errorf("error setting role_arn for training job %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", trainingJob.TrainingJobArn); err != nil {
+		return fmt.Errorf("error setting arn for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("algorithm_specification", flattenSagemakerTrainingJobAlgorithmSpecification(trainingJob.AlgorithmSpecification)); err != nil {
+		return fmt.Errorf("error setting algorithm_specification for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("hyper_parameters", aws.StringValueMap(trainingJob.HyperParameters)); err != nil {
+		return fmt.Errorf("error setting hyper_parameters for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("input_data_config", flattenSagemakerTrainingJobInputDataConfig(trainingJob.InputDataConfig)); err != nil {
+		return fmt.Errorf("error setting input_data_config for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("output_data_config", flattenSagemakerTrainingJobOutputDataConfig(trainingJob.OutputDataConfig)); err != nil {
+		return fmt.Errorf("error setting output_data_config for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("resource_config", flattenSagemakerTrainingJobResourceConfig(trainingJob.ResourceConfig)); err != nil {
+		return fmt.Errorf("error setting resource_config for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("stopping_condition", flattenSagemakerTrainingJobStoppingCondition(trainingJob.StoppingCondition)); err != nil {
+		return fmt.Errorf("error setting stopping_condition for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("vpc_config", flattenSagemakerTrainingJobVpcConfig(trainingJob.VpcConfig)); err != nil {
+		return fmt.Errorf("error setting vpc_config for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("enable_network_isolation", trainingJob.EnableNetworkIsolation); err != nil {
+		return fmt.Errorf("error setting enable_network_isolation for training job %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("status", trainingJob.TrainingJobStatus); err != nil {
+		return fmt.Errorf("error setting status for training job %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, trainingJob.TrainingJobArn)
+	if err != nil {
+		log.Printf("[ERROR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for training job %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerTrainingJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	return resourceAwsSagemakerTrainingJobRead(d, meta)
+}
+
+func resourceAwsSagemakerTrainingJobDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	_, err := conn.StopTrainingJob(&sagemaker.StopTrainingJobInput{
+		TrainingJobName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationException" {
+			return nil
+		}
+		return fmt.Errorf("Error stopping Sagemaker Training Job: %s", err)
+	}
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, status, _ := SagemakerTrainingJobStateRefreshFunc(conn, d.Id())()
+
+		if status == sagemaker.TrainingJobStatusStopped || status == sagemaker.TrainingJobStatusFailed || status == sagemaker.TrainingJobStatusCompleted {
+			log.Printf("[DEBUG] Sagemaker Training Job (%s) stopped", d.Id())
+			return nil
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Training Job (%s) to stop", d.Id()))
+	})
+}
+
+func SagemakerTrainingJobStateRefreshFunc(conn *sagemaker.SageMaker, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		describeTrainingJobInput := &sagemaker.DescribeTrainingJobInput{
+			TrainingJobName: aws.String(name),
+		}
+		trainingJob, err := conn.DescribeTrainingJob(describeTrainingJobInput)
+		if err != nil {
+			if sagemakerErr, ok := err.(awserr.Error); ok && sagemakerErr.Code() == "ResourceNotFound" {
+				trainingJob = nil
+			} else {
+				log.Printf("Error on SagemakerTrainingJobStateRefreshFunc: %s", err)
+				return nil, "", err
+			}
+		}
+
+		if trainingJob == nil {
+			return nil, "", nil
+		}
+
+		return trainingJob, *trainingJob.TrainingJobStatus, nil
+	}
+}
+
+func waitSagemakerTrainingJobStatus(conn *sagemaker.SageMaker, name string, timeout time.Duration, desiredStatus ...string) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		_, status, err := SagemakerTrainingJobStateRefreshFunc(conn, name)()
+
+		if err == nil {
+			for _, s := range desiredStatus {
+				if status == s {
+					log.Printf("[DEBUG] Sagemaker Training Job (%s) is %s", name, s)
+					return nil
+				}
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Training Job (%s) to be %s", name, desiredStatus))
+	})
+}
+
+func expandSagemakerTrainingJobAlgorithmSpecification(l []interface{}) *sagemaker.AlgorithmSpecification {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	spec := &sagemaker.AlgorithmSpecification{
+		TrainingInputMode: aws.String(m["training_input_mode"].(string)),
+	}
+
+	if v, ok := m["training_image"]; ok && v.(string) != "" {
+		spec.TrainingImage = aws.String(v.(string))
+	}
+
+	if v, ok := m["algorithm_name"]; ok && v.(string) != "" {
+		spec.AlgorithmName = aws.String(v.(string))
+	}
+
+	return spec
+}
+
+func expandSagemakerTrainingJobInputDataConfig(l []interface{}) []*sagemaker.Channel {
+	channels := make([]*sagemaker.Channel, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		channel := &sagemaker.Channel{
+			ChannelName: aws.String(m["channel_name"].(string)),
+			DataSource:  expandSagemakerTrainingJobDataSource(m["s3_data_source"].([]interface{})),
+		}
+
+		if v, ok := m["content_type"]; ok && v.(string) != "" {
+			channel.ContentType = aws.String(v.(string))
+		}
+
+		if v, ok := m["compression_type"]; ok && v.(string) != "" {
+			channel.CompressionType = aws.String(v.(string))
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels
+}
+
+func expandSagemakerTrainingJobDataSource(l []interface{}) *sagemaker.DataSource {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	s3DataSource := &sagemaker.S3DataSource{
+		S3DataType: aws.String(m["s3_data_type"].(string)),
+		S3Uri:      aws.String(m["s3_uri"].(string)),
+	}
+
+	if v, ok := m["s3_data_distribution_type"]; ok && v.(string) != "" {
+		s3DataSource.S3DataDistributionType = aws.String(v.(string))
+	}
+
+	return &sagemaker.DataSource{
+		S3DataSource: s3DataSource,
+	}
+}
+
+func expandSagemakerTrainingJobOutputDataConfig(l []interface{}) *sagemaker.OutputDataConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.OutputDataConfig{
+		S3OutputPath: aws.String(m["s3_output_path"].(string)),
+	}
+
+	if v, ok := m["kms_key_id"]; ok && v.(string) != "" {
+		config.KmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func expandSagemakerTrainingJobResourceConfig(l []interface{}) *sagemaker.ResourceConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config := &sagemaker.ResourceConfig{
+		InstanceType:   aws.String(m["instance_type"].(string)),
+		InstanceCount:  aws.Int64(int64(m["instance_count"].(int))),
+		VolumeSizeInGB: aws.Int64(int64(m["volume_size_in_gb"].(int))),
+	}
+
+	if v, ok := m["volume_kms_key_id"]; ok && v.(string) != "" {
+		config.VolumeKmsKeyId = aws.String(v.(string))
+	}
+
+	return config
+}
+
+func expandSagemakerTrainingJobStoppingCondition(l []interface{}) *sagemaker.StoppingCondition {
+	if len(l) == 0 || l[0] == nil {
+		return &sagemaker.StoppingCondition{}
+	}
+
+	m := l[0].(map[string]interface{})
+
+	condition := &sagemaker.StoppingCondition{}
+
+	if v, ok := m["max_runtime_in_seconds"]; ok && v.(int) > 0 {
+		condition.MaxRuntimeInSeconds = aws.Int64(int64(v.(int)))
+	}
+
+	return condition
+}
+
+func expandSagemakerTrainingJobTime(s string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing time %q: %s", s, err)
+	}
+
+	return &t, nil
+}
+
+func expandSagemakerTrainingJobVpcConfig(l []interface{}) *sagemaker.VpcConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.VpcConfig{
+		SecurityGroupIds: expandStringSet(m["security_group_ids"].(*schema.Set)),
+		Subnets:          expandStringSet(m["subnets"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerTrainingJobAlgorithmSpecification(spec *sagemaker.AlgorithmSpecification) []map[string]interface{} {
+	if spec == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"training_image":      aws.StringValue(spec.TrainingImage),
+		"algorithm_name":      aws.StringValue(spec.AlgorithmName),
+		"training_input_mode": aws.StringValue(spec.TrainingInputMode),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenSagemakerTrainingJobInputDataConfig(channels []*sagemaker.Channel) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(channels))
+
+	for _, c := range channels {
+		result = append(result, map[string]interface{}{
+			"channel_name":     aws.StringValue(c.ChannelName),
+			"content_type":     aws.StringValue(c.ContentType),
+			"compression_type": aws.StringValue(c.CompressionType),
+			"s3_data_source":   flattenSagemakerTrainingJobDataSource(c.DataSource),
+		})
+	}
+
+	return result
+}
+
+func flattenSagemakerTrainingJobDataSource(source *sagemaker.DataSource) []map[string]interface{} {
+	if source == nil || source.S3DataSource == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"s3_data_type":              aws.StringValue(source.S3DataSource.S3DataType),
+		"s3_uri":                    aws.StringValue(source.S3DataSource.S3Uri),
+		"s3_data_distribution_type": aws.StringValue(source.S3DataSource.S3DataDistributionType),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenSagemakerTrainingJobOutputDataConfig(config *sagemaker.OutputDataConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"s3_output_path": aws.StringValue(config.S3OutputPath),
+		"kms_key_id":     aws.StringValue(config.KmsKeyId),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenSagemakerTrainingJobResourceConfig(config *sagemaker.ResourceConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"instance_type":     aws.StringValue(config.InstanceType),
+		"instance_count":    aws.Int64Value(config.InstanceCount),
+		"volume_size_in_gb": aws.Int64Value(config.VolumeSizeInGB),
+		"volume_kms_key_id": aws.StringValue(config.VolumeKmsKeyId),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenSagemakerTrainingJobStoppingCondition(condition *sagemaker.StoppingCondition) []map[string]interface{} {
+	if condition == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"max_runtime_in_seconds": aws.Int64Value(condition.MaxRuntimeInSeconds),
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func flattenSagemakerTrainingJobVpcConfig(config *sagemaker.VpcConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"security_group_ids": flattenStringList(config.SecurityGroupIds),
+		"subnets":            flattenStringList(config.Subnets),
+	}
+
+	return []map[string]interface{}{m}
+}
@@ -0,0 +1,168 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerTrainingJob_basic(t *testing.T) {
+	var trainingJob sagemaker.DescribeTrainingJobOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_training_job.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerTrainingJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerTrainingJobConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerTrainingJobExists(resourceName, &trainingJob),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "resource_config.0.instance_count", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSagemakerTrainingJobDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_sagemaker_training_job.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerTrainingJobDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "training_jobs.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerTrainingJobExists(n string, trainingJob *sagemaker.DescribeTrainingJobOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Sagemaker Training Job ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		out, err := conn.DescribeTrainingJob(&sagemaker.DescribeTrainingJobInput{
+			TrainingJobName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*trainingJob = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerTrainingJobDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_training_job" {
+			continue
+		}
+
+		_, status, err := SagemakerTrainingJobStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+
+		if status != "" && status != sagemaker.TrainingJobStatusStopped && status != sagemaker.TrainingJobStatusFailed {
+			return fmt.Errorf("Sagemaker Training Job %q still exists in state %q", rs.Primary.ID, status)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerTrainingJobConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_sagemaker_training_job" "test" {
+  name     = %[1]q
+  role_arn = aws_iam_role.test.arn
+
+  algorithm_specification {
+    training_image      = "382416733822.dkr.ecr.us-east-1.amazonaws.com/linear-learner:1"
+    training_input_mode = "File"
+  }
+
+  input_data_config {
+    channel_name = "train"
+
+    s3_data_source {
+      s3_data_type = "S3Prefix"
+      s3_uri       = "s3://${aws_s3_bucket.test.bucket}/train"
+    }
+  }
+
+  output_data_config {
+    s3_output_path = "s3://${aws_s3_bucket.test.bucket}/output"
+  }
+
+  resource_config {
+    instance_type     = "ml.m5.large"
+    instance_count    = 1
+    volume_size_in_gb = 10
+  }
+
+  stopping_condition {
+    max_runtime_in_seconds = 3600
+  }
+}
+
+resource "aws_s3_bucket" "test" {
+  bucket        = %[1]q
+  force_destroy = true
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "sagemaker.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+`, rName)
+}
+
+func testAccAWSSagemakerTrainingJobDataSourceConfig(rName string) string {
+	return testAccAWSSagemakerTrainingJobConfig(rName) + `
+data "aws_sagemaker_training_job" "test" {
+  name_contains = aws_sagemaker_training_job.test.name
+}
+`
+}
@@ -0,0 +1,353 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerUserProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerUserProfileCreate,
+		Read:   resourceAwsSagemakerUserProfileRead,
+		Update: resourceAwsSagemakerUserProfileUpdate,
+		Delete: resourceAwsSagemakerUserProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"user_profile_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"single_sign_on_user_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"single_sign_on_user_value": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"user_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"execution_role": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"security_groups": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"jupyter_server_app_settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"lifecycle_config_arns": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+
+						"kernel_gateway_app_settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"lifecycle_config_arns": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerUserProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID := d.Get("domain_id").(string)
+	name := d.Get("user_profile_name").(string)
+
+	createOpts := &sagemaker.CreateUserProfileInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(name),
+	}
+
+	if v, ok := d.GetOk("single_sign_on_user_identifier"); ok {
+		createOpts.SingleSignOnUserIdentifier = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("single_sign_on_user_value"); ok {
+		createOpts.SingleSignOnUserValue = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("user_settings"); ok {
+		createOpts.UserSettings = expandSagemakerUserProfileUserSettings(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker User Profile create config: %#v", *createOpts)
+	_, err := conn.CreateUserProfile(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Sagemaker User Profile: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", domainID, name))
+	log.Printf("[INFO] Sagemaker User Profile ID: %s", d.Id())
+
+	return resourceAwsSagemakerUserProfileRead(d, meta)
+}
+
+func resourceAwsSagemakerUserProfileRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, name, err := decodeSagemakerUserProfileId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	userProfile, err := conn.DescribeUserProfile(&sagemaker.DescribeUserProfileInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker user profile %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := d.Set("domain_id", userProfile.DomainId); err != nil {
+		return fmt.Errorf("error setting domain_id for user profile %q: %s", d.Id(), err)
+	}
+	if err := d.Set("user_profile_name", userProfile.UserProfileName); err != nil {
+		return fmt.Errorf("error setting user_profile_name for user profile %q: %s", d.Id(), err)
+	}
+	if err := d.Set("single_sign_on_user_identifier", userProfile.SingleSignOnUserIdentifier); err != nil {
+		return fmt.Errorf("error setting single_sign_on_user_identifier for user profile %q: %s", d.Id(), err)
+	}
+	if err := d.Set("single_sign_on_user_value", userProfile.SingleSignOnUserValue); err != nil {
+		return fmt.Errorf("error setting single_sign_on_user_value for user profile %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", userProfile.UserProfileArn); err != nil {
+		return fmt.Errorf("error setting arn for user profile %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("user_settings", flattenSagemakerUserProfileUserSettings(userProfile.UserSettings)); err != nil {
+		return fmt.Errorf("error setting user_settings for user profile %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, userProfile.UserProfileArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for user profile %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerUserProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("user_settings") {
+		domainID, name, err := decodeSagemakerUserProfileId(d.Id())
+		if err != nil {
+			return err
+		}
+
+		updateOpts := &sagemaker.UpdateUserProfileInput{
+			DomainId:        aws.String(domainID),
+			UserProfileName: aws.String(name),
+			UserSettings:    expandSagemakerUserProfileUserSettings(d.Get("user_settings").([]interface{})),
+		}
+
+		if _, err := conn.UpdateUserProfile(updateOpts); err != nil {
+			return fmt.Errorf("Error updating Sagemaker User Profile: %s", err)
+		}
+	}
+
+	return resourceAwsSagemakerUserProfileRead(d, meta)
+}
+
+func resourceAwsSagemakerUserProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	domainID, name, err := decodeSagemakerUserProfileId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.DeleteUserProfile(&sagemaker.DeleteUserProfileInput{
+		DomainId:        aws.String(domainID),
+		UserProfileName: aws.String(name),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker User Profile: %s", err)
+	}
+
+	return nil
+}
+
+func decodeSagemakerUserProfileId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected DOMAIN-ID/USER-PROFILE-NAME", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func expandSagemakerUserProfileUserSettings(l []interface{}) *sagemaker.UserSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	settings := &sagemaker.UserSettings{}
+
+	if v, ok := m["execution_role"]; ok && v.(string) != "" {
+		settings.ExecutionRole = aws.String(v.(string))
+	}
+
+	if v, ok := m["security_groups"]; ok {
+		settings.SecurityGroups = expandStringSet(v.(*schema.Set))
+	}
+
+	if v, ok := m["jupyter_server_app_settings"]; ok {
+		settings.JupyterServerAppSettings = expandSagemakerUserProfileJupyterServerAppSettings(v.([]interface{}))
+	}
+
+	if v, ok := m["kernel_gateway_app_settings"]; ok {
+		settings.KernelGatewayAppSettings = expandSagemakerUserProfileKernelGatewayAppSettings(v.([]interface{}))
+	}
+
+	return settings
+}
+
+func expandSagemakerUserProfileJupyterServerAppSettings(l []interface{}) *sagemaker.JupyterServerAppSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.JupyterServerAppSettings{
+		LifecycleConfigArns: expandStringSet(m["lifecycle_config_arns"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerUserProfileJupyterServerAppSettings(settings *sagemaker.JupyterServerAppSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"lifecycle_config_arns": flattenStringList(settings.LifecycleConfigArns),
+		},
+	}
+}
+
+func expandSagemakerUserProfileKernelGatewayAppSettings(l []interface{}) *sagemaker.KernelGatewayAppSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.KernelGatewayAppSettings{
+		LifecycleConfigArns: expandStringSet(m["lifecycle_config_arns"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerUserProfileKernelGatewayAppSettings(settings *sagemaker.KernelGatewayAppSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"lifecycle_config_arns": flattenStringList(settings.LifecycleConfigArns),
+		},
+	}
+}
+
+func flattenSagemakerUserProfileUserSettings(settings *sagemaker.UserSettings) []map[string]interface{} {
+	if settings == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"execution_role":              aws.StringValue(settings.ExecutionRole),
+			"security_groups":             flattenStringList(settings.SecurityGroups),
+			"jupyter_server_app_settings": flattenSagemakerUserProfileJupyterServerAppSettings(settings.JupyterServerAppSettings),
+			"kernel_gateway_app_settings": flattenSagemakerUserProfileKernelGatewayAppSettings(settings.KernelGatewayAppSettings),
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSagemakerUserProfile_studioLifecycleConfig(t *testing.T) {
+	var userProfile sagemaker.DescribeUserProfileOutput
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_sagemaker_user_profile.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSagemakerUserProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSagemakerUserProfileConfigLifecycleConfigArns(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerUserProfileExists(resourceName, &userProfile),
+					resource.TestCheckResourceAttr(resourceName, "user_settings.0.jupyter_server_app_settings.0.lifecycle_config_arns.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "user_settings.0.kernel_gateway_app_settings.0.lifecycle_config_arns.#", "1"),
+				),
+			},
+			{
+				Config: testAccAWSSagemakerUserProfileConfigLifecycleConfigArns(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSagemakerUserProfileExists(resourceName, &userProfile),
+					resource.TestCheckResourceAttr(resourceName, "user_settings.0.jupyter_server_app_settings.0.lifecycle_config_arns.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "user_settings.0.kernel_gateway_app_settings.0.lifecycle_config_arns.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSagemakerUserProfileExists(n string, userProfile *sagemaker.DescribeUserProfileOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+		domainID, name, err := decodeSagemakerUserProfileId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.DescribeUserProfile(&sagemaker.DescribeUserProfileInput{
+			DomainId:        aws.String(domainID),
+			UserProfileName: aws.String(name),
+		})
+		if err != nil {
+			return err
+		}
+
+		*userProfile = *out
+
+		return nil
+	}
+}
+
+func testAccCheckAWSSagemakerUserProfileDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).sagemakerconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sagemaker_user_profile" {
+			continue
+		}
+
+		domainID, name, err := decodeSagemakerUserProfileId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeUserProfile(&sagemaker.DescribeUserProfileInput{
+			DomainId:        aws.String(domainID),
+			UserProfileName: aws.String(name),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Sagemaker User Profile %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAWSSagemakerUserProfileConfigLifecycleConfigArns(rName string, associate bool) string {
+	lifecycleConfigArns := ""
+	if associate {
+		lifecycleConfigArns = `lifecycle_config_arns = [aws_sagemaker_studio_lifecycle_config.test.arn]`
+	}
+
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "sagemaker.amazonaws.com"
+      }
+    }]
+  })
+}
+
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.0.0.0/24"
+}
+
+resource "aws_sagemaker_domain" "test" {
+  domain_name = %[1]q
+  auth_mode   = "IAM"
+  vpc_id      = aws_vpc.test.id
+  subnet_ids  = [aws_subnet.test.id]
+
+  default_user_settings {
+    execution_role = aws_iam_role.test.arn
+  }
+}
+
+resource "aws_sagemaker_studio_lifecycle_config" "test" {
+  studio_lifecycle_config_name     = %[1]q
+  studio_lifecycle_config_app_type = "JupyterServer"
+  studio_lifecycle_config_content  = base64encode("echo hello")
+}
+
+resource "aws_sagemaker_user_profile" "test" {
+  domain_id         = aws_sagemaker_domain.test.id
+  user_profile_name = %[1]q
+
+  user_settings {
+    execution_role = aws_iam_role.test.arn
+
+    jupyter_server_app_settings {
+      %[2]s
+    }
+
+    kernel_gateway_app_settings {
+      %[2]s
+    }
+  }
+}
+`, rName, lifecycleConfigArns)
+}
+
+func TestExpandFlattenSagemakerUserProfileJupyterServerAppSettings(t *testing.T) {
+	arn := "arn:aws:sagemaker:us-east-1:123456789012:studio-lifecycle-config/test"
+
+	l := []interface{}{
+		map[string]interface{}{
+			"lifecycle_config_arns": schema.NewSet(schema.HashString, []interface{}{arn}),
+		},
+	}
+
+	settings := expandSagemakerUserProfileJupyterServerAppSettings(l)
+	if settings == nil || len(settings.LifecycleConfigArns) != 1 || aws.StringValue(settings.LifecycleConfigArns[0]) != arn {
+		t.Fatalf("unexpected expand result: %#v", settings)
+	}
+
+	flattened := flattenSagemakerUserProfileJupyterServerAppSettings(settings)
+	if len(flattened) != 1 {
+		t.Fatalf("got %d entries, want 1", len(flattened))
+	}
+}
@@ -0,0 +1,328 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerWorkforce() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerWorkforceCreate,
+		Read:   resourceAwsSagemakerWorkforceRead,
+		Update: resourceAwsSagemakerWorkforceUpdate,
+		Delete: resourceAwsSagemakerWorkforceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"workforce_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"subdomain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cognito_config": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"oidc_config"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_pool": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"oidc_config": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"cognito_config"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authorization_endpoint": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"client_secret": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"issuer": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"jwks_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"logout_endpoint": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"token_endpoint": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"user_info_endpoint": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"source_ip_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidrs": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsSagemakerWorkforceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("workforce_name").(string)
+
+	createOpts := &sagemaker.CreateWorkforceInput{
+		WorkforceName: aws.String(name),
+	}
+
+	if v, ok := d.GetOk("cognito_config"); ok {
+		createOpts.CognitoConfig = expandSagemakerWorkforceCognitoConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("oidc_config"); ok {
+		createOpts.OidcConfig = expandSagemakerWorkforceOidcConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("source_ip_config"); ok {
+		createOpts.SourceIpConfig = expandSagemakerWorkforceSourceIpConfig(v.([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Workforce create config: %#v", *createOpts)
+	if _, err := conn.CreateWorkforce(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Workforce: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Workforce ID: %s", d.Id())
+
+	return resourceAwsSagemakerWorkforceRead(d, meta)
+}
+
+func resourceAwsSagemakerWorkforceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	out, err := conn.DescribeWorkforce(&sagemaker.DescribeWorkforceInput{
+		WorkforceName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker workforce %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	workforce := out.Workforce
+
+	if err := d.Set("workforce_name", workforce.WorkforceName); err != nil {
+		return fmt.Errorf("error setting workforce_name for workforce %q: %s", d.Id(), err)
+	}
+	if err := d.Set("subdomain", workforce.SubDomain); err != nil {
+		return fmt.Errorf("error setting subdomain for workforce %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", workforce.WorkforceArn); err != nil {
+		return fmt.Errorf("error setting arn for workforce %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("cognito_config", flattenSagemakerWorkforceCognitoConfig(workforce.CognitoConfig)); err != nil {
+		return fmt.Errorf("error setting cognito_config for workforce %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("source_ip_config", flattenSagemakerWorkforceSourceIpConfig(workforce.SourceIpConfig)); err != nil {
+		return fmt.Errorf("error setting source_ip_config for workforce %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerWorkforceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	updateOpts := &sagemaker.UpdateWorkforceInput{
+		WorkforceName: aws.String(d.Id()),
+	}
+
+	if v, ok := d.GetOk("oidc_config"); ok {
+		updateOpts.OidcConfig = expandSagemakerWorkforceOidcConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("source_ip_config"); ok {
+		updateOpts.SourceIpConfig = expandSagemakerWorkforceSourceIpConfig(v.([]interface{}))
+	}
+
+	if _, err := conn.UpdateWorkforce(updateOpts); err != nil {
+		return fmt.Errorf("Error updating Sagemaker Workforce: %s", err)
+	}
+
+	return resourceAwsSagemakerWorkforceRead(d, meta)
+}
+
+func resourceAwsSagemakerWorkforceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteWorkforce(&sagemaker.DeleteWorkforceInput{
+		WorkforceName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Workforce: %s", err)
+	}
+
+	if err := waitSagemakerWorkforceDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Sagemaker Workforce (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitSagemakerWorkforceDeleted(conn *sagemaker.SageMaker, name string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.DescribeWorkforce(&sagemaker.DescribeWorkforceInput{
+			WorkforceName: aws.String(name),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		return resource.RetryableError(fmt.Errorf("[DEBUG] Waiting for Sagemaker Workforce (%s) to be deleted", name))
+	})
+}
+
+func expandSagemakerWorkforceCognitoConfig(l []interface{}) *sagemaker.CognitoConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.CognitoConfig{
+		UserPool: aws.String(m["user_pool"].(string)),
+		ClientId: aws.String(m["client_id"].(string)),
+	}
+}
+
+func flattenSagemakerWorkforceCognitoConfig(config *sagemaker.CognitoConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"user_pool": aws.StringValue(config.UserPool),
+			"client_id": aws.StringValue(config.ClientId),
+		},
+	}
+}
+
+func expandSagemakerWorkforceOidcConfig(l []interface{}) *sagemaker.OidcConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.OidcConfig{
+		AuthorizationEndpoint: aws.String(m["authorization_endpoint"].(string)),
+		ClientId:              aws.String(m["client_id"].(string)),
+		ClientSecret:          aws.String(m["client_secret"].(string)),
+		Issuer:                aws.String(m["issuer"].(string)),
+		JwksUri:               aws.String(m["jwks_uri"].(string)),
+		LogoutEndpoint:        aws.String(m["logout_endpoint"].(string)),
+		TokenEndpoint:         aws.String(m["token_endpoint"].(string)),
+		UserInfoEndpoint:      aws.String(m["user_info_endpoint"].(string)),
+	}
+}
+
+func expandSagemakerWorkforceSourceIpConfig(l []interface{}) *sagemaker.SourceIpConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.SourceIpConfig{
+		Cidrs: expandStringSet(m["cidrs"].(*schema.Set)),
+	}
+}
+
+func flattenSagemakerWorkforceSourceIpConfig(config *sagemaker.SourceIpConfig) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"cidrs": flattenStringList(config.Cidrs),
+		},
+	}
+}
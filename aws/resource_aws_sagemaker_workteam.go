@@ -0,0 +1,295 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsSagemakerWorkteam() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSagemakerWorkteamCreate,
+		Read:   resourceAwsSagemakerWorkteamRead,
+		Update: resourceAwsSagemakerWorkteamUpdate,
+		Delete: resourceAwsSagemakerWorkteamDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"workteam_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSagemakerName,
+			},
+
+			"workforce_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"subdomain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"member_definition": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cognito_member_definition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"user_pool":  {Type: schema.TypeString, Required: true},
+									"user_group": {Type: schema.TypeString, Required: true},
+									"client_id":  {Type: schema.TypeString, Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"notification_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"notification_topic_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArn,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsSagemakerWorkteamCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	name := d.Get("workteam_name").(string)
+
+	createOpts := &sagemaker.CreateWorkteamInput{
+		WorkteamName:      aws.String(name),
+		Description:       aws.String(d.Get("description").(string)),
+		MemberDefinitions: expandSagemakerWorkteamMemberDefinitions(d.Get("member_definition").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("workforce_name"); ok {
+		createOpts.WorkforceName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("notification_configuration"); ok {
+		createOpts.NotificationConfiguration = expandSagemakerWorkteamNotificationConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		createOpts.Tags = tagsFromMapSagemaker(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Sagemaker Workteam create config: %#v", *createOpts)
+	if _, err := conn.CreateWorkteam(createOpts); err != nil {
+		return fmt.Errorf("Error creating Sagemaker Workteam: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Sagemaker Workteam ID: %s", d.Id())
+
+	return resourceAwsSagemakerWorkteamRead(d, meta)
+}
+
+func resourceAwsSagemakerWorkteamRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	out, err := conn.DescribeWorkteam(&sagemaker.DescribeWorkteamInput{
+		WorkteamName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "ResourceNotFound" {
+				d.SetId("")
+				log.Printf("[LOG] Unable to find SageMaker workteam %q; removing from state file", d.Id())
+				return nil
+			}
+		}
+		return err
+	}
+
+	workteam := out.Workteam
+
+	if err := d.Set("workteam_name", workteam.WorkteamName); err != nil {
+		return fmt.Errorf("error setting workteam_name for workteam %q: %s", d.Id(), err)
+	}
+	if err := d.Set("description", workteam.Description); err != nil {
+		return fmt.Errorf("error setting description for workteam %q: %s", d.Id(), err)
+	}
+	if err := d.Set("subdomain", workteam.SubDomain); err != nil {
+		return fmt.Errorf("error setting subdomain for workteam %q: %s", d.Id(), err)
+	}
+	if err := d.Set("arn", workteam.WorkteamArn); err != nil {
+		return fmt.Errorf("error setting arn for workteam %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("member_definition", flattenSagemakerWorkteamMemberDefinitions(workteam.MemberDefinitions)); err != nil {
+		return fmt.Errorf("error setting member_definition for workteam %q: %s", d.Id(), err)
+	}
+
+	if err := d.Set("notification_configuration", flattenSagemakerWorkteamNotificationConfiguration(workteam.NotificationConfiguration)); err != nil {
+		return fmt.Errorf("error setting notification_configuration for workteam %q: %s", d.Id(), err)
+	}
+
+	tagsOutput, err := sagemakerListTagsWithRetry(conn, workteam.WorkteamArn)
+	if err != nil {
+		log.Printf("[ERR] Error reading tags: %s", err)
+		return err
+	}
+
+	if err := d.Set("tags", tagsToMapSagemaker(tagsOutput.Tags)); err != nil {
+		return fmt.Errorf("error setting tags for workteam %q: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsSagemakerWorkteamUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if err := setSagemakerTags(conn, d); err != nil {
+		return err
+	}
+
+	updateOpts := &sagemaker.UpdateWorkteamInput{
+		WorkteamName: aws.String(d.Id()),
+	}
+
+	if d.HasChange("description") {
+		updateOpts.Description = aws.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("member_definition") {
+		updateOpts.MemberDefinitions = expandSagemakerWorkteamMemberDefinitions(d.Get("member_definition").([]interface{}))
+	}
+
+	if d.HasChange("notification_configuration") {
+		updateOpts.NotificationConfiguration = expandSagemakerWorkteamNotificationConfiguration(d.Get("notification_configuration").([]interface{}))
+	}
+
+	if _, err := conn.UpdateWorkteam(updateOpts); err != nil {
+		return fmt.Errorf("Error updating Sagemaker Workteam: %s", err)
+	}
+
+	return resourceAwsSagemakerWorkteamRead(d, meta)
+}
+
+func resourceAwsSagemakerWorkteamDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).sagemakerconn
+
+	if _, err := conn.DeleteWorkteam(&sagemaker.DeleteWorkteamInput{
+		WorkteamName: aws.String(d.Id()),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Sagemaker Workteam: %s", err)
+	}
+
+	return nil
+}
+
+func expandSagemakerWorkteamMemberDefinitions(l []interface{}) []*sagemaker.MemberDefinition {
+	members := make([]*sagemaker.MemberDefinition, 0, len(l))
+
+	for _, raw := range l {
+		m := raw.(map[string]interface{})
+
+		member := &sagemaker.MemberDefinition{}
+
+		if v, ok := m["cognito_member_definition"]; ok {
+			if cl := v.([]interface{}); len(cl) > 0 && cl[0] != nil {
+				cm := cl[0].(map[string]interface{})
+				member.CognitoMemberDefinition = &sagemaker.CognitoMemberDefinition{
+					UserPool:  aws.String(cm["user_pool"].(string)),
+					UserGroup: aws.String(cm["user_group"].(string)),
+					ClientId:  aws.String(cm["client_id"].(string)),
+				}
+			}
+		}
+
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func flattenSagemakerWorkteamMemberDefinitions(members []*sagemaker.MemberDefinition) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(members))
+
+	for _, member := range members {
+		m := map[string]interface{}{}
+
+		if member.CognitoMemberDefinition != nil {
+			m["cognito_member_definition"] = []map[string]interface{}{
+				{
+					"user_pool":  aws.StringValue(member.CognitoMemberDefinition.UserPool),
+					"user_group": aws.StringValue(member.CognitoMemberDefinition.UserGroup),
+					"client_id":  aws.StringValue(member.CognitoMemberDefinition.ClientId),
+				},
+			}
+		}
+
+		out = append(out, m)
+	}
+
+	return out
+}
+
+func expandSagemakerWorkteamNotificationConfiguration(l []interface{}) *sagemaker.NotificationConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &sagemaker.NotificationConfiguration{
+		NotificationTopicArn: aws.String(m["notification_topic_arn"].(string)),
+	}
+}
+
+func flattenSagemakerWorkteamNotificationConfiguration(config *sagemaker.NotificationConfiguration) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"notification_topic_arn": aws.StringValue(config.NotificationTopicArn),
+		},
+	}
+}
@@ -0,0 +1,625 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	sagemakerTagMaxCount       = 50
+	sagemakerTagKeyMaxLength   = 128
+	sagemakerTagValueMaxLength = 256
+
+	// sagemakerTagBatchSize is the maximum number of tags AddTags/DeleteTags
+	// accept in a single call. It happens to be the same number as
+	// sagemakerTagMaxCount (the overall per-resource tag limit), but the two
+	// are enforced independently by the API, so they're kept as separate
+	// constants rather than reusing one for both meanings.
+	sagemakerTagBatchSize = 50
+)
+
+// validateSagemakerTags enforces SageMaker's tagging constraints (tag count,
+// key/value length, and the reserved aws: key prefix) before a tags map is
+// converted to API input, so violations surface as a plan-time error instead
+// of a runtime API failure.
+func validateSagemakerTags(tags map[string]interface{}) error {
+	if len(tags) > sagemakerTagMaxCount {
+		return fmt.Errorf("too many tags: %d provided, SageMaker allows a maximum of %d", len(tags), sagemakerTagMaxCount)
+	}
+
+	for k, v := range tags {
+		if strings.HasPrefix(k, "aws:") {
+			return fmt.Errorf("invalid tag key %q: keys with the reserved \"aws:\" prefix are not allowed", k)
+		}
+
+		if len(k) > sagemakerTagKeyMaxLength {
+			return fmt.Errorf("invalid tag key %q: length %d exceeds the maximum of %d", k, len(k), sagemakerTagKeyMaxLength)
+		}
+
+		if s, ok := v.(string); ok && len(s) > sagemakerTagValueMaxLength {
+			return fmt.Errorf("invalid value for tag key %q: length %d exceeds the maximum of %d", k, len(s), sagemakerTagValueMaxLength)
+		}
+	}
+
+	return nil
+}
+
+// sagemakerTagsForCreate is the standard tag-on-create helper: it validates
+// the tags configured on the resource and merges in the provider's
+// default_tags, returning the []*sagemaker.Tag to pass directly as the
+// Tags field on a CreateX input. Tagging at create time, rather than
+// creating untagged and reconciling afterward, avoids a window where the
+// resource exists but doesn't yet carry tags a tag-enforcement SCP requires,
+// so every SageMaker resource's Create should go through this rather than
+// building its Tags field by hand.
+func sagemakerTagsForCreate(meta interface{}, tags map[string]interface{}) ([]*sagemaker.Tag, error) {
+	if err := validateSagemakerTags(tags); err != nil {
+		return nil, err
+	}
+
+	return sagemakerTagsWithDefaults(meta, tags), nil
+}
+
+// sagemakerTagsWithDefaults merges the provider's default_tags into a
+// resource's own tags, giving resource-level values precedence on conflict.
+func sagemakerTagsWithDefaults(meta interface{}, tags map[string]interface{}) []*sagemaker.Tag {
+	all := make(map[string]interface{})
+	for k, v := range meta.(*AWSClient).defaultTagsConfig {
+		all[k] = v
+	}
+	for k, v := range tags {
+		all[k] = v
+	}
+
+	return tagsFromMapSagemaker(all)
+}
+
+// sagemakerTagsWithoutDefaults strips out any tag that matches a provider
+// default_tags key/value pair, so tags only reflects resource-level tags.
+func sagemakerTagsWithoutDefaults(meta interface{}, allTags map[string]string) map[string]string {
+	defaultTags := meta.(*AWSClient).defaultTagsConfig
+
+	resourceTags := make(map[string]string)
+	for k, v := range allTags {
+		if dv, ok := defaultTags[k]; !ok || dv != v {
+			resourceTags[k] = v
+		}
+	}
+
+	return resourceTags
+}
+
+// sagemakerTagsWithoutIgnored strips out any tag matched by the provider's
+// ignore_tags configuration, so a tag applied out-of-band that the provider
+// is configured to ignore (e.g. key_prefixes = ["kubernetes.io/"]) never
+// shows up in tags_all or tags and therefore never diffs as something this
+// resource needs to delete.
+func sagemakerTagsWithoutIgnored(meta interface{}, allTags map[string]string) map[string]string {
+	ignoreConfig := meta.(*AWSClient).ignoreTagsConfig
+	if ignoreConfig == nil {
+		return allTags
+	}
+
+	resourceTags := make(map[string]string)
+	for k, v := range allTags {
+		if !sagemakerTagKeyMatchesIgnoreRules(k, ignoreConfig.Keys, ignoreConfig.KeyPrefixes) {
+			resourceTags[k] = v
+		}
+	}
+
+	return resourceTags
+}
+
+// sagemakerTagKeyMatchesIgnoreRules is the pure matching logic behind
+// sagemakerTagsWithoutIgnored, kept separate so the ignore_tags key/prefix
+// matching can be unit tested without constructing an *AWSClient.
+func sagemakerTagKeyMatchesIgnoreRules(key string, keys, keyPrefixes []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	for _, p := range keyPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sagemakerTagsWithoutAwsManaged strips out any tag whose key has the
+// reserved "aws:" prefix. validateSagemakerTags already rejects these on the
+// way in, but ListTags can still hand one back -- SageMaker itself applies
+// aws:-prefixed tags to some resources -- and without this, one of those
+// would show up as drift against a config that (correctly) never mentions
+// it.
+func sagemakerTagsWithoutAwsManaged(allTags map[string]string) map[string]string {
+	resourceTags := make(map[string]string)
+	for k, v := range allTags {
+		if !strings.HasPrefix(k, "aws:") {
+			resourceTags[k] = v
+		}
+	}
+
+	return resourceTags
+}
+
+// sagemakerListTagsWithRetry wraps ListTags in a bounded, backing-off retry
+// so a resource's Read doesn't abort on the throttling that's common when a
+// large number of SageMaker resources are refreshed at once.
+func sagemakerListTagsWithRetry(conn sagemakeriface.SageMakerAPI, arn *string) (*sagemaker.ListTagsOutput, error) {
+	var out *sagemaker.ListTagsOutput
+
+	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+		err := retryOnSagemakerThrottle(func() error {
+			var err error
+			out, err = conn.ListTags(&sagemaker.ListTagsInput{
+				ResourceArn: arn,
+			})
+			return err
+		})
+		if err != nil {
+			if isSagemakerRetryableErr(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+// sagemakerListAllTagsWithRetry pages through ListTags until NextToken is
+// exhausted, so resources with enough tags to span multiple pages don't read
+// back only the first page and show false drift on every refresh. This is
+// the one ARN-based tag-read path every SageMaker resource's Read should
+// call rather than hand-rolling their own ListTags loop: pagination and
+// throttle-retry only need to be gotten right here, once.
+func sagemakerListAllTagsWithRetry(conn sagemakeriface.SageMakerAPI, arn *string) ([]*sagemaker.Tag, error) {
+	var tags []*sagemaker.Tag
+	var nextToken *string
+
+	for {
+		var out *sagemaker.ListTagsOutput
+
+		err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+			err := retryOnSagemakerThrottle(func() error {
+				var err error
+				out, err = conn.ListTags(&sagemaker.ListTagsInput{
+					ResourceArn: arn,
+					NextToken:   nextToken,
+				})
+				return err
+			})
+			if err != nil {
+				if isSagemakerRetryableErr(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, out.Tags...)
+
+		if out.NextToken == nil {
+			return tags, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+// sagemakerWaitForCreatedTags polls ListTags until every tag passed to a
+// create call is visible, up to timeout. ListTags is eventually consistent,
+// so a resource's trailing Read right after create can come back with no
+// tags (or a stale subset) even though CreateX already accepted them; a
+// single-shot list in that position would get written straight into state
+// and show as drift on the very next plan. On timeout this gives up and
+// returns the latest (possibly still incomplete) result rather than failing
+// the create over a listing lag.
+func sagemakerWaitForCreatedTags(conn sagemakeriface.SageMakerAPI, arn *string, want []*sagemaker.Tag, timeout time.Duration) ([]*sagemaker.Tag, error) {
+	if len(want) == 0 {
+		return sagemakerListAllTagsWithRetry(conn, arn)
+	}
+
+	var tags []*sagemaker.Tag
+	var notYetVisible bool
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		got, err := sagemakerListAllTagsWithRetry(conn, arn)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		tags = got
+
+		gotTags := tagsToMapSagemaker(got)
+		for _, t := range want {
+			if gotTags[aws.StringValue(t.Key)] != aws.StringValue(t.Value) {
+				notYetVisible = true
+				return resource.RetryableError(fmt.Errorf("tag %q not yet visible via ListTags", aws.StringValue(t.Key)))
+			}
+		}
+
+		notYetVisible = false
+		return nil
+	})
+	if err != nil {
+		if !notYetVisible {
+			return tags, err
+		}
+		log.Printf("[WARN] Sagemaker ListTags did not converge on the tags set at create within %s, proceeding with the latest result: %s", timeout, err)
+	}
+
+	return tags, nil
+}
+
+// isSagemakerRetryableErr returns true for throttling and transient 5xx
+// errors, which are worth retrying, as opposed to errors like
+// ResourceNotFound that will never succeed no matter how many times they're
+// retried.
+func isSagemakerRetryableErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	switch awsErr.Code() {
+	case "ThrottlingException", "TooManyRequestsException", "RequestLimitExceeded", "InternalFailure", "ServiceUnavailable":
+		return true
+	}
+
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() >= 500 {
+		return true
+	}
+
+	return false
+}
+
+const (
+	// sagemakerThrottleRetryMaxAttempts bounds retryOnSagemakerThrottle, so a
+	// SageMaker API call that's throttled on every attempt eventually
+	// surfaces as an error instead of retrying indefinitely.
+	sagemakerThrottleRetryMaxAttempts = 5
+
+	// sagemakerThrottleRetryBaseDelay is the backoff before the first retry;
+	// each subsequent retry doubles it.
+	sagemakerThrottleRetryBaseDelay = 500 * time.Millisecond
+
+	// sagemakerThrottleRetryMaxDelay caps the exponential backoff so a
+	// string of throttled attempts doesn't back off longer than this between
+	// retries.
+	sagemakerThrottleRetryMaxDelay = 10 * time.Second
+)
+
+// sagemakerThrottleRetrySleep is called between retryOnSagemakerThrottle
+// attempts. It's a variable, rather than a direct time.Sleep call, so tests
+// can swap in a no-op and assert on attempt counts and delays without
+// actually waiting out the backoff.
+var sagemakerThrottleRetrySleep = time.Sleep
+
+// isSagemakerThrottleErr returns true only for the error codes SageMaker
+// uses for rate limiting, a narrower check than isSagemakerRetryableErr
+// (which also covers transient 5xx server errors worth retrying for other
+// reasons). retryOnSagemakerThrottle uses this one, since backing off with
+// jitter is specifically a throttling mitigation.
+func isSagemakerThrottleErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	switch awsErr.Code() {
+	case "ThrottlingException", "TooManyRequestsException", "RequestLimitExceeded":
+		return true
+	}
+
+	return false
+}
+
+// retryOnSagemakerThrottle calls fn, retrying with exponential backoff and
+// jitter (up to sagemakerThrottleRetryMaxAttempts attempts total) as long as
+// fn keeps failing with a throttling error. Any other error, or the last
+// throttling error once attempts are exhausted, is returned immediately.
+// This sits alongside the resource.Retry-based retries elsewhere in this
+// resource (which retry a specific condition -- IAM eventual consistency, a
+// delete conflict, an invalid-state update -- until a deadline), rather than
+// replacing them: this one is purely about riding out SageMaker API rate
+// limiting on the underlying call.
+func retryOnSagemakerThrottle(fn func() error) error {
+	delay := sagemakerThrottleRetryBaseDelay
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if !isSagemakerThrottleErr(err) || attempt >= sagemakerThrottleRetryMaxAttempts {
+			return err
+		}
+
+		sleep := delay + time.Duration(rand.Int63n(int64(delay)))
+		log.Printf("[DEBUG] SageMaker API call throttled (attempt %d/%d), retrying in %s: %s", attempt, sagemakerThrottleRetryMaxAttempts, sleep, err)
+		sagemakerThrottleRetrySleep(sleep)
+
+		delay *= 2
+		if delay > sagemakerThrottleRetryMaxDelay {
+			delay = sagemakerThrottleRetryMaxDelay
+		}
+	}
+}
+
+// SagemakerKeyValueTags represents a SageMaker resource's tags as a diffable
+// key/value set, mirroring the keyvaluetags pattern used elsewhere in this
+// provider for other AWS services, so new SageMaker resources don't each
+// reimplement tag reconciliation from scratch.
+type SagemakerKeyValueTags map[string]*string
+
+// SagemakerKeyValueTagsFromMap converts a Terraform tags map (as stored in
+// resource state) to SagemakerKeyValueTags.
+func SagemakerKeyValueTagsFromMap(m map[string]interface{}) SagemakerKeyValueTags {
+	tags := make(SagemakerKeyValueTags, len(m))
+
+	for k, v := range m {
+		tags[k] = aws.String(v.(string))
+	}
+
+	return tags
+}
+
+// SagemakerKeyValueTagsFromSagemakerTags converts the API's []*sagemaker.Tag
+// representation to SagemakerKeyValueTags.
+func SagemakerKeyValueTagsFromSagemakerTags(tags []*sagemaker.Tag) SagemakerKeyValueTags {
+	result := make(SagemakerKeyValueTags, len(tags))
+
+	for _, t := range tags {
+		result[aws.StringValue(t.Key)] = t.Value
+	}
+
+	return result
+}
+
+// IgnoreAws returns a copy of tags with any reserved aws:-prefixed keys
+// removed, so provider- or service-managed tags never show up in a diff
+// against user-supplied config.
+func (tags SagemakerKeyValueTags) IgnoreAws() SagemakerKeyValueTags {
+	result := make(SagemakerKeyValueTags, len(tags))
+
+	for k, v := range tags {
+		if !strings.HasPrefix(k, "aws:") {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// SagemakerTags converts tags back to the []*sagemaker.Tag shape the API
+// expects.
+func (tags SagemakerKeyValueTags) SagemakerTags() []*sagemaker.Tag {
+	result := make([]*sagemaker.Tag, 0, len(tags))
+
+	for k, v := range tags {
+		result = append(result, &sagemaker.Tag{
+			Key:   aws.String(k),
+			Value: v,
+		})
+	}
+
+	return result
+}
+
+// Removed returns the tags present in the receiver but absent from newTags.
+func (tags SagemakerKeyValueTags) Removed(newTags SagemakerKeyValueTags) SagemakerKeyValueTags {
+	result := make(SagemakerKeyValueTags)
+
+	for k, v := range tags {
+		if _, ok := newTags[k]; !ok {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// Updated returns the tags in newTags that are either new or whose value
+// differs from the receiver.
+func (tags SagemakerKeyValueTags) Updated(newTags SagemakerKeyValueTags) SagemakerKeyValueTags {
+	result := make(SagemakerKeyValueTags)
+
+	for k, v := range newTags {
+		if old, ok := tags[k]; !ok || aws.StringValue(old) != aws.StringValue(v) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// SagemakerUpdateTags diffs oldTagsRaw and newTagsRaw (both
+// map[string]interface{}, as returned by d.GetChange("tags")) and
+// reconciles the difference on the SageMaker resource identified by arn via
+// DeleteTags and AddTags.
+func SagemakerUpdateTags(conn sagemakeriface.SageMakerAPI, arn string, oldTagsRaw, newTagsRaw interface{}) error {
+	oldTags := SagemakerKeyValueTagsFromMap(oldTagsRaw.(map[string]interface{})).IgnoreAws()
+	newTags := SagemakerKeyValueTagsFromMap(newTagsRaw.(map[string]interface{})).IgnoreAws()
+
+	if removed := oldTags.Removed(newTags); len(removed) > 0 {
+		keys := make([]*string, 0, len(removed))
+		for k := range removed {
+			keys = append(keys, aws.String(k))
+		}
+
+		for _, batch := range chunkSagemakerTagKeys(keys, sagemakerTagBatchSize) {
+			if _, err := conn.DeleteTags(&sagemaker.DeleteTagsInput{
+				ResourceArn: aws.String(arn),
+				TagKeys:     batch,
+			}); err != nil {
+				return fmt.Errorf("error untagging Sagemaker resource (%s): %s", arn, err)
+			}
+		}
+	}
+
+	if updated := oldTags.Updated(newTags); len(updated) > 0 {
+		for _, batch := range chunkSagemakerTags(updated.SagemakerTags(), sagemakerTagBatchSize) {
+			if _, err := conn.AddTags(&sagemaker.AddTagsInput{
+				ResourceArn: aws.String(arn),
+				Tags:        batch,
+			}); err != nil {
+				return fmt.Errorf("error tagging Sagemaker resource (%s): %s", arn, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SagemakerUpdateTagsAdditive behaves like SagemakerUpdateTags except it
+// never calls DeleteTags: tags removed from config are left on the resource
+// instead of being deleted, for callers that only want to add or update the
+// tags present in config and leave everything else alone.
+func SagemakerUpdateTagsAdditive(conn sagemakeriface.SageMakerAPI, arn string, oldTagsRaw, newTagsRaw interface{}) error {
+	oldTags := SagemakerKeyValueTagsFromMap(oldTagsRaw.(map[string]interface{})).IgnoreAws()
+	newTags := SagemakerKeyValueTagsFromMap(newTagsRaw.(map[string]interface{})).IgnoreAws()
+
+	if updated := oldTags.Updated(newTags); len(updated) > 0 {
+		for _, batch := range chunkSagemakerTags(updated.SagemakerTags(), sagemakerTagBatchSize) {
+			if _, err := conn.AddTags(&sagemaker.AddTagsInput{
+				ResourceArn: aws.String(arn),
+				Tags:        batch,
+			}); err != nil {
+				return fmt.Errorf("error tagging Sagemaker resource (%s): %s", arn, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// chunkSagemakerTagKeys splits keys into batches of at most size, so a
+// DeleteTags call for a resource with more tags than SageMaker's per-call
+// limit is split into multiple requests instead of failing outright.
+func chunkSagemakerTagKeys(keys []*string, size int) [][]*string {
+	var chunks [][]*string
+
+	for len(keys) > 0 {
+		n := size
+		if n > len(keys) {
+			n = len(keys)
+		}
+
+		chunks = append(chunks, keys[:n])
+		keys = keys[n:]
+	}
+
+	return chunks
+}
+
+// chunkSagemakerTags splits tags into batches of at most size, the AddTags
+// counterpart to chunkSagemakerTagKeys above.
+func chunkSagemakerTags(tags []*sagemaker.Tag, size int) [][]*sagemaker.Tag {
+	var chunks [][]*sagemaker.Tag
+
+	for len(tags) > 0 {
+		n := size
+		if n > len(tags) {
+			n = len(tags)
+		}
+
+		chunks = append(chunks, tags[:n])
+		tags = tags[n:]
+	}
+
+	return chunks
+}
+
+// setSagemakerTags reconciles a resource's tags on Update, using the arn
+// already present in state. Centralizing it here means each resource's
+// Update only needs this one call instead of hand-rolling AddTags/DeleteTags
+// diffing itself.
+func setSagemakerTags(conn sagemakeriface.SageMakerAPI, d *schema.ResourceData) error {
+	if !d.HasChange("tags") {
+		return nil
+	}
+
+	o, n := d.GetChange("tags")
+
+	return SagemakerUpdateTags(conn, d.Get("arn").(string), o, n)
+}
+
+// tagsFromMapSagemaker converts a raw tags map into the []*sagemaker.Tag form
+// the SageMaker API expects. The map usually comes straight from Terraform
+// config, where schema.TypeMap's Elem already guarantees string values, but
+// it can also come from a map built outside config validation -- decoded
+// from a tag definitions file with jsondecode(), for instance, where a bare
+// number or boolean in the JSON decodes to a float64 or bool, not a string.
+// AWS tags are string-only, so those are coerced to their string form here
+// rather than left to panic on the type assertion a plain v.(string) would
+// be.
+func tagsFromMapSagemaker(m map[string]interface{}) []*sagemaker.Tag {
+	result := make([]*sagemaker.Tag, 0, len(m))
+	for k, v := range m {
+		result = append(result, &sagemaker.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(sagemakerTagValueToString(v)),
+		})
+	}
+
+	return result
+}
+
+// sagemakerTagValueToString is the coercion behind tagsFromMapSagemaker,
+// kept separate so it's testable without building a map or a *sagemaker.Tag.
+func sagemakerTagValueToString(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		// encoding/json decodes every JSON number into a float64, whether or
+		// not it had a decimal point, so a whole-valued tag like 3 decodes
+		// to 3.0; formatting that as "3" rather than "3.0" is done via Trunc
+		// rather than via %g's own formatting, which wouldn't reliably avoid
+		// scientific notation on larger integers.
+		if val == math.Trunc(val) {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// tagsToMapSagemaker converts SageMaker's []*sagemaker.Tag form back into the
+// plain map Terraform's "tags" schema attribute expects.
+func tagsToMapSagemaker(ts []*sagemaker.Tag) map[string]string {
+	result := make(map[string]string, len(ts))
+	for _, t := range ts {
+		result[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return result
+}
@@ -0,0 +1,831 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+)
+
+func TestValidateSagemakerTags(t *testing.T) {
+	tagsOfCount := func(n int) map[string]interface{} {
+		tags := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			tags[fmt.Sprintf("key%d", i)] = "value"
+		}
+		return tags
+	}
+
+	testCases := []struct {
+		name      string
+		tags      map[string]interface{}
+		expectErr bool
+	}{
+		{
+			name:      "50 tags is allowed",
+			tags:      tagsOfCount(50),
+			expectErr: false,
+		},
+		{
+			name:      "51 tags is rejected",
+			tags:      tagsOfCount(51),
+			expectErr: true,
+		},
+		{
+			name:      "aws prefixed key is rejected",
+			tags:      map[string]interface{}{"aws:cloudformation:stack-name": "test"},
+			expectErr: true,
+		},
+		{
+			name:      "oversized value is rejected",
+			tags:      map[string]interface{}{"key": strings.Repeat("a", sagemakerTagValueMaxLength+1)},
+			expectErr: true,
+		},
+		{
+			name:      "oversized key is rejected",
+			tags:      map[string]interface{}{strings.Repeat("k", sagemakerTagKeyMaxLength+1): "value"},
+			expectErr: true,
+		},
+		{
+			name:      "normal tags are allowed",
+			tags:      map[string]interface{}{"environment": "prod", "owner": "ml-team"},
+			expectErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSagemakerTags(tc.tags)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
+
+// throttlingThenSuccessSagemakerAPI embeds sagemakeriface.SageMakerAPI so it
+// satisfies the full interface while only overriding ListTags, which
+// throttles a fixed number of times before succeeding.
+type throttlingThenSuccessSagemakerAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	throttleCount   int
+	calls           int
+	lastResourceArn *string
+}
+
+func (m *throttlingThenSuccessSagemakerAPI) ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	m.calls++
+	m.lastResourceArn = input.ResourceArn
+	if m.calls <= m.throttleCount {
+		return nil, awserr.New("ThrottlingException", "Rate exceeded", nil)
+	}
+
+	return &sagemaker.ListTagsOutput{
+		Tags: []*sagemaker.Tag{
+			{Key: aws.String("environment"), Value: aws.String("prod")},
+		},
+	}, nil
+}
+
+func TestSagemakerListTagsWithRetry(t *testing.T) {
+	t.Run("succeeds after throttling twice", func(t *testing.T) {
+		conn := &throttlingThenSuccessSagemakerAPI{throttleCount: 2}
+
+		out, err := sagemakerListTagsWithRetry(conn, aws.String("arn:aws:sagemaker:us-east-1:123456789012:model/test"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if conn.calls != 3 {
+			t.Errorf("got %d calls, want 3", conn.calls)
+		}
+		if len(out.Tags) != 1 || aws.StringValue(out.Tags[0].Key) != "environment" {
+			t.Errorf("unexpected tags in output: %#v", out.Tags)
+		}
+	})
+
+	t.Run("does not retry non-throttling errors", func(t *testing.T) {
+		conn := &throttlingThenSuccessSagemakerAPI{throttleCount: 0}
+		conn.calls = 0
+
+		nonRetryable := &mockListTagsAPI{
+			err: awserr.New("ValidationException", "bad input", nil),
+		}
+
+		if _, err := sagemakerListTagsWithRetry(nonRetryable, aws.String("arn")); err == nil {
+			t.Error("expected an error, got nil")
+		}
+		if nonRetryable.calls != 1 {
+			t.Errorf("got %d calls, want 1 (non-retryable errors should not be retried)", nonRetryable.calls)
+		}
+	})
+}
+
+type mockListTagsAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	err   error
+	calls int
+}
+
+func (m *mockListTagsAPI) ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	m.calls++
+	return nil, m.err
+}
+
+func TestSagemakerKeyValueTagsIgnoreAws(t *testing.T) {
+	tags := SagemakerKeyValueTags{
+		"environment":            aws.String("prod"),
+		"aws:cloudformation:foo": aws.String("bar"),
+	}
+
+	got := tags.IgnoreAws()
+	if _, ok := got["aws:cloudformation:foo"]; ok {
+		t.Error("expected aws:-prefixed key to be stripped")
+	}
+	if aws.StringValue(got["environment"]) != "prod" {
+		t.Errorf("got %v, want environment=prod", got)
+	}
+}
+
+func TestSagemakerKeyValueTagsRemovedUpdated(t *testing.T) {
+	old := SagemakerKeyValueTags{
+		"keep":    aws.String("same"),
+		"changed": aws.String("old-value"),
+		"gone":    aws.String("bye"),
+	}
+	updatedTags := SagemakerKeyValueTags{
+		"keep":    aws.String("same"),
+		"changed": aws.String("new-value"),
+		"added":   aws.String("hello"),
+	}
+
+	removed := old.Removed(updatedTags)
+	if len(removed) != 1 || aws.StringValue(removed["gone"]) != "bye" {
+		t.Errorf("got removed %v, want only gone=bye", removed)
+	}
+
+	updated := old.Updated(updatedTags)
+	if len(updated) != 2 {
+		t.Fatalf("got %d updated tags, want 2: %v", len(updated), updated)
+	}
+	if aws.StringValue(updated["changed"]) != "new-value" {
+		t.Errorf("got changed=%v, want new-value", updated["changed"])
+	}
+	if aws.StringValue(updated["added"]) != "hello" {
+		t.Errorf("got added=%v, want hello", updated["added"])
+	}
+}
+
+// mockSagemakerTaggingAPI embeds sagemakeriface.SageMakerAPI so it satisfies
+// the full interface while only overriding AddTags/DeleteTags, recording
+// what was called so tests can assert on the resulting diff.
+type mockSagemakerTaggingAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	addTagsInputs    []*sagemaker.AddTagsInput
+	deleteTagsInputs []*sagemaker.DeleteTagsInput
+}
+
+func (m *mockSagemakerTaggingAPI) AddTags(input *sagemaker.AddTagsInput) (*sagemaker.AddTagsOutput, error) {
+	m.addTagsInputs = append(m.addTagsInputs, input)
+	return &sagemaker.AddTagsOutput{}, nil
+}
+
+func (m *mockSagemakerTaggingAPI) DeleteTags(input *sagemaker.DeleteTagsInput) (*sagemaker.DeleteTagsOutput, error) {
+	m.deleteTagsInputs = append(m.deleteTagsInputs, input)
+	return &sagemaker.DeleteTagsOutput{}, nil
+}
+
+func TestSagemakerUpdateTags(t *testing.T) {
+	conn := &mockSagemakerTaggingAPI{}
+
+	oldTags := map[string]interface{}{"keep": "same", "gone": "bye"}
+	newTags := map[string]interface{}{"keep": "same", "added": "hello"}
+
+	if err := SagemakerUpdateTags(conn, "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test", oldTags, newTags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(conn.deleteTagsInputs) != 1 || len(conn.deleteTagsInputs[0].TagKeys) != 1 || aws.StringValue(conn.deleteTagsInputs[0].TagKeys[0]) != "gone" {
+		t.Errorf("expected a single DeleteTags call with [\"gone\"], got %#v", conn.deleteTagsInputs)
+	}
+
+	if len(conn.addTagsInputs) != 1 || len(conn.addTagsInputs[0].Tags) != 1 || aws.StringValue(conn.addTagsInputs[0].Tags[0].Key) != "added" {
+		t.Errorf("expected a single AddTags call with [\"added\"], got %#v", conn.addTagsInputs)
+	}
+}
+
+func TestSagemakerUpdateTagsGovCloudArn(t *testing.T) {
+	// SagemakerUpdateTags takes whatever ARN the caller passes through
+	// unchanged -- callers are expected to pass the ARN DescribeNotebookInstance
+	// (or the equivalent describe call) itself returned rather than
+	// reconstructing one, so a GovCloud-partition ARN round-trips through
+	// AddTags/DeleteTags exactly as given instead of being rewritten against a
+	// commercial-partition assumption.
+	conn := &mockSagemakerTaggingAPI{}
+	govCloudArn := "arn:aws-us-gov:sagemaker:us-gov-west-1:123456789012:notebook-instance/test"
+
+	oldTags := map[string]interface{}{"keep": "same", "gone": "bye"}
+	newTags := map[string]interface{}{"keep": "same", "added": "hello"}
+
+	if err := SagemakerUpdateTags(conn, govCloudArn, oldTags, newTags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(conn.deleteTagsInputs) != 1 || aws.StringValue(conn.deleteTagsInputs[0].ResourceArn) != govCloudArn {
+		t.Errorf("expected DeleteTags called with ResourceArn %q, got %#v", govCloudArn, conn.deleteTagsInputs)
+	}
+
+	if len(conn.addTagsInputs) != 1 || aws.StringValue(conn.addTagsInputs[0].ResourceArn) != govCloudArn {
+		t.Errorf("expected AddTags called with ResourceArn %q, got %#v", govCloudArn, conn.addTagsInputs)
+	}
+}
+
+func TestSagemakerListTagsWithRetryGovCloudArn(t *testing.T) {
+	conn := &throttlingThenSuccessSagemakerAPI{throttleCount: 0}
+	govCloudArn := "arn:aws-us-gov:sagemaker:us-gov-west-1:123456789012:notebook-instance/test"
+
+	if _, err := sagemakerListTagsWithRetry(conn, aws.String(govCloudArn)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if aws.StringValue(conn.lastResourceArn) != govCloudArn {
+		t.Errorf("expected ListTags called with ResourceArn %q, got %q", govCloudArn, aws.StringValue(conn.lastResourceArn))
+	}
+}
+
+func TestSagemakerUpdateTagsNoChange(t *testing.T) {
+	conn := &mockSagemakerTaggingAPI{}
+
+	tags := map[string]interface{}{"keep": "same"}
+
+	if err := SagemakerUpdateTags(conn, "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test", tags, tags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.addTagsInputs != nil || conn.deleteTagsInputs != nil {
+		t.Error("expected neither AddTags nor DeleteTags to be called when nothing changed")
+	}
+}
+
+func TestSagemakerUpdateTagsAdditive(t *testing.T) {
+	conn := &mockSagemakerTaggingAPI{}
+
+	oldTags := map[string]interface{}{"keep": "same", "gone": "bye"}
+	newTags := map[string]interface{}{"keep": "same", "added": "hello"}
+
+	if err := SagemakerUpdateTagsAdditive(conn, "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test", oldTags, newTags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.deleteTagsInputs != nil {
+		t.Errorf("expected no DeleteTags call, got %#v", conn.deleteTagsInputs)
+	}
+
+	if len(conn.addTagsInputs) != 1 || len(conn.addTagsInputs[0].Tags) != 1 || aws.StringValue(conn.addTagsInputs[0].Tags[0].Key) != "added" {
+		t.Errorf("expected a single AddTags call with [\"added\"], got %#v", conn.addTagsInputs)
+	}
+}
+
+func TestSagemakerUpdateTagsBatchesLargeDiffs(t *testing.T) {
+	conn := &mockSagemakerTaggingAPI{}
+
+	oldTags := map[string]interface{}{}
+	newTags := make(map[string]interface{}, 120)
+	for i := 0; i < 120; i++ {
+		newTags[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	if err := SagemakerUpdateTags(conn, "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test", oldTags, newTags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(conn.deleteTagsInputs) != 0 {
+		t.Errorf("expected no DeleteTags calls, got %#v", conn.deleteTagsInputs)
+	}
+
+	if len(conn.addTagsInputs) != 3 {
+		t.Fatalf("expected 120 new tags to be reconciled in 3 batches of at most %d, got %d calls", sagemakerTagBatchSize, len(conn.addTagsInputs))
+	}
+
+	total := 0
+	for i, input := range conn.addTagsInputs {
+		if len(input.Tags) > sagemakerTagBatchSize {
+			t.Errorf("batch %d has %d tags, exceeding the %d limit", i, len(input.Tags), sagemakerTagBatchSize)
+		}
+		total += len(input.Tags)
+	}
+	if total != 120 {
+		t.Errorf("got %d total tags across all batches, want 120", total)
+	}
+}
+
+func TestSagemakerUpdateTagsValueOnlyChange(t *testing.T) {
+	conn := &mockSagemakerTaggingAPI{}
+
+	oldTags := map[string]interface{}{"Name": "foo"}
+	newTags := map[string]interface{}{"Name": "bar"}
+
+	if err := SagemakerUpdateTags(conn, "arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test", oldTags, newTags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.deleteTagsInputs != nil {
+		t.Errorf("expected a value-only change to never issue a DeleteTags call, got %#v", conn.deleteTagsInputs)
+	}
+
+	if len(conn.addTagsInputs) != 1 || len(conn.addTagsInputs[0].Tags) != 1 || aws.StringValue(conn.addTagsInputs[0].Tags[0].Key) != "Name" || aws.StringValue(conn.addTagsInputs[0].Tags[0].Value) != "bar" {
+		t.Errorf("expected a single AddTags call overwriting Name=bar, got %#v", conn.addTagsInputs)
+	}
+}
+
+// pagedListTagsAPI embeds sagemakeriface.SageMakerAPI so it satisfies the
+// full interface while only overriding ListTags, returning its tags in two
+// pages to exercise NextToken handling.
+type pagedListTagsAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	calls int
+}
+
+func (m *pagedListTagsAPI) ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	m.calls++
+
+	if aws.StringValue(input.NextToken) == "" {
+		return &sagemaker.ListTagsOutput{
+			Tags:      []*sagemaker.Tag{{Key: aws.String("environment"), Value: aws.String("prod")}},
+			NextToken: aws.String("page2"),
+		}, nil
+	}
+
+	return &sagemaker.ListTagsOutput{
+		Tags: []*sagemaker.Tag{{Key: aws.String("owner"), Value: aws.String("ml-team")}},
+	}, nil
+}
+
+func TestSagemakerListAllTagsWithRetry(t *testing.T) {
+	conn := &pagedListTagsAPI{}
+
+	tags, err := sagemakerListAllTagsWithRetry(conn, aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.calls != 2 {
+		t.Errorf("got %d ListTags calls, want 2", conn.calls)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("got %d tags, want 2: %#v", len(tags), tags)
+	}
+
+	got := tagsToMapSagemaker(tags)
+	if got["environment"] != "prod" || got["owner"] != "ml-team" {
+		t.Errorf("got %v, want environment=prod and owner=ml-team", got)
+	}
+}
+
+// throttlingPagedListTagsAPI embeds sagemakeriface.SageMakerAPI so it
+// satisfies the full interface while only overriding ListTags, throttling
+// once on the first page before returning two pages of tags, to exercise
+// pagination and throttle-retry together.
+type throttlingPagedListTagsAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	calls int
+}
+
+func (m *throttlingPagedListTagsAPI) ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	m.calls++
+
+	if aws.StringValue(input.NextToken) == "" {
+		if m.calls == 1 {
+			return nil, awserr.New("ThrottlingException", "rate exceeded", nil)
+		}
+		return &sagemaker.ListTagsOutput{
+			Tags:      []*sagemaker.Tag{{Key: aws.String("environment"), Value: aws.String("prod")}},
+			NextToken: aws.String("page2"),
+		}, nil
+	}
+
+	return &sagemaker.ListTagsOutput{
+		Tags: []*sagemaker.Tag{{Key: aws.String("owner"), Value: aws.String("ml-team")}},
+	}, nil
+}
+
+func TestSagemakerListAllTagsWithRetryThrottledFirstPage(t *testing.T) {
+	conn := &throttlingPagedListTagsAPI{}
+
+	restoreSleep := sagemakerThrottleRetrySleep
+	sagemakerThrottleRetrySleep = func(time.Duration) {}
+	defer func() { sagemakerThrottleRetrySleep = restoreSleep }()
+
+	tags, err := sagemakerListAllTagsWithRetry(conn, aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.calls != 3 {
+		t.Errorf("got %d ListTags calls, want 3 (1 throttled + 1 retried first page + 1 second page)", conn.calls)
+	}
+
+	got := tagsToMapSagemaker(tags)
+	if got["environment"] != "prod" || got["owner"] != "ml-team" {
+		t.Errorf("got %v, want environment=prod and owner=ml-team", got)
+	}
+}
+
+func TestChunkSagemakerTagKeys(t *testing.T) {
+	keys := make([]*string, 0, 120)
+	for i := 0; i < 120; i++ {
+		keys = append(keys, aws.String(fmt.Sprintf("key%d", i)))
+	}
+
+	chunks := chunkSagemakerTagKeys(keys, 50)
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	if len(chunks[0]) != 50 || len(chunks[1]) != 50 || len(chunks[2]) != 20 {
+		t.Errorf("got chunk sizes %d/%d/%d, want 50/50/20", len(chunks[0]), len(chunks[1]), len(chunks[2]))
+	}
+}
+
+// eventuallyTaggedAPI embeds sagemakeriface.SageMakerAPI so it satisfies the
+// full interface while only overriding ListTags, which comes back with no
+// tags a fixed number of times before the tags set at create "catch up".
+type eventuallyTaggedAPI struct {
+	sagemakeriface.SageMakerAPI
+
+	staleCount int
+	calls      int
+}
+
+func (m *eventuallyTaggedAPI) ListTags(input *sagemaker.ListTagsInput) (*sagemaker.ListTagsOutput, error) {
+	m.calls++
+	if m.calls <= m.staleCount {
+		return &sagemaker.ListTagsOutput{}, nil
+	}
+
+	return &sagemaker.ListTagsOutput{
+		Tags: []*sagemaker.Tag{
+			{Key: aws.String("environment"), Value: aws.String("prod")},
+		},
+	}, nil
+}
+
+func TestSagemakerWaitForCreatedTags(t *testing.T) {
+	want := []*sagemaker.Tag{
+		{Key: aws.String("environment"), Value: aws.String("prod")},
+	}
+
+	t.Run("retries until the created tags are visible", func(t *testing.T) {
+		conn := &eventuallyTaggedAPI{staleCount: 2}
+
+		tags, err := sagemakerWaitForCreatedTags(conn, aws.String("arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/test"), want, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if conn.calls != 3 {
+			t.Errorf("got %d ListTags calls, want 3", conn.calls)
+		}
+		if len(tags) != 1 || aws.StringValue(tags[0].Key) != "environment" {
+			t.Errorf("unexpected tags returned: %#v", tags)
+		}
+	})
+
+	t.Run("gives up without erroring once the timeout elapses", func(t *testing.T) {
+		conn := &eventuallyTaggedAPI{staleCount: 1000}
+
+		tags, err := sagemakerWaitForCreatedTags(conn, aws.String("arn"), want, time.Millisecond)
+		if err != nil {
+			t.Fatalf("expected no error even though the tags never became visible, got: %s", err)
+		}
+		if len(tags) != 0 {
+			t.Errorf("expected no tags yet, got %#v", tags)
+		}
+	})
+
+	t.Run("propagates a non-retryable ListTags error", func(t *testing.T) {
+		conn := &mockListTagsAPI{err: awserr.New("ValidationException", "bad input", nil)}
+
+		if _, err := sagemakerWaitForCreatedTags(conn, aws.String("arn"), want, time.Minute); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+
+	t.Run("no tags to wait for is a plain list", func(t *testing.T) {
+		conn := &eventuallyTaggedAPI{staleCount: 0}
+
+		if _, err := sagemakerWaitForCreatedTags(conn, aws.String("arn"), nil, time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if conn.calls != 1 {
+			t.Errorf("got %d calls, want 1", conn.calls)
+		}
+	})
+}
+
+// TestSagemakerTagKeyMatchesIgnoreRules covers the ignore_tags key/prefix
+// matching used by sagemakerTagsWithoutIgnored. An acceptance test exercising
+// the full ignore_tags { key_prefixes = [...] } provider configuration isn't
+// possible in this package as checked out here: it has no provider.go to
+// register a second provider alias configured with ignore_tags, so this unit
+// test on the extracted pure matcher is the closest available coverage.
+func TestSagemakerTagKeyMatchesIgnoreRules(t *testing.T) {
+	testCases := []struct {
+		name        string
+		key         string
+		keys        []string
+		keyPrefixes []string
+		expected    bool
+	}{
+		{
+			name:     "no rules configured",
+			key:      "kubernetes.io/cluster/foo",
+			expected: false,
+		},
+		{
+			name:        "matches a key prefix",
+			key:         "kubernetes.io/cluster/foo",
+			keyPrefixes: []string{"kubernetes.io/"},
+			expected:    true,
+		},
+		{
+			name:     "matches an exact key",
+			key:      "managed-by",
+			keys:     []string{"managed-by"},
+			expected: true,
+		},
+		{
+			name:        "matches neither",
+			key:         "Name",
+			keys:        []string{"managed-by"},
+			keyPrefixes: []string{"kubernetes.io/"},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerTagKeyMatchesIgnoreRules(tc.key, tc.keys, tc.keyPrefixes); got != tc.expected {
+				t.Errorf("sagemakerTagKeyMatchesIgnoreRules(%q, %v, %v) = %t, want %t", tc.key, tc.keys, tc.keyPrefixes, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSagemakerTagsWithoutAwsManaged(t *testing.T) {
+	// validateSagemakerTags rejects an aws:-prefixed tag on the way in, but
+	// ListTags can still return one -- this covers that path directly, as
+	// if the API had handed back a system-applied tag alongside a
+	// user-configured one.
+	allTags := map[string]string{
+		"aws:cloudformation:stack-name": "my-stack",
+		"Name":                          "my-notebook",
+	}
+
+	got := sagemakerTagsWithoutAwsManaged(allTags)
+
+	if _, ok := got["aws:cloudformation:stack-name"]; ok {
+		t.Error("expected the aws:-prefixed tag to be stripped, but it was present")
+	}
+	if got["Name"] != "my-notebook" {
+		t.Errorf("expected the non-aws: tag to survive, got: %v", got)
+	}
+}
+
+func TestSagemakerTagsForCreateRejectsInvalidTags(t *testing.T) {
+	// sagemakerTagsForCreate's validation branch is covered directly here; the
+	// merge-with-defaults branch requires a real *AWSClient for its
+	// defaultTagsConfig lookup and so is only exercised via acceptance tests,
+	// the same way sagemakerTagsWithDefaults itself is.
+	tags := map[string]interface{}{"aws:reserved": "not allowed"}
+
+	if _, err := sagemakerTagsForCreate(nil, tags); err == nil {
+		t.Error("expected an error for a reserved aws: tag key, got nil")
+	}
+}
+
+func TestIsSagemakerRetryableErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ThrottlingException is retryable",
+			err:      awserr.New("ThrottlingException", "Rate exceeded", nil),
+			expected: true,
+		},
+		{
+			name:     "ValidationException is not retryable",
+			err:      awserr.New("ValidationException", "bad input", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr is not retryable",
+			err:      fmt.Errorf("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerRetryableErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerRetryableErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsSagemakerThrottleErr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ThrottlingException is a throttle error",
+			err:      awserr.New("ThrottlingException", "Rate exceeded", nil),
+			expected: true,
+		},
+		{
+			name:     "TooManyRequestsException is a throttle error",
+			err:      awserr.New("TooManyRequestsException", "slow down", nil),
+			expected: true,
+		},
+		{
+			name:     "InternalFailure is not a throttle error",
+			err:      awserr.New("InternalFailure", "oops", nil),
+			expected: false,
+		},
+		{
+			name:     "ValidationException is not a throttle error",
+			err:      awserr.New("ValidationException", "bad input", nil),
+			expected: false,
+		},
+		{
+			name:     "non-awserr is not a throttle error",
+			err:      fmt.Errorf("some other error"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSagemakerThrottleErr(tc.err); got != tc.expected {
+				t.Errorf("isSagemakerThrottleErr(%v) = %t; want %t", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRetryOnSagemakerThrottle(t *testing.T) {
+	origSleep := sagemakerThrottleRetrySleep
+	defer func() { sagemakerThrottleRetrySleep = origSleep }()
+
+	var sleeps []time.Duration
+	sagemakerThrottleRetrySleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+	}
+
+	t.Run("succeeds after throttling a few times, backing off between attempts", func(t *testing.T) {
+		sleeps = nil
+		calls := 0
+
+		err := retryOnSagemakerThrottle(func() error {
+			calls++
+			if calls < 3 {
+				return awserr.New("ThrottlingException", "Rate exceeded", nil)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Errorf("got %d calls, want 3", calls)
+		}
+		if len(sleeps) != 2 {
+			t.Fatalf("got %d sleeps, want 2", len(sleeps))
+		}
+		if sleeps[1] <= sleeps[0] {
+			t.Errorf("expected backoff to increase between attempts, got %s then %s", sleeps[0], sleeps[1])
+		}
+	})
+
+	t.Run("gives up immediately on a non-throttle error", func(t *testing.T) {
+		sleeps = nil
+		calls := 0
+
+		err := retryOnSagemakerThrottle(func() error {
+			calls++
+			return awserr.New("ValidationException", "bad input", nil)
+		})
+		if err == nil {
+			t.Error("expected an error, got nil")
+		}
+		if calls != 1 {
+			t.Errorf("got %d calls, want 1 (non-throttle errors should not be retried)", calls)
+		}
+		if len(sleeps) != 0 {
+			t.Errorf("got %d sleeps, want 0", len(sleeps))
+		}
+	})
+
+	t.Run("gives up after sagemakerThrottleRetryMaxAttempts attempts", func(t *testing.T) {
+		sleeps = nil
+		calls := 0
+
+		err := retryOnSagemakerThrottle(func() error {
+			calls++
+			return awserr.New("ThrottlingException", "Rate exceeded", nil)
+		})
+		if err == nil {
+			t.Error("expected an error, got nil")
+		}
+		if calls != sagemakerThrottleRetryMaxAttempts {
+			t.Errorf("got %d calls, want %d", calls, sagemakerThrottleRetryMaxAttempts)
+		}
+		if len(sleeps) != sagemakerThrottleRetryMaxAttempts-1 {
+			t.Errorf("got %d sleeps, want %d", len(sleeps), sagemakerThrottleRetryMaxAttempts-1)
+		}
+	})
+}
+
+func TestSagemakerTagValueToString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "string passes through unchanged",
+			value:    "production",
+			expected: "production",
+		},
+		{
+			name:     "bool is coerced",
+			value:    true,
+			expected: "true",
+		},
+		{
+			name:     "whole-valued JSON number is coerced without a decimal point",
+			value:    float64(3),
+			expected: "3",
+		},
+		{
+			name:     "fractional JSON number is coerced with its decimal point",
+			value:    float64(3.5),
+			expected: "3.5",
+		},
+		{
+			name:     "nil is coerced to an empty string",
+			value:    nil,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sagemakerTagValueToString(tc.value); got != tc.expected {
+				t.Errorf("sagemakerTagValueToString(%#v) = %q; want %q", tc.value, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestTagsFromMapSagemakerCoercesNonStringValues confirms tagsFromMapSagemaker
+// doesn't panic on a map holding the non-string types jsondecode() of a tag
+// definitions file can produce -- float64 and bool -- the way a plain
+// v.(string) type assertion would.
+func TestTagsFromMapSagemakerCoercesNonStringValues(t *testing.T) {
+	tags := map[string]interface{}{
+		"Environment": "production",
+		"RetentionDays": float64(30),
+		"AutoShutdown":  true,
+	}
+
+	got := tagsToMapSagemaker(tagsFromMapSagemaker(tags))
+
+	want := map[string]string{
+		"Environment":   "production",
+		"RetentionDays": "30",
+		"AutoShutdown":  "true",
+	}
+
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("tag %q = %q; want %q", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var sagemakerCodeRepositoryArnRegexp = regexp.MustCompile(`^arn:[\w-]+:sagemaker:[\w-]+:\d{12}:code-repository/`)
+
+// sagemakerNameRegexp is the common SageMaker resource name constraint: must
+// start and end with an alphanumeric character, with hyphens (including
+// consecutive ones) allowed in between.
+var sagemakerNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9](-*[a-zA-Z0-9])*$`)
+
+// sagemakerReservedNamePrefixes are name prefixes SageMaker itself reserves
+// for AWS-managed resources; the API rejects them with a generic
+// ValidationException, so catching it here gives a more specific error at
+// plan time instead.
+var sagemakerReservedNamePrefixes = []string{"aws"}
+
+// sagemakerNameDefaultMaxLength is the length limit shared by most SageMaker
+// resource name fields. Resources whose real limit differs (e.g.
+// pipeline_name, which allows up to 256 characters) use
+// validateSagemakerNameMaxLength directly instead of validateSagemakerName.
+const sagemakerNameDefaultMaxLength = 63
+
+// validateSagemakerName is the shared SageMaker resource name validator used
+// by the majority of SageMaker resources: alphanumeric characters and
+// hyphens only, at most sagemakerNameDefaultMaxLength characters, and not
+// starting with a reserved prefix.
+func validateSagemakerName(v interface{}, k string) (ws []string, errors []error) {
+	return validateSagemakerNameMaxLength(sagemakerNameDefaultMaxLength)(v, k)
+}
+
+// validateSagemakerNameMaxLength returns a SageMaker resource name validator
+// parameterized on maxLength, for the resources whose name length limit
+// differs from the sagemakerNameDefaultMaxLength most resources share.
+func validateSagemakerNameMaxLength(maxLength int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+
+		if len(value) > maxLength {
+			errors = append(errors, fmt.Errorf("%q must be at most %d characters, got: %d", k, maxLength, len(value)))
+		}
+
+		if !sagemakerNameRegexp.MatchString(value) {
+			errors = append(errors, fmt.Errorf("%q must start and end with an alphanumeric character and contain only alphanumeric characters and hyphens, got: %s", k, value))
+		}
+
+		lower := strings.ToLower(value)
+		for _, prefix := range sagemakerReservedNamePrefixes {
+			if strings.HasPrefix(lower, prefix) {
+				errors = append(errors, fmt.Errorf("%q must not begin with the reserved prefix %q, got: %s", k, prefix, value))
+				break
+			}
+		}
+
+		return ws, errors
+	}
+}
+
+// sagemakerNotebookInstanceNameRegexp is AWS's exact constraint for
+// notebook instance names: it must start and end with an alphanumeric
+// character, with hyphens (including consecutive ones) allowed in between,
+// and cannot contain underscores. It is the same pattern as
+// sagemakerNameRegexp.
+var sagemakerNotebookInstanceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9](-*[a-zA-Z0-9])*$`)
+
+const sagemakerNotebookInstanceNameMaxLength = 63
+
+// validateSagemakerNotebookInstanceName checks a notebook instance name
+// against AWS's exact regex and length limit, so names with underscores or
+// other disallowed characters fail at plan time instead of apply time.
+// Unlike validateSagemakerName, it does not reject the reserved "aws" name
+// prefix.
+func validateSagemakerNotebookInstanceName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) > sagemakerNotebookInstanceNameMaxLength {
+		errors = append(errors, fmt.Errorf("%q must be at most %d characters, got: %d", k, sagemakerNotebookInstanceNameMaxLength, len(value)))
+	}
+
+	if !sagemakerNotebookInstanceNameRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must start and end with an alphanumeric character and contain only alphanumeric characters and hyphens, got: %s", k, value))
+	}
+
+	return ws, errors
+}
+
+// iamRoleArnRegexp matches an IAM role ARN across standard, aws-us-gov, and
+// aws-cn partitions, including service-linked roles and roles nested under a
+// path (e.g. arn:aws:iam::123456789012:role/aws-service-role/foo/my-role).
+var iamRoleArnRegexp = regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:role/[\w+=,.@-]+(/[\w+=,.@-]+)*$`)
+
+// iamInstanceProfileArnRegexp matches an IAM instance-profile ARN, the
+// value this is most often confused with: both are IAM resources attached
+// to compute, but SageMaker's role_arn requires the role itself, not the
+// instance profile that wraps it for EC2.
+var iamInstanceProfileArnRegexp = regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:instance-profile/[\w+=,.@-]+(/[\w+=,.@-]+)*$`)
+
+// validateIamRoleArn checks that a value is an IAM role ARN, rather than a
+// user/group ARN or a bare role name, so a common copy-paste mistake is
+// caught at plan time instead of surfacing as a confusing API error during
+// apply. An instance-profile ARN gets its own targeted error, since pasting
+// one in place of its underlying role is common enough to call out
+// specifically rather than leave to the generic format error.
+func validateIamRoleArn(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if iamInstanceProfileArnRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q is an instance profile ARN; SageMaker requires the role ARN, got: %s", k, value))
+		return
+	}
+
+	if !iamRoleArnRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be an IAM role ARN (arn:aws:iam::<account-id>:role/<role-name>), got: %s", k, value))
+	}
+
+	return
+}
+
+// subnetIdRegexp matches an EC2 subnet ID, both the classic 8-hex-character
+// form and the newer 17-hex-character form.
+var subnetIdRegexp = regexp.MustCompile(`^subnet-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// validateSagemakerNotebookInstanceSubnetId checks that a value looks like a
+// subnet ID rather than a VPC ID or some other arbitrary string, so a
+// copy-paste mistake is caught at plan time instead of surfacing as a
+// CreateNotebookInstance failure during apply.
+func validateSagemakerNotebookInstanceSubnetId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !subnetIdRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a subnet ID (subnet-xxxxxxxx), got: %s", k, value))
+	}
+
+	return
+}
+
+// validateSagemakerCodeRepository accepts either an https:// git URL or a
+// SageMaker code-repository ARN, matching what CreateNotebookInstance and
+// UpdateNotebookInstance actually accept for default/additional code
+// repositories, so malformed values fail at plan time instead of apply time.
+func validateSagemakerCodeRepository(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if strings.HasPrefix(value, "https://") || sagemakerCodeRepositoryArnRegexp.MatchString(value) {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf("%q must be a valid https:// git URL or a SageMaker code-repository ARN, got: %s", k, value))
+	return
+}
+
+// sagemakerNotebookInstanceAcceleratorTypes are the Elastic Inference
+// accelerator types SageMaker notebook instances accept, per the
+// AcceleratorTypes field on CreateNotebookInstanceInput.
+var sagemakerNotebookInstanceAcceleratorTypes = []string{
+	"ml.eia1.medium",
+	"ml.eia1.large",
+	"ml.eia1.xlarge",
+	"ml.eia2.medium",
+	"ml.eia2.large",
+	"ml.eia2.xlarge",
+}
+
+// validateSagemakerNotebookInstanceAcceleratorType checks a single
+// accelerator_types value against sagemakerNotebookInstanceAcceleratorTypes.
+func validateSagemakerNotebookInstanceAcceleratorType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	for _, t := range sagemakerNotebookInstanceAcceleratorTypes {
+		if value == t {
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf("%q must be one of %v, got: %s", k, sagemakerNotebookInstanceAcceleratorTypes, value))
+	return
+}
+
+// sagemakerNotebookInstanceLifecycleHookS3UriRegexp matches the s3://bucket/key
+// form accepted by on_create_s3_uri/on_start_s3_uri.
+var sagemakerNotebookInstanceLifecycleHookS3UriRegexp = regexp.MustCompile(`^s3://[^/]+/.+$`)
+
+// validateSagemakerNotebookInstanceLifecycleHookS3Uri checks that a value
+// looks like an s3://bucket/key URI, so a malformed value fails at plan time
+// instead of as a confusing S3 GetObject error during apply.
+func validateSagemakerNotebookInstanceLifecycleHookS3Uri(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !sagemakerNotebookInstanceLifecycleHookS3UriRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be an S3 URI (s3://bucket/key), got: %s", k, value))
+	}
+
+	return
+}
+
+// sagemakerNotebookInstanceLifecycleHookMaxContentBytes is the decoded
+// content size limit CreateNotebookInstanceLifecycleConfig and
+// UpdateNotebookInstanceLifecycleConfig enforce per hook (on_create,
+// on_start).
+const sagemakerNotebookInstanceLifecycleHookMaxContentBytes = 16384
+
+// validateSagemakerNotebookInstanceLifecycleHookContent decodes on_create/
+// on_start's base64 content and checks it against SageMaker's 16KB decoded
+// size limit per hook, so an oversized lifecycle script fails at plan time
+// instead of as a CreateNotebookInstanceLifecycleConfig/
+// UpdateNotebookInstanceLifecycleConfig rejection during apply. A value that
+// isn't valid base64 is left for the API itself to reject.
+func validateSagemakerNotebookInstanceLifecycleHookContent(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return
+	}
+
+	if len(decoded) > sagemakerNotebookInstanceLifecycleHookMaxContentBytes {
+		errors = append(errors, fmt.Errorf("%q decoded content is %d bytes, which exceeds SageMaker's %d byte limit per lifecycle hook", k, len(decoded), sagemakerNotebookInstanceLifecycleHookMaxContentBytes))
+	}
+
+	return
+}
+
+// sagemakerNotebookInstanceMaintenanceWindowRegexp matches the day/time
+// range format maintenance_window accepts: ddd:hh24:mi-ddd:hh24:mi, e.g.
+// Mon:03:00-Mon:05:00, the same shape RDS's preferred_maintenance_window
+// uses.
+var sagemakerNotebookInstanceMaintenanceWindowRegexp = regexp.MustCompile(`(?i)^(sun|mon|tue|wed|thu|fri|sat):([01][0-9]|2[0-3]):([0-5][0-9])-(sun|mon|tue|wed|thu|fri|sat):([01][0-9]|2[0-3]):([0-5][0-9])$`)
+
+// validateSagemakerNotebookInstanceMaintenanceWindow checks maintenance_window
+// against the ddd:hh24:mi-ddd:hh24:mi format, so a malformed window is caught
+// at plan time instead of silently never matching when
+// resourceAwsSagemakerNotebookInstanceCustomizeDiffMaintenanceWindow checks
+// the current time against it.
+func validateSagemakerNotebookInstanceMaintenanceWindow(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !sagemakerNotebookInstanceMaintenanceWindowRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be in the format ddd:hh24:mi-ddd:hh24:mi (e.g. Mon:03:00-Mon:05:00), got: %s", k, value))
+	}
+
+	return
+}
+
+// kmsKeyIdRegexp, kmsKeyArnRegexp, kmsAliasNameRegexp, and kmsAliasArnRegexp
+// match the four forms KMS accepts for a key identifier: a bare key ID, a
+// key ARN, an alias name, and an alias ARN, respectively.
+var (
+	kmsKeyIdRegexp     = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	kmsKeyArnRegexp    = regexp.MustCompile(`^arn:[\w-]+:kms:[\w-]+:\d{12}:key/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	kmsAliasNameRegexp = regexp.MustCompile(`^alias/[a-zA-Z0-9:/_-]+$`)
+	kmsAliasArnRegexp  = regexp.MustCompile(`^arn:[\w-]+:kms:[\w-]+:\d{12}:alias/[a-zA-Z0-9:/_-]+$`)
+)
+
+// validateSagemakerNotebookInstanceKmsKeyId checks that kms_key_id is one of
+// the four forms KMS accepts -- a bare key ID, a key ARN, an alias name
+// (alias/...), or an alias ARN -- so a malformed value fails at plan time
+// instead of as a CreateNotebookInstance rejection during apply.
+func validateSagemakerNotebookInstanceKmsKeyId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if kmsKeyIdRegexp.MatchString(value) ||
+		kmsKeyArnRegexp.MatchString(value) ||
+		kmsAliasNameRegexp.MatchString(value) ||
+		kmsAliasArnRegexp.MatchString(value) {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf("%q must be a KMS key ID, key ARN, alias name (alias/...), or alias ARN, got: %s", k, value))
+	return
+}
@@ -0,0 +1,533 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestValidateIamRoleArn(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "standard partition role ARN",
+			value: "arn:aws:iam::123456789012:role/my-role",
+		},
+		{
+			name:  "aws-us-gov partition role ARN",
+			value: "arn:aws-us-gov:iam::123456789012:role/my-role",
+		},
+		{
+			name:  "aws-cn partition role ARN",
+			value: "arn:aws-cn:iam::123456789012:role/my-role",
+		},
+		{
+			name:  "path-prefixed role ARN",
+			value: "arn:aws:iam::123456789012:role/some/path/my-role",
+		},
+		{
+			name:  "service-linked role ARN",
+			value: "arn:aws:iam::123456789012:role/aws-service-role/sagemaker.amazonaws.com/AWSServiceRoleForSageMaker",
+		},
+		{
+			name:      "user ARN is rejected",
+			value:     "arn:aws:iam::123456789012:user/my-user",
+			expectErr: true,
+		},
+		{
+			name:      "instance profile ARN is rejected",
+			value:     "arn:aws:iam::123456789012:instance-profile/my-profile",
+			expectErr: true,
+		},
+		{
+			name:      "bare role name is rejected",
+			value:     "my-role",
+			expectErr: true,
+		},
+		{
+			name:      "empty string is rejected",
+			value:     "",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateIamRoleArn(tc.value, "role_arn")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+// TestValidateIamRoleArnInstanceProfileMessage asserts that an instance
+// profile ARN gets the targeted "is an instance profile ARN" error rather
+// than the generic "must be an IAM role ARN" message a user ARN or bare name
+// gets, so a user who pastes the wrong IAM resource type is told exactly
+// what's wrong instead of just that the format doesn't match.
+func TestValidateIamRoleArnInstanceProfileMessage(t *testing.T) {
+	_, errors := validateIamRoleArn("arn:aws:iam::123456789012:instance-profile/my-profile", "role_arn")
+	if len(errors) != 1 {
+		t.Fatalf("expected exactly one error, got %d: %v", len(errors), errors)
+	}
+	if !strings.Contains(errors[0].Error(), "instance profile ARN") {
+		t.Errorf("expected error to specifically mention an instance profile ARN, got: %s", errors[0])
+	}
+
+	_, userErrors := validateIamRoleArn("arn:aws:iam::123456789012:user/my-user", "role_arn")
+	if len(userErrors) != 1 {
+		t.Fatalf("expected exactly one error, got %d: %v", len(userErrors), userErrors)
+	}
+	if strings.Contains(userErrors[0].Error(), "instance profile ARN") {
+		t.Errorf("expected the generic format error for a user ARN, got the instance profile message: %s", userErrors[0])
+	}
+}
+
+func TestValidateSagemakerName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "simple name",
+			value: "my-model",
+		},
+		{
+			name:  "maximum length (63 characters)",
+			value: strings.Repeat("a", 63),
+		},
+		{
+			name:      "too long (64 characters)",
+			value:     strings.Repeat("a", 64),
+			expectErr: true,
+		},
+		{
+			name:      "underscore is rejected",
+			value:     "my_model",
+			expectErr: true,
+		},
+		{
+			name:      "reserved aws prefix is rejected",
+			value:     "aws-my-model",
+			expectErr: true,
+		},
+		{
+			name:      "reserved aws prefix is rejected case-insensitively",
+			value:     "AWS-my-model",
+			expectErr: true,
+		},
+		{
+			name:  "aws appearing mid-name is allowed",
+			value: "my-aws-model",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerName(tc.value, "name")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNameMaxLength(t *testing.T) {
+	testCases := []struct {
+		name      string
+		maxLength int
+		value     string
+		expectErr bool
+	}{
+		{
+			name:      "pipeline_name at its 256 character limit",
+			maxLength: 256,
+			value:     strings.Repeat("a", 256),
+		},
+		{
+			name:      "pipeline_name over its 256 character limit",
+			maxLength: 256,
+			value:     strings.Repeat("a", 257),
+			expectErr: true,
+		},
+		{
+			name:      "project_name at its 32 character limit",
+			maxLength: 32,
+			value:     strings.Repeat("a", 32),
+		},
+		{
+			name:      "project_name over its 32 character limit",
+			maxLength: 32,
+			value:     strings.Repeat("a", 33),
+			expectErr: true,
+		},
+		{
+			name:      "reserved aws prefix is rejected regardless of maxLength",
+			maxLength: 256,
+			value:     "aws-pipeline",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNameMaxLength(tc.maxLength)(tc.value, "name")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceLifecycleHookContent(t *testing.T) {
+	testCases := []struct {
+		name      string
+		size      int
+		expectErr bool
+	}{
+		{
+			name: "one byte under the limit",
+			size: 16383,
+		},
+		{
+			name: "exactly at the limit",
+			size: 16384,
+		},
+		{
+			name:      "one byte over the limit",
+			size:      16385,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("a"), tc.size))
+			_, errors := validateSagemakerNotebookInstanceLifecycleHookContent(value, "on_create")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %d bytes, got none", tc.size)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %d bytes, got: %v", tc.size, errors)
+			}
+		})
+	}
+
+	t.Run("empty value is fine", func(t *testing.T) {
+		_, errors := validateSagemakerNotebookInstanceLifecycleHookContent("", "on_create")
+		if len(errors) != 0 {
+			t.Errorf("expected no error, got: %v", errors)
+		}
+	})
+
+	t.Run("invalid base64 is left for the API to reject", func(t *testing.T) {
+		_, errors := validateSagemakerNotebookInstanceLifecycleHookContent("not valid base64!!", "on_create")
+		if len(errors) != 0 {
+			t.Errorf("expected no error, got: %v", errors)
+		}
+	})
+}
+
+func TestValidateSagemakerNotebookInstanceName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "simple name",
+			value: "my-notebook",
+		},
+		{
+			name:  "single character name",
+			value: "a",
+		},
+		{
+			name:  "alphanumeric with multiple hyphens",
+			value: "my--notebook-2",
+		},
+		{
+			name:  "maximum length (63 characters)",
+			value: strings.Repeat("a", 63),
+		},
+		{
+			name:      "too long (64 characters)",
+			value:     strings.Repeat("a", 64),
+			expectErr: true,
+		},
+		{
+			name:      "leading hyphen is rejected",
+			value:     "-my-notebook",
+			expectErr: true,
+		},
+		{
+			name:      "trailing hyphen is rejected",
+			value:     "my-notebook-",
+			expectErr: true,
+		},
+		{
+			name:      "underscore is rejected",
+			value:     "my_notebook",
+			expectErr: true,
+		},
+		{
+			name:      "empty string is rejected",
+			value:     "",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceName(tc.value, "name")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceSubnetId(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "valid subnet id",
+			value: "subnet-0123abcd",
+		},
+		{
+			name:  "valid long-form subnet id",
+			value: "subnet-0123abcd0123abcde",
+		},
+		{
+			name:      "vpc id is rejected",
+			value:     "vpc-0123abcd",
+			expectErr: true,
+		},
+		{
+			name:      "arbitrary string is rejected",
+			value:     "my-subnet",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceSubnetId(tc.value, "subnet_id")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceLifecycleHookS3Uri(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "valid s3 uri",
+			value: "s3://my-bucket/my-script.sh",
+		},
+		{
+			name:  "valid s3 uri with nested key",
+			value: "s3://my-bucket/scripts/on-start.sh",
+		},
+		{
+			name:      "missing key is rejected",
+			value:     "s3://my-bucket",
+			expectErr: true,
+		},
+		{
+			name:      "missing key with trailing slash is rejected",
+			value:     "s3://my-bucket/",
+			expectErr: true,
+		},
+		{
+			name:      "https url is rejected",
+			value:     "https://my-bucket.s3.amazonaws.com/my-script.sh",
+			expectErr: true,
+		},
+		{
+			name:      "arbitrary string is rejected",
+			value:     "my-script.sh",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceLifecycleHookS3Uri(tc.value, "on_create_s3_uri")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceMaintenanceWindow(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "simple window",
+			value: "Mon:03:00-Mon:05:00",
+		},
+		{
+			name:  "window wrapping the week boundary",
+			value: "Sat:22:00-Sun:02:00",
+		},
+		{
+			name:  "lowercase day abbreviations are allowed",
+			value: "mon:03:00-mon:05:00",
+		},
+		{
+			name:      "missing end of range is rejected",
+			value:     "Mon:03:00",
+			expectErr: true,
+		},
+		{
+			name:      "invalid day abbreviation is rejected",
+			value:     "Monday:03:00-Monday:05:00",
+			expectErr: true,
+		},
+		{
+			name:      "out of range hour is rejected",
+			value:     "Mon:24:00-Mon:05:00",
+			expectErr: true,
+		},
+		{
+			name:      "empty string is rejected",
+			value:     "",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceMaintenanceWindow(tc.value, "maintenance_window")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceKmsKeyId(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "bare key id",
+			value: "1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name:  "key arn",
+			value: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name:  "alias name",
+			value: "alias/foo",
+		},
+		{
+			name:  "alias arn",
+			value: "arn:aws:kms:us-east-1:123456789012:alias/foo",
+		},
+		{
+			name:      "invalid value is rejected",
+			value:     "not-a-kms-identifier",
+			expectErr: true,
+		},
+		{
+			name:      "empty string is rejected",
+			value:     "",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceKmsKeyId(tc.value, "kms_key_id")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}
+
+func TestValidateSagemakerNotebookInstanceAcceleratorType(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:  "eia1 medium",
+			value: "ml.eia1.medium",
+		},
+		{
+			name:  "eia2 xlarge",
+			value: "ml.eia2.xlarge",
+		},
+		{
+			name:      "unknown accelerator type is rejected",
+			value:     "ml.eia3.medium",
+			expectErr: true,
+		},
+		{
+			name:      "instance type is not an accelerator type",
+			value:     "ml.t2.medium",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateSagemakerNotebookInstanceAcceleratorType(tc.value, "accelerator_types")
+			if tc.expectErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got: %v", tc.value, errors)
+			}
+		})
+	}
+}